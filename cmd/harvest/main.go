@@ -6,7 +6,15 @@ import (
 	"github.com/dedene/harvest-cli/internal/cmd"
 )
 
+// Build metadata, set with -ldflags "-X main.version=...".
+var (
+	version = "0.1.0"
+	commit  = ""
+	date    = ""
+)
+
 func main() {
-	err := cmd.Execute(os.Args[1:])
+	cmd.SetBuildInfo(cmd.BuildInfo{Version: version, Commit: commit, Date: date})
+	err := cmd.Execute(os.Args[1:], os.Stdout, os.Stderr)
 	os.Exit(cmd.ExitCode(err))
 }
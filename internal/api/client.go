@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"golang.org/x/oauth2"
@@ -31,6 +32,20 @@ type Client struct {
 	reportsLimiter *RateLimiter
 	contactEmail   string
 	version        string
+	timeout        time.Duration
+	dryRun         bool
+	dryRunLog      io.Writer
+	dryRunCalls    []DryRunCall
+
+	mu         sync.Mutex // guards serverDate for concurrent requests
+	serverDate time.Time
+}
+
+// DryRunCall describes a mutating request skipped in dry-run mode.
+type DryRunCall struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Body   any    `json:"body,omitempty"`
 }
 
 // NewClient creates a new Harvest API client.
@@ -51,7 +66,8 @@ func NewClient(ts oauth2.TokenSource, accountID int64, contactEmail string) *Cli
 	}
 }
 
-// NewClientWithBaseURL creates a client with a custom base URL (for testing).
+// NewClientWithBaseURL creates a client with a custom base URL, such as a
+// proxy or a mock server. An empty baseURL keeps the production URL.
 func NewClientWithBaseURL(ts oauth2.TokenSource, accountID int64, contactEmail, baseURL string) *Client {
 	client := NewClient(ts, accountID, contactEmail)
 	if strings.TrimSpace(baseURL) != "" {
@@ -60,11 +76,106 @@ func NewClientWithBaseURL(ts oauth2.TokenSource, accountID int64, contactEmail,
 	return client
 }
 
+// AccountID returns the Harvest account ID requests are made against.
+func (c *Client) AccountID() int64 {
+	return c.accountID
+}
+
+// ServerDate returns the Date header of the last API response, or the zero
+// time before any response arrived.
+func (c *Client) ServerDate() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.serverDate
+}
+
+// WaitForCapacity blocks until the general rate limit window resets when
+// no requests remain in it. Commands fanning out many requests call it
+// before each one.
+func (c *Client) WaitForCapacity(ctx context.Context) error {
+	if c.rateLimiter == nil {
+		return nil
+	}
+	return c.rateLimiter.WaitForCapacity(ctx)
+}
+
 // SetVersion sets the version string for User-Agent.
 func (c *Client) SetVersion(version string) {
 	c.version = version
 }
 
+// SetRetryPolicy overrides retry limits and backoff for rate-limited and
+// server-error responses. A negative maxRetries or non-positive baseDelay
+// keeps the corresponding default.
+func (c *Client) SetRetryPolicy(maxRetries int, baseDelay time.Duration) {
+	rt, ok := c.httpClient.Transport.(*RetryTransport)
+	if !ok {
+		return
+	}
+	if maxRetries >= 0 {
+		rt.MaxRetries429 = maxRetries
+		rt.MaxRetries5xx = maxRetries
+	}
+	if baseDelay > 0 {
+		rt.BaseDelay = baseDelay
+	}
+}
+
+// SetRetryHook registers fn to be called each time a rate-limited or
+// failed request is about to be retried. A nil fn removes the hook.
+func (c *Client) SetRetryHook(fn func(RetryEvent)) {
+	if rt, ok := c.httpClient.Transport.(*RetryTransport); ok {
+		rt.OnRetry = fn
+	}
+}
+
+// SetTimeout sets a deadline applied to each request, including retries.
+// Zero disables the deadline.
+func (c *Client) SetTimeout(timeout time.Duration) {
+	c.timeout = timeout
+}
+
+// SetDryRun makes POST, PATCH and DELETE requests no-ops that succeed
+// without contacting the API. Each skipped request is recorded and, if w
+// is non-nil, described on w.
+func (c *Client) SetDryRun(w io.Writer) {
+	c.dryRun = true
+	c.dryRunLog = w
+}
+
+// SetLogging logs each HTTP attempt to w: method, URL, status and duration
+// at LogRequests, plus redacted request headers and error response bodies
+// at LogBodies. A level of zero leaves logging off.
+func (c *Client) SetLogging(w io.Writer, level int) {
+	rt, ok := c.httpClient.Transport.(*RetryTransport)
+	if !ok || level <= 0 || w == nil {
+		return
+	}
+	rt.Base = &LoggingTransport{Base: rt.Base, Out: w, Level: level}
+}
+
+// DryRunCalls returns the mutating requests skipped in dry-run mode.
+func (c *Client) DryRunCalls() []DryRunCall {
+	return c.dryRunCalls
+}
+
+// recordDryRun records a skipped request and writes it to the dry-run log.
+func (c *Client) recordDryRun(method, path string, body any) {
+	call := DryRunCall{Method: method, Path: path, Body: body}
+	c.dryRunCalls = append(c.dryRunCalls, call)
+
+	if c.dryRunLog == nil {
+		return
+	}
+	fmt.Fprintf(c.dryRunLog, "[dry-run] %s %s\n", method, path)
+	if body != nil {
+		data, err := json.MarshalIndent(body, "", "  ")
+		if err == nil {
+			fmt.Fprintf(c.dryRunLog, "%s\n", data)
+		}
+	}
+}
+
 // Get performs a GET request.
 func (c *Client) Get(ctx context.Context, path string, result any) error {
 	return c.doRequest(ctx, http.MethodGet, path, nil, result, false)
@@ -92,8 +203,19 @@ func (c *Client) Delete(ctx context.Context, path string) error {
 
 // doRequest executes an HTTP request with auth and error handling.
 func (c *Client) doRequest(ctx context.Context, method, path string, body, result any, isReports bool) error {
+	if c.dryRun && method != http.MethodGet {
+		c.recordDryRun(method, path, body)
+		return nil
+	}
+
 	reqURL := c.baseURL + path
 
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
 	// Proactive rate limiting for reports
 	if isReports && c.reportsLimiter != nil {
 		if err := c.reportsLimiter.Wait(ctx); err != nil {
@@ -139,6 +261,12 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body, resul
 	}
 	defer resp.Body.Close()
 
+	if date, err := http.ParseTime(resp.Header.Get("Date")); err == nil {
+		c.mu.Lock()
+		c.serverDate = date
+		c.mu.Unlock()
+	}
+
 	// Update reports rate limiter
 	if isReports && c.reportsLimiter != nil {
 		c.reportsLimiter.UpdateFromHeaders(resp.Header)
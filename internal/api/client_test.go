@@ -1,10 +1,13 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -263,6 +266,199 @@ func TestTransportRetry5xx(t *testing.T) {
 	}
 }
 
+func TestSetRetryHook(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	client := NewClientWithBaseURL(&staticTokenSource{token: "test-token"}, 12345, "test@example.com", srv.URL)
+	var events []RetryEvent
+	client.SetRetryHook(func(ev RetryEvent) { events = append(events, ev) })
+
+	var result map[string]any
+	if err := client.Get(context.Background(), "/test", &result); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+
+	want := []RetryEvent{
+		{StatusCode: http.StatusTooManyRequests, Attempt: 1},
+		{StatusCode: http.StatusTooManyRequests, Attempt: 2},
+	}
+	if len(events) != len(want) || events[0] != want[0] || events[1] != want[1] {
+		t.Errorf("events = %+v, want %+v", events, want)
+	}
+}
+
+func TestSetRetryPolicy_ZeroDisablesRetries(t *testing.T) {
+	var attempts int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	ts := &staticTokenSource{token: "test-token"}
+	client := NewClientWithBaseURL(ts, 12345, "test@example.com", srv.URL)
+	client.SetRetryPolicy(0, 0)
+
+	var result map[string]any
+	if err := client.Get(context.Background(), "/test", &result); err == nil {
+		t.Fatal("expected error for 503 response")
+	}
+
+	if atomic.LoadInt32(&attempts) != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts)
+	}
+}
+
+func TestSetRetryPolicy_BaseDelay(t *testing.T) {
+	ts := &staticTokenSource{token: "test-token"}
+	client := NewClient(ts, 12345, "test@example.com")
+	client.SetRetryPolicy(-1, 20*time.Millisecond)
+
+	rt, ok := client.httpClient.Transport.(*RetryTransport)
+	if !ok {
+		t.Fatal("expected RetryTransport")
+	}
+
+	if rt.MaxRetries429 != DefaultMaxRetries429 || rt.MaxRetries5xx != DefaultMaxRetries5xx {
+		t.Errorf("negative max retries should keep defaults, got 429=%d 5xx=%d", rt.MaxRetries429, rt.MaxRetries5xx)
+	}
+
+	// Backoff is base * 2^attempt plus up to 50% jitter
+	d := rt.calculateExponentialBackoff(1)
+	if d < 40*time.Millisecond || d >= 60*time.Millisecond {
+		t.Errorf("backoff for attempt 1 = %v, want [40ms, 60ms)", d)
+	}
+}
+
+func TestSetTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	ts := &staticTokenSource{token: "test-token"}
+	client := NewClientWithBaseURL(ts, 12345, "test@example.com", srv.URL)
+	client.SetTimeout(20 * time.Millisecond)
+
+	var result map[string]any
+	err := client.Get(context.Background(), "/slow", &result)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestSetLogging(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"error":"maintenance"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		level    int
+		contains []string
+		excludes []string
+	}{
+		{0, nil, []string{"[http]"}},
+		{LogRequests, []string{"GET " + srv.URL + "/test -> 503", "-> 200 OK", "attempt 2"}, []string{"maintenance", "Authorization"}},
+		{LogBodies, []string{`{"error":"maintenance"}`, "Authorization: [REDACTED]"}, []string{"secret-token"}},
+	}
+
+	for _, tt := range tests {
+		atomic.StoreInt32(&attempts, 0)
+		client := NewClientWithBaseURL(&staticTokenSource{token: "secret-token"}, 12345, "test@example.com", srv.URL)
+		client.SetRetryPolicy(-1, time.Millisecond)
+		var log bytes.Buffer
+		client.SetLogging(&log, tt.level)
+
+		var result map[string]any
+		if err := client.Get(context.Background(), "/test", &result); err != nil {
+			t.Fatalf("level %d: Get failed: %v", tt.level, err)
+		}
+		for _, want := range tt.contains {
+			if !strings.Contains(log.String(), want) {
+				t.Errorf("level %d: log missing %q, got: %s", tt.level, want, log.String())
+			}
+		}
+		for _, unwanted := range tt.excludes {
+			if strings.Contains(log.String(), unwanted) {
+				t.Errorf("level %d: log should not contain %q, got: %s", tt.level, unwanted, log.String())
+			}
+		}
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	var methods []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":1}`))
+	}))
+	defer srv.Close()
+
+	ts := &staticTokenSource{token: "test-token"}
+	client := NewClientWithBaseURL(ts, 12345, "test@example.com", srv.URL)
+
+	var log bytes.Buffer
+	client.SetDryRun(&log)
+
+	ctx := context.Background()
+	var result map[string]any
+	if err := client.Get(ctx, "/projects/1", &result); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if err := client.Post(ctx, "/time_entries", map[string]any{"hours": 1.5}, &result); err != nil {
+		t.Fatalf("Post failed: %v", err)
+	}
+	if err := client.Delete(ctx, "/time_entries/9"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	// Only the GET reaches the server
+	if len(methods) != 1 || methods[0] != http.MethodGet {
+		t.Errorf("server saw %v, want [GET]", methods)
+	}
+
+	calls := client.DryRunCalls()
+	if len(calls) != 2 {
+		t.Fatalf("expected 2 recorded calls, got %d", len(calls))
+	}
+	if calls[0].Method != http.MethodPost || calls[0].Path != "/time_entries" {
+		t.Errorf("unexpected first call: %+v", calls[0])
+	}
+	if calls[1].Method != http.MethodDelete || calls[1].Path != "/time_entries/9" {
+		t.Errorf("unexpected second call: %+v", calls[1])
+	}
+
+	out := log.String()
+	if !strings.Contains(out, "POST /time_entries") || !strings.Contains(out, `"hours": 1.5`) {
+		t.Errorf("dry-run log missing request details: %s", out)
+	}
+}
+
 func TestCircuitBreaker(t *testing.T) {
 	cb := NewCircuitBreaker()
 
@@ -334,6 +530,29 @@ func TestRateLimiterProactiveWait(t *testing.T) {
 	}
 }
 
+func TestRateLimiterWaitForCapacity(t *testing.T) {
+	rl := NewGeneralRateLimiter()
+
+	// Requests left: no wait, even though the general limiter is reactive
+	start := time.Now()
+	if err := rl.WaitForCapacity(context.Background()); err != nil || time.Since(start) > 20*time.Millisecond {
+		t.Fatalf("WaitForCapacity() with capacity = %v after %v", err, time.Since(start))
+	}
+
+	rl.mu.Lock()
+	rl.remaining = 0
+	rl.resetAt = time.Now().Add(60 * time.Millisecond)
+	rl.mu.Unlock()
+
+	start = time.Now()
+	if err := rl.WaitForCapacity(context.Background()); err != nil {
+		t.Fatalf("WaitForCapacity failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("waited too short: %v", elapsed)
+	}
+}
+
 func TestExitCodes(t *testing.T) {
 	tests := []struct {
 		err  error
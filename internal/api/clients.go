@@ -25,6 +25,7 @@ type ClientListOptions struct {
 	UpdatedSince string
 	Page         int
 	PerPage      int
+	MaxItems     int
 }
 
 // QueryParams converts options to URL query parameters.
@@ -97,15 +98,16 @@ func (c *Client) DeleteClient(ctx context.Context, id int64) error {
 func (c *Client) ListAllClients(ctx context.Context, opts ClientListOptions) ([]HarvestClient, error) {
 	var all []HarvestClient
 	opts.Page = 1
-	if opts.PerPage == 0 {
-		opts.PerPage = 100
-	}
+	opts.PerPage = pageSize(opts.PerPage, opts.MaxItems)
 	for {
 		resp, err := c.ListClients(ctx, opts)
 		if err != nil {
 			return nil, err
 		}
 		all = append(all, resp.Clients...)
+		if capped, done := capItems(all, opts.MaxItems); done {
+			return capped, nil
+		}
 		if resp.NextPage == nil {
 			break
 		}
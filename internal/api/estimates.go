@@ -28,6 +28,7 @@ type EstimateListOptions struct {
 	To           string
 	Page         int
 	PerPage      int
+	MaxItems     int
 }
 
 // QueryParams converts options to URL query parameters.
@@ -109,15 +110,16 @@ func (c *Client) DeleteEstimate(ctx context.Context, id int64) error {
 func (c *Client) ListAllEstimates(ctx context.Context, opts EstimateListOptions) ([]Estimate, error) {
 	var all []Estimate
 	opts.Page = 1
-	if opts.PerPage == 0 {
-		opts.PerPage = 100
-	}
+	opts.PerPage = pageSize(opts.PerPage, opts.MaxItems)
 	for {
 		resp, err := c.ListEstimates(ctx, opts)
 		if err != nil {
 			return nil, err
 		}
 		all = append(all, resp.Estimates...)
+		if capped, done := capItems(all, opts.MaxItems); done {
+			return capped, nil
+		}
 		if resp.NextPage == nil {
 			break
 		}
@@ -68,6 +68,31 @@ func (c *Client) GetExpenseCategory(ctx context.Context, id int64) (*ExpenseCate
 	return &category, nil
 }
 
+// CreateExpenseCategory creates a new expense category.
+func (c *Client) CreateExpenseCategory(ctx context.Context, input *ExpenseCategoryInput) (*ExpenseCategory, error) {
+	var category ExpenseCategory
+	if err := c.Post(ctx, "/expense_categories", input, &category); err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// UpdateExpenseCategory updates an existing expense category.
+func (c *Client) UpdateExpenseCategory(ctx context.Context, id int64, input *ExpenseCategoryInput) (*ExpenseCategory, error) {
+	path := fmt.Sprintf("/expense_categories/%d", id)
+	var category ExpenseCategory
+	if err := c.Patch(ctx, path, input, &category); err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// DeleteExpenseCategory deletes an expense category.
+func (c *Client) DeleteExpenseCategory(ctx context.Context, id int64) error {
+	path := fmt.Sprintf("/expense_categories/%d", id)
+	return c.Delete(ctx, path)
+}
+
 // ListAllExpenseCategories fetches all expense categories across all pages.
 func (c *Client) ListAllExpenseCategories(ctx context.Context, opts ExpenseCategoryListOptions) ([]ExpenseCategory, error) {
 	var all []ExpenseCategory
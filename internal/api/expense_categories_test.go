@@ -0,0 +1,131 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+func TestCreateExpenseCategory(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if r.URL.Path != "/expense_categories" {
+			t.Errorf("expected /expense_categories, got %s", r.URL.Path)
+		}
+
+		var input ExpenseCategoryInput
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			t.Fatalf("failed to decode body: %v", err)
+		}
+		if input.Name != "Mileage" {
+			t.Errorf("expected 'Mileage', got '%s'", input.Name)
+		}
+		if input.UnitName == nil || *input.UnitName != "mile" {
+			t.Errorf("expected unit_name 'mile', got %v", input.UnitName)
+		}
+
+		category := ExpenseCategory{
+			ID:        42,
+			Name:      input.Name,
+			UnitName:  input.UnitName,
+			UnitPrice: input.UnitPrice,
+			IsActive:  true,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(category)
+	}))
+	defer ts.Close()
+
+	client := NewClientWithBaseURL(
+		oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"}),
+		12345,
+		"test@example.com",
+		ts.URL,
+	)
+
+	unitName := "mile"
+	unitPrice := 0.58
+	category, err := client.CreateExpenseCategory(context.Background(), &ExpenseCategoryInput{
+		Name:      "Mileage",
+		UnitName:  &unitName,
+		UnitPrice: &unitPrice,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if category.ID != 42 {
+		t.Errorf("expected ID 42, got %d", category.ID)
+	}
+	if category.UnitPrice == nil || *category.UnitPrice != 0.58 {
+		t.Errorf("expected unit price 0.58, got %v", category.UnitPrice)
+	}
+}
+
+func TestUpdateExpenseCategory(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("expected PATCH, got %s", r.Method)
+		}
+		if r.URL.Path != "/expense_categories/42" {
+			t.Errorf("expected /expense_categories/42, got %s", r.URL.Path)
+		}
+
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode body: %v", err)
+		}
+		if body["is_active"] != false {
+			t.Errorf("expected is_active=false, got %v", body["is_active"])
+		}
+		if _, ok := body["name"]; ok {
+			t.Error("name should be omitted when unchanged")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ExpenseCategory{ID: 42, Name: "Mileage"})
+	}))
+	defer ts.Close()
+
+	client := NewClientWithBaseURL(
+		oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"}),
+		12345,
+		"test@example.com",
+		ts.URL,
+	)
+
+	inactive := false
+	if _, err := client.UpdateExpenseCategory(context.Background(), 42, &ExpenseCategoryInput{IsActive: &inactive}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestDeleteExpenseCategory(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("expected DELETE, got %s", r.Method)
+		}
+		if r.URL.Path != "/expense_categories/42" {
+			t.Errorf("expected /expense_categories/42, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	client := NewClientWithBaseURL(
+		oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"}),
+		12345,
+		"test@example.com",
+		ts.URL,
+	)
+
+	if err := client.DeleteExpenseCategory(context.Background(), 42); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
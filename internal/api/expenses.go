@@ -38,6 +38,7 @@ type ExpenseListOptions struct {
 	To             string
 	Page           int
 	PerPage        int
+	MaxItems       int
 }
 
 // QueryParams converts options to URL query parameters.
@@ -128,15 +129,16 @@ func (c *Client) DeleteExpense(ctx context.Context, id int64) error {
 func (c *Client) ListAllExpenses(ctx context.Context, opts ExpenseListOptions) ([]Expense, error) {
 	var all []Expense
 	opts.Page = 1
-	if opts.PerPage == 0 {
-		opts.PerPage = 100
-	}
+	opts.PerPage = pageSize(opts.PerPage, opts.MaxItems)
 	for {
 		resp, err := c.ListExpenses(ctx, opts)
 		if err != nil {
 			return nil, err
 		}
 		all = append(all, resp.Expenses...)
+		if capped, done := capItems(all, opts.MaxItems); done {
+			return capped, nil
+		}
 		if resp.NextPage == nil {
 			break
 		}
@@ -147,6 +149,12 @@ func (c *Client) ListAllExpenses(ctx context.Context, opts ExpenseListOptions) (
 
 // UploadExpenseReceipt uploads a receipt file to an expense using multipart/form-data.
 func (c *Client) UploadExpenseReceipt(ctx context.Context, expenseID int64, receiptPath string) (*Expense, error) {
+	if c.dryRun {
+		c.recordDryRun(http.MethodPatch, fmt.Sprintf("/expenses/%d", expenseID),
+			map[string]string{"receipt": filepath.Base(receiptPath)})
+		return &Expense{ID: expenseID}, nil
+	}
+
 	// Open the file
 	file, err := os.Open(receiptPath)
 	if err != nil {
@@ -90,6 +90,7 @@ type InvoiceListOptions struct {
 	State        string // draft, open, paid, closed
 	Page         int
 	PerPage      int
+	MaxItems     int
 }
 
 // QueryParams converts options to URL query parameters.
@@ -255,15 +256,16 @@ func (c *Client) MarkInvoiceOpen(ctx context.Context, id int64) (*Invoice, error
 func (c *Client) ListAllInvoices(ctx context.Context, opts InvoiceListOptions) ([]Invoice, error) {
 	var all []Invoice
 	opts.Page = 1
-	if opts.PerPage == 0 {
-		opts.PerPage = 100
-	}
+	opts.PerPage = pageSize(opts.PerPage, opts.MaxItems)
 	for {
 		resp, err := c.ListInvoices(ctx, opts)
 		if err != nil {
 			return nil, err
 		}
 		all = append(all, resp.Invoices...)
+		if capped, done := capItems(all, opts.MaxItems); done {
+			return capped, nil
+		}
 		if resp.NextPage == nil {
 			break
 		}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Verbosity levels for LoggingTransport.
+const (
+	// LogRequests logs one line per HTTP attempt.
+	LogRequests = 1
+	// LogBodies also logs request headers and the body of error responses.
+	LogBodies = 2
+)
+
+// maxLoggedBody caps how much of an error response body is logged.
+const maxLoggedBody = 4096
+
+// LoggingTransport wraps an http.RoundTripper and logs each request it
+// sends. It sits beneath RetryTransport, so every retry attempt is logged.
+type LoggingTransport struct {
+	Base  http.RoundTripper
+	Out   io.Writer
+	Level int
+}
+
+// RoundTrip sends the request and logs its method, URL, status and duration.
+func (t *LoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.Base.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+
+	suffix := elapsed.String()
+	if n := attemptFromContext(req.Context()); n > 1 {
+		suffix += fmt.Sprintf(", attempt %d", n)
+	}
+
+	if err != nil {
+		fmt.Fprintf(t.Out, "[http] %s %s -> error: %v (%s)\n", req.Method, req.URL, err, suffix)
+	} else {
+		fmt.Fprintf(t.Out, "[http] %s %s -> %s (%s)\n", req.Method, req.URL, resp.Status, suffix)
+	}
+	if t.Level >= LogBodies {
+		writeHeaders(t.Out, req.Header)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if t.Level >= LogBodies && resp.StatusCode >= 400 && resp.Body != nil {
+		body, readErr := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		if readErr != nil {
+			return nil, fmt.Errorf("read response body: %w", readErr)
+		}
+		if len(body) > maxLoggedBody {
+			body = append(body[:maxLoggedBody:maxLoggedBody], "..."...)
+		}
+		if len(body) > 0 {
+			fmt.Fprintf(t.Out, "[http]   %s\n", bytes.TrimSpace(body))
+		}
+	}
+	return resp, nil
+}
+
+// writeHeaders logs request headers in name order with credentials redacted.
+func writeHeaders(w io.Writer, header http.Header) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := strings.Join(header[name], ", ")
+		if strings.EqualFold(name, "Authorization") {
+			value = "[REDACTED]"
+		}
+		fmt.Fprintf(w, "[http]   %s: %s\n", name, value)
+	}
+}
+
+type attemptKey struct{}
+
+// withAttempt returns a shallow copy of req tagged with its attempt number.
+func withAttempt(req *http.Request, attempt int) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), attemptKey{}, attempt))
+}
+
+// attemptFromContext returns the attempt number set by RetryTransport, or
+// zero outside of it.
+func attemptFromContext(ctx context.Context) int {
+	n, _ := ctx.Value(attemptKey{}).(int)
+	return n
+}
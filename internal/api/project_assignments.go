@@ -0,0 +1,131 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// TaskAssignmentsResponse is the paginated response for a project's task
+// assignments.
+type TaskAssignmentsResponse struct {
+	TaskAssignments []TaskAssignment `json:"task_assignments"`
+	PerPage         int              `json:"per_page"`
+	TotalPages      int              `json:"total_pages"`
+	TotalEntries    int              `json:"total_entries"`
+	NextPage        *int             `json:"next_page"`
+	PreviousPage    *int             `json:"previous_page"`
+	Page            int              `json:"page"`
+	Links           PaginationLinks  `json:"links"`
+}
+
+// UserAssignmentsResponse is the paginated response for a project's user
+// assignments.
+type UserAssignmentsResponse struct {
+	UserAssignments []UserAssignment `json:"user_assignments"`
+	PerPage         int              `json:"per_page"`
+	TotalPages      int              `json:"total_pages"`
+	TotalEntries    int              `json:"total_entries"`
+	NextPage        *int             `json:"next_page"`
+	PreviousPage    *int             `json:"previous_page"`
+	Page            int              `json:"page"`
+	Links           PaginationLinks  `json:"links"`
+}
+
+// AssignmentListOptions filters task and user assignment list requests.
+type AssignmentListOptions struct {
+	IsActive     *bool
+	UpdatedSince string
+	Page         int
+	PerPage      int
+}
+
+// QueryParams converts options to URL query parameters.
+func (o AssignmentListOptions) QueryParams() string {
+	v := url.Values{}
+	if o.IsActive != nil {
+		v.Set("is_active", strconv.FormatBool(*o.IsActive))
+	}
+	if o.UpdatedSince != "" {
+		v.Set("updated_since", o.UpdatedSince)
+	}
+	if o.Page > 0 {
+		v.Set("page", strconv.Itoa(o.Page))
+	}
+	if o.PerPage > 0 {
+		v.Set("per_page", strconv.Itoa(o.PerPage))
+	}
+	if len(v) == 0 {
+		return ""
+	}
+	return "?" + v.Encode()
+}
+
+// ListTaskAssignments returns a paginated list of a project's task assignments.
+func (c *Client) ListTaskAssignments(ctx context.Context, projectID int64, opts AssignmentListOptions) (*TaskAssignmentsResponse, error) {
+	path := fmt.Sprintf("/projects/%d/task_assignments", projectID) + opts.QueryParams()
+	var resp TaskAssignmentsResponse
+	if err := c.Get(ctx, path, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListAllTaskAssignments fetches all of a project's task assignments.
+func (c *Client) ListAllTaskAssignments(ctx context.Context, projectID int64, opts AssignmentListOptions) ([]TaskAssignment, error) {
+	var all []TaskAssignment
+	opts.Page = 1
+	opts.PerPage = pageSize(opts.PerPage, 0)
+	for {
+		resp, err := c.ListTaskAssignments(ctx, projectID, opts)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, resp.TaskAssignments...)
+		if resp.NextPage == nil {
+			break
+		}
+		opts.Page = *resp.NextPage
+	}
+	return all, nil
+}
+
+// CreateTaskAssignment assigns a task to a project.
+func (c *Client) CreateTaskAssignment(ctx context.Context, projectID int64, input *TaskAssignmentInput) (*TaskAssignment, error) {
+	path := fmt.Sprintf("/projects/%d/task_assignments", projectID)
+	var ta TaskAssignment
+	if err := c.Post(ctx, path, input, &ta); err != nil {
+		return nil, err
+	}
+	return &ta, nil
+}
+
+// ListUserAssignments returns a paginated list of a project's user assignments.
+func (c *Client) ListUserAssignments(ctx context.Context, projectID int64, opts AssignmentListOptions) (*UserAssignmentsResponse, error) {
+	path := fmt.Sprintf("/projects/%d/user_assignments", projectID) + opts.QueryParams()
+	var resp UserAssignmentsResponse
+	if err := c.Get(ctx, path, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListAllUserAssignments fetches all of a project's user assignments.
+func (c *Client) ListAllUserAssignments(ctx context.Context, projectID int64, opts AssignmentListOptions) ([]UserAssignment, error) {
+	var all []UserAssignment
+	opts.Page = 1
+	opts.PerPage = pageSize(opts.PerPage, 0)
+	for {
+		resp, err := c.ListUserAssignments(ctx, projectID, opts)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, resp.UserAssignments...)
+		if resp.NextPage == nil {
+			break
+		}
+		opts.Page = *resp.NextPage
+	}
+	return all, nil
+}
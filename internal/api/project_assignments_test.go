@@ -0,0 +1,123 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+func TestListAllTaskAssignments(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/projects/42/task_assignments" {
+			t.Errorf("expected /projects/42/task_assignments, got %s", r.URL.Path)
+		}
+
+		resp := TaskAssignmentsResponse{Page: 1}
+		if r.URL.Query().Get("page") == "1" {
+			next := 2
+			resp.NextPage = &next
+			resp.TaskAssignments = []TaskAssignment{{ID: 1, Billable: true, Task: TaskRef{ID: 10, Name: "Design"}}}
+		} else {
+			resp.TaskAssignments = []TaskAssignment{{ID: 2, Task: TaskRef{ID: 11, Name: "Meetings"}}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer ts.Close()
+
+	client := NewClientWithBaseURL(
+		oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"}),
+		12345,
+		"test@example.com",
+		ts.URL,
+	)
+
+	assignments, err := client.ListAllTaskAssignments(context.Background(), 42, AssignmentListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(assignments) != 2 {
+		t.Fatalf("expected 2 assignments, got %d", len(assignments))
+	}
+	if assignments[0].Task.Name != "Design" || assignments[1].Task.ID != 11 {
+		t.Errorf("unexpected assignments: %+v", assignments)
+	}
+}
+
+func TestListAllUserAssignments(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/projects/42/user_assignments" {
+			t.Errorf("expected /projects/42/user_assignments, got %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("is_active"); got != "true" {
+			t.Errorf("expected is_active=true, got %q", got)
+		}
+
+		resp := UserAssignmentsResponse{
+			UserAssignments: []UserAssignment{{ID: 5, IsProjectManager: true, User: UserRef{ID: 7, Name: "Ada"}}},
+			Page:            1,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer ts.Close()
+
+	client := NewClientWithBaseURL(
+		oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"}),
+		12345,
+		"test@example.com",
+		ts.URL,
+	)
+
+	active := true
+	assignments, err := client.ListAllUserAssignments(context.Background(), 42, AssignmentListOptions{IsActive: &active})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(assignments) != 1 || assignments[0].User.Name != "Ada" || !assignments[0].IsProjectManager {
+		t.Errorf("unexpected assignments: %+v", assignments)
+	}
+}
+
+func TestCreateTaskAssignment(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/projects/42/task_assignments" {
+			t.Errorf("expected POST /projects/42/task_assignments, got %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if body["task_id"] != float64(10) || body["billable"] != true {
+			t.Errorf("unexpected body: %v", body)
+		}
+		if _, ok := body["hourly_rate"]; ok {
+			t.Errorf("hourly_rate should be omitted, got %v", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(TaskAssignment{ID: 7, Billable: true, Task: TaskRef{ID: 10, Name: "QA"}})
+	}))
+	defer ts.Close()
+
+	client := NewClientWithBaseURL(
+		oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"}),
+		12345,
+		"test@example.com",
+		ts.URL,
+	)
+
+	billable := true
+	ta, err := client.CreateTaskAssignment(context.Background(), 42, &TaskAssignmentInput{TaskID: 10, Billable: &billable})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ta.ID != 7 || ta.Task.Name != "QA" {
+		t.Errorf("unexpected assignment: %+v", ta)
+	}
+}
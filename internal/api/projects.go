@@ -26,6 +26,7 @@ type ProjectListOptions struct {
 	UpdatedSince string
 	Page         int
 	PerPage      int
+	MaxItems     int
 }
 
 // QueryParams converts options to URL query parameters.
@@ -101,15 +102,16 @@ func (c *Client) DeleteProject(ctx context.Context, id int64) error {
 func (c *Client) ListAllProjects(ctx context.Context, opts ProjectListOptions) ([]Project, error) {
 	var all []Project
 	opts.Page = 1
-	if opts.PerPage == 0 {
-		opts.PerPage = 100
-	}
+	opts.PerPage = pageSize(opts.PerPage, opts.MaxItems)
 	for {
 		resp, err := c.ListProjects(ctx, opts)
 		if err != nil {
 			return nil, err
 		}
 		all = append(all, resp.Projects...)
+		if capped, done := capItems(all, opts.MaxItems); done {
+			return capped, nil
+		}
 		if resp.NextPage == nil {
 			break
 		}
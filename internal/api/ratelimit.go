@@ -81,6 +81,21 @@ func (rl *RateLimiter) Wait(ctx context.Context) error {
 	return sleep(ctx, interval)
 }
 
+// WaitForCapacity blocks until the window resets when no requests remain,
+// in either mode. Batched operations call it between requests so a long run
+// pauses rather than running into 429 responses.
+func (rl *RateLimiter) WaitForCapacity(ctx context.Context) error {
+	rl.mu.Lock()
+	remaining := rl.remaining
+	resetAt := rl.resetAt
+	rl.mu.Unlock()
+
+	if remaining > 0 || resetAt.IsZero() {
+		return nil
+	}
+	return sleepUntil(ctx, resetAt)
+}
+
 // UpdateFromHeaders updates rate limit state from Harvest API headers.
 // Headers: X-RateLimit-Limit, X-RateLimit-Remaining, Retry-After.
 func (rl *RateLimiter) UpdateFromHeaders(h http.Header) {
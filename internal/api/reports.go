@@ -9,10 +9,12 @@ import (
 
 // ReportListOptions contains common options for report requests.
 type ReportListOptions struct {
-	From    string // Required for most reports (YYYY-MM-DD)
-	To      string // Required for most reports (YYYY-MM-DD)
-	Page    int
-	PerPage int
+	From      string // Required for most reports (YYYY-MM-DD)
+	To        string // Required for most reports (YYYY-MM-DD)
+	ProjectID int64  // Scope results to a single project
+	UserID    int64  // Scope results to a single user
+	Page      int
+	PerPage   int
 }
 
 // QueryParams converts options to URL query parameters.
@@ -24,6 +26,12 @@ func (o ReportListOptions) QueryParams() string {
 	if o.To != "" {
 		v.Set("to", o.To)
 	}
+	if o.ProjectID > 0 {
+		v.Set("project_id", strconv.FormatInt(o.ProjectID, 10))
+	}
+	if o.UserID > 0 {
+		v.Set("user_id", strconv.FormatInt(o.UserID, 10))
+	}
 	if o.Page > 0 {
 		v.Set("page", strconv.Itoa(o.Page))
 	}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+func TestReportListOptions_QueryParams(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     ReportListOptions
+		contains []string
+		want     string
+	}{
+		{
+			name: "empty",
+			opts: ReportListOptions{},
+			want: "",
+		},
+		{
+			name:     "date range",
+			opts:     ReportListOptions{From: "2024-01-01", To: "2024-01-31"},
+			contains: []string{"from=2024-01-01", "to=2024-01-31"},
+		},
+		{
+			name:     "project and user scope",
+			opts:     ReportListOptions{ProjectID: 42, UserID: 7},
+			contains: []string{"project_id=42", "user_id=7"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.opts.QueryParams()
+			if tt.contains == nil && result != tt.want {
+				t.Errorf("QueryParams() = %q, want %q", result, tt.want)
+			}
+			for _, c := range tt.contains {
+				if !strings.Contains(result, c) {
+					t.Errorf("expected query params to contain %q, got %q", c, result)
+				}
+			}
+		})
+	}
+}
+
+func TestListTimeReportsByTeam_Scoped(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/reports/time/team" {
+			t.Errorf("expected /reports/time/team, got %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("project_id") != "42" {
+			t.Errorf("expected project_id=42, got %s", q.Get("project_id"))
+		}
+
+		resp := TimeReportsResponse{
+			Results: []TimeReportResult{
+				{UserID: 1, UserName: "Alice", TotalHours: 8, BillableHours: 6, Currency: "EUR"},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer ts.Close()
+
+	client := NewClientWithBaseURL(
+		oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"}),
+		12345,
+		"test@example.com",
+		ts.URL,
+	)
+
+	results, err := client.ListAllTimeReportsByTeam(context.Background(), ReportListOptions{
+		From:      "2024-01-01",
+		To:        "2024-01-31",
+		ProjectID: 42,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+	if results[0].UserName != "Alice" {
+		t.Errorf("expected Alice, got %s", results[0].UserName)
+	}
+}
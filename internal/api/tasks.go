@@ -25,6 +25,7 @@ type TaskListOptions struct {
 	UpdatedSince string
 	Page         int
 	PerPage      int
+	MaxItems     int
 }
 
 // QueryParams converts options to URL query parameters.
@@ -97,15 +98,16 @@ func (c *Client) DeleteTask(ctx context.Context, id int64) error {
 func (c *Client) ListAllTasks(ctx context.Context, opts TaskListOptions) ([]Task, error) {
 	var all []Task
 	opts.Page = 1
-	if opts.PerPage == 0 {
-		opts.PerPage = 100
-	}
+	opts.PerPage = pageSize(opts.PerPage, opts.MaxItems)
 	for {
 		resp, err := c.ListTasks(ctx, opts)
 		if err != nil {
 			return nil, err
 		}
 		all = append(all, resp.Tasks...)
+		if capped, done := capItems(all, opts.MaxItems); done {
+			return capped, nil
+		}
 		if resp.NextPage == nil {
 			break
 		}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 
 	"golang.org/x/oauth2"
@@ -236,3 +237,59 @@ func TestDeleteTask(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestListAllTasksMaxItems(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		q := r.URL.Query()
+		if q.Get("per_page") != "3" {
+			t.Errorf("expected per_page=3, got %s", q.Get("per_page"))
+		}
+
+		page, _ := strconv.Atoi(q.Get("page"))
+		next := page + 1
+		resp := TasksResponse{
+			Tasks:    []Task{{ID: int64(page*10 + 1)}, {ID: int64(page*10 + 2)}, {ID: int64(page*10 + 3)}},
+			NextPage: &next,
+			Page:     page,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer ts.Close()
+
+	client := NewClientWithBaseURL(
+		oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"}),
+		12345,
+		"test@example.com",
+		ts.URL,
+	)
+
+	tasks, err := client.ListAllTasks(context.Background(), TaskListOptions{PerPage: 3, MaxItems: 5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tasks) != 5 {
+		t.Fatalf("expected 5 tasks, got %d", len(tasks))
+	}
+	if requests != 2 {
+		t.Errorf("expected paging to stop after 2 requests, got %d", requests)
+	}
+}
+
+func TestPageSize(t *testing.T) {
+	tests := []struct {
+		perPage, maxItems, want int
+	}{
+		{0, 0, DefaultPerPage},
+		{50, 0, 50},
+		{0, 10, 10},
+		{50, 200, 50},
+	}
+	for _, tt := range tests {
+		if got := pageSize(tt.perPage, tt.maxItems); got != tt.want {
+			t.Errorf("pageSize(%d, %d) = %d, want %d", tt.perPage, tt.maxItems, got, tt.want)
+		}
+	}
+}
@@ -34,6 +34,7 @@ type TimeEntryListOptions struct {
 	UpdatedSince        string
 	Page                int
 	PerPage             int
+	MaxItems            int
 }
 
 // QueryParams converts options to URL query parameters.
@@ -176,15 +177,16 @@ func (c *Client) GetRunningTimeEntry(ctx context.Context) (*TimeEntry, error) {
 func (c *Client) ListAllTimeEntries(ctx context.Context, opts TimeEntryListOptions) ([]TimeEntry, error) {
 	var all []TimeEntry
 	opts.Page = 1
-	if opts.PerPage == 0 {
-		opts.PerPage = 100
-	}
+	opts.PerPage = pageSize(opts.PerPage, opts.MaxItems)
 	for {
 		resp, err := c.ListTimeEntries(ctx, opts)
 		if err != nil {
 			return nil, err
 		}
 		all = append(all, resp.TimeEntries...)
+		if capped, done := capItems(all, opts.MaxItems); done {
+			return capped, nil
+		}
 		if resp.NextPage == nil {
 			break
 		}
@@ -198,26 +200,70 @@ type TimeEntryApprovalRequest struct {
 	TimeEntryIDs []int64 `json:"time_entry_ids"`
 }
 
+// ApprovalBatchSize is the most time entry IDs sent in one approval request.
+// Larger batches are split into several requests.
+const ApprovalBatchSize = 100
+
+// BatchProgress is called after each request of a batched operation with the
+// number of items done so far and the total. It may be nil.
+type BatchProgress func(done, total int)
+
 // SubmitTimeEntriesForApproval submits time entries for manager approval.
-func (c *Client) SubmitTimeEntriesForApproval(ctx context.Context, ids []int64) error {
-	req := TimeEntryApprovalRequest{TimeEntryIDs: ids}
-	return c.Post(ctx, "/time_entries/submit_for_approval", req, nil)
+func (c *Client) SubmitTimeEntriesForApproval(ctx context.Context, ids []int64, progress BatchProgress) error {
+	return c.postApprovalBatches(ctx, "/time_entries/submit_for_approval", ids, progress, func(chunk []int64) any {
+		return TimeEntryApprovalRequest{TimeEntryIDs: chunk}
+	})
 }
 
 // ApproveTimeEntries approves submitted time entries (manager action).
-func (c *Client) ApproveTimeEntries(ctx context.Context, ids []int64) error {
-	req := TimeEntryApprovalRequest{TimeEntryIDs: ids}
-	return c.Post(ctx, "/time_entries/approve", req, nil)
+func (c *Client) ApproveTimeEntries(ctx context.Context, ids []int64, progress BatchProgress) error {
+	return c.postApprovalBatches(ctx, "/time_entries/approve", ids, progress, func(chunk []int64) any {
+		return TimeEntryApprovalRequest{TimeEntryIDs: chunk}
+	})
+}
+
+// TimeEntryRejectRequest is the request body for rejecting entries.
+type TimeEntryRejectRequest struct {
+	TimeEntryIDs []int64 `json:"time_entry_ids"`
+	Message      string  `json:"message,omitempty"`
 }
 
-// RejectTimeEntries rejects submitted time entries (manager action).
-func (c *Client) RejectTimeEntries(ctx context.Context, ids []int64) error {
-	req := TimeEntryApprovalRequest{TimeEntryIDs: ids}
-	return c.Post(ctx, "/time_entries/reject", req, nil)
+// RejectTimeEntries rejects submitted time entries (manager action). A
+// non-empty message is shown to the entries' owners as the reason.
+func (c *Client) RejectTimeEntries(ctx context.Context, ids []int64, message string, progress BatchProgress) error {
+	return c.postApprovalBatches(ctx, "/time_entries/reject", ids, progress, func(chunk []int64) any {
+		return TimeEntryRejectRequest{TimeEntryIDs: chunk, Message: message}
+	})
 }
 
 // UnsubmitTimeEntries returns submitted time entries to draft status.
-func (c *Client) UnsubmitTimeEntries(ctx context.Context, ids []int64) error {
-	req := TimeEntryApprovalRequest{TimeEntryIDs: ids}
-	return c.Post(ctx, "/time_entries/unsubmit", req, nil)
+func (c *Client) UnsubmitTimeEntries(ctx context.Context, ids []int64, progress BatchProgress) error {
+	return c.postApprovalBatches(ctx, "/time_entries/unsubmit", ids, progress, func(chunk []int64) any {
+		return TimeEntryApprovalRequest{TimeEntryIDs: chunk}
+	})
+}
+
+// postApprovalBatches posts ids to path in chunks of ApprovalBatchSize.
+// Before each follow-up request it waits out an exhausted general rate limit
+// window, so approving a whole team's week pauses instead of failing. An
+// error after the first chunk says how many entries were already done.
+func (c *Client) postApprovalBatches(ctx context.Context, path string, ids []int64, progress BatchProgress, body func([]int64) any) error {
+	for start := 0; start < len(ids); start += ApprovalBatchSize {
+		end := min(start+ApprovalBatchSize, len(ids))
+		if start > 0 && c.rateLimiter != nil {
+			if err := c.rateLimiter.WaitForCapacity(ctx); err != nil {
+				return fmt.Errorf("%d of %d entries done: %w", start, len(ids), err)
+			}
+		}
+		if err := c.Post(ctx, path, body(ids[start:end]), nil); err != nil {
+			if start > 0 {
+				return fmt.Errorf("%d of %d entries done: %w", start, len(ids), err)
+			}
+			return err
+		}
+		if progress != nil {
+			progress(end, len(ids))
+		}
+	}
+	return nil
 }
@@ -3,8 +3,10 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -436,3 +438,113 @@ func containsInMiddle(s, substr string) bool {
 	}
 	return false
 }
+
+func TestRejectTimeEntries(t *testing.T) {
+	var body map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/time_entries/reject" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		body = nil
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode body: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	client := NewClientWithBaseURL(
+		oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"}),
+		12345,
+		"test@example.com",
+		ts.URL,
+	)
+
+	if err := client.RejectTimeEntries(context.Background(), []int64{1, 2}, "Missing notes", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body["message"] != "Missing notes" {
+		t.Errorf("message = %v, want %q", body["message"], "Missing notes")
+	}
+
+	if err := client.RejectTimeEntries(context.Background(), []int64{1}, "", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := body["message"]; ok {
+		t.Errorf("empty message should be omitted, got body %v", body)
+	}
+}
+
+func TestApproveTimeEntries_Batches(t *testing.T) {
+	var sizes []int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/time_entries/approve" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body TimeEntryApprovalRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode body: %v", err)
+		}
+		sizes = append(sizes, len(body.TimeEntryIDs))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	client := NewClientWithBaseURL(
+		oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"}),
+		12345,
+		"test@example.com",
+		ts.URL,
+	)
+
+	ids := make([]int64, 250)
+	for i := range ids {
+		ids[i] = int64(i + 1)
+	}
+	var progress []int
+	err := client.ApproveTimeEntries(context.Background(), ids, func(done, total int) {
+		if total != 250 {
+			t.Errorf("total = %d, want 250", total)
+		}
+		progress = append(progress, done)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if fmt.Sprint(sizes) != "[100 100 50]" {
+		t.Errorf("request sizes = %v, want [100 100 50]", sizes)
+	}
+	if fmt.Sprint(progress) != "[100 200 250]" {
+		t.Errorf("progress = %v, want [100 200 250]", progress)
+	}
+}
+
+func TestApproveTimeEntries_PartialFailure(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 2 {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"message":"Entry is locked"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	client := NewClientWithBaseURL(
+		oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"}),
+		12345,
+		"test@example.com",
+		ts.URL,
+	)
+
+	err := client.ApproveTimeEntries(context.Background(), make([]int64, 150), nil)
+	if err == nil || !strings.Contains(err.Error(), "100 of 150 entries done") {
+		t.Errorf("error = %v, want partial progress", err)
+	}
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2", requests)
+	}
+}
@@ -29,6 +29,16 @@ type RetryTransport struct {
 	BaseDelay      time.Duration
 	CircuitBreaker *CircuitBreaker
 	RateLimiter    *RateLimiter
+
+	// OnRetry, if set, is called before waiting to retry a request.
+	OnRetry func(RetryEvent)
+}
+
+// RetryEvent describes a retry RetryTransport is about to wait for.
+type RetryEvent struct {
+	StatusCode int
+	Attempt    int // retry number, starting at 1
+	Delay      time.Duration
 }
 
 // NewRetryTransport creates a transport with sensible defaults.
@@ -83,7 +93,7 @@ func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 			}
 		}
 
-		resp, err = t.Base.RoundTrip(req)
+		resp, err = t.Base.RoundTrip(withAttempt(req, retries429+retries5xx+1))
 		if err != nil {
 			return nil, fmt.Errorf("round trip: %w", err)
 		}
@@ -109,6 +119,7 @@ func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 
 			delay := t.calculateBackoff(retries429, resp)
 			drainAndClose(resp.Body)
+			t.notifyRetry(resp.StatusCode, retries429+1, delay)
 
 			if err := t.sleep(req.Context(), delay); err != nil {
 				return nil, err
@@ -130,6 +141,7 @@ func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 
 			delay := t.calculateExponentialBackoff(retries5xx)
 			drainAndClose(resp.Body)
+			t.notifyRetry(resp.StatusCode, retries5xx+1, delay)
 
 			if err := t.sleep(req.Context(), delay); err != nil {
 				return nil, err
@@ -144,6 +156,12 @@ func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	}
 }
 
+func (t *RetryTransport) notifyRetry(status, attempt int, delay time.Duration) {
+	if t.OnRetry != nil {
+		t.OnRetry(RetryEvent{StatusCode: status, Attempt: attempt, Delay: delay})
+	}
+}
+
 // calculateBackoff determines wait time for 429 responses.
 // Uses Retry-After header if present, otherwise exponential backoff.
 func (t *RetryTransport) calculateBackoff(attempt int, resp *http.Response) time.Duration {
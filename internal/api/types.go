@@ -21,6 +21,30 @@ type Pagination struct {
 	Links        PaginationLinks `json:"links"`
 }
 
+// DefaultPerPage is the page size ListAll helpers use when none is set.
+const DefaultPerPage = 100
+
+// pageSize returns the per_page value for a ListAll loop. No larger page
+// than maxItems is requested.
+func pageSize(perPage, maxItems int) int {
+	if perPage == 0 {
+		perPage = DefaultPerPage
+	}
+	if maxItems > 0 && maxItems < perPage {
+		perPage = maxItems
+	}
+	return perPage
+}
+
+// capItems truncates items to maxItems and reports whether the cap was
+// reached. A zero maxItems means no cap.
+func capItems[T any](items []T, maxItems int) ([]T, bool) {
+	if maxItems <= 0 || len(items) < maxItems {
+		return items, false
+	}
+	return items[:maxItems], true
+}
+
 // UserRef is a reference to a user in nested objects.
 type UserRef struct {
 	ID   int64  `json:"id"`
@@ -69,6 +93,7 @@ type UserAssignment struct {
 	IsActive         bool      `json:"is_active"`
 	Budget           *float64  `json:"budget"`
 	HourlyRate       *float64  `json:"hourly_rate"`
+	User             UserRef   `json:"user"`
 	CreatedAt        time.Time `json:"created_at"`
 	UpdatedAt        time.Time `json:"updated_at"`
 }
@@ -80,6 +105,7 @@ type TaskAssignment struct {
 	IsActive   bool      `json:"is_active"`
 	HourlyRate *float64  `json:"hourly_rate"`
 	Budget     *float64  `json:"budget"`
+	Task       TaskRef   `json:"task"`
 	CreatedAt  time.Time `json:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at"`
 }
@@ -96,6 +122,7 @@ type TimeEntry struct {
 	LockedReason      string             `json:"locked_reason"`
 	IsClosed          bool               `json:"is_closed"`
 	ApprovalStatus    string             `json:"approval_status"`
+	RejectionReason   string             `json:"rejection_reason,omitempty"`
 	IsBilled          bool               `json:"is_billed"`
 	TimerStartedAt    *time.Time         `json:"timer_started_at"`
 	StartedTime       string             `json:"started_time"`
@@ -273,6 +300,15 @@ type TaskInput struct {
 	IsActive          *bool    `json:"is_active,omitempty"`
 }
 
+// TaskAssignmentInput is used to assign a task to a project.
+type TaskAssignmentInput struct {
+	TaskID     int64    `json:"task_id,omitempty"`
+	IsActive   *bool    `json:"is_active,omitempty"`
+	Billable   *bool    `json:"billable,omitempty"`
+	HourlyRate *float64 `json:"hourly_rate,omitempty"`
+	Budget     *float64 `json:"budget,omitempty"`
+}
+
 // ClientInput is used to create or update a client.
 type ClientInput struct {
 	Name     string  `json:"name,omitempty"`
@@ -372,6 +408,14 @@ type ExpenseCategory struct {
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// ExpenseCategoryInput is used to create or update an expense category.
+type ExpenseCategoryInput struct {
+	Name      string   `json:"name,omitempty"`
+	UnitName  *string  `json:"unit_name,omitempty"`
+	UnitPrice *float64 `json:"unit_price,omitempty"`
+	IsActive  *bool    `json:"is_active,omitempty"`
+}
+
 // ExpenseInput is used to create or update an expense.
 type ExpenseInput struct {
 	UserID            *int64   `json:"user_id,omitempty"`
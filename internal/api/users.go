@@ -25,6 +25,7 @@ type UserListOptions struct {
 	UpdatedSince string
 	Page         int
 	PerPage      int
+	MaxItems     int
 }
 
 // QueryParams converts options to URL query parameters.
@@ -106,15 +107,16 @@ func (c *Client) DeleteUser(ctx context.Context, id int64) error {
 func (c *Client) ListAllUsers(ctx context.Context, opts UserListOptions) ([]User, error) {
 	var all []User
 	opts.Page = 1
-	if opts.PerPage == 0 {
-		opts.PerPage = 100
-	}
+	opts.PerPage = pageSize(opts.PerPage, opts.MaxItems)
 	for {
 		resp, err := c.ListUsers(ctx, opts)
 		if err != nil {
 			return nil, err
 		}
 		all = append(all, resp.Users...)
+		if capped, done := capItems(all, opts.MaxItems); done {
+			return capped, nil
+		}
 		if resp.NextPage == nil {
 			break
 		}
@@ -183,3 +185,31 @@ func (c *Client) ListAllMyProjectAssignments(ctx context.Context) ([]ProjectAssi
 	}
 	return all, nil
 }
+
+// ListUserProjectAssignments returns project assignments for a specific user.
+func (c *Client) ListUserProjectAssignments(ctx context.Context, userID int64, opts MyProjectAssignmentsOptions) (*MyProjectAssignmentsResponse, error) {
+	path := fmt.Sprintf("/users/%d/project_assignments", userID) + opts.QueryParams()
+	var resp MyProjectAssignmentsResponse
+	if err := c.Get(ctx, path, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListAllUserProjectAssignments fetches all project assignments for a specific user.
+func (c *Client) ListAllUserProjectAssignments(ctx context.Context, userID int64) ([]ProjectAssignment, error) {
+	var all []ProjectAssignment
+	opts := MyProjectAssignmentsOptions{Page: 1, PerPage: 100}
+	for {
+		resp, err := c.ListUserProjectAssignments(ctx, userID, opts)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, resp.ProjectAssignments...)
+		if resp.NextPage == nil {
+			break
+		}
+		opts.Page = *resp.NextPage
+	}
+	return all, nil
+}
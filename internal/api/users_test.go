@@ -270,3 +270,37 @@ func TestUserFullName(t *testing.T) {
 		t.Errorf("expected 'John Smith', got '%s'", user.FullName())
 	}
 }
+
+func TestListAllUserProjectAssignments(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/users/42/project_assignments" {
+			t.Errorf("expected /users/42/project_assignments, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page") == "2" {
+			w.Write([]byte(`{"project_assignments":[{"id":2,"project":{"id":20,"name":"Intranet"}}],"next_page":null}`))
+			return
+		}
+		w.Write([]byte(`{"project_assignments":[{"id":1,"is_project_manager":true,"project":{"id":10,"name":"Website"}}],"next_page":2}`))
+	}))
+	defer ts.Close()
+
+	client := NewClientWithBaseURL(
+		oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"}),
+		12345,
+		"test@example.com",
+		ts.URL,
+	)
+
+	assignments, err := client.ListAllUserProjectAssignments(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(assignments) != 2 {
+		t.Fatalf("expected 2 assignments, got %d", len(assignments))
+	}
+	if !assignments[0].IsProjectManager || assignments[1].Project.Name != "Intranet" {
+		t.Errorf("unexpected assignments: %+v", assignments)
+	}
+}
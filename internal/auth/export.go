@@ -0,0 +1,208 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dedene/harvest-cli/internal/config"
+)
+
+const (
+	exportFormat  = "harvest-cli-credentials"
+	exportVersion = 1
+
+	// exportIterations is the PBKDF2-SHA256 work factor for export files.
+	exportIterations = 600_000
+
+	// MinPassphraseLength is the shortest passphrase accepted for exports.
+	MinPassphraseLength = 8
+)
+
+var (
+	// ErrWrongPassphrase means an export file could not be decrypted, either
+	// because the passphrase is wrong or the file was modified.
+	ErrWrongPassphrase = errors.New("wrong passphrase or corrupted file")
+
+	errNotExportFile = errors.New("not a harvest credentials export file")
+)
+
+// ExportedToken is a stored credential in an export bundle, including the
+// refresh token (or PAT) that Token never serializes.
+type ExportedToken struct {
+	Client       string    `json:"client"`
+	Email        string    `json:"email"`
+	AccountID    int64     `json:"account_id"`
+	Scopes       []string  `json:"scopes,omitempty"`
+	CreatedAt    time.Time `json:"created_at,omitempty"`
+	RefreshToken string    `json:"refresh_token"`
+}
+
+// Bundle is the decrypted content of an export file. Clients holds the OAuth
+// app credentials needed to refresh the exported tokens.
+type Bundle struct {
+	ExportedAt time.Time                           `json:"exported_at"`
+	Tokens     []ExportedToken                     `json:"tokens"`
+	Clients    map[string]config.ClientCredentials `json:"clients,omitempty"`
+}
+
+// exportEnvelope is the on-disk format: the bundle sealed with AES-256-GCM
+// under a key derived from the passphrase.
+type exportEnvelope struct {
+	Format     string `json:"format"`
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// ExportBundle collects every token in store, plus the OAuth client
+// credentials the tokens were issued to.
+func ExportBundle(store Store) (*Bundle, error) {
+	tokens, err := store.ListTokens()
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Bundle{ExportedAt: time.Now().UTC(), Tokens: make([]ExportedToken, len(tokens))}
+	for i, tok := range tokens {
+		b.Tokens[i] = ExportedToken{
+			Client:       tok.Client,
+			Email:        tok.Email,
+			AccountID:    tok.AccountID,
+			Scopes:       tok.Scopes,
+			CreatedAt:    tok.CreatedAt,
+			RefreshToken: tok.RefreshToken,
+		}
+		if tok.Client == PATClient || !config.ClientCredentialsExist(tok.Client) {
+			continue
+		}
+		creds, err := config.ReadClientCredentials(tok.Client)
+		if err != nil {
+			return nil, fmt.Errorf("read %s client credentials: %w", tok.Client, err)
+		}
+		if b.Clients == nil {
+			b.Clients = make(map[string]config.ClientCredentials)
+		}
+		b.Clients[tok.Client] = *creds
+	}
+	return b, nil
+}
+
+// ImportBundle stores the bundle's tokens in store. OAuth client credentials
+// are written only for clients not already set up on this machine.
+func ImportBundle(store Store, b *Bundle) error {
+	for name, creds := range b.Clients {
+		if config.ClientCredentialsExist(name) {
+			continue
+		}
+		if err := config.WriteClientCredentials(name, &creds); err != nil {
+			return fmt.Errorf("save %s client credentials: %w", name, err)
+		}
+	}
+
+	for _, et := range b.Tokens {
+		tok := Token{
+			Client:       et.Client,
+			Email:        et.Email,
+			AccountID:    et.AccountID,
+			Scopes:       et.Scopes,
+			CreatedAt:    et.CreatedAt,
+			RefreshToken: et.RefreshToken,
+		}
+		if err := store.SetToken(et.Client, et.Email, et.AccountID, tok); err != nil {
+			return fmt.Errorf("store token for %s: %w", et.Email, err)
+		}
+	}
+	return nil
+}
+
+// EncryptBundle seals the bundle with a key derived from passphrase.
+func EncryptBundle(b *Bundle, passphrase string) ([]byte, error) {
+	if len(passphrase) < MinPassphraseLength {
+		return nil, fmt.Errorf("passphrase must be at least %d characters", MinPassphraseLength)
+	}
+
+	plaintext, err := json.Marshal(b)
+	if err != nil {
+		return nil, fmt.Errorf("encode bundle: %w", err)
+	}
+
+	env := exportEnvelope{
+		Format:     exportFormat,
+		Version:    exportVersion,
+		KDF:        "pbkdf2-sha256",
+		Iterations: exportIterations,
+		Salt:       make([]byte, 16),
+	}
+	if _, err := rand.Read(env.Salt); err != nil {
+		return nil, fmt.Errorf("generate salt: %w", err)
+	}
+
+	gcm, err := exportCipher(passphrase, env.Salt, env.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	env.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(env.Nonce); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+	env.Ciphertext = gcm.Seal(nil, env.Nonce, plaintext, []byte(exportFormat))
+
+	return json.MarshalIndent(env, "", "  ")
+}
+
+// DecryptBundle opens an export file written by EncryptBundle.
+func DecryptBundle(data []byte, passphrase string) (*Bundle, error) {
+	var env exportEnvelope
+	if err := json.Unmarshal(data, &env); err != nil || env.Format != exportFormat {
+		return nil, errNotExportFile
+	}
+	if env.Version != exportVersion || env.KDF != "pbkdf2-sha256" {
+		return nil, fmt.Errorf("unsupported export file version %d (%s)", env.Version, env.KDF)
+	}
+	// The work factor is fixed for this version; trusting the file's value
+	// would let a crafted file make the import hang or weaken the key
+	if env.Iterations != exportIterations {
+		return nil, fmt.Errorf("unsupported export file: %d key derivation iterations, want %d", env.Iterations, exportIterations)
+	}
+
+	gcm, err := exportCipher(passphrase, env.Salt, env.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	if len(env.Nonce) != gcm.NonceSize() {
+		return nil, ErrWrongPassphrase
+	}
+	plaintext, err := gcm.Open(nil, env.Nonce, env.Ciphertext, []byte(exportFormat))
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+
+	var b Bundle
+	if err := json.Unmarshal(plaintext, &b); err != nil {
+		return nil, fmt.Errorf("decode bundle: %w", err)
+	}
+	return &b, nil
+}
+
+// exportCipher derives the AES-256-GCM cipher for a passphrase and salt.
+func exportCipher(passphrase string, salt []byte, iterations int) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, iterations, 32)
+	if err != nil {
+		return nil, fmt.Errorf("derive key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
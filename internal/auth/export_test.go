@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/dedene/harvest-cli/internal/config"
+)
+
+func TestEncryptDecryptBundle(t *testing.T) {
+	b := &Bundle{
+		Tokens: []ExportedToken{{Client: "default", Email: "a@example.com", AccountID: 1, RefreshToken: "refresh-secret"}},
+		Clients: map[string]config.ClientCredentials{
+			"default": {ClientID: "id", ClientSecret: "client-secret"},
+		},
+	}
+
+	data, err := EncryptBundle(b, "correct horse")
+	if err != nil {
+		t.Fatalf("EncryptBundle() error = %v", err)
+	}
+	for _, secret := range []string{"refresh-secret", "client-secret", "a@example.com"} {
+		if bytes.Contains(data, []byte(secret)) {
+			t.Errorf("export file contains %q in clear text", secret)
+		}
+	}
+
+	got, err := DecryptBundle(data, "correct horse")
+	if err != nil {
+		t.Fatalf("DecryptBundle() error = %v", err)
+	}
+	if len(got.Tokens) != 1 || got.Tokens[0].RefreshToken != "refresh-secret" || got.Clients["default"].ClientSecret != "client-secret" {
+		t.Errorf("DecryptBundle() = %+v", got)
+	}
+
+	if _, err := DecryptBundle(data, "wrong horse"); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("DecryptBundle(wrong passphrase) error = %v, want ErrWrongPassphrase", err)
+	}
+	if _, err := DecryptBundle([]byte(`{"tokens":[]}`), "correct horse"); err == nil {
+		t.Error("DecryptBundle() should reject files that are not exports")
+	}
+	for _, iterations := range []string{`"iterations": 1,`, `"iterations": 2000000000,`} {
+		tampered := bytes.Replace(data, []byte(`"iterations": 600000,`), []byte(iterations), 1)
+		if _, err := DecryptBundle(tampered, "correct horse"); err == nil || errors.Is(err, ErrWrongPassphrase) {
+			t.Errorf("DecryptBundle(%s) error = %v, want the work factor rejected", iterations, err)
+		}
+	}
+	if _, err := EncryptBundle(b, "short"); err == nil {
+		t.Error("EncryptBundle() should reject short passphrases")
+	}
+}
+
+func TestExportImportBundle(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	if err := config.WriteClientCredentials("default", &config.ClientCredentials{ClientID: "id", ClientSecret: "secret"}); err != nil {
+		t.Fatal(err)
+	}
+
+	src := &KeyringStore{ring: newMockKeyring()}
+	if err := src.SetToken("default", "a@example.com", 1, Token{RefreshToken: "r1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := StorePAT(src, "b@example.com", 2, "pat-b"); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ExportBundle(src)
+	if err != nil {
+		t.Fatalf("ExportBundle() error = %v", err)
+	}
+	if len(b.Tokens) != 2 || len(b.Clients) != 1 || b.Clients["default"].ClientID != "id" {
+		t.Fatalf("ExportBundle() = %+v, want 2 tokens and the default client", b)
+	}
+
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	dst := &KeyringStore{ring: newMockKeyring()}
+	if err := ImportBundle(dst, b); err != nil {
+		t.Fatalf("ImportBundle() error = %v", err)
+	}
+	if tok, err := dst.GetToken("default", "a@example.com"); err != nil || tok.RefreshToken != "r1" || tok.AccountID != 1 {
+		t.Errorf("imported OAuth token = %+v, %v", tok, err)
+	}
+	if pat, id, err := GetPAT(dst, "b@example.com"); err != nil || pat != "pat-b" || id != 2 {
+		t.Errorf("imported PAT = %q, %d, %v", pat, id, err)
+	}
+	if creds, err := config.ReadClientCredentials("default"); err != nil || creds.ClientSecret != "secret" {
+		t.Errorf("imported client credentials = %+v, %v", creds, err)
+	}
+}
@@ -43,7 +43,10 @@ type Token struct {
 
 const (
 	keyringPasswordEnv = "HARVESTCLI_KEYRING_PASSWORD" //nolint:gosec // env var name
-	keyringBackendEnv  = "HARVESTCLI_KEYRING_BACKEND"  //nolint:gosec // env var name
+
+	// KeyringBackendEnv selects the keyring backend unless --keyring-backend
+	// is given.
+	KeyringBackendEnv = "HARVESTCLI_KEYRING_BACKEND" //nolint:gosec // env var name
 )
 
 var (
@@ -59,24 +62,48 @@ var (
 	keyringOpenFunc = keyring.Open
 )
 
-const keyringOpenTimeout = 5 * time.Second
+// Backends lists the keyring backend names users can choose.
+var Backends = []string{"auto", "keychain", "file", "secret-service", "wincred"}
 
-func openKeyring() (keyring.Keyring, error) {
-	backend := normalizeBackend(os.Getenv(keyringBackendEnv))
+// OpenOption configures how OpenDefault opens the keyring.
+type OpenOption func(*openOptions)
 
-	backends, err := allowedBackends(backend)
-	if err != nil {
-		return nil, err
+// openOptions is the keyring backend to use and the setting it came from,
+// e.g. "--keyring-backend", for messages.
+type openOptions struct {
+	backend string
+	source  string
+}
+
+// WithBackend selects the keyring backend instead of
+// HARVESTCLI_KEYRING_BACKEND. source names the setting it came from for
+// messages. An empty backend keeps the environment's.
+func WithBackend(backend, source string) OpenOption {
+	return func(o *openOptions) {
+		if backend = normalizeBackend(backend); backend != "" {
+			o.backend, o.source = backend, source
+		}
 	}
+}
 
+// resolveOpenOptions applies opts over the backend set in the environment.
+func resolveOpenOptions(opts []OpenOption) openOptions {
+	o := openOptions{backend: normalizeBackend(os.Getenv(KeyringBackendEnv)), source: KeyringBackendEnv}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+const keyringOpenTimeout = 5 * time.Second
+
+func openKeyring(o openOptions) (keyring.Keyring, error) {
+	backend, source := o.backend, o.source
 	dbusAddr := os.Getenv("DBUS_SESSION_BUS_ADDRESS")
 
-	// Platform-specific backend defaults
-	switch {
-	case runtime.GOOS == "darwin" && (backend == "" || backend == "auto"):
-		backends = []keyring.BackendType{keyring.KeychainBackend}
-	case shouldForceFileBackend(runtime.GOOS, backend, dbusAddr):
-		backends = []keyring.BackendType{keyring.FileBackend}
+	backends, err := selectBackends(runtime.GOOS, backend, dbusAddr)
+	if err != nil {
+		return nil, fmt.Errorf("%w (from %s)", err, source)
 	}
 
 	keyringDir := config.KeyringDir()
@@ -111,6 +138,55 @@ func openKeyring() (keyring.Keyring, error) {
 	return ring, nil
 }
 
+// selectBackends returns the keyring backends to try, applying the
+// platform defaults when no backend is configured.
+func selectBackends(goos, backend, dbusAddr string) ([]keyring.BackendType, error) {
+	backends, err := allowedBackends(backend)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case goos == "darwin" && (backend == "" || backend == "auto"):
+		backends = []keyring.BackendType{keyring.KeychainBackend}
+	case shouldForceFileBackend(goos, backend, dbusAddr):
+		backends = []keyring.BackendType{keyring.FileBackend}
+	}
+	return backends, nil
+}
+
+// DescribeBackend explains which keyring backend OpenDefault would use with
+// opts, e.g. "file (no D-Bus session; set HARVESTCLI_KEYRING_PASSWORD)".
+func DescribeBackend(opts ...OpenOption) (string, error) {
+	o := resolveOpenOptions(opts)
+	dbusAddr := os.Getenv("DBUS_SESSION_BUS_ADDRESS")
+	return describeBackend(runtime.GOOS, o.backend, o.source, dbusAddr)
+}
+
+func describeBackend(goos, backend, source, dbusAddr string) (string, error) {
+	backends, err := selectBackends(goos, backend, dbusAddr)
+	if err != nil {
+		return "", fmt.Errorf("%w from %s; use %s", err, source, strings.Join(Backends, ", "))
+	}
+
+	var desc string
+	if len(backends) == 0 {
+		desc = "auto (first available system keyring)"
+	} else {
+		names := make([]string, len(backends))
+		for i, b := range backends {
+			names[i] = string(b)
+		}
+		desc = strings.Join(names, ", ")
+	}
+	if shouldForceFileBackend(goos, backend, dbusAddr) {
+		desc += fmt.Sprintf(" (no D-Bus session; set %s to avoid a password prompt)", keyringPasswordEnv)
+	} else if backend != "" && backend != "auto" {
+		desc += fmt.Sprintf(" (from %s)", source)
+	}
+	return desc, nil
+}
+
 func normalizeBackend(value string) string {
 	return strings.ToLower(strings.TrimSpace(value))
 }
@@ -185,13 +261,14 @@ func openKeyringWithTimeout(cfg keyring.Config, timeout time.Duration) (keyring.
 		return res.ring, nil
 	case <-time.After(timeout):
 		return nil, fmt.Errorf("%w after %v; set %s=file and %s=<password>",
-			errKeyringTimeout, timeout, keyringBackendEnv, keyringPasswordEnv)
+			errKeyringTimeout, timeout, KeyringBackendEnv, keyringPasswordEnv)
 	}
 }
 
-// OpenDefault opens the keyring store with auto-detected backend.
-func OpenDefault() (Store, error) {
-	ring, err := openKeyringFunc()
+// OpenDefault opens the keyring store with the backend chosen by opts, or
+// else by HARVESTCLI_KEYRING_BACKEND, auto-detecting it when neither is set.
+func OpenDefault(opts ...OpenOption) (Store, error) {
+	ring, err := openKeyringFunc(resolveOpenOptions(opts))
 	if err != nil {
 		return nil, err
 	}
@@ -201,11 +278,7 @@ func OpenDefault() (Store, error) {
 
 // OpenWithBackend opens the keyring store with a specific backend.
 func OpenWithBackend(backend string) (Store, error) {
-	orig := os.Getenv(keyringBackendEnv)
-	os.Setenv(keyringBackendEnv, backend)
-	defer os.Setenv(keyringBackendEnv, orig)
-
-	return OpenDefault()
+	return OpenDefault(WithBackend(backend, "backend argument"))
 }
 
 // storedToken is the JSON-serialized format for keyring storage.
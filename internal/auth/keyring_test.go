@@ -2,6 +2,7 @@ package auth
 
 import (
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -355,6 +356,51 @@ func TestShouldForceFileBackend(t *testing.T) {
 	}
 }
 
+func TestDescribeBackend(t *testing.T) {
+	tests := []struct {
+		goos     string
+		backend  string
+		source   string
+		dbusAddr string
+		want     string
+	}{
+		{"linux", "", KeyringBackendEnv, "", "file (no D-Bus session; set HARVESTCLI_KEYRING_PASSWORD to avoid a password prompt)"},
+		{"linux", "", KeyringBackendEnv, "/run/user/1000/bus", "auto (first available system keyring)"},
+		{"darwin", "", KeyringBackendEnv, "", "keychain"},
+		{"linux", "secret-service", KeyringBackendEnv, "", "secret-service (from HARVESTCLI_KEYRING_BACKEND)"},
+		{"linux", "file", "--keyring-backend", "/run/user/1000/bus", "file (from --keyring-backend)"},
+	}
+
+	for _, tt := range tests {
+		got, err := describeBackend(tt.goos, tt.backend, tt.source, tt.dbusAddr)
+		if err != nil {
+			t.Fatalf("describeBackend(%q, %q, %q) error = %v", tt.goos, tt.backend, tt.dbusAddr, err)
+		}
+		if got != tt.want {
+			t.Errorf("describeBackend(%q, %q, %q) = %q, want %q",
+				tt.goos, tt.backend, tt.dbusAddr, got, tt.want)
+		}
+	}
+
+	if _, err := describeBackend("linux", "bogus", "keyring_backend", ""); err == nil || !strings.Contains(err.Error(), "from keyring_backend") {
+		t.Errorf("describeBackend() error = %v, want unknown backend from keyring_backend", err)
+	}
+}
+
+func TestResolveOpenOptions(t *testing.T) {
+	t.Setenv(KeyringBackendEnv, "secret-service")
+
+	if o := resolveOpenOptions(nil); o.backend != "secret-service" || o.source != KeyringBackendEnv {
+		t.Errorf("resolveOpenOptions() = %+v; want the environment", o)
+	}
+	if o := resolveOpenOptions([]OpenOption{WithBackend(" File ", "--keyring-backend")}); o.backend != "file" || o.source != "--keyring-backend" {
+		t.Errorf("resolveOpenOptions(WithBackend) = %+v; want file from --keyring-backend", o)
+	}
+	if o := resolveOpenOptions([]OpenOption{WithBackend("", "--keyring-backend")}); o.backend != "secret-service" {
+		t.Errorf("resolveOpenOptions(empty backend) = %+v; want secret-service", o)
+	}
+}
+
 func TestShouldUseTimeout(t *testing.T) {
 	tests := []struct {
 		goos     string
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"sync"
 	"time"
 
@@ -69,6 +70,45 @@ func (ts *TokenSource) Token() (*oauth2.Token, error) {
 	}, nil
 }
 
+// Refresh exchanges the stored refresh token for a new access token, even if
+// a cached access token is still valid.
+func (ts *TokenSource) Refresh() (*oauth2.Token, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if err := ts.refresh(); err != nil {
+		return nil, err
+	}
+
+	return &oauth2.Token{
+		AccessToken: ts.accessToken,
+		Expiry:      ts.accessExpiry,
+	}, nil
+}
+
+// Email returns the account email this token source refreshes for.
+func (ts *TokenSource) Email() string {
+	return ts.email
+}
+
+// IsRefreshRejected reports whether err means the OAuth server refused the
+// stored refresh token, so the user has to log in again.
+func IsRefreshRejected(err error) bool {
+	if errors.Is(err, ErrNotAuthenticated) {
+		return true
+	}
+	var retrieveErr *oauth2.RetrieveError
+	if !errors.As(err, &retrieveErr) {
+		return false
+	}
+	if retrieveErr.ErrorCode == "invalid_grant" {
+		return true
+	}
+	return retrieveErr.Response != nil &&
+		(retrieveErr.Response.StatusCode == http.StatusBadRequest ||
+			retrieveErr.Response.StatusCode == http.StatusUnauthorized)
+}
+
 // Invalidate marks the current access token as invalid.
 // Forces a refresh on the next Token() call.
 // Call this on 401 responses.
@@ -133,8 +173,8 @@ func (ts *TokenSource) refresh() error {
 
 // GetAuthenticatedEmail returns the email for any authenticated account,
 // optionally filtered by client name.
-func GetAuthenticatedEmail(client string) (string, error) {
-	store, err := OpenDefault()
+func GetAuthenticatedEmail(client string, opts ...OpenOption) (string, error) {
+	store, err := OpenDefault(opts...)
 	if err != nil {
 		return "", err
 	}
@@ -2,6 +2,9 @@ package auth
 
 import (
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"sync"
 	"testing"
 	"time"
@@ -241,6 +244,73 @@ func TestTokenSource_ThreadSafe(t *testing.T) {
 	wg.Wait()
 }
 
+func TestTokenSource_Refresh_IgnoresCache(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("parse form: %v", err)
+		}
+		if r.Form.Get("refresh_token") != "refresh-token" {
+			t.Errorf("refresh_token = %q", r.Form.Get("refresh_token"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"new-access-token","token_type":"bearer","expires_in":3600}`)
+	}))
+	defer srv.Close()
+
+	store := newMockStore()
+	_ = store.SetToken("default", "test@example.com", 123, Token{RefreshToken: "refresh-token"})
+	cfg := &oauth2.Config{ClientID: "id", ClientSecret: "secret", Endpoint: oauth2.Endpoint{TokenURL: srv.URL}}
+	ts := NewTokenSource(store, "default", "test@example.com", cfg)
+
+	// A valid cached token must not prevent the refresh
+	ts.mu.Lock()
+	ts.accessToken = "cached-token"
+	ts.accessExpiry = time.Now().Add(time.Hour)
+	ts.mu.Unlock()
+
+	tok, err := ts.Refresh()
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if tok.AccessToken != "new-access-token" {
+		t.Errorf("AccessToken = %q, want %q", tok.AccessToken, "new-access-token")
+	}
+	if time.Until(tok.Expiry) < 50*time.Minute {
+		t.Errorf("Expiry = %v, want about an hour from now", tok.Expiry)
+	}
+}
+
+func TestTokenSource_Refresh_Rejected(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error":"invalid_grant","error_description":"refresh token revoked"}`)
+	}))
+	defer srv.Close()
+
+	store := newMockStore()
+	_ = store.SetToken("default", "test@example.com", 123, Token{RefreshToken: "revoked"})
+	cfg := &oauth2.Config{ClientID: "id", ClientSecret: "secret", Endpoint: oauth2.Endpoint{TokenURL: srv.URL}}
+	ts := NewTokenSource(store, "default", "test@example.com", cfg)
+
+	_, err := ts.Refresh()
+	if err == nil {
+		t.Fatal("Refresh() should fail for a revoked refresh token")
+	}
+	if !IsRefreshRejected(err) {
+		t.Errorf("IsRefreshRejected(%v) = false, want true", err)
+	}
+}
+
+func TestIsRefreshRejected(t *testing.T) {
+	if !IsRefreshRejected(ErrNotAuthenticated) {
+		t.Error("ErrNotAuthenticated should count as rejected")
+	}
+	if IsRefreshRejected(errors.New("network down")) {
+		t.Error("network errors should not count as rejected")
+	}
+}
+
 func TestHarvestOAuthEndpoint(t *testing.T) {
 	if HarvestOAuthEndpoint.AuthURL == "" {
 		t.Error("AuthURL is empty")
@@ -0,0 +1,339 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/alecthomas/kong"
+
+	"github.com/dedene/harvest-cli/internal/auth"
+	"github.com/dedene/harvest-cli/internal/output"
+)
+
+// allAccountsCommands are the read-only commands that may run with
+// --all-accounts.
+var allAccountsCommands = map[string]bool{
+	"approvals list":           true,
+	"clients list":             true,
+	"estimates list":           true,
+	"expenses categories list": true,
+	"expenses list":            true,
+	"invoices aging":           true,
+	"invoices list":            true,
+	"invoices payments list":   true,
+	"projects list":            true,
+	"reports budget":           true,
+	"reports detailed":         true,
+	"reports expenses":         true,
+	"reports time":             true,
+	"reports uninvoiced":       true,
+	"tasks list":               true,
+	"time last":                true,
+	"time list":                true,
+	"users list":               true,
+}
+
+// runAllAccounts runs the selected command once per authenticated account
+// and merges the results, tagging each row with the account it came from.
+// Tables are merged from their captured rows, so alignment, dates and totals
+// survive; plain output merges the TSV.
+// Failing accounts are reported to stderr without stopping the others.
+func runAllAccounts(kctx *kong.Context, cli *CLI) error {
+	command := commandPath(kctx)
+	if !allAccountsCommands[command] {
+		return fmt.Errorf("--all-accounts only works with read commands, not %q", command)
+	}
+	if _, _, ok := auth.GetPATFromEnv(); ok {
+		return fmt.Errorf("--all-accounts uses keyring accounts; unset %s to use it", auth.PATEnvToken)
+	}
+	if cli.Account != "" || cli.AccountID != 0 {
+		return fmt.Errorf("--all-accounts cannot be combined with --account or --account-id")
+	}
+
+	store, err := openStore(&cli.RootFlags)
+	if err != nil {
+		return fmt.Errorf("open keyring: %w", err)
+	}
+	tokens, err := store.ListTokens()
+	if err != nil {
+		return fmt.Errorf("list tokens: %w", err)
+	}
+	if len(tokens) == 0 {
+		return fmt.Errorf("not authenticated; run 'harvest auth login'")
+	}
+
+	mode := output.ModeFromFlags(cli.JSON, cli.Plain)
+	ndjson := ndjsonRequested(kctx)
+	stdout := cli.Stdout
+	defer func() {
+		cli.Stdout = stdout
+		cli.Account, cli.Client = "", ""
+		currencyFormat = nil
+	}()
+
+	var (
+		tagged   []json.RawMessage
+		headers  []string
+		rows     [][]string
+		captures []accountCapture
+		failures int
+	)
+	for _, tok := range tokens {
+		var buf bytes.Buffer
+		cli.Stdout = &buf
+		// Tables are captured whole, keeping their alignment and totals,
+		// and each account formats amounts its own way
+		capture := &output.TableCapture{}
+		if mode == output.ModeTable || mode == output.ModeMarkdown {
+			cli.Stdout = capture
+		}
+		cli.Account, cli.Client = tok.Email, tok.Client
+		cli.company = nil
+		currencyFormat = nil
+
+		if err := kctx.Run(); err != nil {
+			failures++
+			fmt.Fprintf(cli.Stderr, "%s: %v\n", tok.Email, err)
+			continue
+		}
+
+		if mode == output.ModeJSON {
+			values, err := tagJSONValues(tok.Email, buf.Bytes())
+			if err != nil {
+				failures++
+				fmt.Fprintf(cli.Stderr, "%s: %v\n", tok.Email, err)
+				continue
+			}
+			tagged = append(tagged, values...)
+			continue
+		}
+		if mode != output.ModePlain {
+			captures = append(captures, accountCapture{account: tok.Email, capture: capture})
+			continue
+		}
+
+		h, r := tagTSV(tok.Email, buf.String())
+		if headers == nil {
+			headers = h
+		}
+		rows = append(rows, r...)
+	}
+
+	if failures == len(tokens) {
+		return fmt.Errorf("command failed for all %d accounts", failures)
+	}
+
+	switch {
+	case mode == output.ModeJSON && ndjson:
+		return output.WriteNDJSON(stdout, tagged)
+	case mode == output.ModeJSON:
+		if tagged == nil {
+			tagged = []json.RawMessage{}
+		}
+		return output.WriteJSON(stdout, tagged)
+	case mode == output.ModePlain:
+		return output.WriteTSV(stdout, headers, rows)
+	default:
+		return writeMergedTables(stdout, captures)
+	}
+}
+
+// accountCapture is the table output of one account's run.
+type accountCapture struct {
+	account string
+	capture *output.TableCapture
+}
+
+// writeMergedTables writes the tables captured from each account as one
+// table with an Account column, keeping each account's totals as tagged
+// footers. Tables with other columns than the first, and any text printed
+// around the tables, follow per account.
+func writeMergedTables(w io.Writer, captures []accountCapture) error {
+	var merged *output.Table
+	var headers []string
+	for _, c := range captures {
+		if len(c.capture.Tables) > 0 {
+			headers = c.capture.Tables[0].Headers
+			merged = newCapturedTable(w, c.capture.Tables[0], true)
+			break
+		}
+	}
+
+	var rest []accountCapture
+	for _, c := range captures {
+		other := &output.TableCapture{}
+		for _, ct := range c.capture.Tables {
+			if slices.Equal(ct.Headers, headers) {
+				addCapturedRows(merged, ct, c.account)
+			} else {
+				other.Tables = append(other.Tables, ct)
+			}
+		}
+		if text := bytes.TrimSpace(c.capture.Text.Bytes()); len(text) > 0 || len(other.Tables) > 0 {
+			other.Text.Write(text)
+			rest = append(rest, accountCapture{account: c.account, capture: other})
+		}
+	}
+
+	if merged != nil {
+		if err := merged.Render(); err != nil {
+			return err
+		}
+	}
+	for i, c := range rest {
+		if merged != nil || i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%s:\n", c.account)
+		if c.capture.Text.Len() > 0 {
+			fmt.Fprintln(w, c.capture.Text.String())
+		}
+		for _, ct := range c.capture.Tables {
+			t := newCapturedTable(w, ct, false)
+			addCapturedRows(t, ct, "")
+			if err := t.Render(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// newCapturedTable returns an empty table with the columns, alignment and
+// date columns of ct, led by an Account column when tagged is set.
+func newCapturedTable(w io.Writer, ct output.CapturedTable, tagged bool) *output.Table {
+	headers, shift := ct.Headers, 0
+	if tagged {
+		headers, shift = append([]string{"Account"}, ct.Headers...), 1
+	}
+	t := output.NewTable(w, headers...)
+	for i, align := range ct.Aligns {
+		t.SetAlign(align, i+shift)
+	}
+	for _, col := range ct.DateCols {
+		t.SetDateColumns(col + shift)
+	}
+	return t
+}
+
+// addCapturedRows adds the rows and footers of ct to t, led by account
+// unless it is empty.
+func addCapturedRows(t *output.Table, ct output.CapturedTable, account string) {
+	tag := func(cells []string) []string {
+		if account == "" {
+			return cells
+		}
+		return append([]string{account}, cells...)
+	}
+	for i, row := range ct.Rows {
+		var style func(string) string
+		if i < len(ct.Styles) {
+			style = ct.Styles[i]
+		}
+		t.AddStyledRow(style, tag(row)...)
+	}
+	for _, footer := range ct.Footers {
+		t.AddFooter(tag(footer)...)
+	}
+}
+
+// commandPath returns the selected command without its arguments, e.g.
+// "time list".
+func commandPath(kctx *kong.Context) string {
+	var parts []string
+	for _, part := range strings.Fields(kctx.Command()) {
+		if !strings.HasPrefix(part, "<") {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// ndjsonRequested reports whether the selected command was given --ndjson.
+func ndjsonRequested(kctx *kong.Context) bool {
+	for _, flag := range kctx.Flags() {
+		if flag.Name == "ndjson" {
+			v, _ := kctx.FlagValue(flag).(bool)
+			return v
+		}
+	}
+	return false
+}
+
+// tagJSONValues decodes one or more JSON values from data and adds an
+// "account" field to each object. Arrays are flattened into their elements.
+func tagJSONValues(account string, data []byte) ([]json.RawMessage, error) {
+	var out []json.RawMessage
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				return out, nil
+			}
+			return nil, fmt.Errorf("parse JSON output: %w", err)
+		}
+
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			items = []json.RawMessage{raw}
+		}
+		for _, item := range items {
+			tagged, err := tagJSONObject(account, item)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, tagged)
+		}
+	}
+}
+
+// tagJSONObject prepends an "account" field to a JSON object. Other values
+// are wrapped as {"account": ..., "value": ...}.
+func tagJSONObject(account string, raw json.RawMessage) (json.RawMessage, error) {
+	name, err := json.Marshal(account)
+	if err != nil {
+		return nil, err
+	}
+
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return json.RawMessage(fmt.Sprintf(`{"account":%s,"value":%s}`, name, trimmed)), nil
+	}
+
+	rest := bytes.TrimSpace(trimmed[1:])
+	if len(rest) > 0 && rest[0] == '}' {
+		return json.RawMessage(fmt.Sprintf(`{"account":%s}`, name)), nil
+	}
+	return json.RawMessage(fmt.Sprintf(`{"account":%s,%s`, name, rest)), nil
+}
+
+// tagTSV splits TSV output into its header and rows, prefixing each with an
+// Account column.
+func tagTSV(account, data string) ([]string, [][]string) {
+	var (
+		headers []string
+		rows    [][]string
+	)
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if headers == nil {
+			headers = append([]string{"Account"}, fields...)
+			continue
+		}
+		rows = append(rows, append([]string{account}, fields...))
+	}
+	return headers, rows
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/dedene/harvest-cli/internal/output"
+)
+
+func TestTagJSONValues(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []string
+	}{
+		{
+			name: "array",
+			data: "[\n  {\"id\": 1},\n  {\"id\": 2}\n]\n",
+			want: []string{`{"account":"a@example.com","id":1}`, `{"account":"a@example.com","id":2}`},
+		},
+		{
+			name: "ndjson",
+			data: "{\"id\":1}\n{\"id\":2}\n",
+			want: []string{`{"account":"a@example.com","id":1}`, `{"account":"a@example.com","id":2}`},
+		},
+		{
+			name: "summary object",
+			data: `{"entries":[],"totals":{"hours":0}}`,
+			want: []string{`{"account":"a@example.com","entries":[],"totals":{"hours":0}}`},
+		},
+		{
+			name: "empty object",
+			data: `{}`,
+			want: []string{`{"account":"a@example.com"}`},
+		},
+		{
+			name: "empty array",
+			data: `[]`,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := tagJSONValues("a@example.com", []byte(tt.data))
+			if err != nil {
+				t.Fatalf("tagJSONValues() error = %v", err)
+			}
+			if len(values) != len(tt.want) {
+				t.Fatalf("got %d values, want %d", len(values), len(tt.want))
+			}
+			for i, v := range values {
+				var compact bytes.Buffer
+				if err := json.Compact(&compact, v); err != nil {
+					t.Fatalf("value %d is not valid JSON: %s", i, v)
+				}
+				if compact.String() != tt.want[i] {
+					t.Errorf("value %d = %s, want %s", i, compact.String(), tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTagJSONValues_Invalid(t *testing.T) {
+	if _, err := tagJSONValues("a@example.com", []byte("not json")); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+}
+
+func TestTagTSV(t *testing.T) {
+	headers, rows := tagTSV("b@example.com", "ID\tName\n1\tWebsite\n2\tApp\n")
+
+	if strings.Join(headers, ",") != "Account,ID,Name" {
+		t.Errorf("headers = %v", headers)
+	}
+	if len(rows) != 2 || strings.Join(rows[1], ",") != "b@example.com,2,App" {
+		t.Errorf("rows = %v", rows)
+	}
+}
+
+func TestWriteMergedTables(t *testing.T) {
+	capture := func(amount string, rows int) *output.TableCapture {
+		c := &output.TableCapture{}
+		tbl := output.NewTable(c, "Client", "Amount").SetAlign(output.AlignRight, 1)
+		for range rows {
+			tbl.AddRow("Acme", amount)
+		}
+		tbl.AddFooter("Total", amount)
+		if err := tbl.Render(); err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+		return c
+	}
+	empty := &output.TableCapture{}
+	empty.Text.WriteString("No payments found\n")
+
+	var buf bytes.Buffer
+	err := writeMergedTables(&buf, []accountCapture{
+		{account: "a@example.com", capture: capture("€1.234,00", 1)},
+		{account: "b@example.com", capture: capture("$5.00", 1)},
+		{account: "c@example.com", capture: empty},
+	})
+	if err != nil {
+		t.Fatalf("writeMergedTables() error = %v", err)
+	}
+
+	want := "Account        Client     Amount\n" +
+		"-------        ------     ------\n" +
+		"a@example.com  Acme    €1.234,00\n" +
+		"b@example.com  Acme        $5.00\n" +
+		"-------------  ------  ---------\n" +
+		"a@example.com  Total   €1.234,00\n" +
+		"b@example.com  Total       $5.00\n" +
+		"\n" +
+		"c@example.com:\n" +
+		"No payments found\n"
+	if buf.String() != want {
+		t.Errorf("writeMergedTables() =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestExecute_AllAccountsRejectsMutations(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	var stdout, stderr bytes.Buffer
+
+	err := Execute([]string{"--all-accounts", "tasks", "remove", "1"}, &stdout, &stderr)
+	if err == nil || !strings.Contains(err.Error(), "only works with read commands") {
+		t.Errorf("Execute() error = %v, want read-only error", err)
+	}
+}
@@ -4,8 +4,8 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/dedene/harvest-cli/internal/api"
@@ -25,14 +25,27 @@ type ApprovalsCmd struct {
 
 // ApprovalsListCmd lists time entries pending approval.
 type ApprovalsListCmd struct {
-	Status string `help:"Filter by status" enum:"submitted,unsubmitted,approved" default:"submitted"`
-	User   string `help:"Filter by user ID or 'me'"`
+	Status string `help:"Filter by status" enum:"submitted,unsubmitted,approved,rejected" default:"submitted"`
+	User   string `help:"Filter by user ID, email or 'me'"`
 	Week   bool   `help:"Show current week only"`
+	NDJSON bool   `help:"Output one JSON object per line" name:"ndjson"`
+
+	Watch         bool          `help:"Keep polling and print entries that are new since the last poll (Ctrl-C to stop)"`
+	WatchInterval time.Duration `help:"Polling interval for --watch" name:"watch-interval" default:"1m"`
 }
 
 func (c *ApprovalsListCmd) Run(cli *CLI) error {
+	if c.Watch && c.WatchInterval <= 0 {
+		return fmt.Errorf("--watch-interval must be positive")
+	}
+
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	if c.Watch {
+		var stop context.CancelFunc
+		ctx, stop = watchContext()
+		defer stop()
+	}
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -43,26 +56,26 @@ func (c *ApprovalsListCmd) Run(cli *CLI) error {
 
 	// Handle week filter
 	if c.Week {
-		from, to := currentWeekRange()
+		weekStart, err := resolveWeekStart(ctx, cli, client)
+		if err != nil {
+			return err
+		}
+		from, to := currentWeekRange(weekStart)
 		opts.From = from
 		opts.To = to
 	}
 
 	// Handle user filter
 	if c.User != "" {
-		if c.User == "me" {
-			me, err := client.GetMe(ctx)
-			if err != nil {
-				return fmt.Errorf("get current user: %w", err)
-			}
-			opts.UserID = me.ID
-		} else {
-			id, err := strconv.ParseInt(c.User, 10, 64)
-			if err != nil {
-				return fmt.Errorf("invalid user ID: %s", c.User)
-			}
-			opts.UserID = id
+		userID, err := resolveUserID(ctx, client, c.User)
+		if err != nil {
+			return err
 		}
+		opts.UserID = userID
+	}
+
+	if c.Watch {
+		return c.watch(ctx, cli, client, opts)
 	}
 
 	entries, err := client.ListAllTimeEntries(ctx, opts)
@@ -70,28 +83,119 @@ func (c *ApprovalsListCmd) Run(cli *CLI) error {
 		return fmt.Errorf("list time entries: %w", err)
 	}
 
-	return outputApprovalsEntries(os.Stdout, entries, output.ModeFromFlags(cli.JSON, cli.Plain))
+	if c.NDJSON {
+		return output.WriteNDJSON(cli.Stdout, entries)
+	}
+
+	return outputApprovalsEntries(cli.Stdout, entries, output.ModeFromFlags(cli.JSON, cli.Plain), c.Status == "rejected")
+}
+
+// watch polls until ctx is canceled, printing entries that were not in the
+// previous poll: everything on the first poll, then new arrivals. An entry
+// that leaves the list and comes back (rejected, then resubmitted) is shown
+// again. JSON output is one object per line.
+func (c *ApprovalsListCmd) watch(ctx context.Context, cli *CLI, client *api.Client, opts api.TimeEntryListOptions) error {
+	mode := output.ModeFromFlags(cli.JSON || c.NDJSON, cli.Plain)
+	reasons := c.Status == "rejected"
+	fmt.Fprintf(cli.Stderr, "Watching %s entries every %s (Ctrl-C to stop)\n", c.Status, c.WatchInterval)
+
+	ticker := time.NewTicker(c.WatchInterval)
+	defer ticker.Stop()
+
+	var seen map[int64]bool
+	for polls := 0; ; polls++ {
+		entries, err := client.ListAllTimeEntries(ctx, opts)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("list time entries: %w", err)
+		}
+
+		fresh := newApprovalEntries(entries, seen)
+		seen = make(map[int64]bool, len(entries))
+		for _, e := range entries {
+			seen[e.ID] = true
+		}
+		if err := writeApprovalsWatch(cli.Stdout, fresh, mode, reasons, polls == 0); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// newApprovalEntries returns the entries whose IDs are not in seen.
+func newApprovalEntries(entries []api.TimeEntry, seen map[int64]bool) []api.TimeEntry {
+	var fresh []api.TimeEntry
+	for _, e := range entries {
+		if !seen[e.ID] {
+			fresh = append(fresh, e)
+		}
+	}
+	return fresh
+}
+
+// writeApprovalsWatch writes one poll's new entries. Plain output has a
+// header row only on the first poll; tables are stamped with the poll time.
+func writeApprovalsWatch(w io.Writer, entries []api.TimeEntry, mode output.Mode, reasons, first bool) error {
+	switch mode {
+	case output.ModeJSON:
+		return output.WriteNDJSON(w, entries)
+	case output.ModePlain:
+		headers, rows := approvalsRows(entries, reasons)
+		if !first {
+			headers = nil
+		}
+		return output.WriteTSV(w, headers, rows)
+	default:
+		if len(entries) == 0 {
+			return nil
+		}
+		if !first {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%s: %d new\n", time.Now().Format("15:04"), len(entries))
+		return outputApprovalsEntries(w, entries, mode, reasons)
+	}
 }
 
 // ApprovalsSubmitCmd submits time entries for approval.
 type ApprovalsSubmitCmd struct {
 	IDs   []int64 `arg:"" optional:"" help:"Time entry IDs to submit"`
 	Week  bool    `help:"Submit all unsubmitted entries for current week" short:"w"`
+	From  string  `help:"Submit unsubmitted entries from this date" aliases:"since"`
+	To    string  `help:"Submit unsubmitted entries up to this date" aliases:"until"`
 	Force bool    `help:"Skip confirmation" short:"f"`
 }
 
 func (c *ApprovalsSubmitCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	var weekStart time.Weekday
+	if c.Week {
+		if weekStart, err = resolveWeekStart(ctx, cli, client); err != nil {
+			return err
+		}
+	}
+
+	from, to, period, err := approvalRange(c.IDs, c.Week, c.From, c.To, weekStart)
 	if err != nil {
 		return err
 	}
 
 	ids := c.IDs
 
-	// If --week, fetch unsubmitted entries for current week
-	if c.Week {
-		from, to := currentWeekRange()
+	// With --week or --from/--to, fetch unsubmitted entries in the window
+	if from != "" {
 		me, err := client.GetMe(ctx)
 		if err != nil {
 			return fmt.Errorf("get current user: %w", err)
@@ -108,7 +212,7 @@ func (c *ApprovalsSubmitCmd) Run(cli *CLI) error {
 		}
 
 		if len(entries) == 0 {
-			fmt.Fprintln(os.Stdout, "No unsubmitted entries for current week")
+			fmt.Fprintf(cli.Stdout, "No unsubmitted entries for %s\n", period)
 			return nil
 		}
 
@@ -118,18 +222,14 @@ func (c *ApprovalsSubmitCmd) Run(cli *CLI) error {
 		}
 
 		// Show what will be submitted
-		fmt.Fprintf(os.Stderr, "Entries to submit (%d):\n", len(entries))
+		fmt.Fprintf(cli.Stderr, "Entries to submit (%d):\n", len(entries))
 		var totalHours float64
 		for _, e := range entries {
-			fmt.Fprintf(os.Stderr, "  #%d: %s - %s - %.2fh (%s)\n",
+			fmt.Fprintf(cli.Stderr, "  #%d: %s - %s - %.2fh (%s)\n",
 				e.ID, e.Project.Name, e.Task.Name, e.Hours, e.SpentDate)
 			totalHours += e.Hours
 		}
-		fmt.Fprintf(os.Stderr, "Total: %.2fh\n\n", totalHours)
-	}
-
-	if len(ids) == 0 {
-		return fmt.Errorf("no time entry IDs specified; use --week or provide IDs")
+		fmt.Fprintf(cli.Stderr, "Total: %.2fh\n\n", totalHours)
 	}
 
 	// Confirm
@@ -138,29 +238,29 @@ func (c *ApprovalsSubmitCmd) Run(cli *CLI) error {
 		confirmed, err := ui.ConfirmPrompt(msg)
 		if err != nil {
 			if err == ui.ErrCanceled {
-				fmt.Fprintln(os.Stderr, "Canceled")
+				fmt.Fprintln(cli.Stderr, "Canceled")
 				return nil
 			}
 			return err
 		}
 		if !confirmed {
-			fmt.Fprintln(os.Stderr, "Aborted")
+			fmt.Fprintln(cli.Stderr, "Aborted")
 			return nil
 		}
 	}
 
-	if err := client.SubmitTimeEntriesForApproval(ctx, ids); err != nil {
+	if err := client.SubmitTimeEntriesForApproval(ctx, ids, batchProgress(cli, "Submitted", len(ids))); err != nil {
 		return fmt.Errorf("submit for approval: %w", err)
 	}
 
-	if cli.JSON {
-		return output.WriteJSON(os.Stdout, map[string]any{
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, map[string]any{
 			"submitted": len(ids),
 			"ids":       ids,
 		})
 	}
 
-	fmt.Fprintf(os.Stdout, "Submitted %d entries for approval\n", len(ids))
+	printSuccess(cli, 0, "Submitted %d entries for approval\n", len(ids))
 	return nil
 }
 
@@ -168,22 +268,35 @@ func (c *ApprovalsSubmitCmd) Run(cli *CLI) error {
 type ApprovalsApproveCmd struct {
 	IDs   []int64 `arg:"" optional:"" help:"Time entry IDs to approve"`
 	Week  bool    `help:"Approve all submitted entries for current week" short:"w"`
-	User  string  `help:"Filter by user ID when using --week"`
+	From  string  `help:"Approve submitted entries from this date" aliases:"since"`
+	To    string  `help:"Approve submitted entries up to this date" aliases:"until"`
+	User  string  `help:"Filter by user ID, email or 'me' when using --week or --from/--to"`
 	Force bool    `help:"Skip confirmation" short:"f"`
 }
 
 func (c *ApprovalsApproveCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	var weekStart time.Weekday
+	if c.Week {
+		if weekStart, err = resolveWeekStart(ctx, cli, client); err != nil {
+			return err
+		}
+	}
+
+	from, to, _, err := approvalRange(c.IDs, c.Week, c.From, c.To, weekStart)
 	if err != nil {
 		return err
 	}
 
 	ids := c.IDs
 
-	// If --week, fetch submitted entries for current week
-	if c.Week {
-		from, to := currentWeekRange()
+	// With --week or --from/--to, fetch submitted entries in the window
+	if from != "" {
 		opts := api.TimeEntryListOptions{
 			From:           from,
 			To:             to,
@@ -191,9 +304,9 @@ func (c *ApprovalsApproveCmd) Run(cli *CLI) error {
 		}
 
 		if c.User != "" {
-			id, err := strconv.ParseInt(c.User, 10, 64)
+			id, err := resolveUserID(ctx, client, c.User)
 			if err != nil {
-				return fmt.Errorf("invalid user ID: %s", c.User)
+				return err
 			}
 			opts.UserID = id
 		}
@@ -204,7 +317,7 @@ func (c *ApprovalsApproveCmd) Run(cli *CLI) error {
 		}
 
 		if len(entries) == 0 {
-			fmt.Fprintln(os.Stdout, "No submitted entries to approve")
+			fmt.Fprintln(cli.Stdout, "No submitted entries to approve")
 			return nil
 		}
 
@@ -214,18 +327,14 @@ func (c *ApprovalsApproveCmd) Run(cli *CLI) error {
 		}
 
 		// Show what will be approved
-		fmt.Fprintf(os.Stderr, "Entries to approve (%d):\n", len(entries))
+		fmt.Fprintf(cli.Stderr, "Entries to approve (%d):\n", len(entries))
 		var totalHours float64
 		for _, e := range entries {
-			fmt.Fprintf(os.Stderr, "  #%d: %s - %s - %.2fh (%s) [%s]\n",
+			fmt.Fprintf(cli.Stderr, "  #%d: %s - %s - %.2fh (%s) [%s]\n",
 				e.ID, e.User.Name, e.Project.Name, e.Hours, e.SpentDate, e.Task.Name)
 			totalHours += e.Hours
 		}
-		fmt.Fprintf(os.Stderr, "Total: %.2fh\n\n", totalHours)
-	}
-
-	if len(ids) == 0 {
-		return fmt.Errorf("no time entry IDs specified; use --week or provide IDs")
+		fmt.Fprintf(cli.Stderr, "Total: %.2fh\n\n", totalHours)
 	}
 
 	// Confirm
@@ -234,41 +343,42 @@ func (c *ApprovalsApproveCmd) Run(cli *CLI) error {
 		confirmed, err := ui.ConfirmPrompt(msg)
 		if err != nil {
 			if err == ui.ErrCanceled {
-				fmt.Fprintln(os.Stderr, "Canceled")
+				fmt.Fprintln(cli.Stderr, "Canceled")
 				return nil
 			}
 			return err
 		}
 		if !confirmed {
-			fmt.Fprintln(os.Stderr, "Aborted")
+			fmt.Fprintln(cli.Stderr, "Aborted")
 			return nil
 		}
 	}
 
-	if err := client.ApproveTimeEntries(ctx, ids); err != nil {
+	if err := client.ApproveTimeEntries(ctx, ids, batchProgress(cli, "Approved", len(ids))); err != nil {
 		return fmt.Errorf("approve entries: %w", err)
 	}
 
-	if cli.JSON {
-		return output.WriteJSON(os.Stdout, map[string]any{
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, map[string]any{
 			"approved": len(ids),
 			"ids":      ids,
 		})
 	}
 
-	fmt.Fprintf(os.Stdout, "Approved %d entries\n", len(ids))
+	printSuccess(cli, 0, "Approved %d entries\n", len(ids))
 	return nil
 }
 
 // ApprovalsRejectCmd rejects submitted time entries.
 type ApprovalsRejectCmd struct {
-	IDs   []int64 `arg:"" help:"Time entry IDs to reject"`
-	Force bool    `help:"Skip confirmation" short:"f"`
+	IDs    []int64 `arg:"" help:"Time entry IDs to reject"`
+	Reason string  `help:"Why the entries were rejected, shown to their owners"`
+	Force  bool    `help:"Skip confirmation" short:"f"`
 }
 
 func (c *ApprovalsRejectCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -283,29 +393,33 @@ func (c *ApprovalsRejectCmd) Run(cli *CLI) error {
 		confirmed, err := ui.ConfirmPrompt(msg)
 		if err != nil {
 			if err == ui.ErrCanceled {
-				fmt.Fprintln(os.Stderr, "Canceled")
+				fmt.Fprintln(cli.Stderr, "Canceled")
 				return nil
 			}
 			return err
 		}
 		if !confirmed {
-			fmt.Fprintln(os.Stderr, "Aborted")
+			fmt.Fprintln(cli.Stderr, "Aborted")
 			return nil
 		}
 	}
 
-	if err := client.RejectTimeEntries(ctx, c.IDs); err != nil {
+	if err := client.RejectTimeEntries(ctx, c.IDs, strings.TrimSpace(c.Reason), batchProgress(cli, "Rejected", len(c.IDs))); err != nil {
 		return fmt.Errorf("reject entries: %w", err)
 	}
 
-	if cli.JSON {
-		return output.WriteJSON(os.Stdout, map[string]any{
+	if cli.jsonOutput() {
+		result := map[string]any{
 			"rejected": len(c.IDs),
 			"ids":      c.IDs,
-		})
+		}
+		if reason := strings.TrimSpace(c.Reason); reason != "" {
+			result["reason"] = reason
+		}
+		return output.WriteJSON(cli.Stdout, result)
 	}
 
-	fmt.Fprintf(os.Stdout, "Rejected %d entries\n", len(c.IDs))
+	printSuccess(cli, 0, "Rejected %d entries\n", len(c.IDs))
 	return nil
 }
 
@@ -313,21 +427,34 @@ func (c *ApprovalsRejectCmd) Run(cli *CLI) error {
 type ApprovalsUnsubmitCmd struct {
 	IDs   []int64 `arg:"" optional:"" help:"Time entry IDs to unsubmit"`
 	Week  bool    `help:"Unsubmit all submitted entries for current week" short:"w"`
+	From  string  `help:"Unsubmit submitted entries from this date" aliases:"since"`
+	To    string  `help:"Unsubmit submitted entries up to this date" aliases:"until"`
 	Force bool    `help:"Skip confirmation" short:"f"`
 }
 
 func (c *ApprovalsUnsubmitCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	var weekStart time.Weekday
+	if c.Week {
+		if weekStart, err = resolveWeekStart(ctx, cli, client); err != nil {
+			return err
+		}
+	}
+
+	from, to, period, err := approvalRange(c.IDs, c.Week, c.From, c.To, weekStart)
 	if err != nil {
 		return err
 	}
 
 	ids := c.IDs
 
-	// If --week, fetch submitted entries for current week
-	if c.Week {
-		from, to := currentWeekRange()
+	// With --week or --from/--to, fetch submitted entries in the window
+	if from != "" {
 		me, err := client.GetMe(ctx)
 		if err != nil {
 			return fmt.Errorf("get current user: %w", err)
@@ -344,7 +471,7 @@ func (c *ApprovalsUnsubmitCmd) Run(cli *CLI) error {
 		}
 
 		if len(entries) == 0 {
-			fmt.Fprintln(os.Stdout, "No submitted entries for current week")
+			fmt.Fprintf(cli.Stdout, "No submitted entries for %s\n", period)
 			return nil
 		}
 
@@ -354,18 +481,14 @@ func (c *ApprovalsUnsubmitCmd) Run(cli *CLI) error {
 		}
 
 		// Show what will be unsubmitted
-		fmt.Fprintf(os.Stderr, "Entries to unsubmit (%d):\n", len(entries))
+		fmt.Fprintf(cli.Stderr, "Entries to unsubmit (%d):\n", len(entries))
 		var totalHours float64
 		for _, e := range entries {
-			fmt.Fprintf(os.Stderr, "  #%d: %s - %s - %.2fh (%s)\n",
+			fmt.Fprintf(cli.Stderr, "  #%d: %s - %s - %.2fh (%s)\n",
 				e.ID, e.Project.Name, e.Task.Name, e.Hours, e.SpentDate)
 			totalHours += e.Hours
 		}
-		fmt.Fprintf(os.Stderr, "Total: %.2fh\n\n", totalHours)
-	}
-
-	if len(ids) == 0 {
-		return fmt.Errorf("no time entry IDs specified; use --week or provide IDs")
+		fmt.Fprintf(cli.Stderr, "Total: %.2fh\n\n", totalHours)
 	}
 
 	// Confirm
@@ -374,82 +497,136 @@ func (c *ApprovalsUnsubmitCmd) Run(cli *CLI) error {
 		confirmed, err := ui.ConfirmPrompt(msg)
 		if err != nil {
 			if err == ui.ErrCanceled {
-				fmt.Fprintln(os.Stderr, "Canceled")
+				fmt.Fprintln(cli.Stderr, "Canceled")
 				return nil
 			}
 			return err
 		}
 		if !confirmed {
-			fmt.Fprintln(os.Stderr, "Aborted")
+			fmt.Fprintln(cli.Stderr, "Aborted")
 			return nil
 		}
 	}
 
-	if err := client.UnsubmitTimeEntries(ctx, ids); err != nil {
+	if err := client.UnsubmitTimeEntries(ctx, ids, batchProgress(cli, "Unsubmitted", len(ids))); err != nil {
 		return fmt.Errorf("unsubmit entries: %w", err)
 	}
 
-	if cli.JSON {
-		return output.WriteJSON(os.Stdout, map[string]any{
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, map[string]any{
 			"unsubmitted": len(ids),
 			"ids":         ids,
 		})
 	}
 
-	fmt.Fprintf(os.Stdout, "Unsubmitted %d entries\n", len(ids))
+	printSuccess(cli, 0, "Unsubmitted %d entries\n", len(ids))
 	return nil
 }
 
-// currentWeekRange returns the start and end dates for the current week (Monday-Sunday).
-func currentWeekRange() (from, to string) {
-	now := time.Now()
-	weekday := now.Weekday()
+// approvalRange resolves the date window selected by --week or --from/--to.
+// Exactly one of --week, --from/--to, or explicit IDs must be given; when IDs
+// are given, from and to are empty. period describes the window for messages.
+func approvalRange(ids []int64, week bool, fromInput, toInput string, weekStart time.Weekday) (from, to, period string, err error) {
+	hasRange := fromInput != "" || toInput != ""
+
+	selectors := 0
+	for _, set := range []bool{len(ids) > 0, week, hasRange} {
+		if set {
+			selectors++
+		}
+	}
+	if selectors != 1 {
+		return "", "", "", fmt.Errorf("specify exactly one of --week, --from/--to, or entry IDs")
+	}
+
+	switch {
+	case week:
+		from, to = currentWeekRange(weekStart)
+		return from, to, "current week", nil
+	case hasRange:
+		if fromInput == "" || toInput == "" {
+			return "", "", "", fmt.Errorf("--from and --to must be used together")
+		}
+		from, to, err = parseDateRange(fromInput, toInput)
+		if err != nil {
+			return "", "", "", err
+		}
+		if from == to {
+			return from, to, from, nil
+		}
+		return from, to, from + " to " + to, nil
+	default:
+		return "", "", "", nil
+	}
+}
+
+// currentWeekRange returns the start and end dates for the current week.
+func currentWeekRange(weekStart time.Weekday) (from, to string) {
+	return weekRange(time.Now(), weekStart)
+}
 
-	// Calculate days since Monday (Go: Sunday=0, Monday=1, ..., Saturday=6)
-	daysSinceMonday := int(weekday) - 1
-	if daysSinceMonday < 0 {
-		daysSinceMonday = 6 // Sunday
+// weekRange returns the start and end dates of the week containing now.
+func weekRange(now time.Time, weekStart time.Weekday) (from, to string) {
+	daysBack := int(now.Weekday()) - int(weekStart)
+	if daysBack < 0 {
+		daysBack += 7
 	}
 
-	monday := now.AddDate(0, 0, -daysSinceMonday)
-	sunday := monday.AddDate(0, 0, 6)
+	start := now.AddDate(0, 0, -daysBack)
+	end := start.AddDate(0, 0, 6)
 
-	return dateparse.FormatDate(monday), dateparse.FormatDate(sunday)
+	return dateparse.FormatDate(start), dateparse.FormatDate(end)
 }
 
-// outputApprovalsEntries writes time entries with approval status.
-func outputApprovalsEntries(w io.Writer, entries []api.TimeEntry, mode output.Mode) error {
-	switch mode {
-	case output.ModeJSON:
+// batchProgress reports approval progress on stderr ("Approved 100/250")
+// when the IDs take more than one request.
+func batchProgress(cli *CLI, verb string, total int) api.BatchProgress {
+	if total <= api.ApprovalBatchSize {
+		return nil
+	}
+	return func(done, total int) {
+		fmt.Fprintf(cli.Stderr, "%s %d/%d\n", verb, done, total)
+	}
+}
+
+// outputApprovalsEntries writes time entries with approval status. With
+// reasons, a Reason column shows why each entry was rejected.
+func outputApprovalsEntries(w io.Writer, entries []api.TimeEntry, mode output.Mode, reasons bool) error {
+	if mode == output.ModeJSON {
 		return output.WriteJSON(w, entries)
-	case output.ModePlain:
-		headers := []string{"ID", "Date", "User", "Project", "Task", "Hours", "Status"}
-		rows := make([][]string, len(entries))
-		for i, e := range entries {
-			rows[i] = []string{
-				strconv.FormatInt(e.ID, 10),
-				e.SpentDate,
-				e.User.Name,
-				e.Project.Name,
-				e.Task.Name,
-				fmt.Sprintf("%.2f", e.Hours),
-				e.ApprovalStatus,
-			}
-		}
+	}
+
+	headers, rows := approvalsRows(entries, reasons)
+	if mode == output.ModePlain {
 		return output.WriteTSV(w, headers, rows)
-	default:
-		t := output.NewTable(w, "ID", "Date", "User", "Project", "Task", "Hours", "Status")
-		for _, e := range entries {
-			t.AddRow(
-				strconv.FormatInt(e.ID, 10),
-				e.SpentDate,
-				e.User.Name,
-				e.Project.Name,
-				e.Task.Name,
-				fmt.Sprintf("%.2f", e.Hours),
-				e.ApprovalStatus,
-			)
-		}
-		return t.Render()
 	}
+	t := output.NewTable(w, headers...).SetAlign(output.AlignRight, 5).SetDateColumns(1)
+	for _, row := range rows {
+		t.AddRow(row...)
+	}
+	return t.Render()
+}
+
+// approvalsRows returns the plain/table columns of approval entries.
+func approvalsRows(entries []api.TimeEntry, reasons bool) (headers []string, rows [][]string) {
+	headers = []string{"ID", "Date", "User", "Project", "Task", "Hours", "Status"}
+	if reasons {
+		headers = append(headers, "Reason")
+	}
+	rows = make([][]string, len(entries))
+	for i, e := range entries {
+		rows[i] = []string{
+			strconv.FormatInt(e.ID, 10),
+			e.SpentDate,
+			e.User.Name,
+			e.Project.Name,
+			e.Task.Name,
+			fmt.Sprintf("%.2f", e.Hours),
+			e.ApprovalStatus,
+		}
+		if reasons {
+			rows[i] = append(rows[i], e.RejectionReason)
+		}
+	}
+	return headers, rows
 }
@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/dedene/harvest-cli/internal/api"
+	"github.com/dedene/harvest-cli/internal/output"
+)
+
+func TestApprovalRange(t *testing.T) {
+	tests := []struct {
+		name       string
+		ids        []int64
+		week       bool
+		from, to   string
+		wantFrom   string
+		wantTo     string
+		wantPeriod string
+		wantErr    bool
+	}{
+		{name: "ids only", ids: []int64{1, 2}},
+		{name: "date range", from: "2024-01-01", to: "2024-01-15", wantFrom: "2024-01-01", wantTo: "2024-01-15", wantPeriod: "2024-01-01 to 2024-01-15"},
+		{name: "single day", from: "2024-03-04", to: "2024-03-04", wantFrom: "2024-03-04", wantTo: "2024-03-04", wantPeriod: "2024-03-04"},
+		{name: "nothing selected", wantErr: true},
+		{name: "week and ids", ids: []int64{1}, week: true, wantErr: true},
+		{name: "week and range", week: true, from: "2024-01-01", to: "2024-01-02", wantErr: true},
+		{name: "from without to", from: "2024-01-01", wantErr: true},
+		{name: "to before from", from: "2024-01-10", to: "2024-01-01", wantErr: true},
+		{name: "invalid date", from: "notadate", to: "2024-01-01", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, period, err := approvalRange(tt.ids, tt.week, tt.from, tt.to, time.Monday)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("approvalRange() error = %v", err)
+			}
+			if from != tt.wantFrom || to != tt.wantTo || period != tt.wantPeriod {
+				t.Errorf("approvalRange() = %q, %q, %q; want %q, %q, %q",
+					from, to, period, tt.wantFrom, tt.wantTo, tt.wantPeriod)
+			}
+		})
+	}
+}
+
+func TestApprovalRange_Week(t *testing.T) {
+	from, to, period, err := approvalRange(nil, true, "", "", time.Sunday)
+	if err != nil {
+		t.Fatalf("approvalRange() error = %v", err)
+	}
+	wantFrom, wantTo := currentWeekRange(time.Sunday)
+	if from != wantFrom || to != wantTo || period != "current week" {
+		t.Errorf("approvalRange() = %q, %q, %q", from, to, period)
+	}
+}
+
+func TestWeekRange(t *testing.T) {
+	// Wednesday 2024-01-17
+	now := time.Date(2024, time.January, 17, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name      string
+		weekStart time.Weekday
+		wantFrom  string
+		wantTo    string
+	}{
+		{name: "monday", weekStart: time.Monday, wantFrom: "2024-01-15", wantTo: "2024-01-21"},
+		{name: "sunday", weekStart: time.Sunday, wantFrom: "2024-01-14", wantTo: "2024-01-20"},
+		{name: "saturday", weekStart: time.Saturday, wantFrom: "2024-01-13", wantTo: "2024-01-19"},
+		{name: "same day", weekStart: time.Wednesday, wantFrom: "2024-01-17", wantTo: "2024-01-23"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to := weekRange(now, tt.weekStart)
+			if from != tt.wantFrom || to != tt.wantTo {
+				t.Errorf("weekRange() = %s, %s; want %s, %s", from, to, tt.wantFrom, tt.wantTo)
+			}
+		})
+	}
+}
+
+func TestOutputApprovalsEntries_Reasons(t *testing.T) {
+	entries := []api.TimeEntry{
+		{ID: 7, SpentDate: "2024-01-02", Hours: 3, ApprovalStatus: "rejected", RejectionReason: "Wrong project"},
+	}
+
+	var buf bytes.Buffer
+	if err := outputApprovalsEntries(&buf, entries, output.ModePlain, true); err != nil {
+		t.Fatalf("outputApprovalsEntries() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if want := "ID\tDate\tUser\tProject\tTask\tHours\tStatus\tReason"; lines[0] != want {
+		t.Errorf("header = %q, want %q", lines[0], want)
+	}
+	if want := "7\t2024-01-02\t\t\t\t3.00\trejected\tWrong project"; lines[1] != want {
+		t.Errorf("row = %q, want %q", lines[1], want)
+	}
+
+	buf.Reset()
+	if err := outputApprovalsEntries(&buf, entries, output.ModePlain, false); err != nil {
+		t.Fatalf("outputApprovalsEntries() error = %v", err)
+	}
+	if strings.Contains(buf.String(), "Reason") {
+		t.Errorf("reason column shown without reasons: %q", buf.String())
+	}
+}
+
+func TestBatchProgress(t *testing.T) {
+	var stderr bytes.Buffer
+	cli := &CLI{Stderr: &stderr}
+
+	if batchProgress(cli, "Approved", api.ApprovalBatchSize) != nil {
+		t.Error("a single-request batch should not report progress")
+	}
+	progress := batchProgress(cli, "Approved", 250)
+	progress(100, 250)
+	progress(250, 250)
+	if got := stderr.String(); got != "Approved 100/250\nApproved 250/250\n" {
+		t.Errorf("stderr = %q", got)
+	}
+}
+
+func TestApprovalsListWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Entry 2 leaves after the first poll and comes back on the third.
+	polls := []string{
+		`[{"id":1,"spent_date":"2024-03-04"},{"id":2,"spent_date":"2024-03-04"}]`,
+		`[{"id":1,"spent_date":"2024-03-04"},{"id":3,"spent_date":"2024-03-05"}]`,
+		`[{"id":1,"spent_date":"2024-03-04"},{"id":2,"spent_date":"2024-03-04"},{"id":3,"spent_date":"2024-03-05"}]`,
+	}
+	n := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("approval_status"); got != "submitted" {
+			t.Errorf("approval_status = %q, want submitted", got)
+		}
+		if n == len(polls) {
+			cancel()
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"time_entries":%s,"total_pages":1,"page":1}`, polls[n])
+		n++
+	}))
+	defer srv.Close()
+
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"})
+	client := api.NewClientWithBaseURL(ts, 1, "test@example.com", srv.URL)
+
+	var stdout, stderr bytes.Buffer
+	cli := &CLI{Stdout: &stdout, Stderr: &stderr}
+	cli.Plain = true
+	c := &ApprovalsListCmd{Status: "submitted", Watch: true, WatchInterval: time.Millisecond}
+	if err := c.watch(ctx, cli, client, api.TimeEntryListOptions{ApprovalStatus: "submitted"}); err != nil {
+		t.Fatalf("watch() error = %v", err)
+	}
+
+	var ids []string
+	for i, line := range strings.Split(strings.TrimSpace(stdout.String()), "\n") {
+		if i == 0 {
+			if !strings.HasPrefix(line, "ID\t") {
+				t.Errorf("first line = %q, want header", line)
+			}
+			continue
+		}
+		ids = append(ids, strings.SplitN(line, "\t", 2)[0])
+	}
+	if got := strings.Join(ids, ","); got != "1,2,3,2" {
+		t.Errorf("printed IDs = %s, want 1,2,3,2", got)
+	}
+}
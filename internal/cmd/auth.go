@@ -14,16 +14,20 @@ import (
 
 	"github.com/dedene/harvest-cli/internal/auth"
 	"github.com/dedene/harvest-cli/internal/config"
+	"github.com/dedene/harvest-cli/internal/output"
 )
 
 // AuthCmd groups authentication subcommands.
 type AuthCmd struct {
-	Setup  AuthSetupCmd  `cmd:"" help:"Store OAuth client credentials"`
-	Login  AuthLoginCmd  `cmd:"" help:"Authenticate with Harvest"`
-	Logout AuthLogoutCmd `cmd:"" help:"Remove stored authentication"`
-	Status AuthStatusCmd `cmd:"" help:"Show authentication status"`
-	List   AuthListCmd   `cmd:"" help:"List authenticated accounts"`
-	Switch AuthSwitchCmd `cmd:"" help:"Switch default account"`
+	Setup   AuthSetupCmd   `cmd:"" help:"Store OAuth client credentials"`
+	Login   AuthLoginCmd   `cmd:"" help:"Authenticate with Harvest"`
+	Logout  AuthLogoutCmd  `cmd:"" help:"Remove stored authentication"`
+	Status  AuthStatusCmd  `cmd:"" help:"Show authentication status"`
+	Refresh AuthRefreshCmd `cmd:"" help:"Refresh the access token and verify it"`
+	List    AuthListCmd    `cmd:"" help:"List authenticated accounts"`
+	Switch  AuthSwitchCmd  `cmd:"" help:"Switch default account"`
+	Export  AuthExportCmd  `cmd:"" help:"Write stored credentials to a passphrase-encrypted file"`
+	Import  AuthImportCmd  `cmd:"" help:"Load credentials from a file written by auth export"`
 }
 
 // AuthSetupCmd stores OAuth credentials.
@@ -33,9 +37,9 @@ type AuthSetupCmd struct {
 	ClientName   string `help:"Client name (default: default)" default:"default" name:"client-name"`
 }
 
-func (c *AuthSetupCmd) Run() error {
+func (c *AuthSetupCmd) Run(cli *CLI) error {
 	if c.ClientID == "" {
-		fmt.Fprintln(os.Stderr, `Usage: harvest auth setup <client_id> [--client-secret <secret>]
+		fmt.Fprintln(cli.Stderr, `Usage: harvest auth setup <client_id> [--client-secret <secret>]
 
 Create a developer app at https://id.getharvest.com/developers
 Use http://localhost:8484/oauth/callback as the Redirect URI
@@ -52,9 +56,9 @@ For non-interactive use (scripts/agents):
 	clientSecret := c.ClientSecret
 	if clientSecret == "" {
 		// Prompt for client secret securely (no echo)
-		fmt.Fprint(os.Stderr, "Client Secret: ")
+		fmt.Fprint(cli.Stderr, "Client Secret: ")
 		secretBytes, err := term.ReadPassword(int(syscall.Stdin))
-		fmt.Fprintln(os.Stderr) // newline after hidden input
+		fmt.Fprintln(cli.Stderr) // newline after hidden input
 		if err != nil {
 			return fmt.Errorf("read secret: %w", err)
 		}
@@ -75,8 +79,8 @@ For non-interactive use (scripts/agents):
 	}
 
 	path := config.ClientCredentialsPath(c.ClientName)
-	fmt.Fprintf(os.Stdout, "Credentials saved to %s\n", path)
-	fmt.Fprintln(os.Stdout, "Run 'harvest auth login' to authenticate.")
+	fmt.Fprintf(cli.Stdout, "Credentials saved to %s\n", path)
+	fmt.Fprintln(cli.Stdout, "Run 'harvest auth login' to authenticate.")
 
 	return nil
 }
@@ -93,16 +97,16 @@ func (c *AuthLoginCmd) Run(cli *CLI) error {
 	ctx := context.Background()
 
 	if c.PAT {
-		return c.loginWithPAT(ctx)
+		return c.loginWithPAT(ctx, cli)
 	}
 
-	return c.loginWithOAuth(ctx)
+	return c.loginWithOAuth(ctx, cli)
 }
 
-func (c *AuthLoginCmd) loginWithPAT(ctx context.Context) error {
+func (c *AuthLoginCmd) loginWithPAT(ctx context.Context, cli *CLI) error {
 	reader := bufio.NewReader(os.Stdin)
 
-	fmt.Fprint(os.Stderr, "Personal Access Token: ")
+	fmt.Fprint(cli.Stderr, "Personal Access Token: ")
 	token, err := reader.ReadString('\n')
 	if err != nil {
 		return fmt.Errorf("read token: %w", err)
@@ -113,7 +117,7 @@ func (c *AuthLoginCmd) loginWithPAT(ctx context.Context) error {
 		return fmt.Errorf("token cannot be empty")
 	}
 
-	fmt.Fprint(os.Stderr, "Account ID: ")
+	fmt.Fprint(cli.Stderr, "Account ID: ")
 	accountIDStr, err := reader.ReadString('\n')
 	if err != nil {
 		return fmt.Errorf("read account ID: %w", err)
@@ -126,14 +130,14 @@ func (c *AuthLoginCmd) loginWithPAT(ctx context.Context) error {
 	}
 
 	// Validate PAT by calling /users/me
-	fmt.Fprintln(os.Stderr, "Validating token...")
+	fmt.Fprintln(cli.Stderr, "Validating token...")
 	email, err := auth.ValidatePAT(ctx, token, accountID)
 	if err != nil {
 		return fmt.Errorf("validate token: %w", err)
 	}
 
 	// Store PAT
-	store, err := auth.OpenDefault()
+	store, err := openStore(&cli.RootFlags)
 	if err != nil {
 		return fmt.Errorf("open keyring: %w", err)
 	}
@@ -142,19 +146,19 @@ func (c *AuthLoginCmd) loginWithPAT(ctx context.Context) error {
 		return fmt.Errorf("store token: %w", err)
 	}
 
-	fmt.Fprintf(os.Stdout, "Successfully authenticated as %s (account %d)\n", email, accountID)
+	fmt.Fprintf(cli.Stdout, "Successfully authenticated as %s (account %d)\n", email, accountID)
 
 	// Set as default if no default exists
 	cfg, _ := config.ReadConfig()
 	if cfg != nil && cfg.DefaultAccount == "" {
 		_ = config.SetDefaultAccount(email)
-		fmt.Fprintf(os.Stdout, "Set %s as default account\n", email)
+		fmt.Fprintf(cli.Stdout, "Set %s as default account\n", email)
 	}
 
 	return nil
 }
 
-func (c *AuthLoginCmd) loginWithOAuth(ctx context.Context) error {
+func (c *AuthLoginCmd) loginWithOAuth(ctx context.Context, cli *CLI) error {
 	// Read client credentials
 	creds, err := config.ReadClientCredentials(c.ClientName)
 	if err != nil {
@@ -174,7 +178,7 @@ func (c *AuthLoginCmd) loginWithOAuth(ctx context.Context) error {
 	}
 
 	// Store token in keyring
-	store, err := auth.OpenDefault()
+	store, err := openStore(&cli.RootFlags)
 	if err != nil {
 		return fmt.Errorf("open keyring: %w", err)
 	}
@@ -190,13 +194,13 @@ func (c *AuthLoginCmd) loginWithOAuth(ctx context.Context) error {
 		return fmt.Errorf("store token: %w", err)
 	}
 
-	fmt.Fprintf(os.Stdout, "Successfully authenticated as %s (account %d)\n", email, accountID)
+	fmt.Fprintf(cli.Stdout, "Successfully authenticated as %s (account %d)\n", email, accountID)
 
 	// Set as default if no default exists
 	cfg, _ := config.ReadConfig()
 	if cfg != nil && cfg.DefaultAccount == "" {
 		_ = config.SetDefaultAccount(email)
-		fmt.Fprintf(os.Stdout, "Set %s as default account\n", email)
+		fmt.Fprintf(cli.Stdout, "Set %s as default account\n", email)
 	}
 
 	return nil
@@ -209,20 +213,20 @@ type AuthLogoutCmd struct {
 	All        bool   `help:"Log out all accounts"`
 }
 
-func (c *AuthLogoutCmd) Run() error {
-	store, err := auth.OpenDefault()
+func (c *AuthLogoutCmd) Run(cli *CLI) error {
+	store, err := openStore(&cli.RootFlags)
 	if err != nil {
 		return fmt.Errorf("open keyring: %w", err)
 	}
 
 	if c.All {
-		return c.logoutAll(store)
+		return c.logoutAll(store, cli)
 	}
 
-	return c.logoutOne(store)
+	return c.logoutOne(store, cli)
 }
 
-func (c *AuthLogoutCmd) logoutAll(store auth.Store) error {
+func (c *AuthLogoutCmd) logoutAll(store auth.Store, cli *CLI) error {
 	tokens, err := store.ListTokens()
 	if err != nil {
 		return fmt.Errorf("list tokens: %w", err)
@@ -231,13 +235,13 @@ func (c *AuthLogoutCmd) logoutAll(store auth.Store) error {
 	count := 0
 	for _, tok := range tokens {
 		if err := store.DeleteToken(tok.Client, tok.Email); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to remove token for %s: %v\n", tok.Email, err)
+			fmt.Fprintf(cli.Stderr, "Warning: failed to remove token for %s: %v\n", tok.Email, err)
 		} else {
 			count++
 		}
 	}
 
-	fmt.Fprintf(os.Stdout, "Logged out %d account(s)\n", count)
+	fmt.Fprintf(cli.Stdout, "Logged out %d account(s)\n", count)
 
 	// Clear default account
 	cfg, _ := config.ReadConfig()
@@ -249,7 +253,7 @@ func (c *AuthLogoutCmd) logoutAll(store auth.Store) error {
 	return nil
 }
 
-func (c *AuthLogoutCmd) logoutOne(store auth.Store) error {
+func (c *AuthLogoutCmd) logoutOne(store auth.Store, cli *CLI) error {
 	email := c.Email
 	clientName := c.ClientName
 
@@ -295,14 +299,14 @@ func (c *AuthLogoutCmd) logoutOne(store auth.Store) error {
 		return fmt.Errorf("no tokens found for %s", email)
 	}
 
-	fmt.Fprintf(os.Stdout, "Logged out %s\n", email)
+	fmt.Fprintf(cli.Stdout, "Logged out %s\n", email)
 
 	// Update default if needed
 	cfg, _ := config.ReadConfig()
 	if cfg != nil && cfg.DefaultAccount == email {
 		cfg.DefaultAccount = ""
 		_ = config.WriteConfig(cfg)
-		fmt.Fprintln(os.Stdout, "Cleared default account")
+		fmt.Fprintln(cli.Stdout, "Cleared default account")
 	}
 
 	return nil
@@ -313,18 +317,18 @@ type AuthStatusCmd struct {
 	ClientName string `help:"OAuth client name" default:"default" name:"client-name"`
 }
 
-func (c *AuthStatusCmd) Run() error {
+func (c *AuthStatusCmd) Run(cli *CLI) error {
 	// Check if credentials exist
 	exists := config.ClientCredentialsExist(c.ClientName)
 
 	if !exists {
-		fmt.Fprintln(os.Stdout, "Not configured")
-		fmt.Fprintln(os.Stdout, "Run 'harvest auth setup <client_id> <client_secret>' to configure OAuth.")
-		fmt.Fprintln(os.Stdout, "Or run 'harvest auth login --pat' to use a Personal Access Token.")
+		fmt.Fprintln(cli.Stdout, "Not configured")
+		fmt.Fprintln(cli.Stdout, "Run 'harvest auth setup <client_id> <client_secret>' to configure OAuth.")
+		fmt.Fprintln(cli.Stdout, "Or run 'harvest auth login --pat' to use a Personal Access Token.")
 		return nil
 	}
 
-	store, err := auth.OpenDefault()
+	store, err := openStore(&cli.RootFlags)
 	if err != nil {
 		return fmt.Errorf("open keyring: %w", err)
 	}
@@ -348,8 +352,8 @@ func (c *AuthStatusCmd) Run() error {
 	}
 
 	if len(matching) == 0 {
-		fmt.Fprintln(os.Stdout, "OAuth credentials configured but not authenticated.")
-		fmt.Fprintln(os.Stdout, "Run 'harvest auth login' to authenticate.")
+		fmt.Fprintln(cli.Stdout, "OAuth credentials configured but not authenticated.")
+		fmt.Fprintln(cli.Stdout, "Run 'harvest auth login' to authenticate.")
 		return nil
 	}
 
@@ -360,7 +364,7 @@ func (c *AuthStatusCmd) Run() error {
 		defaultAccount = cfg.DefaultAccount
 	}
 
-	fmt.Fprintf(os.Stdout, "Authenticated: %d account(s)\n", len(matching))
+	fmt.Fprintf(cli.Stdout, "Authenticated: %d account(s)\n", len(matching))
 	for _, tok := range matching {
 		marker := ""
 		if tok.Email == defaultAccount {
@@ -370,18 +374,77 @@ func (c *AuthStatusCmd) Run() error {
 		if tok.Client == auth.PATClient {
 			authType = "pat"
 		}
-		fmt.Fprintf(os.Stdout, "  - %s [%s] account:%d%s (since %s)\n",
+		fmt.Fprintf(cli.Stdout, "  - %s [%s] account:%d%s (since %s)\n",
 			tok.Email, authType, tok.AccountID, marker, tok.CreatedAt.Format("2006-01-02"))
 	}
+	if backend, err := auth.DescribeBackend(keyringOptions(&cli.RootFlags)...); err == nil {
+		fmt.Fprintf(cli.Stdout, "Keyring: %s\n", backend)
+	}
 
 	return nil
 }
 
+// AuthRefreshCmd forces an OAuth token refresh and verifies the new token.
+type AuthRefreshCmd struct{}
+
+// authRefreshResult is the JSON output of auth refresh.
+type authRefreshResult struct {
+	Email     string    `json:"email"`
+	AccountID int64     `json:"account_id"`
+	UserID    int64     `json:"user_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func (c *AuthRefreshCmd) Run(cli *CLI) error {
+	ctx := context.Background()
+	ts, accountID, err := GetTokenSource(ctx, &cli.RootFlags)
+	if err != nil {
+		return err
+	}
+
+	oauthTS, ok := ts.(*auth.TokenSource)
+	if !ok {
+		fmt.Fprintln(cli.Stderr, "Personal access tokens do not expire; nothing to refresh.")
+		return nil
+	}
+
+	tok, err := oauthTS.Refresh()
+	if err != nil {
+		if auth.IsRefreshRejected(err) {
+			fmt.Fprintf(cli.Stderr, "The stored refresh token for %s was rejected.\n", oauthTS.Email())
+			fmt.Fprintf(cli.Stderr, "Run 'harvest auth login' to sign in again.\n")
+		}
+		return fmt.Errorf("refresh token: %w", err)
+	}
+
+	me, err := newAPIClient(cli, oauthTS, accountID).GetMe(ctx)
+	if err != nil {
+		return fmt.Errorf("verify token: %w", err)
+	}
+
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, authRefreshResult{
+			Email:     me.Email,
+			AccountID: accountID,
+			UserID:    me.ID,
+			ExpiresAt: tok.Expiry,
+		})
+	}
+
+	fmt.Fprintf(cli.Stdout, "Refreshed token for %s %s <%s> (account %d)\n",
+		me.FirstName, me.LastName, me.Email, accountID)
+	if !tok.Expiry.IsZero() {
+		fmt.Fprintf(cli.Stdout, "Access token expires %s (in %s)\n",
+			tok.Expiry.Local().Format("2006-01-02 15:04"), time.Until(tok.Expiry).Round(time.Minute))
+	}
+	return nil
+}
+
 // AuthListCmd lists all authenticated accounts.
 type AuthListCmd struct{}
 
-func (c *AuthListCmd) Run() error {
-	store, err := auth.OpenDefault()
+func (c *AuthListCmd) Run(cli *CLI) error {
+	store, err := openStore(&cli.RootFlags)
 	if err != nil {
 		return fmt.Errorf("open keyring: %w", err)
 	}
@@ -392,7 +455,7 @@ func (c *AuthListCmd) Run() error {
 	}
 
 	if len(tokens) == 0 {
-		fmt.Fprintln(os.Stdout, "No authenticated accounts.")
+		fmt.Fprintln(cli.Stdout, "No authenticated accounts.")
 		return nil
 	}
 
@@ -403,7 +466,7 @@ func (c *AuthListCmd) Run() error {
 		defaultAccount = cfg.DefaultAccount
 	}
 
-	fmt.Fprintln(os.Stdout, "Authenticated accounts:")
+	fmt.Fprintln(cli.Stdout, "Authenticated accounts:")
 	for _, tok := range tokens {
 		marker := ""
 		if tok.Email == defaultAccount {
@@ -413,7 +476,7 @@ func (c *AuthListCmd) Run() error {
 		if tok.Client == auth.PATClient {
 			authType = "pat"
 		}
-		fmt.Fprintf(os.Stdout, "  %s [%s] client:%s account:%d%s (since %s)\n",
+		fmt.Fprintf(cli.Stdout, "  %s [%s] client:%s account:%d%s (since %s)\n",
 			tok.Email, authType, tok.Client, tok.AccountID, marker, tok.CreatedAt.Format("2006-01-02"))
 	}
 
@@ -425,7 +488,7 @@ type AuthSwitchCmd struct {
 	Account string `arg:"" help:"Account email or alias to set as default"`
 }
 
-func (c *AuthSwitchCmd) Run() error {
+func (c *AuthSwitchCmd) Run(cli *CLI) error {
 	// Resolve alias if needed
 	email, err := config.ResolveAccount(c.Account)
 	if err != nil {
@@ -434,7 +497,7 @@ func (c *AuthSwitchCmd) Run() error {
 	}
 
 	// Verify account exists
-	store, err := auth.OpenDefault()
+	store, err := openStore(&cli.RootFlags)
 	if err != nil {
 		return fmt.Errorf("open keyring: %w", err)
 	}
@@ -460,7 +523,148 @@ func (c *AuthSwitchCmd) Run() error {
 		return fmt.Errorf("set default account: %w", err)
 	}
 
-	fmt.Fprintf(os.Stdout, "Default account set to %s\n", email)
+	fmt.Fprintf(cli.Stdout, "Default account set to %s\n", email)
+
+	return nil
+}
+
+// AuthExportCmd writes every stored credential to an encrypted file so it
+// can be imported on another machine.
+type AuthExportCmd struct {
+	File  string `arg:"" help:"File to write" type:"path"`
+	Force bool   `help:"Overwrite an existing file" short:"f"`
+}
+
+// exportedAccount describes a credential in an export file, without secrets.
+type exportedAccount struct {
+	Email     string `json:"email"`
+	Client    string `json:"client"`
+	AccountID int64  `json:"account_id"`
+}
+
+func (c *AuthExportCmd) Run(cli *CLI) error {
+	if !c.Force {
+		if _, err := os.Stat(c.File); err == nil {
+			return fmt.Errorf("%s already exists; use --force to overwrite", c.File)
+		}
+	}
+
+	store, err := openStore(&cli.RootFlags)
+	if err != nil {
+		return fmt.Errorf("open keyring: %w", err)
+	}
+	bundle, err := auth.ExportBundle(store)
+	if err != nil {
+		return fmt.Errorf("list tokens: %w", err)
+	}
+	if len(bundle.Tokens) == 0 {
+		return fmt.Errorf("no stored credentials to export; run 'harvest auth login' first")
+	}
+
+	fmt.Fprintln(cli.Stderr, "WARNING: the export file contains refresh tokens and personal access tokens")
+	fmt.Fprintln(cli.Stderr, "with full access to your Harvest accounts. Anyone who has the file and the")
+	fmt.Fprintln(cli.Stderr, "passphrase can act as you. Keep it private and delete it after importing.")
+	fmt.Fprintln(cli.Stderr)
+
+	passphrase, err := readPassphrase(cli, "Passphrase: ")
+	if err != nil {
+		return err
+	}
+	if len(passphrase) < auth.MinPassphraseLength {
+		return fmt.Errorf("passphrase must be at least %d characters", auth.MinPassphraseLength)
+	}
+	repeat, err := readPassphrase(cli, "Repeat passphrase: ")
+	if err != nil {
+		return err
+	}
+	if repeat != passphrase {
+		return fmt.Errorf("passphrases do not match")
+	}
+
+	data, err := auth.EncryptBundle(bundle, passphrase)
+	if err != nil {
+		return fmt.Errorf("encrypt credentials: %w", err)
+	}
+	if err := os.WriteFile(c.File, data, 0o600); err != nil {
+		return fmt.Errorf("write %s: %w", c.File, err)
+	}
+	// WriteFile keeps the mode of a file being overwritten
+	if err := os.Chmod(c.File, 0o600); err != nil {
+		return fmt.Errorf("restrict %s: %w", c.File, err)
+	}
+
+	accounts := make([]exportedAccount, len(bundle.Tokens))
+	for i, t := range bundle.Tokens {
+		accounts[i] = exportedAccount{Email: t.Email, Client: t.Client, AccountID: t.AccountID}
+	}
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, map[string]any{"file": c.File, "accounts": accounts})
+	}
 
+	fmt.Fprintf(cli.Stdout, "Exported %d credentials to %s\n", len(accounts), c.File)
+	fmt.Fprintf(cli.Stderr, "On the other machine run 'harvest auth import %s', then delete the file.\n", c.File)
 	return nil
 }
+
+// AuthImportCmd stores the credentials from an export file in the keyring.
+type AuthImportCmd struct {
+	File string `arg:"" help:"File written by 'harvest auth export'" type:"existingfile"`
+}
+
+func (c *AuthImportCmd) Run(cli *CLI) error {
+	data, err := os.ReadFile(c.File)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", c.File, err)
+	}
+
+	passphrase, err := readPassphrase(cli, "Passphrase: ")
+	if err != nil {
+		return err
+	}
+	bundle, err := auth.DecryptBundle(data, passphrase)
+	if err != nil {
+		return err
+	}
+
+	store, err := openStore(&cli.RootFlags)
+	if err != nil {
+		return fmt.Errorf("open keyring: %w", err)
+	}
+	if err := auth.ImportBundle(store, bundle); err != nil {
+		return err
+	}
+
+	accounts := make([]exportedAccount, len(bundle.Tokens))
+	for i, t := range bundle.Tokens {
+		accounts[i] = exportedAccount{Email: t.Email, Client: t.Client, AccountID: t.AccountID}
+	}
+
+	// Set a default if none exists, as login does
+	cfg, _ := config.ReadConfig()
+	if cfg != nil && cfg.DefaultAccount == "" && len(accounts) > 0 {
+		_ = config.SetDefaultAccount(accounts[0].Email)
+	}
+
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, map[string]any{"file": c.File, "accounts": accounts})
+	}
+
+	fmt.Fprintf(cli.Stdout, "Imported %d credentials from %s:\n", len(accounts), c.File)
+	for _, a := range accounts {
+		fmt.Fprintf(cli.Stdout, "  %s [%s] account:%d\n", a.Email, a.Client, a.AccountID)
+	}
+	fmt.Fprintf(cli.Stderr, "Delete %s now that it has been imported.\n", c.File)
+	return nil
+}
+
+// readPassphrase prompts on stderr and reads a passphrase without echo.
+// Tests replace it.
+var readPassphrase = func(cli *CLI, prompt string) (string, error) {
+	fmt.Fprint(cli.Stderr, prompt)
+	b, err := term.ReadPassword(int(syscall.Stdin))
+	fmt.Fprintln(cli.Stderr) // newline after hidden input
+	if err != nil {
+		return "", fmt.Errorf("read passphrase: %w", err)
+	}
+	return string(b), nil
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dedene/harvest-cli/internal/auth"
+)
+
+// stubPassphrase makes readPassphrase return answers in order.
+func stubPassphrase(t *testing.T, answers ...string) {
+	t.Helper()
+	saved := readPassphrase
+	t.Cleanup(func() { readPassphrase = saved })
+	readPassphrase = func(_ *CLI, _ string) (string, error) {
+		answer := answers[0]
+		answers = answers[1:]
+		return answer, nil
+	}
+}
+
+func TestAuthExportImport(t *testing.T) {
+	setupAccounts(t)
+	file := filepath.Join(t.TempDir(), "harvest-creds.json")
+
+	stubPassphrase(t, "long passphrase", "long passphrase")
+	var stdout, stderr bytes.Buffer
+	if err := Execute([]string{"auth", "export", file}, &stdout, &stderr); err != nil {
+		t.Fatalf("export error = %v, stderr: %s", err, stderr.String())
+	}
+	if !strings.Contains(stderr.String(), "WARNING") {
+		t.Errorf("export should warn about the file's sensitivity, stderr: %s", stderr.String())
+	}
+	info, err := os.Stat(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("export file mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	stubPassphrase(t, "long passphrase", "long passphrase")
+	if err := Execute([]string{"auth", "export", file}, &stdout, &stderr); err == nil {
+		t.Error("export should refuse to overwrite without --force")
+	}
+
+	// A fresh machine: empty config and keyring
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	stubPassphrase(t, "wrong passphrase")
+	if err := Execute([]string{"auth", "import", file}, &stdout, &stderr); err == nil {
+		t.Error("import should fail with the wrong passphrase")
+	}
+
+	stubPassphrase(t, "long passphrase")
+	stdout.Reset()
+	if err := Execute([]string{"auth", "import", file}, &stdout, &stderr); err != nil {
+		t.Fatalf("import error = %v, stderr: %s", err, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Imported 2 credentials") {
+		t.Errorf("stdout = %q", stdout.String())
+	}
+
+	store, err := auth.OpenDefault()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pat, id, err := auth.GetPAT(store, "side@example.com"); err != nil || pat != "pat-side@example.com" || id != 2 {
+		t.Errorf("imported PAT = %q, %d, %v", pat, id, err)
+	}
+}
+
+func TestAuthExport_PassphraseMismatch(t *testing.T) {
+	setupAccounts(t)
+	file := filepath.Join(t.TempDir(), "creds.json")
+
+	stubPassphrase(t, "long passphrase", "other passphrase")
+	var stdout, stderr bytes.Buffer
+	err := Execute([]string{"auth", "export", file}, &stdout, &stderr)
+	if err == nil || !strings.Contains(err.Error(), "do not match") {
+		t.Errorf("export error = %v, want mismatch", err)
+	}
+	if _, err := os.Stat(file); !os.IsNotExist(err) {
+		t.Error("no file should be written when passphrases differ")
+	}
+}
@@ -5,12 +5,16 @@ import (
 	"encoding/csv"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/dedene/harvest-cli/internal/api"
 	"github.com/dedene/harvest-cli/internal/dateparse"
+	"github.com/dedene/harvest-cli/internal/output"
 )
 
 // BulkCmd groups bulk operation subcommands.
@@ -21,16 +25,16 @@ type BulkCmd struct {
 
 // BulkExportCmd exports time entries to CSV.
 type BulkExportCmd struct {
-	From    string `help:"Start date (required)" short:"f" required:""`
-	To      string `help:"End date (required)" short:"t" required:""`
+	From    string `help:"Start date (required)" short:"f" required:"" aliases:"since"`
+	To      string `help:"End date (required)" short:"t" required:"" aliases:"until"`
 	Project string `help:"Filter by project ID or name" short:"p"`
-	User    string `help:"Filter by user ID or 'me'" short:"u"`
+	User    string `help:"Filter by user ID, email or 'me'" short:"u"`
 	Output  string `help:"Output file path (default: stdout)" short:"o"`
 }
 
 func (c *BulkExportCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -38,33 +42,17 @@ func (c *BulkExportCmd) Run(cli *CLI) error {
 	opts := api.TimeEntryListOptions{}
 
 	// Parse date filters
-	fromDate, err := dateparse.Parse(c.From)
-	if err != nil {
-		return fmt.Errorf("invalid from date: %w", err)
-	}
-	opts.From = dateparse.FormatDate(fromDate)
-
-	toDate, err := dateparse.Parse(c.To)
-	if err != nil {
-		return fmt.Errorf("invalid to date: %w", err)
+	if opts.From, opts.To, err = parseDateRange(c.From, c.To); err != nil {
+		return err
 	}
-	opts.To = dateparse.FormatDate(toDate)
 
 	// Parse user filter
 	if c.User != "" {
-		if c.User == "me" {
-			me, err := client.GetMe(ctx)
-			if err != nil {
-				return fmt.Errorf("get current user: %w", err)
-			}
-			opts.UserID = me.ID
-		} else {
-			id, err := strconv.ParseInt(c.User, 10, 64)
-			if err != nil {
-				return fmt.Errorf("invalid user ID: %s", c.User)
-			}
-			opts.UserID = id
+		userID, err := resolveUserID(ctx, client, c.User)
+		if err != nil {
+			return err
 		}
+		opts.UserID = userID
 	}
 
 	// Parse project filter
@@ -82,7 +70,7 @@ func (c *BulkExportCmd) Run(cli *CLI) error {
 	}
 
 	// Determine output writer
-	var w io.Writer = os.Stdout
+	var w io.Writer = cli.Stdout
 	if c.Output != "" {
 		f, err := os.Create(c.Output)
 		if err != nil {
@@ -92,16 +80,14 @@ func (c *BulkExportCmd) Run(cli *CLI) error {
 		w = f
 	}
 
-	return writeTimeEntriesCSV(w, entries)
+	headers, rows := timeEntriesCSVRows(entries)
+	return output.WriteCSV(w, headers, rows)
 }
 
-// writeTimeEntriesCSV writes time entries as CSV.
-func writeTimeEntriesCSV(w io.Writer, entries []api.TimeEntry) error {
-	cw := csv.NewWriter(w)
-	defer cw.Flush()
-
-	// Write header
-	header := []string{
+// timeEntriesCSVRows returns the columns bulk export writes for time
+// entries.
+func timeEntriesCSVRows(entries []api.TimeEntry) (headers []string, rows [][]string) {
+	headers = []string{
 		"date",
 		"project_id",
 		"project_name",
@@ -111,18 +97,13 @@ func writeTimeEntriesCSV(w io.Writer, entries []api.TimeEntry) error {
 		"notes",
 		"external_ref_id",
 	}
-	if err := cw.Write(header); err != nil {
-		return err
-	}
-
-	// Write rows
-	for _, e := range entries {
+	rows = make([][]string, len(entries))
+	for i, e := range entries {
 		extRefID := ""
 		if e.ExternalReference != nil {
 			extRefID = e.ExternalReference.ID
 		}
-
-		row := []string{
+		rows[i] = []string{
 			e.SpentDate,
 			strconv.FormatInt(e.Project.ID, 10),
 			e.Project.Name,
@@ -132,23 +113,27 @@ func writeTimeEntriesCSV(w io.Writer, entries []api.TimeEntry) error {
 			e.Notes,
 			extRefID,
 		}
-		if err := cw.Write(row); err != nil {
-			return err
-		}
 	}
-
-	return cw.Error()
+	return headers, rows
 }
 
 // BulkImportCmd imports time entries from CSV.
+// With the global --dry-run flag, it previews entries without creating them.
 type BulkImportCmd struct {
-	File   string `arg:"" help:"CSV file path"`
-	DryRun bool   `help:"Show what would be created without creating" short:"n"`
+	File          string `arg:"" help:"CSV file path"`
+	StrictHeaders bool   `help:"Reject unknown CSV columns instead of ignoring them" name:"strict-headers"`
+	// DryRunShort keeps the importer's old -n working; the global --dry-run
+	// cannot take -n because --notes uses it.
+	DryRunShort bool `help:"Deprecated: use --dry-run" name:"preview" short:"n" hidden:""`
 }
 
 func (c *BulkImportCmd) Run(cli *CLI) error {
+	if c.DryRunShort {
+		cli.DryRun = true
+	}
+
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -160,13 +145,13 @@ func (c *BulkImportCmd) Run(cli *CLI) error {
 	}
 	defer f.Close()
 
-	rows, err := parseImportCSV(f)
+	rows, err := parseImportCSV(f, c.StrictHeaders)
 	if err != nil {
 		return err
 	}
 
 	if len(rows) == 0 {
-		fmt.Fprintln(os.Stdout, "No entries to import")
+		fmt.Fprintln(cli.Stdout, "No entries to import")
 		return nil
 	}
 
@@ -177,38 +162,55 @@ func (c *BulkImportCmd) Run(cli *CLI) error {
 	}
 
 	// Show summary
-	fmt.Fprintf(os.Stdout, "%d entries will be created\n\n", len(validatedRows))
+	fmt.Fprintf(cli.Stdout, "%d entries will be created\n\n", len(validatedRows))
 
-	if c.DryRun {
-		fmt.Fprintln(os.Stdout, "Dry run - preview of entries:")
+	if cli.DryRun {
+		fmt.Fprintln(cli.Stdout, "Dry run - preview of entries:")
 		for i, r := range validatedRows {
-			fmt.Fprintf(os.Stdout, "  %d. %s: %s - %s (%.2fh)",
+			fmt.Fprintf(cli.Stdout, "  %d. %s: %s - %s (%.2fh)",
 				i+1, r.SpentDate, r.ProjectName, r.TaskName, *r.Input.Hours)
 			if r.Input.Notes != nil && *r.Input.Notes != "" {
-				fmt.Fprintf(os.Stdout, " - %s", truncateNotes(*r.Input.Notes, 30))
+				fmt.Fprintf(cli.Stdout, " - %s", truncateNotes(*r.Input.Notes, 30))
 			}
-			fmt.Fprintln(os.Stdout)
+			fmt.Fprintln(cli.Stdout)
 		}
 		return nil
 	}
 
-	// Create entries one by one with progress
-	created := 0
+	// Create entries one by one with progress. Retries are reported so a
+	// throttled import does not look stuck.
+	created, row := 0, 0
+	client.SetRetryHook(func(ev api.RetryEvent) {
+		fmt.Fprintln(cli.Stderr, describeRetry(ev, row, len(validatedRows)))
+	})
+	defer client.SetRetryHook(nil)
+
 	for i, r := range validatedRows {
+		row = i + 1
 		entry, err := client.CreateTimeEntry(ctx, r.Input)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error creating entry %d: %v\n", i+1, err)
+			fmt.Fprintf(cli.Stderr, "Error creating entry %d: %v\n", i+1, err)
 			continue
 		}
 		created++
-		fmt.Fprintf(os.Stdout, "[%d/%d] Created #%d: %s - %s (%.2fh)\n",
+		fmt.Fprintf(cli.Stdout, "[%d/%d] Created #%d: %s - %s (%.2fh)\n",
 			i+1, len(validatedRows), entry.ID, entry.Project.Name, entry.Task.Name, entry.Hours)
 	}
 
-	fmt.Fprintf(os.Stdout, "\nImport complete: %d/%d entries created\n", created, len(validatedRows))
+	fmt.Fprintf(cli.Stdout, "\nImport complete: %d/%d entries created\n", created, len(validatedRows))
 	return nil
 }
 
+// describeRetry explains why creating a row is being retried.
+func describeRetry(ev api.RetryEvent, row, total int) string {
+	reason := "rate limited"
+	if ev.StatusCode != http.StatusTooManyRequests {
+		reason = fmt.Sprintf("server error %d", ev.StatusCode)
+	}
+	return fmt.Sprintf("%s, retrying row %d/%d in %s (attempt %d)...",
+		reason, row, total, ev.Delay.Round(100*time.Millisecond), ev.Attempt)
+}
+
 // importRow represents a parsed CSV row.
 type importRow struct {
 	LineNum int
@@ -227,8 +229,50 @@ type validatedRow struct {
 	Input       *api.TimeEntryInput
 }
 
-// parseImportCSV parses the import CSV file.
-func parseImportCSV(r io.Reader) ([]importRow, error) {
+// importColumns are the CSV columns understood by the importer.
+var importColumns = []string{"date", "project", "task", "hours", "notes"}
+
+// importRequiredColumns must be present in every import file.
+var importRequiredColumns = []string{"date", "project", "task", "hours"}
+
+// parseImportCSV parses the import CSV file. In strict mode, columns the
+// importer does not know are rejected rather than ignored.
+func parseImportCSV(r io.Reader, strict bool) ([]importRow, error) {
+	records, err := readImportCSV(r, importColumns, importRequiredColumns, strict)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]importRow, len(records))
+	for i, rec := range records {
+		rows[i] = importRow{
+			LineNum: rec.LineNum,
+			Date:    rec.get("date"),
+			Project: rec.get("project"),
+			Task:    rec.get("task"),
+			Hours:   rec.get("hours"),
+			Notes:   rec.get("notes"),
+		}
+	}
+	return rows, nil
+}
+
+// csvRecord is a data row of an import file.
+type csvRecord struct {
+	LineNum int
+	fields  []string
+	colMap  map[string]int
+}
+
+// get returns the trimmed value of a column, or "" when it is absent.
+func (r csvRecord) get(name string) string {
+	return getCol(r.fields, r.colMap, name)
+}
+
+// readImportCSV reads the header and data rows of an import file. Every
+// required column must be present; other columns not in columns are ignored,
+// or rejected in strict mode.
+func readImportCSV(r io.Reader, columns, required []string, strict bool) ([]csvRecord, error) {
 	cr := csv.NewReader(r)
 	cr.TrimLeadingSpace = true
 
@@ -244,40 +288,118 @@ func parseImportCSV(r io.Reader) ([]importRow, error) {
 		colMap[strings.ToLower(strings.TrimSpace(h))] = i
 	}
 
-	// Required columns
-	required := []string{"date", "project", "task", "hours"}
+	if strict {
+		if err := checkImportHeaders(header, columns); err != nil {
+			return nil, err
+		}
+	}
+
 	for _, col := range required {
 		if _, ok := colMap[col]; !ok {
-			return nil, fmt.Errorf("missing required column: %s", col)
+			return nil, missingColumnError(col, header, columns)
 		}
 	}
 
-	var rows []importRow
+	var records []csvRecord
 	lineNum := 1 // header is line 1
 
 	for {
 		lineNum++
-		record, err := cr.Read()
+		fields, err := cr.Read()
 		if err == io.EOF {
 			break
 		}
 		if err != nil {
 			return nil, fmt.Errorf("line %d: %w", lineNum, err)
 		}
+		records = append(records, csvRecord{LineNum: lineNum, fields: fields, colMap: colMap})
+	}
 
-		row := importRow{
-			LineNum: lineNum,
-			Date:    getCol(record, colMap, "date"),
-			Project: getCol(record, colMap, "project"),
-			Task:    getCol(record, colMap, "task"),
-			Hours:   getCol(record, colMap, "hours"),
-			Notes:   getCol(record, colMap, "notes"),
+	return records, nil
+}
+
+// checkImportHeaders rejects header columns not in columns, suggesting the
+// closest known column for likely typos.
+func checkImportHeaders(header, columns []string) error {
+	var unknown []string
+	for _, h := range header {
+		name := strings.ToLower(strings.TrimSpace(h))
+		if slices.Contains(columns, name) {
+			continue
 		}
+		msg := fmt.Sprintf("unknown column %q", h)
+		if match, ok := closestMatch(name, columns); ok {
+			msg += fmt.Sprintf(" (did you mean %q?)", match)
+		}
+		unknown = append(unknown, msg)
+	}
 
-		rows = append(rows, row)
+	if len(unknown) > 0 {
+		return fmt.Errorf("invalid CSV header:\n  %s\nknown columns: %s",
+			strings.Join(unknown, "\n  "), strings.Join(columns, ", "))
 	}
+	return nil
+}
 
-	return rows, nil
+// missingColumnError reports a missing required column, pointing at a header
+// column that looks like a misspelling of it.
+func missingColumnError(col string, header, columns []string) error {
+	for _, h := range header {
+		name := strings.ToLower(strings.TrimSpace(h))
+		if slices.Contains(columns, name) {
+			continue
+		}
+		if match, ok := closestMatch(name, columns); ok && match == col {
+			return fmt.Errorf("missing required column: %s (found %q)", col, h)
+		}
+	}
+	return fmt.Errorf("missing required column: %s", col)
+}
+
+// closestMatch returns the candidate nearest to s by edit distance, if it is
+// close enough to be a plausible typo.
+func closestMatch(s string, candidates []string) (string, bool) {
+	if s == "" {
+		return "", false
+	}
+
+	best, bestDist := "", -1
+	for _, c := range candidates {
+		d := levenshtein(s, c)
+		if bestDist < 0 || d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+
+	// Allow roughly one edit per three characters, and at least one
+	maxDist := max(1, len([]rune(best))/3)
+	if bestDist < 0 || bestDist > maxDist {
+		return "", false
+	}
+	return best, true
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
 }
 
 // getCol safely gets a column value.
@@ -293,10 +415,8 @@ func validateImportRows(ctx context.Context, client *api.Client, rows []importRo
 	var validated []validatedRow
 	var errors []string
 
-	// Cache for resolved IDs
-	projectCache := make(map[string]int64)
+	resolver := newIDResolver(client)
 	projectNames := make(map[int64]string)
-	taskCache := make(map[string]int64) // key: "projectID:task"
 	taskNames := make(map[int64]string)
 
 	for _, row := range rows {
@@ -315,15 +435,14 @@ func validateImportRows(ctx context.Context, client *api.Client, rows []importRo
 		spentDate := dateparse.FormatDate(date)
 
 		// Resolve project
-		projectID, ok := projectCache[row.Project]
-		if !ok {
-			projectID, err = resolveProjectID(ctx, client, row.Project)
-			if err != nil {
-				errors = append(errors, fmt.Sprintf("line %d: %v", row.LineNum, err))
-				continue
-			}
-			projectCache[row.Project] = projectID
-			// Get project name for display
+		projectID, err := resolver.projectID(ctx, row.Project)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("line %d: %v", row.LineNum, err))
+			continue
+		}
+		if _, ok := projectNames[projectID]; !ok {
+			// Get project name for display, once even if it is not found
+			projectNames[projectID] = ""
 			projects, _ := client.ListAllProjects(ctx, api.ProjectListOptions{})
 			for _, p := range projects {
 				if p.ID == projectID {
@@ -334,16 +453,14 @@ func validateImportRows(ctx context.Context, client *api.Client, rows []importRo
 		}
 
 		// Resolve task
-		taskKey := fmt.Sprintf("%d:%s", projectID, row.Task)
-		taskID, ok := taskCache[taskKey]
-		if !ok {
-			taskID, err = resolveTaskID(ctx, client, projectID, row.Task)
-			if err != nil {
-				errors = append(errors, fmt.Sprintf("line %d: %v", row.LineNum, err))
-				continue
-			}
-			taskCache[taskKey] = taskID
-			// Get task name for display
+		taskID, err := resolver.taskID(ctx, projectID, row.Task)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("line %d: %v", row.LineNum, err))
+			continue
+		}
+		if _, ok := taskNames[taskID]; !ok {
+			// Get task name for display, once even if it is not found
+			taskNames[taskID] = ""
 			assignments, _ := client.ListAllMyProjectAssignments(ctx)
 			for _, pa := range assignments {
 				if pa.Project.ID == projectID {
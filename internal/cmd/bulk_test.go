@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dedene/harvest-cli/internal/api"
+	"github.com/dedene/harvest-cli/internal/auth"
+)
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"hours", "hours", 0},
+		{"hour", "hours", 1},
+		{"", "task", 4},
+		{"tsak", "task", 2},
+		{"kitten", "sitting", 3},
+		{"café", "cafe", 1},
+	}
+
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestClosestMatch(t *testing.T) {
+	tests := []struct {
+		input  string
+		want   string
+		wantOK bool
+	}{
+		{"hour", "hours", true},
+		{"projet", "project", true},
+		{"note", "notes", true},
+		{"dat", "date", true},
+		{"client", "", false},
+		{"project_id", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, ok := closestMatch(tt.input, importColumns)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("closestMatch(%q) = %q, %v; want %q, %v", tt.input, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestParseImportCSV_Headers(t *testing.T) {
+	tests := []struct {
+		name    string
+		csv     string
+		strict  bool
+		wantErr string
+	}{
+		{
+			name: "known columns",
+			csv:  "date,project,task,hours,notes\n2024-01-02,Acme,Dev,1.5,Work\n",
+		},
+		{
+			name: "extra column ignored by default",
+			csv:  "date,project,task,hours,note\n2024-01-02,Acme,Dev,1.5,Work\n",
+		},
+		{
+			name:    "extra column rejected in strict mode",
+			csv:     "date,project,task,hours,note\n2024-01-02,Acme,Dev,1.5,Work\n",
+			strict:  true,
+			wantErr: `unknown column "note" (did you mean "notes"?)`,
+		},
+		{
+			name:    "unknown column without suggestion",
+			csv:     "date,project,task,hours,client\n",
+			strict:  true,
+			wantErr: `unknown column "client"`,
+		},
+		{
+			name:    "misspelled required column",
+			csv:     "date,project,task,hour\n",
+			wantErr: `missing required column: hours (found "hour")`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := parseImportCSV(strings.NewReader(tt.csv), tt.strict)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseImportCSV() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseImportCSV() error = %v", err)
+			}
+			if len(rows) != 1 || rows[0].Hours != "1.5" {
+				t.Errorf("rows = %+v, want one row with 1.5 hours", rows)
+			}
+		})
+	}
+}
+
+func TestBulkExport(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(auth.PATEnvToken, "pat")
+	t.Setenv(auth.PATEnvAccountID, "1")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"time_entries":[{"id":1,"spent_date":"2024-01-02","hours":1.5,"notes":"Mockups, round 2",
+			"project":{"id":10,"name":"Website"},"task":{"id":20,"name":"Design"},
+			"external_reference":{"id":"JIRA-1"}}],"total_pages":1,"page":1}`))
+	}))
+	defer srv.Close()
+
+	var stdout, stderr bytes.Buffer
+	if err := Execute([]string{"bulk", "export", "--from", "2024-01-01", "--to", "2024-01-31", "--api-base-url", srv.URL}, &stdout, &stderr); err != nil {
+		t.Fatalf("Execute() error = %v, stderr: %s", err, stderr.String())
+	}
+	want := "date,project_id,project_name,task_id,task_name,hours,notes,external_ref_id\n" +
+		"2024-01-02,10,Website,20,Design,1.50,\"Mockups, round 2\",JIRA-1\n"
+	if stdout.String() != want {
+		t.Errorf("stdout = %q, want %q", stdout.String(), want)
+	}
+}
+
+func TestDescribeRetry(t *testing.T) {
+	tests := []struct {
+		ev   api.RetryEvent
+		want string
+	}{
+		{
+			api.RetryEvent{StatusCode: 429, Attempt: 1, Delay: 2 * time.Second},
+			"rate limited, retrying row 3/10 in 2s (attempt 1)...",
+		},
+		{
+			api.RetryEvent{StatusCode: 503, Attempt: 2, Delay: 4123 * time.Millisecond},
+			"server error 503, retrying row 3/10 in 4.1s (attempt 2)...",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := describeRetry(tt.ev, 3, 10); got != tt.want {
+			t.Errorf("describeRetry() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestBulkImport_ShortDryRun(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(auth.PATEnvToken, "pat")
+	t.Setenv(auth.PATEnvAccountID, "1")
+
+	posted := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			posted++
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"project_assignments":[],"projects":[],"total_pages":1,"page":1}`))
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "time.csv")
+	if err := os.WriteFile(path, []byte("date,project,task,hours\n2024-05-02,10,20,1.5\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if err := Execute([]string{"bulk", "import", "-n", path, "--api-base-url", srv.URL}, &stdout, &stderr); err != nil {
+		t.Fatalf("Execute() error = %v, stderr: %s", err, stderr.String())
+	}
+	if posted != 0 || !strings.Contains(stdout.String(), "Dry run - preview of entries:") {
+		t.Errorf("posted %d requests, stdout %q; want a preview only", posted, stdout.String())
+	}
+}
@@ -3,21 +3,56 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 
 	"golang.org/x/oauth2"
 
 	"github.com/dedene/harvest-cli/internal/api"
 	"github.com/dedene/harvest-cli/internal/auth"
 	"github.com/dedene/harvest-cli/internal/config"
+	"github.com/dedene/harvest-cli/internal/output"
 )
 
 // NewClientFromFlags creates an API client from CLI flags.
-func NewClientFromFlags(ctx context.Context, flags *RootFlags) (*api.Client, error) {
-	ts, accountID, err := GetTokenSource(ctx, flags)
+func NewClientFromFlags(ctx context.Context, cli *CLI) (*api.Client, error) {
+	ts, accountID, err := GetTokenSource(ctx, &cli.RootFlags)
 	if err != nil {
 		return nil, err
 	}
 
+	client := newAPIClient(cli, ts, accountID)
+	applyAccountDateFormat(ctx, cli, client)
+	return client, nil
+}
+
+// applyAccountDateFormat shows table and markdown dates in the company's
+// date format when the date format is "account". The company is fetched the
+// first time a date is shown, so output without dates makes no extra
+// request; if that fails, dates stay ISO. Plain and JSON output stay ISO.
+func applyAccountDateFormat(ctx context.Context, cli *CLI, client *api.Client) {
+	if !strings.EqualFold(resolveDateFormat(&cli.RootFlags), "account") {
+		return
+	}
+	if mode := output.ModeFromFlags(cli.JSON, cli.Plain); mode != output.ModeTable && mode != output.ModeMarkdown {
+		return
+	}
+	output.SetDateLayoutFunc(func() string {
+		company, err := getCompany(ctx, cli, client)
+		if err != nil {
+			return ""
+		}
+		layout, _ := output.DateLayout(company.DateFormat)
+		return layout
+	})
+}
+
+// newAPIClient builds an API client for ts, applying the global base URL,
+// retry, timeout, dry-run and verbose flags.
+func newAPIClient(cli *CLI, ts oauth2.TokenSource, accountID int64) *api.Client {
+	flags := &cli.RootFlags
+
 	// Get contact email from config for User-Agent
 	cfg, _ := config.ReadConfig()
 	contactEmail := ""
@@ -28,14 +63,27 @@ func NewClientFromFlags(ctx context.Context, flags *RootFlags) (*api.Client, err
 		contactEmail = "harvest@example.com"
 	}
 
-	client := api.NewClient(ts, accountID, contactEmail)
+	client := api.NewClientWithBaseURL(ts, accountID, contactEmail, flags.APIBaseURL)
 	client.SetVersion(VersionString())
 
-	return client, nil
+	maxRetries := -1
+	if flags.MaxRetries != nil {
+		maxRetries = *flags.MaxRetries
+	}
+	client.SetRetryPolicy(maxRetries, flags.RetryBaseDelay)
+	client.SetTimeout(flags.Timeout)
+
+	if flags.DryRun {
+		client.SetDryRun(cli.Stderr)
+	}
+	client.SetLogging(cli.Stderr, flags.Verbose)
+
+	return client
 }
 
 // GetTokenSource returns an oauth2.TokenSource and account ID for API calls.
-// Priority: env PAT > --account-id flag > keyring OAuth token
+// Priority: env PAT > --account-id flag > keyring OAuth token. --account only
+// selects the stored token for this run; it never changes default_account.
 func GetTokenSource(ctx context.Context, flags *RootFlags) (oauth2.TokenSource, int64, error) {
 	// 1. Check for PAT in environment
 	if token, accountID, ok := auth.GetPATFromEnv(); ok {
@@ -56,13 +104,25 @@ func GetTokenSource(ctx context.Context, flags *RootFlags) (oauth2.TokenSource,
 		}
 	} else {
 		// Try to get default or only account
-		email, err = resolveDefaultAccount()
+		email, err = resolveDefaultAccount(flags)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	// 3. Open keyring and check an explicit account against it
+	store, err := openStore(flags)
+	if err != nil {
+		return nil, 0, fmt.Errorf("open keyring: %w", err)
+	}
+	if flags != nil && flags.Account != "" {
+		email, err = matchStoredAccount(store, flags.Account, email)
 		if err != nil {
 			return nil, 0, err
 		}
 	}
 
-	// 3. Determine client name
+	// 4. Determine client name
 	clientName := ""
 	if flags != nil {
 		clientName = flags.Client
@@ -72,12 +132,6 @@ func GetTokenSource(ctx context.Context, flags *RootFlags) (oauth2.TokenSource,
 		return nil, 0, err
 	}
 
-	// 4. Open keyring and get token
-	store, err := auth.OpenDefault()
-	if err != nil {
-		return nil, 0, fmt.Errorf("open keyring: %w", err)
-	}
-
 	// Check for PAT stored in keyring
 	if pat, patAccountID, err := auth.GetPAT(store, email); err == nil && pat != "" {
 		accountID := patAccountID
@@ -104,8 +158,49 @@ func GetTokenSource(ctx context.Context, flags *RootFlags) (oauth2.TokenSource,
 	return ts, accountID, nil
 }
 
+// matchStoredAccount returns the stored account email that --account
+// (already resolved from an alias to email) refers to, ignoring case. If no
+// stored account matches, the error lists the available accounts and their
+// aliases.
+func matchStoredAccount(store auth.Store, requested, email string) (string, error) {
+	tokens, err := store.ListTokens()
+	if err != nil {
+		return "", fmt.Errorf("list tokens: %w", err)
+	}
+	if len(tokens) == 0 {
+		return "", fmt.Errorf("not authenticated; run 'harvest auth login'")
+	}
+
+	var emails []string
+	for _, tok := range tokens {
+		if strings.EqualFold(tok.Email, email) {
+			return tok.Email, nil
+		}
+		if !slices.Contains(emails, tok.Email) {
+			emails = append(emails, tok.Email)
+		}
+	}
+	sort.Strings(emails)
+
+	aliases := make(map[string][]string)
+	if cfg, err := config.ReadConfig(); err == nil {
+		for alias, target := range cfg.AccountAliases {
+			aliases[target] = append(aliases[target], alias)
+		}
+	}
+	available := make([]string, len(emails))
+	for i, e := range emails {
+		available[i] = e
+		if names := aliases[e]; len(names) > 0 {
+			sort.Strings(names)
+			available[i] += " (alias: " + strings.Join(names, ", ") + ")"
+		}
+	}
+	return "", fmt.Errorf("no stored account matches %q; available: %s", requested, strings.Join(available, "; "))
+}
+
 // resolveDefaultAccount finds the account to use when none specified.
-func resolveDefaultAccount() (string, error) {
+func resolveDefaultAccount(flags *RootFlags) (string, error) {
 	// Check config for default
 	cfg, err := config.ReadConfig()
 	if err == nil && cfg.DefaultAccount != "" {
@@ -113,7 +208,7 @@ func resolveDefaultAccount() (string, error) {
 	}
 
 	// Check for single authenticated account
-	store, err := auth.OpenDefault()
+	store, err := openStore(flags)
 	if err != nil {
 		return "", fmt.Errorf("open keyring: %w", err)
 	}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dedene/harvest-cli/internal/auth"
+	"github.com/dedene/harvest-cli/internal/config"
+)
+
+// setupAccounts stores PATs for two accounts in a file keyring under a
+// temporary config dir, with work@example.com as the default.
+func setupAccounts(t *testing.T) {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HARVESTCLI_KEYRING_BACKEND", "file")
+	t.Setenv("HARVESTCLI_KEYRING_PASSWORD", "test")
+	t.Setenv(auth.PATEnvToken, "")
+	t.Setenv(config.EnvAccount, "")
+
+	store, err := auth.OpenDefault()
+	if err != nil {
+		t.Fatalf("OpenDefault() error = %v", err)
+	}
+	for email, id := range map[string]int64{"work@example.com": 1, "side@example.com": 2} {
+		if err := auth.StorePAT(store, email, id, "pat-"+email); err != nil {
+			t.Fatalf("StorePAT() error = %v", err)
+		}
+	}
+	if err := config.SetDefaultAccount("work@example.com"); err != nil {
+		t.Fatalf("SetDefaultAccount() error = %v", err)
+	}
+	if err := config.SetAccountAlias("side", "side@example.com"); err != nil {
+		t.Fatalf("SetAccountAlias() error = %v", err)
+	}
+}
+
+func TestGetTokenSource_AccountIsOneShot(t *testing.T) {
+	setupAccounts(t)
+
+	for _, account := range []string{"side@example.com", "Side@Example.com", "side"} {
+		_, accountID, err := GetTokenSource(context.Background(), &RootFlags{Account: account})
+		if err != nil {
+			t.Fatalf("GetTokenSource(%q) error = %v", account, err)
+		}
+		if accountID != 2 {
+			t.Errorf("GetTokenSource(%q) account ID = %d, want 2", account, accountID)
+		}
+	}
+
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		t.Fatalf("ReadConfig() error = %v", err)
+	}
+	if cfg.DefaultAccount != "work@example.com" {
+		t.Errorf("DefaultAccount = %q, want it unchanged", cfg.DefaultAccount)
+	}
+
+	_, accountID, err := GetTokenSource(context.Background(), &RootFlags{})
+	if err != nil {
+		t.Fatalf("GetTokenSource() error = %v", err)
+	}
+	if accountID != 1 {
+		t.Errorf("default account ID = %d, want 1", accountID)
+	}
+}
+
+func TestGetTokenSource_UnknownAccountListsAvailable(t *testing.T) {
+	setupAccounts(t)
+
+	_, _, err := GetTokenSource(context.Background(), &RootFlags{Account: "nobody@example.com"})
+	if err == nil {
+		t.Fatal("GetTokenSource() should fail for an unknown account")
+	}
+	want := `no stored account matches "nobody@example.com"; available: side@example.com (alias: side); work@example.com`
+	if err.Error() != want {
+		t.Errorf("error = %q, want %q", err, want)
+	}
+}
+
+func TestExecute_APIBaseURL(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(auth.PATEnvToken, "pat")
+	t.Setenv(auth.PATEnvAccountID, "1")
+
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"clients":[],"total_pages":1,"page":1}`))
+	}))
+	defer srv.Close()
+
+	t.Setenv("HARVEST_API_BASE_URL", srv.URL+"/v2/")
+	var stdout, stderr bytes.Buffer
+	if err := Execute([]string{"clients", "list", "--json"}, &stdout, &stderr); err != nil {
+		t.Fatalf("Execute() error = %v, stderr: %s", err, stderr.String())
+	}
+	if path != "/v2/clients" {
+		t.Errorf("request path = %q, want /v2/clients", path)
+	}
+
+	for _, bad := range []string{"api.example.com", "ftp://api.example.com", "https://"} {
+		stderr.Reset()
+		err := Execute([]string{"clients", "list", "--api-base-url", bad}, &stdout, &stderr)
+		if ExitCode(err) != 2 || !strings.Contains(stderr.String(), "must be an http or https URL") {
+			t.Errorf("--api-base-url %q: exit %d, stderr %q", bad, ExitCode(err), stderr.String())
+		}
+	}
+}
@@ -4,8 +4,9 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"os"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/dedene/harvest-cli/internal/api"
 	"github.com/dedene/harvest-cli/internal/dateparse"
@@ -15,22 +16,32 @@ import (
 
 // ClientsCmd groups client subcommands.
 type ClientsCmd struct {
-	List   ClientsListCmd   `cmd:"" help:"List all clients"`
-	Show   ClientsShowCmd   `cmd:"" help:"Show a client"`
-	Add    ClientsAddCmd    `cmd:"" help:"Create a client"`
-	Edit   ClientsEditCmd   `cmd:"" help:"Update a client"`
-	Remove ClientsRemoveCmd `cmd:"" help:"Delete a client"`
+	List      ClientsListCmd      `cmd:"" help:"List all clients"`
+	Show      ClientsShowCmd      `cmd:"" help:"Show a client"`
+	Add       ClientsAddCmd       `cmd:"" help:"Create a client"`
+	Edit      ClientsEditCmd      `cmd:"" help:"Update a client"`
+	Remove    ClientsRemoveCmd    `cmd:"" help:"Delete a client"`
+	Archive   ClientsArchiveCmd   `cmd:"" help:"Deactivate a client"`
+	Unarchive ClientsUnarchiveCmd `cmd:"" help:"Reactivate an archived client"`
+	Balance   ClientsBalanceCmd   `cmd:"" help:"Show a client's outstanding balance and open invoices"`
+	Merge     ClientsMergeCmd     `cmd:"" help:"Move a duplicate client's projects to another client and deactivate it"`
 }
 
 // ClientsListCmd lists clients with filters.
 type ClientsListCmd struct {
 	Active       *bool  `help:"Filter by active status"`
 	UpdatedSince string `help:"Filter by updated since (ISO datetime)"`
+	NDJSON       bool   `help:"Output one JSON object per line" name:"ndjson"`
+	PagingFlags  `embed:""`
 }
 
 func (c *ClientsListCmd) Run(cli *CLI) error {
+	if err := c.PagingFlags.validate(); err != nil {
+		return err
+	}
+
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -46,12 +57,18 @@ func (c *ClientsListCmd) Run(cli *CLI) error {
 		opts.UpdatedSince = t.Format("2006-01-02T15:04:05Z")
 	}
 
+	opts.PerPage = c.PerPage
+	opts.MaxItems = c.MaxItems
 	clients, err := client.ListAllClients(ctx, opts)
 	if err != nil {
 		return fmt.Errorf("list clients: %w", err)
 	}
 
-	return outputClients(os.Stdout, clients, output.ModeFromFlags(cli.JSON, cli.Plain))
+	if c.NDJSON {
+		return output.WriteNDJSON(cli.Stdout, clients)
+	}
+
+	return outputClients(cli.Stdout, clients, output.ModeFromFlags(cli.JSON, cli.Plain))
 }
 
 // ClientsShowCmd shows a single client.
@@ -61,7 +78,7 @@ type ClientsShowCmd struct {
 
 func (c *ClientsShowCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -71,7 +88,7 @@ func (c *ClientsShowCmd) Run(cli *CLI) error {
 		return fmt.Errorf("get client: %w", err)
 	}
 
-	return outputClient(os.Stdout, hc, output.ModeFromFlags(cli.JSON, cli.Plain))
+	return outputClient(cli.Stdout, hc, output.ModeFromFlags(cli.JSON, cli.Plain))
 }
 
 // ClientsAddCmd creates a new client.
@@ -84,7 +101,7 @@ type ClientsAddCmd struct {
 
 func (c *ClientsAddCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -105,11 +122,11 @@ func (c *ClientsAddCmd) Run(cli *CLI) error {
 		return fmt.Errorf("create client: %w", err)
 	}
 
-	if cli.JSON {
-		return output.WriteJSON(os.Stdout, hc)
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, hc)
 	}
 
-	fmt.Fprintf(os.Stdout, "Created client #%d: %s\n", hc.ID, hc.Name)
+	printSuccess(cli, hc.ID, "Created client #%d: %s\n", hc.ID, hc.Name)
 	return nil
 }
 
@@ -124,7 +141,7 @@ type ClientsEditCmd struct {
 
 func (c *ClientsEditCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -158,11 +175,11 @@ func (c *ClientsEditCmd) Run(cli *CLI) error {
 		return fmt.Errorf("update client: %w", err)
 	}
 
-	if cli.JSON {
-		return output.WriteJSON(os.Stdout, hc)
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, hc)
 	}
 
-	fmt.Fprintf(os.Stdout, "Updated client #%d: %s\n", hc.ID, hc.Name)
+	printSuccess(cli, hc.ID, "Updated client #%d: %s\n", hc.ID, hc.Name)
 	return nil
 }
 
@@ -174,7 +191,7 @@ type ClientsRemoveCmd struct {
 
 func (c *ClientsRemoveCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -190,13 +207,13 @@ func (c *ClientsRemoveCmd) Run(cli *CLI) error {
 		confirmed, err := ui.ConfirmPrompt(msg)
 		if err != nil {
 			if err == ui.ErrCanceled {
-				fmt.Fprintln(os.Stderr, "Canceled")
+				fmt.Fprintln(cli.Stderr, "Canceled")
 				return nil
 			}
 			return err
 		}
 		if !confirmed {
-			fmt.Fprintln(os.Stderr, "Aborted")
+			fmt.Fprintln(cli.Stderr, "Aborted")
 			return nil
 		}
 	}
@@ -205,10 +222,271 @@ func (c *ClientsRemoveCmd) Run(cli *CLI) error {
 		return fmt.Errorf("delete client: %w", err)
 	}
 
-	fmt.Fprintf(os.Stdout, "Deleted client #%d\n", c.ID)
+	printSuccess(cli, 0, "Deleted client #%d\n", c.ID)
+	return nil
+}
+
+// ClientsArchiveCmd deactivates a client.
+type ClientsArchiveCmd struct {
+	ID int64 `arg:"" help:"Client ID"`
+}
+
+func (c *ClientsArchiveCmd) Run(cli *CLI) error {
+	return setClientActive(cli, c.ID, false)
+}
+
+// ClientsUnarchiveCmd reactivates an archived client.
+type ClientsUnarchiveCmd struct {
+	ID int64 `arg:"" help:"Client ID"`
+}
+
+func (c *ClientsUnarchiveCmd) Run(cli *CLI) error {
+	return setClientActive(cli, c.ID, true)
+}
+
+// setClientActive archives or unarchives a client by updating only is_active.
+func setClientActive(cli *CLI, id int64, active bool) error {
+	ctx := context.Background()
+	client, err := NewClientFromFlags(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	hc, err := client.UpdateClient(ctx, id, &api.ClientInput{IsActive: &active})
+	if err != nil {
+		return fmt.Errorf("update client: %w", err)
+	}
+	if cli.DryRun {
+		return nil
+	}
+
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, hc)
+	}
+
+	printSuccess(cli, hc.ID, "%s client #%d: %s\n", archiveVerb(active), hc.ID, hc.Name)
+	return nil
+}
+
+// ClientsMergeCmd moves every project of one client to another and then
+// deactivates the emptied client.
+type ClientsMergeCmd struct {
+	From  string `help:"Client ID or name to merge away" required:""`
+	Into  string `help:"Client ID or name that receives the projects" required:""`
+	Force bool   `help:"Skip confirmation" short:"f"`
+}
+
+func (c *ClientsMergeCmd) Run(cli *CLI) error {
+	ctx := context.Background()
+	client, err := NewClientFromFlags(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	fromID, err := resolveClientID(ctx, client, c.From)
+	if err != nil {
+		return err
+	}
+	intoID, err := resolveClientID(ctx, client, c.Into)
+	if err != nil {
+		return err
+	}
+	if fromID == intoID {
+		return fmt.Errorf("--from and --into are the same client (#%d)", fromID)
+	}
+
+	source, err := client.GetClient(ctx, fromID)
+	if err != nil {
+		return fmt.Errorf("get client: %w", err)
+	}
+	target, err := client.GetClient(ctx, intoID)
+	if err != nil {
+		return fmt.Errorf("get client: %w", err)
+	}
+
+	projects, err := client.ListAllProjects(ctx, api.ProjectListOptions{ClientID: fromID})
+	if err != nil {
+		return fmt.Errorf("list projects: %w", err)
+	}
+
+	if source.Currency != target.Currency {
+		fmt.Fprintf(cli.Stderr, "Warning: %s bills in %s but %s bills in %s; moved projects will be invoiced in %s\n",
+			source.Name, source.Currency, target.Name, target.Currency, target.Currency)
+	}
+
+	// Show what will be merged
+	fmt.Fprintf(cli.Stderr, "Projects to move from %s (#%d) to %s (#%d) (%d):\n",
+		source.Name, source.ID, target.Name, target.ID, len(projects))
+	for _, p := range projects {
+		fmt.Fprintf(cli.Stderr, "  #%d: %s\n", p.ID, p.Name)
+	}
+	fmt.Fprintf(cli.Stderr, "Then deactivate %s (#%d)\n\n", source.Name, source.ID)
+
+	if cli.DryRun {
+		fmt.Fprintln(cli.Stderr, "Dry run - no projects moved")
+		return nil
+	}
+
+	if !c.Force {
+		msg := fmt.Sprintf("Move %d projects to %s and deactivate %s?", len(projects), target.Name, source.Name)
+		confirmed, err := ui.ConfirmPrompt(msg)
+		if err != nil {
+			if err == ui.ErrCanceled {
+				fmt.Fprintln(cli.Stderr, "Canceled")
+				return nil
+			}
+			return err
+		}
+		if !confirmed {
+			fmt.Fprintln(cli.Stderr, "Aborted")
+			return nil
+		}
+	}
+
+	moved := moveProjects(ctx, client, projects, target, cli.Stderr)
+
+	// A partial merge leaves the source active so the command can be rerun;
+	// projects already moved no longer belong to it.
+	if failed := len(projects) - len(moved); failed > 0 {
+		return fmt.Errorf("%d of %d projects could not be moved; %s (#%d) left active, rerun to retry",
+			failed, len(projects), source.Name, source.ID)
+	}
+
+	inactive := false
+	if _, err := client.UpdateClient(ctx, source.ID, &api.ClientInput{IsActive: &inactive}); err != nil {
+		return fmt.Errorf("all projects moved, but deactivate client #%d: %w", source.ID, err)
+	}
+
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, map[string]any{
+			"from":        source.ID,
+			"into":        target.ID,
+			"moved":       moved,
+			"deactivated": true,
+		})
+	}
+
+	printSuccess(cli, target.ID, "Merged %s (#%d) into %s (#%d): moved %d projects\n",
+		source.Name, source.ID, target.Name, target.ID, len(moved))
 	return nil
 }
 
+// moveProjects reassigns each project to target, reporting every move or
+// failure to w. It returns the IDs of the projects that moved.
+func moveProjects(ctx context.Context, client *api.Client, projects []api.Project, target *api.HarvestClient, w io.Writer) []int64 {
+	moved := []int64{}
+	for _, p := range projects {
+		if _, err := client.UpdateProject(ctx, p.ID, &api.ProjectInput{ClientID: target.ID}); err != nil {
+			fmt.Fprintf(w, "Error moving #%d (%s): %v\n", p.ID, p.Name, err)
+			continue
+		}
+		fmt.Fprintf(w, "Moved #%d (%s) to %s\n", p.ID, p.Name, target.Name)
+		moved = append(moved, p.ID)
+	}
+	return moved
+}
+
+// ClientsBalanceCmd sums a client's open invoices per currency.
+type ClientsBalanceCmd struct {
+	Client string `arg:"" help:"Client ID or name"`
+}
+
+// clientBalance is a client's outstanding amount and the invoices behind it.
+type clientBalance struct {
+	ClientID int64         `json:"client_id"`
+	Client   string        `json:"client"`
+	Totals   []amountTotal `json:"totals"`
+	Invoices []api.Invoice `json:"invoices"`
+}
+
+func (c *ClientsBalanceCmd) Run(cli *CLI) error {
+	ctx := context.Background()
+	client, err := NewClientFromFlags(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	clientID, err := resolveClientID(ctx, client, c.Client)
+	if err != nil {
+		return err
+	}
+	hc, err := client.GetClient(ctx, clientID)
+	if err != nil {
+		return fmt.Errorf("get client: %w", err)
+	}
+
+	invoices, err := client.ListAllInvoices(ctx, api.InvoiceListOptions{ClientID: clientID, State: "open"})
+	if err != nil {
+		return fmt.Errorf("list invoices: %w", err)
+	}
+
+	loadCurrencyFormat(ctx, cli, client)
+	return outputClientBalance(cli.Stdout, newClientBalance(hc, invoices), output.ModeFromFlags(cli.JSON, cli.Plain))
+}
+
+// newClientBalance totals open invoices per currency, listing the oldest
+// due date first.
+func newClientBalance(hc *api.HarvestClient, invoices []api.Invoice) clientBalance {
+	sorted := slices.Clone(invoices)
+	slices.SortStableFunc(sorted, func(a, b api.Invoice) int {
+		return strings.Compare(a.DueDate, b.DueDate)
+	})
+	if sorted == nil {
+		sorted = []api.Invoice{}
+	}
+	return clientBalance{
+		ClientID: hc.ID,
+		Client:   hc.Name,
+		Totals: sumAmounts(sorted, func(inv api.Invoice) (string, float64) {
+			return inv.Currency, inv.DueAmount
+		}),
+		Invoices: sorted,
+	}
+}
+
+// outputClientBalance writes a client balance in the specified format.
+func outputClientBalance(w io.Writer, b clientBalance, mode output.Mode) error {
+	switch mode {
+	case output.ModeJSON:
+		return output.WriteJSON(w, b)
+	case output.ModePlain:
+		headers := []string{"ID", "Number", "Issue Date", "Due Date", "Due Amount", "Currency"}
+		rows := make([][]string, 0, len(b.Invoices)+len(b.Totals))
+		for _, inv := range b.Invoices {
+			rows = append(rows, []string{
+				strconv.FormatInt(inv.ID, 10),
+				inv.Number,
+				inv.IssueDate,
+				inv.DueDate,
+				fmt.Sprintf("%.2f", inv.DueAmount),
+				inv.Currency,
+			})
+		}
+		for _, t := range b.Totals {
+			rows = append(rows, []string{"TOTAL", "", "", "", fmt.Sprintf("%.2f", t.Amount), t.Currency})
+		}
+		return output.WriteTSV(w, headers, rows)
+	default:
+		if len(b.Invoices) == 0 {
+			fmt.Fprintf(w, "%s has no open invoices\n", b.Client)
+			return nil
+		}
+		fmt.Fprintf(w, "%s: %d open invoices\n\n", b.Client, len(b.Invoices))
+		t := output.NewTable(w, "ID", "Number", "Issued", "Due", "Amount Due").SetAlign(output.AlignRight, 4).SetDateColumns(2, 3)
+		for _, inv := range b.Invoices {
+			t.AddRow(
+				strconv.FormatInt(inv.ID, 10),
+				inv.Number,
+				inv.IssueDate,
+				inv.DueDate,
+				formatAmount(inv.DueAmount, inv.Currency),
+			)
+		}
+		addAmountTotals(t, 4, b.Totals)
+		return t.Render()
+	}
+}
+
 // outputClients writes clients in the specified format.
 func outputClients(w io.Writer, clients []api.HarvestClient, mode output.Mode) error {
 	switch mode {
@@ -246,8 +524,9 @@ func outputClient(w io.Writer, c *api.HarvestClient, mode output.Mode) error {
 	case output.ModeJSON:
 		return output.WriteJSON(w, c)
 	case output.ModePlain:
-		fmt.Fprintf(w, "%d\t%s\t%t\t%s\n", c.ID, c.Name, c.IsActive, c.Currency)
-		return nil
+		return output.WriteTSV(w, nil, [][]string{{
+			strconv.FormatInt(c.ID, 10), c.Name, strconv.FormatBool(c.IsActive), c.Currency,
+		}})
 	default:
 		fmt.Fprintf(w, "ID:       %d\n", c.ID)
 		fmt.Fprintf(w, "Name:     %s\n", c.Name)
@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dedene/harvest-cli/internal/api"
+	"github.com/dedene/harvest-cli/internal/auth"
+	"github.com/dedene/harvest-cli/internal/output"
+)
+
+func TestNewClientBalance(t *testing.T) {
+	hc := &api.HarvestClient{ID: 5, Name: "Acme"}
+	invoices := []api.Invoice{
+		{ID: 1, Number: "INV-1", DueAmount: 100, Currency: "USD", DueDate: "2024-03-15"},
+		{ID: 2, Number: "INV-2", DueAmount: 250.5, Currency: "EUR", DueDate: "2024-02-01"},
+		{ID: 3, Number: "INV-3", DueAmount: 40, Currency: "USD", DueDate: "2024-01-10"},
+	}
+
+	b := newClientBalance(hc, invoices)
+	if b.ClientID != 5 || b.Client != "Acme" {
+		t.Errorf("client = %d %q", b.ClientID, b.Client)
+	}
+	if got := []int64{b.Invoices[0].ID, b.Invoices[1].ID, b.Invoices[2].ID}; got[0] != 3 || got[1] != 2 || got[2] != 1 {
+		t.Errorf("invoice order = %v, want oldest due first [3 2 1]", got)
+	}
+	want := []amountTotal{{Currency: "EUR", Amount: 250.5}, {Currency: "USD", Amount: 140}}
+	if len(b.Totals) != 2 || b.Totals[0] != want[0] || b.Totals[1] != want[1] {
+		t.Errorf("totals = %+v, want %+v", b.Totals, want)
+	}
+
+	var buf bytes.Buffer
+	if err := outputClientBalance(&buf, b, output.ModePlain); err != nil {
+		t.Fatalf("outputClientBalance() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if got := lines[len(lines)-1]; got != "TOTAL\t\t\t\t140.00\tUSD" {
+		t.Errorf("last plain row = %q", got)
+	}
+}
+
+func TestOutputClientBalance_NoInvoices(t *testing.T) {
+	b := newClientBalance(&api.HarvestClient{ID: 5, Name: "Acme"}, nil)
+
+	var buf bytes.Buffer
+	if err := outputClientBalance(&buf, b, output.ModeJSON); err != nil {
+		t.Fatalf("outputClientBalance() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), `"invoices": []`) || !strings.Contains(buf.String(), `"totals": []`) {
+		t.Errorf("JSON should use empty arrays, got: %s", buf.String())
+	}
+
+	buf.Reset()
+	if err := outputClientBalance(&buf, b, output.ModeTable); err != nil {
+		t.Fatalf("outputClientBalance() error = %v", err)
+	}
+	if got := buf.String(); got != "Acme has no open invoices\n" {
+		t.Errorf("table output = %q", got)
+	}
+}
+
+func TestClientsBalance_CurrencyFormat(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(auth.PATEnvToken, "pat")
+	t.Setenv(auth.PATEnvAccountID, "1")
+	t.Cleanup(func() { currencyFormat = nil })
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/company":
+			_, _ = w.Write([]byte(`{"currency_symbol_display":"symbol_after","currency_code_display":"none",
+				"decimal_symbol":",","thousands_separator":"."}`))
+		case "/clients/5":
+			_, _ = w.Write([]byte(`{"id":5,"name":"Acme","currency":"EUR"}`))
+		case "/invoices":
+			_, _ = w.Write([]byte(`{"invoices":[{"id":1,"number":"2024-1","due_date":"2024-05-01","due_amount":1250.5,"currency":"EUR"}],
+				"total_pages":1,"page":1}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	var stdout, stderr bytes.Buffer
+	if err := Execute([]string{"clients", "balance", "5", "--api-base-url", srv.URL}, &stdout, &stderr); err != nil {
+		t.Fatalf("Execute() error = %v, stderr: %s", err, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "1.250,50 €") {
+		t.Errorf("stdout = %q, want amounts in the account's currency format", stdout.String())
+	}
+}
+
+// mergeServer fakes the client and project endpoints used by clients merge.
+// Updating a project in failProjects returns 422.
+func mergeServer(t *testing.T, failProjects ...int64) (*httptest.Server, *[]string) {
+	t.Helper()
+	var mu sync.Mutex
+	var updates []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/clients/1":
+			_, _ = w.Write([]byte(`{"id":1,"name":"Acme Inc","currency":"USD"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/clients/2":
+			_, _ = w.Write([]byte(`{"id":2,"name":"Acme","currency":"USD"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/projects":
+			if got := r.URL.Query().Get("client_id"); got != "1" {
+				t.Errorf("client_id = %q, want 1", got)
+			}
+			_, _ = w.Write([]byte(`{"projects":[{"id":10,"name":"Website"},{"id":11,"name":"App"}],"total_pages":1,"page":1}`))
+		case r.Method == http.MethodPatch:
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			mu.Lock()
+			updates = append(updates, fmt.Sprintf("%s %v", r.URL.Path, body))
+			mu.Unlock()
+			for _, id := range failProjects {
+				if r.URL.Path == fmt.Sprintf("/projects/%d", id) {
+					w.WriteHeader(http.StatusUnprocessableEntity)
+					_, _ = w.Write([]byte(`{"message":"Project is locked"}`))
+					return
+				}
+			}
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &updates
+}
+
+func TestClientsMerge(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(auth.PATEnvToken, "pat")
+	t.Setenv(auth.PATEnvAccountID, "1")
+
+	srv, updates := mergeServer(t)
+	var stdout, stderr bytes.Buffer
+	args := []string{"clients", "merge", "--from", "1", "--into", "2", "--force", "--api-base-url", srv.URL}
+	if err := Execute(args, &stdout, &stderr); err != nil {
+		t.Fatalf("Execute() error = %v, stderr: %s", err, stderr.String())
+	}
+
+	want := []string{
+		"/projects/10 map[client_id:2]",
+		"/projects/11 map[client_id:2]",
+		"/clients/1 map[is_active:false]",
+	}
+	if strings.Join(*updates, "; ") != strings.Join(want, "; ") {
+		t.Errorf("updates = %v, want %v", *updates, want)
+	}
+	if !strings.Contains(stderr.String(), "Moved #11 (App) to Acme") {
+		t.Errorf("stderr missing reassignment report: %s", stderr.String())
+	}
+	if got := stdout.String(); got != "Merged Acme Inc (#1) into Acme (#2): moved 2 projects\n" {
+		t.Errorf("stdout = %q", got)
+	}
+}
+
+func TestClientsMerge_PartialFailureKeepsSource(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(auth.PATEnvToken, "pat")
+	t.Setenv(auth.PATEnvAccountID, "1")
+
+	srv, updates := mergeServer(t, 10)
+	var stdout, stderr bytes.Buffer
+	args := []string{"clients", "merge", "--from", "1", "--into", "2", "--force", "--api-base-url", srv.URL}
+	err := Execute(args, &stdout, &stderr)
+	if err == nil || !strings.Contains(err.Error(), "1 of 2 projects could not be moved") {
+		t.Fatalf("Execute() error = %v, want partial failure", err)
+	}
+	for _, u := range *updates {
+		if strings.HasPrefix(u, "/clients/") {
+			t.Errorf("source client updated after a failed move: %v", *updates)
+		}
+	}
+	if len(*updates) != 2 {
+		t.Errorf("updates = %v, want both projects attempted", *updates)
+	}
+}
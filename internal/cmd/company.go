@@ -4,7 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"os"
+	"strconv"
 
 	"github.com/dedene/harvest-cli/internal/api"
 	"github.com/dedene/harvest-cli/internal/output"
@@ -19,7 +19,7 @@ type CompanyCmd struct {
 
 func (c *CompanyCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -33,7 +33,7 @@ func (c *CompanyCmd) Run(cli *CLI) error {
 		return fmt.Errorf("get company: %w", err)
 	}
 
-	return outputCompany(os.Stdout, company, output.ModeFromFlags(cli.JSON, cli.Plain))
+	return outputCompany(cli.Stdout, company, output.ModeFromFlags(cli.JSON, cli.Plain))
 }
 
 func (c *CompanyCmd) runEdit(ctx context.Context, client *api.Client, cli *CLI) error {
@@ -58,11 +58,11 @@ func (c *CompanyCmd) runEdit(ctx context.Context, client *api.Client, cli *CLI)
 		return fmt.Errorf("update company: %w", err)
 	}
 
-	if cli.JSON {
-		return output.WriteJSON(os.Stdout, company)
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, company)
 	}
 
-	fmt.Fprintf(os.Stdout, "Updated company: %s\n", company.Name)
+	printSuccess(cli, 0, "Updated company: %s\n", company.Name)
 	return nil
 }
 
@@ -71,14 +71,13 @@ func outputCompany(w io.Writer, company *api.Company, mode output.Mode) error {
 	case output.ModeJSON:
 		return output.WriteJSON(w, company)
 	case output.ModePlain:
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\n",
+		return output.WriteTSV(w, nil, [][]string{{
 			company.Name,
 			company.WeekStartDay,
 			company.TimeFormat,
 			company.PlanType,
-			company.WeeklyCapacity/3600,
-		)
-		return nil
+			strconv.Itoa(company.WeeklyCapacity / 3600),
+		}})
 	default:
 		fmt.Fprintf(w, "Name:             %s\n", company.Name)
 		fmt.Fprintf(w, "Domain:           %s\n", company.FullDomain)
@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 )
 
 // CompletionCmd generates shell completions.
@@ -14,7 +13,7 @@ type CompletionCmd struct {
 
 type CompletionBashCmd struct{}
 
-func (c *CompletionBashCmd) Run() error {
+func (c *CompletionBashCmd) Run(cli *CLI) error {
 	script := `_harvest_completions() {
     local cur="${COMP_WORDS[COMP_CWORD]}"
     local commands="version config auth completion time timer projects clients tasks users"
@@ -26,13 +25,13 @@ func (c *CompletionBashCmd) Run() error {
 
 complete -F _harvest_completions harvest
 `
-	fmt.Fprint(os.Stdout, script)
+	fmt.Fprint(cli.Stdout, script)
 	return nil
 }
 
 type CompletionZshCmd struct{}
 
-func (c *CompletionZshCmd) Run() error {
+func (c *CompletionZshCmd) Run(cli *CLI) error {
 	script := `#compdef harvest
 
 _harvest() {
@@ -63,13 +62,13 @@ _harvest() {
 
 compdef _harvest harvest
 `
-	fmt.Fprint(os.Stdout, script)
+	fmt.Fprint(cli.Stdout, script)
 	return nil
 }
 
 type CompletionFishCmd struct{}
 
-func (c *CompletionFishCmd) Run() error {
+func (c *CompletionFishCmd) Run(cli *CLI) error {
 	script := `complete -c harvest -f
 
 complete -c harvest -n '__fish_use_subcommand' -a 'version' -d 'Print version'
@@ -83,6 +82,6 @@ complete -c harvest -n '__fish_use_subcommand' -a 'clients' -d 'Clients'
 complete -c harvest -n '__fish_use_subcommand' -a 'tasks' -d 'Tasks'
 complete -c harvest -n '__fish_use_subcommand' -a 'users' -d 'Users'
 `
-	fmt.Fprint(os.Stdout, script)
+	fmt.Fprint(cli.Stdout, script)
 	return nil
 }
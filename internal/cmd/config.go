@@ -1,20 +1,28 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"os"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/dedene/harvest-cli/internal/auth"
 	"github.com/dedene/harvest-cli/internal/config"
+	"github.com/dedene/harvest-cli/internal/dateparse"
+	"github.com/dedene/harvest-cli/internal/output"
 )
 
 // ConfigCmd groups configuration subcommands.
 type ConfigCmd struct {
-	Show  ConfigShowCmd  `cmd:"" default:"1" help:"Show current configuration"`
-	Set   ConfigSetCmd   `cmd:"" help:"Set a configuration value"`
-	Unset ConfigUnsetCmd `cmd:"" help:"Remove a configuration value"`
-	Path  ConfigPathCmd  `cmd:"" help:"Show configuration directory path"`
+	Show    ConfigShowCmd    `cmd:"" default:"1" help:"Show current configuration"`
+	Get     ConfigGetCmd     `cmd:"" help:"Print a configuration value"`
+	Set     ConfigSetCmd     `cmd:"" help:"Set a configuration value"`
+	Unset   ConfigUnsetCmd   `cmd:"" help:"Remove a configuration value"`
+	Path    ConfigPathCmd    `cmd:"" help:"Show configuration directory path"`
+	Profile ConfigProfileCmd `cmd:"" aliases:"profiles" help:"Manage named profiles of settings"`
 }
 
 // ConfigShowCmd shows current configuration.
@@ -26,52 +34,86 @@ func (c *ConfigShowCmd) Run(cli *CLI) error {
 		return fmt.Errorf("read config: %w", err)
 	}
 
-	if cli.JSON {
-		enc := json.NewEncoder(os.Stdout)
+	if cli.jsonOutput() {
+		enc := json.NewEncoder(cli.Stdout)
 		enc.SetIndent("", "  ")
 		return enc.Encode(cfg)
 	}
 
 	// Human-readable output
-	fmt.Fprintf(os.Stdout, "Config file: %s\n\n", config.ConfigPath())
+	fmt.Fprintf(cli.Stdout, "Config file: %s\n\n", config.ConfigPath())
 
 	if cfg.DefaultAccount != "" {
-		fmt.Fprintf(os.Stdout, "default_account:   %s\n", cfg.DefaultAccount)
+		fmt.Fprintf(cli.Stdout, "default_account:   %s\n", cfg.DefaultAccount)
 	}
 	if cfg.DefaultTimezone != "" {
-		fmt.Fprintf(os.Stdout, "default_timezone:  %s\n", cfg.DefaultTimezone)
+		fmt.Fprintf(cli.Stdout, "default_timezone:  %s\n", cfg.DefaultTimezone)
 	}
 	if cfg.WeekStart != "" {
-		fmt.Fprintf(os.Stdout, "week_start:        %s\n", cfg.WeekStart)
+		fmt.Fprintf(cli.Stdout, "week_start:        %s\n", cfg.WeekStart)
 	}
 	if cfg.Color != "" {
-		fmt.Fprintf(os.Stdout, "color:             %s\n", cfg.Color)
+		fmt.Fprintf(cli.Stdout, "color:             %s\n", cfg.Color)
+	}
+	if cfg.DateFormat != "" {
+		fmt.Fprintf(cli.Stdout, "date_format:       %s\n", cfg.DateFormat)
+	}
+	if cfg.DefaultOutput != "" {
+		fmt.Fprintf(cli.Stdout, "default_output:    %s\n", cfg.DefaultOutput)
+	}
+	if cfg.FiscalYearStart != "" {
+		fmt.Fprintf(cli.Stdout, "fiscal_year_start: %s\n", cfg.FiscalYearStart)
 	}
 	if cfg.KeyringBackend != "" {
-		fmt.Fprintf(os.Stdout, "keyring_backend:   %s\n", cfg.KeyringBackend)
+		fmt.Fprintf(cli.Stdout, "keyring_backend:   %s\n", cfg.KeyringBackend)
 	}
 	if cfg.ContactEmail != "" {
-		fmt.Fprintf(os.Stdout, "contact_email:     %s\n", cfg.ContactEmail)
+		fmt.Fprintf(cli.Stdout, "contact_email:     %s\n", cfg.ContactEmail)
+	}
+	if cfg.IdleWarn != "" {
+		fmt.Fprintf(cli.Stdout, "idle_warn:         %s\n", cfg.IdleWarn)
+	}
+	if cfg.ActiveProfile != "" {
+		fmt.Fprintf(cli.Stdout, "active_profile:    %s\n", cfg.ActiveProfile)
+	}
+
+	if len(cfg.AccountDefaults) > 0 {
+		fmt.Fprintln(cli.Stdout, "\nAccount defaults:")
+		for accountID, d := range cfg.AccountDefaults {
+			fmt.Fprintf(cli.Stdout, "  %s -> project: %s, task: %s\n", accountID, orDash(d.Project), orDash(d.Task))
+		}
+	}
+
+	if len(cfg.Profiles) > 0 {
+		fmt.Fprintln(cli.Stdout, "\nProfiles:")
+		names := make([]string, 0, len(cfg.Profiles))
+		for name := range cfg.Profiles {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(cli.Stdout, "  %s\n", name)
+		}
 	}
 
 	if len(cfg.AccountAliases) > 0 {
-		fmt.Fprintln(os.Stdout, "\nAccount aliases:")
+		fmt.Fprintln(cli.Stdout, "\nAccount aliases:")
 		for alias, email := range cfg.AccountAliases {
-			fmt.Fprintf(os.Stdout, "  %s -> %s\n", alias, email)
+			fmt.Fprintf(cli.Stdout, "  %s -> %s\n", alias, email)
 		}
 	}
 
 	if len(cfg.AccountClients) > 0 {
-		fmt.Fprintln(os.Stdout, "\nAccount clients:")
+		fmt.Fprintln(cli.Stdout, "\nAccount clients:")
 		for email, client := range cfg.AccountClients {
-			fmt.Fprintf(os.Stdout, "  %s -> %s\n", email, client)
+			fmt.Fprintf(cli.Stdout, "  %s -> %s\n", email, client)
 		}
 	}
 
 	if len(cfg.ClientDomains) > 0 {
-		fmt.Fprintln(os.Stdout, "\nClient domains:")
+		fmt.Fprintln(cli.Stdout, "\nClient domains:")
 		for domain, client := range cfg.ClientDomains {
-			fmt.Fprintf(os.Stdout, "  %s -> %s\n", domain, client)
+			fmt.Fprintf(cli.Stdout, "  %s -> %s\n", domain, client)
 		}
 	}
 
@@ -86,16 +128,22 @@ type ConfigSetCmd struct {
 
 // Allowed configuration keys.
 var allowedConfigKeys = map[string]bool{
-	"default_account":  true,
-	"default_timezone": true,
-	"week_start":       true,
-	"color":            true,
-	"keyring_backend":  true,
-	"contact_email":    true,
+	"default_account":   true,
+	"default_timezone":  true,
+	"week_start":        true,
+	"color":             true,
+	"date_format":       true,
+	"default_output":    true,
+	"fiscal_year_start": true,
+	"keyring_backend":   true,
+	"contact_email":     true,
+	"idle_warn":         true,
+	"default_project":   true,
+	"default_task":      true,
 }
 
-func (c *ConfigSetCmd) Run() error {
-	key := strings.ToLower(strings.TrimSpace(c.Key))
+func (c *ConfigSetCmd) Run(cli *CLI) error {
+	key := normalizeConfigKey(c.Key)
 
 	// Handle aliases specially
 	if strings.HasPrefix(key, "alias.") {
@@ -116,8 +164,10 @@ func (c *ConfigSetCmd) Run() error {
 	}
 
 	if !allowedConfigKeys[key] {
-		return fmt.Errorf("unknown config key: %q\nAllowed keys: %s",
-			key, strings.Join(sortedKeys(allowedConfigKeys), ", "))
+		return unknownConfigKeyError(key)
+	}
+	if err := validateConfigValue(key, c.Value); err != nil {
+		return err
 	}
 
 	cfg, err := config.ReadConfig()
@@ -134,17 +184,29 @@ func (c *ConfigSetCmd) Run() error {
 		cfg.WeekStart = c.Value
 	case "color":
 		cfg.Color = c.Value
+	case "date_format":
+		cfg.DateFormat = c.Value
+	case "default_output":
+		cfg.DefaultOutput = c.Value
+	case "fiscal_year_start":
+		cfg.FiscalYearStart = c.Value
 	case "keyring_backend":
 		cfg.KeyringBackend = c.Value
 	case "contact_email":
 		cfg.ContactEmail = c.Value
+	case "idle_warn":
+		cfg.IdleWarn = c.Value
+	case "default_project", "default_task":
+		if err := setAccountDefault(cli, cfg, key, c.Value); err != nil {
+			return err
+		}
 	}
 
 	if err := config.WriteConfig(cfg); err != nil {
 		return fmt.Errorf("write config: %w", err)
 	}
 
-	fmt.Fprintf(os.Stdout, "Set %s = %s\n", key, c.Value)
+	fmt.Fprintf(cli.Stdout, "Set %s = %s\n", key, c.Value)
 
 	return nil
 }
@@ -154,8 +216,8 @@ type ConfigUnsetCmd struct {
 	Key string `arg:"" help:"Configuration key to remove"`
 }
 
-func (c *ConfigUnsetCmd) Run() error {
-	key := strings.ToLower(strings.TrimSpace(c.Key))
+func (c *ConfigUnsetCmd) Run(cli *CLI) error {
+	key := normalizeConfigKey(c.Key)
 
 	// Handle aliases specially
 	if strings.HasPrefix(key, "alias.") {
@@ -163,19 +225,32 @@ func (c *ConfigUnsetCmd) Run() error {
 		if err := config.DeleteAccountAlias(alias); err != nil {
 			return err
 		}
-		fmt.Fprintf(os.Stdout, "Removed alias %s\n", alias)
+		fmt.Fprintf(cli.Stdout, "Removed alias %s\n", alias)
 		return nil
 	}
 
-	if !allowedConfigKeys[key] {
-		return fmt.Errorf("unknown config key: %q", key)
-	}
-
 	cfg, err := config.ReadConfig()
 	if err != nil {
 		return fmt.Errorf("read config: %w", err)
 	}
 
+	// Handle client domains and account clients
+	if m, name, ok := configMapEntry(cfg, key); ok {
+		if _, exists := m[name]; !exists {
+			return fmt.Errorf("%s is not set", key)
+		}
+		delete(m, name)
+		if err := config.WriteConfig(cfg); err != nil {
+			return fmt.Errorf("write config: %w", err)
+		}
+		fmt.Fprintf(cli.Stdout, "Unset %s\n", key)
+		return nil
+	}
+
+	if !allowedConfigKeys[key] {
+		return unknownConfigKeyError(key)
+	}
+
 	switch key {
 	case "default_account":
 		cfg.DefaultAccount = ""
@@ -185,34 +260,217 @@ func (c *ConfigUnsetCmd) Run() error {
 		cfg.WeekStart = ""
 	case "color":
 		cfg.Color = ""
+	case "date_format":
+		cfg.DateFormat = ""
+	case "default_output":
+		cfg.DefaultOutput = ""
+	case "fiscal_year_start":
+		cfg.FiscalYearStart = ""
 	case "keyring_backend":
 		cfg.KeyringBackend = ""
 	case "contact_email":
 		cfg.ContactEmail = ""
+	case "idle_warn":
+		cfg.IdleWarn = ""
+	case "default_project", "default_task":
+		if err := setAccountDefault(cli, cfg, key, ""); err != nil {
+			return err
+		}
 	}
 
 	if err := config.WriteConfig(cfg); err != nil {
 		return fmt.Errorf("write config: %w", err)
 	}
 
-	fmt.Fprintf(os.Stdout, "Unset %s\n", key)
+	fmt.Fprintf(cli.Stdout, "Unset %s\n", key)
+
+	return nil
+}
+
+// ConfigGetCmd prints a single configuration value.
+type ConfigGetCmd struct {
+	Key string `arg:"" help:"Configuration key"`
+}
+
+func (c *ConfigGetCmd) Run(cli *CLI) error {
+	key := normalizeConfigKey(c.Key)
+
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+
+	value, err := configValue(cli, cfg, key)
+	if err != nil {
+		return err
+	}
+	if value == "" {
+		return fmt.Errorf("%s is not set", key)
+	}
+
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, map[string]string{"key": key, "value": value})
+	}
+	fmt.Fprintln(cli.Stdout, value)
+	return nil
+}
+
+// configValue returns the stored value for key, or "" when unset.
+func configValue(cli *CLI, cfg *config.File, key string) (string, error) {
+	if strings.HasPrefix(key, "alias.") {
+		return cfg.AccountAliases[strings.TrimPrefix(key, "alias.")], nil
+	}
+	if m, name, ok := configMapEntry(cfg, key); ok {
+		return m[name], nil
+	}
+
+	if !allowedConfigKeys[key] {
+		return "", unknownConfigKeyError(key)
+	}
 
+	switch key {
+	case "default_account":
+		return cfg.DefaultAccount, nil
+	case "default_timezone":
+		return cfg.DefaultTimezone, nil
+	case "week_start":
+		return cfg.WeekStart, nil
+	case "color":
+		return cfg.Color, nil
+	case "date_format":
+		return cfg.DateFormat, nil
+	case "default_output":
+		return cfg.DefaultOutput, nil
+	case "fiscal_year_start":
+		return cfg.FiscalYearStart, nil
+	case "keyring_backend":
+		return cfg.KeyringBackend, nil
+	case "contact_email":
+		return cfg.ContactEmail, nil
+	case "idle_warn":
+		return cfg.IdleWarn, nil
+	case "default_project", "default_task":
+		_, accountID, err := GetTokenSource(context.Background(), &cli.RootFlags)
+		if err != nil {
+			return "", err
+		}
+		d := cfg.DefaultsFor(accountID)
+		if key == "default_project" {
+			return d.Project, nil
+		}
+		return d.Task, nil
+	}
+	return "", nil
+}
+
+// configMapEntry returns the map and entry name for "domain.<domain>" and
+// "client.<email>" keys.
+func configMapEntry(cfg *config.File, key string) (map[string]string, string, bool) {
+	switch {
+	case strings.HasPrefix(key, "domain."):
+		return cfg.ClientDomains, config.NormalizeDomain(strings.TrimPrefix(key, "domain.")), true
+	case strings.HasPrefix(key, "client."):
+		return cfg.AccountClients, strings.TrimPrefix(key, "client."), true
+	}
+	return nil, "", false
+}
+
+// normalizeConfigKey lowercases key and accepts dashes for underscores in
+// plain keys, so default-project and default_project are the same key.
+func normalizeConfigKey(key string) string {
+	key = strings.ToLower(strings.TrimSpace(key))
+	if !strings.Contains(key, ".") {
+		key = strings.ReplaceAll(key, "-", "_")
+	}
+	return key
+}
+
+// validateConfigValue rejects values the CLI would not understand later.
+func validateConfigValue(key, value string) error {
+	switch key {
+	case "week_start":
+		switch strings.ToLower(value) {
+		case "monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday":
+		default:
+			return fmt.Errorf("invalid week_start %q (use a weekday, e.g. monday)", value)
+		}
+	case "color":
+		if value != "auto" && value != "always" && value != "never" {
+			return fmt.Errorf("invalid color %q (use auto, always or never)", value)
+		}
+	case "date_format":
+		if !strings.EqualFold(value, "iso") && !strings.EqualFold(value, "account") {
+			if _, err := output.DateLayout(value); err != nil {
+				return fmt.Errorf("invalid date_format: %w (or use iso or account)", err)
+			}
+		}
+	case "default_output":
+		if !slices.Contains(outputFormats, value) {
+			return fmt.Errorf("invalid default_output %q (use %s)", value, strings.Join(outputFormats, ", "))
+		}
+	case "fiscal_year_start":
+		if _, err := dateparse.ParseMonth(value); err != nil {
+			return fmt.Errorf("invalid fiscal_year_start: %w", err)
+		}
+	case "keyring_backend":
+		if !slices.Contains(auth.Backends, strings.ToLower(value)) {
+			return fmt.Errorf("invalid keyring_backend %q (use %s)", value, strings.Join(auth.Backends, ", "))
+		}
+	case "default_timezone":
+		if _, err := time.LoadLocation(value); err != nil {
+			return fmt.Errorf("invalid default_timezone %q (e.g. America/New_York)", value)
+		}
+	case "idle_warn":
+		if _, err := time.ParseDuration(value); err != nil {
+			return fmt.Errorf("invalid idle_warn duration %q (e.g. 8h, 90m)", value)
+		}
+	}
 	return nil
 }
 
+func unknownConfigKeyError(key string) error {
+	keys := append(sortedKeys(allowedConfigKeys), "alias.<name>", "client.<email>", "domain.<domain>")
+	return fmt.Errorf("unknown config key: %q\nAllowed keys: %s", key, strings.Join(keys, ", "))
+}
+
+// setAccountDefault updates the default project or task of the current
+// account. Defaults are stored per Harvest account ID.
+func setAccountDefault(cli *CLI, cfg *config.File, key, value string) error {
+	_, accountID, err := GetTokenSource(context.Background(), &cli.RootFlags)
+	if err != nil {
+		return err
+	}
+
+	d := cfg.DefaultsFor(accountID)
+	if key == "default_project" {
+		d.Project = value
+	} else {
+		d.Task = value
+	}
+	cfg.SetDefaultsFor(accountID, d)
+	return nil
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
 // ConfigPathCmd shows configuration paths.
 type ConfigPathCmd struct{}
 
-func (c *ConfigPathCmd) Run() error {
+func (c *ConfigPathCmd) Run(cli *CLI) error {
 	dir, err := config.Dir()
 	if err != nil {
 		return fmt.Errorf("resolve config dir: %w", err)
 	}
 
-	fmt.Fprintf(os.Stdout, "Config dir:  %s\n", dir)
-	fmt.Fprintf(os.Stdout, "Config file: %s\n", config.ConfigPath())
-	fmt.Fprintf(os.Stdout, "Clients dir: %s\n", config.ClientsDir())
-	fmt.Fprintf(os.Stdout, "Keyring dir: %s\n", config.KeyringDir())
+	fmt.Fprintf(cli.Stdout, "Config dir:  %s\n", dir)
+	fmt.Fprintf(cli.Stdout, "Config file: %s\n", config.ConfigPath())
+	fmt.Fprintf(cli.Stdout, "Clients dir: %s\n", config.ClientsDir())
+	fmt.Fprintf(cli.Stdout, "Keyring dir: %s\n", config.KeyringDir())
 
 	return nil
 }
@@ -222,5 +480,6 @@ func sortedKeys(m map[string]bool) []string {
 	for k := range m {
 		keys = append(keys, k)
 	}
+	sort.Strings(keys)
 	return keys
 }
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func runConfig(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	err := Execute(append([]string{"config"}, args...), &stdout, &stderr)
+	return stdout.String(), err
+}
+
+func TestConfigSetGetUnset(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	if _, err := runConfig(t, "set", "week-start", "tuesday"); err != nil {
+		t.Fatalf("config set error = %v", err)
+	}
+
+	out, err := runConfig(t, "get", "week_start")
+	if err != nil {
+		t.Fatalf("config get error = %v", err)
+	}
+	if out != "tuesday\n" {
+		t.Errorf("config get = %q, want %q", out, "tuesday\n")
+	}
+
+	if _, err := runConfig(t, "unset", "week_start"); err != nil {
+		t.Fatalf("config unset error = %v", err)
+	}
+	if _, err := runConfig(t, "get", "week_start"); err == nil || !strings.Contains(err.Error(), "not set") {
+		t.Errorf("config get after unset error = %v, want not set", err)
+	}
+}
+
+func TestConfigMapKeys(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	if _, err := runConfig(t, "set", "domain.Example.com", "work"); err != nil {
+		t.Fatalf("config set error = %v", err)
+	}
+	out, err := runConfig(t, "get", "domain.example.com")
+	if err != nil || out != "work\n" {
+		t.Errorf("config get = %q, %v; want work", out, err)
+	}
+
+	if _, err := runConfig(t, "unset", "domain.example.com"); err != nil {
+		t.Fatalf("config unset error = %v", err)
+	}
+	if _, err := runConfig(t, "unset", "domain.example.com"); err == nil {
+		t.Error("unsetting a missing domain should fail")
+	}
+}
+
+func TestConfigValidation(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{args: []string{"set", "colour", "auto"}, want: "unknown config key"},
+		{args: []string{"get", "cache"}, want: "unknown config key"},
+		{args: []string{"set", "color", "blue"}, want: "invalid color"},
+		{args: []string{"set", "week_start", "funday"}, want: "invalid week_start"},
+		{args: []string{"set", "default_timezone", "Mars/Olympus"}, want: "invalid default_timezone"},
+		{args: []string{"set", "idle_warn", "soon"}, want: "invalid idle_warn"},
+		{args: []string{"set", "keyring_backend", "gnome"}, want: "invalid keyring_backend"},
+		{args: []string{"set", "default_output", "xml"}, want: "invalid default_output"},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			_, err := runConfig(t, tt.args...)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
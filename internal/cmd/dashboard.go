@@ -3,7 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"os"
+	"io"
 	"strings"
 	"time"
 
@@ -21,31 +21,49 @@ type DashboardCmd struct {
 func (c *DashboardCmd) Run(cli *CLI) error {
 	ctx := context.Background()
 
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
 
-	// Get company settings for week_start_day
-	company, err := client.GetCompany(ctx)
+	// Get company settings for weekly capacity
+	company, err := getCompany(ctx, cli, client)
 	if err != nil {
-		return fmt.Errorf("get company: %w", err)
+		return err
 	}
 
-	// Calculate week boundaries
-	weekStart, weekEnd := c.calculateWeekBoundaries(company.WeekStartDay)
-
-	// Fetch time entries for the week
-	entries, err := client.ListAllTimeEntries(ctx, api.TimeEntryListOptions{
-		From: weekStart.Format("2006-01-02"),
-		To:   weekEnd.Format("2006-01-02"),
-	})
+	startDay, err := resolveWeekStart(ctx, cli, client)
 	if err != nil {
-		return fmt.Errorf("list entries: %w", err)
+		return err
 	}
 
-	// Check for running timer
-	running, _ := client.GetRunningTimeEntry(ctx)
+	// Calculate week boundaries
+	weekStart, weekEnd := c.calculateWeekBoundaries(startDay)
+
+	// Fetch the week's time entries and the running timer together
+	var entries []api.TimeEntry
+	var running *api.TimeEntry
+	err = fetchAll(ctx,
+		func(ctx context.Context) error {
+			var err error
+			entries, err = client.ListAllTimeEntries(ctx, api.TimeEntryListOptions{
+				From: weekStart.Format("2006-01-02"),
+				To:   weekEnd.Format("2006-01-02"),
+			})
+			if err != nil {
+				return fmt.Errorf("list entries: %w", err)
+			}
+			return nil
+		},
+		func(ctx context.Context) error {
+			// A failed timer lookup only hides the running timer
+			running, _ = client.GetRunningTimeEntry(ctx)
+			return nil
+		},
+	)
+	if err != nil {
+		return err
+	}
 
 	// Get week target (company.WeeklyCapacity is in seconds, default 40h = 144000)
 	weekTarget := float64(company.WeeklyCapacity) / 3600.0
@@ -57,16 +75,16 @@ func (c *DashboardCmd) Run(cli *CLI) error {
 	dashboard := ui.NewDashboard(entries, running, weekStart, weekTarget)
 
 	// Render output
-	if cli.JSON {
-		return c.outputJSON(dashboard)
+	if cli.jsonOutput() {
+		return c.outputJSON(cli.Stdout, dashboard)
 	}
 
-	fmt.Fprint(os.Stdout, dashboard.View())
+	fmt.Fprint(cli.Stdout, dashboard.View())
 	return nil
 }
 
-// calculateWeekBoundaries returns start (Monday by default) and end of week.
-func (c *DashboardCmd) calculateWeekBoundaries(weekStartDay string) (time.Time, time.Time) {
+// calculateWeekBoundaries returns the start and end of the week beginning on startWeekday.
+func (c *DashboardCmd) calculateWeekBoundaries(startWeekday time.Weekday) (time.Time, time.Time) {
 	now := time.Now()
 
 	// Parse --week flag if provided (format: 2024-01-15 or YYYY-Www)
@@ -89,9 +107,6 @@ func (c *DashboardCmd) calculateWeekBoundaries(weekStartDay string) (time.Time,
 		}
 	}
 
-	// Map Harvest week_start_day to Go weekday
-	startWeekday := parseWeekStartDay(weekStartDay)
-
 	// Find start of week
 	daysBack := int(now.Weekday()) - int(startWeekday)
 	if daysBack < 0 {
@@ -125,7 +140,7 @@ func parseWeekStartDay(day string) time.Weekday {
 	}
 }
 
-func (c *DashboardCmd) outputJSON(d *ui.DashboardModel) error {
+func (c *DashboardCmd) outputJSON(w io.Writer, d *ui.DashboardModel) error {
 	data := map[string]any{
 		"week_start":  d.WeekStart.Format("2006-01-02"),
 		"today_hours": d.TodayHours,
@@ -145,5 +160,5 @@ func (c *DashboardCmd) outputJSON(d *ui.DashboardModel) error {
 		}
 	}
 
-	return output.WriteJSON(os.Stdout, data)
+	return output.WriteJSON(w, data)
 }
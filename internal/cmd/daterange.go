@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dedene/harvest-cli/internal/config"
+	"github.com/dedene/harvest-cli/internal/dateparse"
+)
+
+// parseDateRange parses --from/--to values into YYYY-MM-DD dates. Either
+// may be empty and is returned empty. When both are set, from must not be
+// after to; the range is inclusive, so equal dates select a single day.
+func parseDateRange(fromInput, toInput string) (from, to string, err error) {
+	if fromInput != "" {
+		t, err := dateparse.Parse(fromInput)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid from date: %w", err)
+		}
+		from = dateparse.FormatDate(t)
+	}
+	if toInput != "" {
+		t, err := dateparse.Parse(toInput)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid to date: %w", err)
+		}
+		to = dateparse.FormatDate(t)
+	}
+	// YYYY-MM-DD strings sort chronologically
+	if from != "" && to != "" && from > to {
+		return "", "", fmt.Errorf("--from %s is after --to %s", from, to)
+	}
+	return from, to, nil
+}
+
+// FiscalFlags select an issue-date range by fiscal period instead of
+// --from/--to. The fiscal year starts in the fiscal_year_start config month
+// (January by default) and is named after the calendar year it ends in.
+type FiscalFlags struct {
+	Quarter     int  `help:"Issue dates in this fiscal quarter (1-4), of --year or the current fiscal year"`
+	Year        int  `help:"Issue dates in this fiscal year (YYYY, the calendar year it ends in)"`
+	ThisQuarter bool `help:"Issue dates in the current fiscal quarter" name:"this-quarter"`
+}
+
+// set reports whether any fiscal period flag was given.
+func (f FiscalFlags) set() bool {
+	return f.Quarter != 0 || f.Year != 0 || f.ThisQuarter
+}
+
+// dateRange returns the YYYY-MM-DD range of the selected fiscal period, as
+// of today. It must only be called when set reports true.
+func (f FiscalFlags) dateRange(today time.Time, startMonth time.Month) (from, to string, err error) {
+	if f.ThisQuarter && (f.Quarter != 0 || f.Year != 0) {
+		return "", "", fmt.Errorf("--this-quarter cannot be combined with --quarter or --year")
+	}
+	if f.Year < 0 {
+		return "", "", fmt.Errorf("invalid --year %d", f.Year)
+	}
+
+	year, quarter := dateparse.FiscalQuarterOf(today, startMonth)
+	if f.Year != 0 {
+		year = f.Year
+	}
+	if f.Quarter != 0 {
+		quarter = f.Quarter
+	}
+
+	var start, end time.Time
+	if f.Year != 0 && f.Quarter == 0 {
+		start, end = dateparse.FiscalYear(year, startMonth)
+	} else if start, end, err = dateparse.FiscalQuarter(year, quarter, startMonth); err != nil {
+		return "", "", fmt.Errorf("--quarter: %w", err)
+	}
+	return dateparse.FormatDate(start), dateparse.FormatDate(end), nil
+}
+
+// issueDateRange resolves --from/--to or the fiscal period flags, which
+// cannot be combined.
+func issueDateRange(fromInput, toInput string, fiscal FiscalFlags) (from, to string, err error) {
+	if !fiscal.set() {
+		return parseDateRange(fromInput, toInput)
+	}
+	if fromInput != "" || toInput != "" {
+		return "", "", fmt.Errorf("--quarter, --year and --this-quarter cannot be combined with --from or --to")
+	}
+	return fiscal.dateRange(time.Now(), fiscalYearStart())
+}
+
+// fiscalYearStart returns the configured first month of the fiscal year,
+// January when unset.
+func fiscalYearStart() time.Month {
+	cfg, err := config.ReadConfig()
+	if err != nil || cfg.FiscalYearStart == "" {
+		return time.January
+	}
+	m, err := dateparse.ParseMonth(cfg.FiscalYearStart)
+	if err != nil {
+		return time.January
+	}
+	return m
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/kong"
+
+	"github.com/dedene/harvest-cli/internal/config"
+)
+
+func TestParseDateRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to string
+		wantFrom string
+		wantTo   string
+		wantErr  string
+	}{
+		{name: "both", from: "2024-01-01", to: "2024-01-31", wantFrom: "2024-01-01", wantTo: "2024-01-31"},
+		{name: "same day", from: "2024-01-15", to: "2024-01-15", wantFrom: "2024-01-15", wantTo: "2024-01-15"},
+		{name: "from only", from: "2024-01-01", wantFrom: "2024-01-01"},
+		{name: "to only", to: "2024-01-31", wantTo: "2024-01-31"},
+		{name: "neither"},
+		{name: "reversed", from: "2024-02-01", to: "2024-01-01", wantErr: "--from 2024-02-01 is after --to 2024-01-01"},
+		{name: "bad from", from: "someday", to: "2024-01-01", wantErr: "invalid from date"},
+		{name: "bad to", from: "2024-01-01", to: "someday", wantErr: "invalid to date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, err := parseDateRange(tt.from, tt.to)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("parseDateRange() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseDateRange() error = %v", err)
+			}
+			if from != tt.wantFrom || to != tt.wantTo {
+				t.Errorf("parseDateRange() = %q, %q, want %q, %q", from, to, tt.wantFrom, tt.wantTo)
+			}
+		})
+	}
+}
+
+func TestExecute_SinceUntilAliases(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	args := []string{"invoices", "payments", "list", "--all", "--since", "2024-02-01", "--until", "2024-01-01"}
+	err := Execute(args, &stdout, &stderr)
+	if err == nil || !strings.Contains(err.Error(), "--from 2024-02-01 is after --to 2024-01-01") {
+		t.Errorf("Execute() error = %v, want reversed range error", err)
+	}
+}
+
+// TestDateRangeFlagAliases checks that every --from/--to date flag also
+// answers to --since/--until. clients merge --from names a client instead.
+func TestDateRangeFlagAliases(t *testing.T) {
+	parser, _, err := newParser(&bytes.Buffer{}, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("newParser() error = %v", err)
+	}
+	want := map[string]string{"from": "since", "to": "until"}
+	checked := 0
+	_ = kong.Visit(parser.Model.Node, func(n kong.Visitable, next kong.Next) error {
+		if node, ok := n.(*kong.Node); ok && node.Type == kong.CommandNode && node.Path() != "clients merge" {
+			for _, f := range node.Flags {
+				alias, ok := want[f.Name]
+				if !ok {
+					continue
+				}
+				checked++
+				if !slices.Contains(f.Aliases, alias) {
+					t.Errorf("%s --%s has no --%s alias", node.Path(), f.Name, alias)
+				}
+			}
+		}
+		return next(nil)
+	})
+	if checked == 0 {
+		t.Fatal("no --from/--to flags found")
+	}
+}
+
+func TestFiscalFlagsDateRange(t *testing.T) {
+	today := time.Date(2025, 5, 20, 10, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name     string
+		flags    FiscalFlags
+		start    time.Month
+		wantFrom string
+		wantTo   string
+		wantErr  string
+	}{
+		{name: "this quarter", flags: FiscalFlags{ThisQuarter: true}, start: time.January, wantFrom: "2025-04-01", wantTo: "2025-06-30"},
+		{name: "this quarter april start", flags: FiscalFlags{ThisQuarter: true}, start: time.April, wantFrom: "2025-04-01", wantTo: "2025-06-30"},
+		{name: "quarter of current year", flags: FiscalFlags{Quarter: 4}, start: time.April, wantFrom: "2026-01-01", wantTo: "2026-03-31"},
+		{name: "quarter and year", flags: FiscalFlags{Quarter: 1, Year: 2024}, start: time.October, wantFrom: "2023-10-01", wantTo: "2023-12-31"},
+		{name: "year", flags: FiscalFlags{Year: 2025}, start: time.July, wantFrom: "2024-07-01", wantTo: "2025-06-30"},
+		{name: "bad quarter", flags: FiscalFlags{Quarter: 5}, start: time.January, wantErr: "invalid quarter"},
+		{name: "conflict", flags: FiscalFlags{ThisQuarter: true, Year: 2025}, start: time.January, wantErr: "--this-quarter"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, err := tt.flags.dateRange(today, tt.start)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("dateRange() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("dateRange() error = %v", err)
+			}
+			if from != tt.wantFrom || to != tt.wantTo {
+				t.Errorf("dateRange() = %s..%s, want %s..%s", from, to, tt.wantFrom, tt.wantTo)
+			}
+		})
+	}
+}
+
+func TestIssueDateRange(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	if err := config.WriteConfig(&config.File{FiscalYearStart: "april"}); err != nil {
+		t.Fatalf("WriteConfig() error = %v", err)
+	}
+
+	from, to, err := issueDateRange("", "", FiscalFlags{Year: 2025})
+	if err != nil || from != "2024-04-01" || to != "2025-03-31" {
+		t.Errorf("issueDateRange() = %s..%s, %v; want fiscal 2025 from the configured April start", from, to, err)
+	}
+
+	if _, _, err := issueDateRange("2025-01-01", "", FiscalFlags{Quarter: 1}); err == nil {
+		t.Error("issueDateRange() should reject --quarter with --from")
+	}
+
+	from, to, err = issueDateRange("2025-01-01", "2025-01-31", FiscalFlags{})
+	if err != nil || from != "2025-01-01" || to != "2025-01-31" {
+		t.Errorf("issueDateRange() = %s..%s, %v; want --from/--to unchanged", from, to, err)
+	}
+}
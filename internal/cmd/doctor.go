@@ -0,0 +1,254 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/dedene/harvest-cli/internal/api"
+	"github.com/dedene/harvest-cli/internal/auth"
+	"github.com/dedene/harvest-cli/internal/config"
+	"github.com/dedene/harvest-cli/internal/output"
+)
+
+// maxClockSkew is how far the local clock may drift from Harvest's before
+// doctor reports it. OAuth token expiry is judged by the local clock.
+const maxClockSkew = time.Minute
+
+// Doctor check results.
+const (
+	checkOK   = "ok"
+	checkFail = "fail"
+	checkSkip = "skip"
+)
+
+// DoctorCmd checks configuration, credentials and connectivity.
+type DoctorCmd struct{}
+
+// doctorCheck is the outcome of one diagnostic.
+type doctorCheck struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail"`
+	Hint   string `json:"hint,omitempty"`
+}
+
+func (c *DoctorCmd) Run(cli *CLI) error {
+	ctx := context.Background()
+
+	checks := []doctorCheck{checkConfig()}
+
+	keyringCheck, store := checkKeyring(&cli.RootFlags)
+	checks = append(checks, keyringCheck)
+
+	tokensCheck := checkTokens(store)
+	checks = append(checks, tokensCheck)
+
+	apiCheck, serverDate := checkAPI(ctx, cli, tokensCheck.Status == checkOK)
+	checks = append(checks, apiCheck, checkClockSkew(serverDate, time.Now()))
+
+	if err := outputDoctorChecks(cli.Stdout, checks, output.ModeFromFlags(cli.JSON, cli.Plain)); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, check := range checks {
+		if check.Status == checkFail {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d checks failed", failed, len(checks))
+	}
+	return nil
+}
+
+// checkConfig verifies the config file, if any, can be read and parsed.
+func checkConfig() doctorCheck {
+	check := doctorCheck{Name: "Config"}
+	path := config.ConfigPath()
+	if path == "" {
+		check.Status = checkFail
+		check.Detail = "could not determine config path"
+		check.Hint = "set HOME or XDG_CONFIG_HOME"
+		return check
+	}
+	if !config.ConfigExists() {
+		check.Status = checkOK
+		check.Detail = path + " not created yet; using defaults"
+		return check
+	}
+	if _, err := config.ReadConfig(); err != nil {
+		check.Status = checkFail
+		check.Detail = err.Error()
+		check.Hint = "fix the JSON in " + path + " or remove it to start over"
+		return check
+	}
+	check.Status = checkOK
+	check.Detail = path
+	return check
+}
+
+// checkKeyring verifies the keyring backend opens, returning the store for
+// the token check.
+func checkKeyring(flags *RootFlags) (doctorCheck, auth.Store) {
+	check := doctorCheck{Name: "Keyring"}
+	backend, err := auth.DescribeBackend(keyringOptions(flags)...)
+	if err != nil {
+		check.Status = checkFail
+		check.Detail = err.Error()
+		return check, nil
+	}
+
+	store, err := openStore(flags)
+	if err != nil {
+		check.Status = checkFail
+		check.Detail = fmt.Sprintf("%s: %v", backend, err)
+		check.Hint = "use --keyring-backend file (or 'harvest config set keyring_backend file') and set HARVESTCLI_KEYRING_PASSWORD=<password>"
+		return check, nil
+	}
+	check.Status = checkOK
+	check.Detail = backend
+	return check, store
+}
+
+// checkTokens verifies there is a credential to call the API with: a
+// personal access token in the environment or a stored OAuth token.
+func checkTokens(store auth.Store) doctorCheck {
+	check := doctorCheck{Name: "Tokens"}
+	if _, accountID, ok := auth.GetPATFromEnv(); ok {
+		check.Status = checkOK
+		check.Detail = fmt.Sprintf("personal access token from %s (account %d)", auth.PATEnvToken, accountID)
+		return check
+	}
+	if store == nil {
+		check.Status = checkSkip
+		check.Detail = "keyring unavailable"
+		return check
+	}
+
+	tokens, err := store.ListTokens()
+	if err != nil {
+		check.Status = checkFail
+		check.Detail = err.Error()
+		check.Hint = "run 'harvest auth login' to store a fresh token"
+		return check
+	}
+	if len(tokens) == 0 {
+		check.Status = checkFail
+		check.Detail = "no stored accounts"
+		check.Hint = "run 'harvest auth login', or set " + auth.PATEnvToken + " and " + auth.PATEnvAccountID
+		return check
+	}
+
+	emails := make([]string, len(tokens))
+	for i, tok := range tokens {
+		emails[i] = tok.Email
+	}
+	check.Status = checkOK
+	check.Detail = fmt.Sprintf("%d stored: %s", len(tokens), strings.Join(emails, ", "))
+	return check
+}
+
+// checkAPI calls /users/me with the selected account and returns the
+// server's Date header for the clock check.
+func checkAPI(ctx context.Context, cli *CLI, haveToken bool) (doctorCheck, time.Time) {
+	check := doctorCheck{Name: "API"}
+	if !haveToken {
+		check.Status = checkSkip
+		check.Detail = "no credentials"
+		return check, time.Time{}
+	}
+
+	client, err := NewClientFromFlags(ctx, cli)
+	if err != nil {
+		check.Status = checkFail
+		check.Detail = err.Error()
+		check.Hint = "run 'harvest auth list' to see stored accounts"
+		return check, time.Time{}
+	}
+
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		check.Status = checkFail
+		check.Detail = err.Error()
+		var apiErr *api.APIError
+		var authErr *api.AuthError
+		switch {
+		case errors.As(err, &authErr), errors.As(err, &apiErr) && apiErr.StatusCode == 401:
+			check.Hint = "the token was rejected; run 'harvest auth login' again"
+		case errors.As(err, &apiErr) && apiErr.StatusCode == 403:
+			check.Hint = "check the account ID; run 'harvest auth list'"
+		default:
+			check.Hint = "check your network connection and proxy settings"
+		}
+		return check, client.ServerDate()
+	}
+
+	check.Status = checkOK
+	check.Detail = fmt.Sprintf("signed in as %s on account %d", me.Email, client.AccountID())
+	return check, client.ServerDate()
+}
+
+// checkClockSkew compares the local clock with the server's Date header.
+func checkClockSkew(server, local time.Time) doctorCheck {
+	check := doctorCheck{Name: "Clock"}
+	if server.IsZero() {
+		check.Status = checkSkip
+		check.Detail = "no response from Harvest"
+		return check
+	}
+
+	skew := local.Sub(server).Round(time.Second)
+	switch {
+	case skew > maxClockSkew:
+		check.Status = checkFail
+		check.Detail = fmt.Sprintf("local clock is %s ahead of Harvest", skew)
+	case skew < -maxClockSkew:
+		check.Status = checkFail
+		check.Detail = fmt.Sprintf("local clock is %s behind Harvest", -skew)
+	default:
+		check.Status = checkOK
+		check.Detail = fmt.Sprintf("within %s of Harvest", maxClockSkew)
+		return check
+	}
+	check.Hint = "sync your system clock (enable NTP); token expiry and 'today' depend on it"
+	return check
+}
+
+// outputDoctorChecks writes the checks in the specified format. The table
+// puts each hint on its own line under the failing check.
+func outputDoctorChecks(w io.Writer, checks []doctorCheck, mode output.Mode) error {
+	switch mode {
+	case output.ModeJSON:
+		return output.WriteJSON(w, checks)
+	case output.ModePlain:
+		headers := []string{"Check", "Status", "Detail", "Hint"}
+		rows := make([][]string, len(checks))
+		for i, c := range checks {
+			rows[i] = []string{c.Name, c.Status, c.Detail, c.Hint}
+		}
+		return output.WriteTSV(w, headers, rows)
+	default:
+		colors := output.DefaultColors()
+		for _, c := range checks {
+			var mark string
+			switch c.Status {
+			case checkOK:
+				mark = colors.Success("ok  ")
+			case checkFail:
+				mark = colors.Error("FAIL")
+			default:
+				mark = colors.Dim("skip")
+			}
+			fmt.Fprintf(w, "%s  %-8s %s\n", mark, c.Name, c.Detail)
+			if c.Hint != "" {
+				fmt.Fprintf(w, "      %-8s %s\n", "", colors.Dim("hint: "+c.Hint))
+			}
+		}
+		return nil
+	}
+}
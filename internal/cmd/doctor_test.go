@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dedene/harvest-cli/internal/config"
+	"github.com/dedene/harvest-cli/internal/output"
+)
+
+func TestCheckClockSkew(t *testing.T) {
+	server := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		server time.Time
+		local  time.Time
+		status string
+		detail string
+	}{
+		{"in sync", server, server.Add(2 * time.Second), checkOK, "within"},
+		{"ahead", server, server.Add(5 * time.Minute), checkFail, "5m0s ahead"},
+		{"behind", server, server.Add(-90 * time.Second), checkFail, "1m30s behind"},
+		{"no response", time.Time{}, server, checkSkip, "no response"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := checkClockSkew(tt.server, tt.local)
+			if got.Status != tt.status || !strings.Contains(got.Detail, tt.detail) {
+				t.Errorf("checkClockSkew() = %+v, want %s containing %q", got, tt.status, tt.detail)
+			}
+		})
+	}
+}
+
+func TestCheckConfig_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+
+	if got := checkConfig(); got.Status != checkOK {
+		t.Errorf("checkConfig() without a file = %+v, want ok", got)
+	}
+
+	path := config.ConfigPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("{not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if got := checkConfig(); got.Status != checkFail || got.Hint == "" {
+		t.Errorf("checkConfig() with a bad file = %+v, want fail with a hint", got)
+	}
+}
+
+func TestOutputDoctorChecks(t *testing.T) {
+	checks := []doctorCheck{
+		{Name: "Config", Status: checkOK, Detail: "/tmp/config.json"},
+		{Name: "Tokens", Status: checkFail, Detail: "no stored accounts", Hint: "run 'harvest auth login'"},
+	}
+
+	var buf bytes.Buffer
+	if err := outputDoctorChecks(&buf, checks, output.ModeTable); err != nil {
+		t.Fatalf("outputDoctorChecks() error = %v", err)
+	}
+	for _, want := range []string{"ok", "Config", "FAIL", "Tokens", "hint: run 'harvest auth login'"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output missing %q, got: %s", want, buf.String())
+		}
+	}
+
+	buf.Reset()
+	if err := outputDoctorChecks(&buf, checks, output.ModePlain); err != nil {
+		t.Fatalf("outputDoctorChecks() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if want := "Tokens\tfail\tno stored accounts\trun 'harvest auth login'"; lines[2] != want {
+		t.Errorf("plain row = %q, want %q", lines[2], want)
+	}
+}
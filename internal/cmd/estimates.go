@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"os"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/dedene/harvest-cli/internal/api"
 	"github.com/dedene/harvest-cli/internal/dateparse"
@@ -33,13 +35,42 @@ type EstimatesListCmd struct {
 	HarvestClient string `help:"Filter by client ID or name" name:"harvest-client" short:"c"`
 	State         string `help:"Filter by state: draft, sent, accepted, declined" enum:",draft,sent,accepted,declined" default:""`
 	UpdatedSince  string `help:"Filter by updated since date"`
-	From          string `help:"Filter by issue date on or after" short:"f"`
-	To            string `help:"Filter by issue date on or before" short:"t"`
+	From          string `help:"Filter by issue date on or after" short:"f" aliases:"since"`
+	To            string `help:"Filter by issue date on or before" short:"t" aliases:"until"`
+	Sort          string `help:"Sort by amount (largest first), issue-date (newest first) or state" enum:",amount,issue-date,state" default:""`
+	OlderThan     string `help:"Only sent estimates issued before this date, or more than a number of days or weeks ago (e.g. 30d, 2w)" name:"unaccepted-older-than" aliases:"older-than"`
+	Summary       bool   `help:"Append the total amount per currency"`
+	NDJSON        bool   `help:"Output one JSON object per line" name:"ndjson"`
+	FiscalFlags   `embed:""`
+	PagingFlags   `embed:""`
+}
+
+// staleEstimate is the JSON form of a sent estimate still awaiting an
+// answer, with its age.
+type staleEstimate struct {
+	api.Estimate
+	DaysSinceIssue int `json:"days_since_issue"`
+}
+
+// withDaysSinceIssue pairs estimates with their ages for JSON output.
+func withDaysSinceIssue(estimates []api.Estimate, days []int) []staleEstimate {
+	stale := make([]staleEstimate, len(estimates))
+	for i, e := range estimates {
+		stale[i] = staleEstimate{Estimate: e, DaysSinceIssue: days[i]}
+	}
+	return stale
 }
 
 func (c *EstimatesListCmd) Run(cli *CLI) error {
+	if err := c.PagingFlags.validate(); err != nil {
+		return err
+	}
+	if c.OlderThan != "" && c.State != "" && c.State != "sent" {
+		return fmt.Errorf("--unaccepted-older-than only applies to sent estimates; drop --state %s", c.State)
+	}
+
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -66,30 +97,129 @@ func (c *EstimatesListCmd) Run(cli *CLI) error {
 		opts.UpdatedSince = t.Format("2006-01-02T15:04:05Z")
 	}
 
-	// Parse from filter
-	if c.From != "" {
-		t, err := dateparse.Parse(c.From)
+	if opts.From, opts.To, err = issueDateRange(c.From, c.To, c.FiscalFlags); err != nil {
+		return err
+	}
+
+	opts.PerPage = c.PerPage
+	if c.OlderThan != "" {
+		// The API cannot filter by age: fetch every sent estimate, then
+		// filter and limit here.
+		opts.State = "sent"
+	} else if c.Sort == "" {
+		// A sorted list is limited after sorting, so the top estimates
+		// are kept rather than the first ones fetched
+		opts.MaxItems = c.MaxItems
+	}
+	estimates, err := client.ListAllEstimates(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("list estimates: %w", err)
+	}
+	sortEstimates(estimates, c.Sort)
+
+	if c.OlderThan != "" {
+		today, err := dateparse.Parse("today")
 		if err != nil {
-			return fmt.Errorf("invalid from date: %w", err)
+			return err
+		}
+		cutoff, err := estimateCutoff(c.OlderThan, today)
+		if err != nil {
+			return err
+		}
+		stale, days, err := staleEstimates(estimates, cutoff, today, c.Sort == "")
+		if err != nil {
+			return err
+		}
+		estimates, days = limitItems(stale, c.MaxItems), limitItems(days, c.MaxItems)
+		if c.NDJSON {
+			return output.WriteNDJSON(cli.Stdout, withDaysSinceIssue(estimates, days))
+		}
+		if c.Summary {
+			loadCurrencyFormat(ctx, cli, client)
 		}
-		opts.From = dateparse.FormatDate(t)
+		return outputEstimates(cli.Stdout, estimates, days, output.ModeFromFlags(cli.JSON, cli.Plain), c.Summary)
 	}
 
-	// Parse to filter
-	if c.To != "" {
-		t, err := dateparse.Parse(c.To)
+	estimates = limitItems(estimates, c.MaxItems)
+	if c.NDJSON {
+		return output.WriteNDJSON(cli.Stdout, estimates)
+	}
+
+	if c.Summary {
+		loadCurrencyFormat(ctx, cli, client)
+	}
+	return outputEstimates(cli.Stdout, estimates, nil, output.ModeFromFlags(cli.JSON, cli.Plain), c.Summary)
+}
+
+// sortEstimates orders estimates by the given --sort key. Amounts are
+// compared as-is, without converting between currencies. Ties keep the
+// order Harvest returned.
+func sortEstimates(estimates []api.Estimate, by string) {
+	switch by {
+	case "amount":
+		sort.SliceStable(estimates, func(i, j int) bool { return estimates[i].Amount > estimates[j].Amount })
+	case "issue-date":
+		sort.SliceStable(estimates, func(i, j int) bool { return estimates[i].IssueDate > estimates[j].IssueDate })
+	case "state":
+		sort.SliceStable(estimates, func(i, j int) bool { return estimates[i].State < estimates[j].State })
+	}
+}
+
+// olderThanRe matches an --unaccepted-older-than age in days or weeks.
+var olderThanRe = regexp.MustCompile(`^(\d+)\s*([dw])$`)
+
+// estimateCutoff returns the issue date an --unaccepted-older-than value refers to:
+// an age such as 30d or 2w counted back from today, or a date.
+func estimateCutoff(value string, today time.Time) (time.Time, error) {
+	if m := olderThanRe.FindStringSubmatch(strings.ToLower(strings.TrimSpace(value))); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		if m[2] == "w" {
+			n *= 7
+		}
+		return today.AddDate(0, 0, -n), nil
+	}
+	t, err := dateparse.Parse(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --unaccepted-older-than %q (use a date or an age like 30d or 2w)", value)
+	}
+	return t, nil
+}
+
+// staleEstimates keeps the estimates issued before cutoff, with their age
+// in days on today. With oldestFirst they are ordered by age, oldest first;
+// otherwise they keep their order.
+func staleEstimates(estimates []api.Estimate, cutoff, today time.Time, oldestFirst bool) ([]api.Estimate, []int, error) {
+	before := dateparse.FormatDate(cutoff)
+	day := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+
+	var stale []staleEstimate
+	for _, e := range estimates {
+		if e.IssueDate == "" || e.IssueDate >= before {
+			continue
+		}
+		issued, err := time.Parse("2006-01-02", e.IssueDate)
 		if err != nil {
-			return fmt.Errorf("invalid to date: %w", err)
+			return nil, nil, fmt.Errorf("invalid issue date %q: %w", e.IssueDate, err)
 		}
-		opts.To = dateparse.FormatDate(t)
+		stale = append(stale, staleEstimate{Estimate: e, DaysSinceIssue: int(day.Sub(issued).Hours() / 24)})
+	}
+	if oldestFirst {
+		sort.SliceStable(stale, func(i, j int) bool {
+			return stale[i].DaysSinceIssue > stale[j].DaysSinceIssue
+		})
 	}
 
-	estimates, err := client.ListAllEstimates(ctx, opts)
-	if err != nil {
-		return fmt.Errorf("list estimates: %w", err)
+	kept := make([]api.Estimate, len(stale))
+	days := make([]int, len(stale))
+	for i, e := range stale {
+		kept[i], days[i] = e.Estimate, e.DaysSinceIssue
 	}
+	return kept, days, nil
+}
 
-	return outputEstimates(os.Stdout, estimates, output.ModeFromFlags(cli.JSON, cli.Plain))
+// sumEstimates totals estimate amounts per currency.
+func sumEstimates(estimates []api.Estimate) []amountTotal {
+	return sumAmounts(estimates, func(e api.Estimate) (string, float64) { return e.Currency, e.Amount })
 }
 
 // EstimatesShowCmd shows a single estimate.
@@ -99,7 +229,7 @@ type EstimatesShowCmd struct {
 
 func (c *EstimatesShowCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -109,7 +239,7 @@ func (c *EstimatesShowCmd) Run(cli *CLI) error {
 		return fmt.Errorf("get estimate: %w", err)
 	}
 
-	return outputEstimate(os.Stdout, estimate, output.ModeFromFlags(cli.JSON, cli.Plain))
+	return outputEstimate(cli.Stdout, estimate, output.ModeFromFlags(cli.JSON, cli.Plain))
 }
 
 // EstimatesAddCmd creates a new estimate.
@@ -124,11 +254,18 @@ type EstimatesAddCmd struct {
 	Tax2          float64 `help:"Second tax percentage"`
 	Discount      float64 `help:"Discount percentage"`
 	Notes         string  `help:"Additional notes" short:"n"`
+	NotesFile     string  `help:"Read notes from a file ('-' for stdin)" name:"notes-file"`
 }
 
 func (c *EstimatesAddCmd) Run(cli *CLI) error {
+	notes, err := readNotes(cli, c.Notes, c.NotesFile)
+	if err != nil {
+		return err
+	}
+	c.Notes = notes
+
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -180,11 +317,11 @@ func (c *EstimatesAddCmd) Run(cli *CLI) error {
 		return fmt.Errorf("create estimate: %w", err)
 	}
 
-	if cli.JSON {
-		return output.WriteJSON(os.Stdout, estimate)
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, estimate)
 	}
 
-	fmt.Fprintf(os.Stdout, "Created estimate #%d: %s (%.2f %s)\n",
+	printSuccess(cli, estimate.ID, "Created estimate #%d: %s (%.2f %s)\n",
 		estimate.ID, estimate.Subject, estimate.Amount, estimate.Currency)
 	return nil
 }
@@ -202,11 +339,18 @@ type EstimatesEditCmd struct {
 	Tax2          float64 `help:"Second tax percentage"`
 	Discount      float64 `help:"Discount percentage"`
 	Notes         string  `help:"Additional notes" short:"n"`
+	NotesFile     string  `help:"Read notes from a file ('-' for stdin)" name:"notes-file"`
 }
 
 func (c *EstimatesEditCmd) Run(cli *CLI) error {
+	notes, err := readNotes(cli, c.Notes, c.NotesFile)
+	if err != nil {
+		return err
+	}
+	c.Notes = notes
+
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -273,11 +417,11 @@ func (c *EstimatesEditCmd) Run(cli *CLI) error {
 		return fmt.Errorf("update estimate: %w", err)
 	}
 
-	if cli.JSON {
-		return output.WriteJSON(os.Stdout, estimate)
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, estimate)
 	}
 
-	fmt.Fprintf(os.Stdout, "Updated estimate #%d: %s\n", estimate.ID, estimate.Subject)
+	printSuccess(cli, estimate.ID, "Updated estimate #%d: %s\n", estimate.ID, estimate.Subject)
 	return nil
 }
 
@@ -289,7 +433,7 @@ type EstimatesRemoveCmd struct {
 
 func (c *EstimatesRemoveCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -306,13 +450,13 @@ func (c *EstimatesRemoveCmd) Run(cli *CLI) error {
 		confirmed, err := ui.ConfirmPrompt(msg)
 		if err != nil {
 			if err == ui.ErrCanceled {
-				fmt.Fprintln(os.Stderr, "Canceled")
+				fmt.Fprintln(cli.Stderr, "Canceled")
 				return nil
 			}
 			return err
 		}
 		if !confirmed {
-			fmt.Fprintln(os.Stderr, "Aborted")
+			fmt.Fprintln(cli.Stderr, "Aborted")
 			return nil
 		}
 	}
@@ -321,7 +465,7 @@ func (c *EstimatesRemoveCmd) Run(cli *CLI) error {
 		return fmt.Errorf("delete estimate: %w", err)
 	}
 
-	fmt.Fprintf(os.Stdout, "Deleted estimate #%d\n", c.ID)
+	printSuccess(cli, 0, "Deleted estimate #%d\n", c.ID)
 	return nil
 }
 
@@ -336,7 +480,7 @@ type EstimatesSendCmd struct {
 
 func (c *EstimatesSendCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -375,11 +519,11 @@ func (c *EstimatesSendCmd) Run(cli *CLI) error {
 		return fmt.Errorf("send estimate: %w", err)
 	}
 
-	if cli.JSON {
-		return output.WriteJSON(os.Stdout, msg)
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, msg)
 	}
 
-	fmt.Fprintf(os.Stdout, "Sent estimate #%d to %d recipient(s)\n", c.ID, len(msg.Recipients))
+	printSuccess(cli, 0, "Sent estimate #%d to %d recipient(s)\n", c.ID, len(msg.Recipients))
 	return nil
 }
 
@@ -390,7 +534,7 @@ type EstimatesMarkSentCmd struct {
 
 func (c *EstimatesMarkSentCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -400,11 +544,11 @@ func (c *EstimatesMarkSentCmd) Run(cli *CLI) error {
 		return fmt.Errorf("mark estimate as sent: %w", err)
 	}
 
-	if cli.JSON {
-		return output.WriteJSON(os.Stdout, msg)
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, msg)
 	}
 
-	fmt.Fprintf(os.Stdout, "Marked estimate #%d as sent\n", c.ID)
+	printSuccess(cli, 0, "Marked estimate #%d as sent\n", c.ID)
 	return nil
 }
 
@@ -415,7 +559,7 @@ type EstimatesMarkAcceptedCmd struct {
 
 func (c *EstimatesMarkAcceptedCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -425,11 +569,11 @@ func (c *EstimatesMarkAcceptedCmd) Run(cli *CLI) error {
 		return fmt.Errorf("mark estimate as accepted: %w", err)
 	}
 
-	if cli.JSON {
-		return output.WriteJSON(os.Stdout, msg)
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, msg)
 	}
 
-	fmt.Fprintf(os.Stdout, "Marked estimate #%d as accepted\n", c.ID)
+	printSuccess(cli, 0, "Marked estimate #%d as accepted\n", c.ID)
 	return nil
 }
 
@@ -440,7 +584,7 @@ type EstimatesMarkDeclinedCmd struct {
 
 func (c *EstimatesMarkDeclinedCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -450,11 +594,11 @@ func (c *EstimatesMarkDeclinedCmd) Run(cli *CLI) error {
 		return fmt.Errorf("mark estimate as declined: %w", err)
 	}
 
-	if cli.JSON {
-		return output.WriteJSON(os.Stdout, msg)
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, msg)
 	}
 
-	fmt.Fprintf(os.Stdout, "Marked estimate #%d as declined\n", c.ID)
+	printSuccess(cli, 0, "Marked estimate #%d as declined\n", c.ID)
 	return nil
 }
 
@@ -465,7 +609,7 @@ type EstimatesMarkDraftCmd struct {
 
 func (c *EstimatesMarkDraftCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -475,61 +619,97 @@ func (c *EstimatesMarkDraftCmd) Run(cli *CLI) error {
 		return fmt.Errorf("mark estimate as draft: %w", err)
 	}
 
-	if cli.JSON {
-		return output.WriteJSON(os.Stdout, msg)
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, msg)
 	}
 
-	fmt.Fprintf(os.Stdout, "Converted estimate #%d back to draft\n", c.ID)
+	printSuccess(cli, 0, "Converted estimate #%d back to draft\n", c.ID)
 	return nil
 }
 
 // outputEstimates writes estimates in the specified format.
-func outputEstimates(w io.Writer, estimates []api.Estimate, mode output.Mode) error {
+// When summary is set, the total amount per currency is included. days, when
+// not nil, holds the days since each estimate was issued, shown in a Days
+// column (days_since_issue in JSON).
+func outputEstimates(w io.Writer, estimates []api.Estimate, days []int, mode output.Mode, summary bool) error {
+	headers := []string{"ID", "Number", "Client", "Subject", "Amount", "State", "Issue Date"}
+	if days != nil {
+		headers = append(headers, "Days")
+	}
+
 	switch mode {
 	case output.ModeJSON:
-		return output.WriteJSON(w, estimates)
+		var v any = estimates
+		if days != nil {
+			v = withDaysSinceIssue(estimates, days)
+		}
+		if summary {
+			return output.WriteJSON(w, map[string]any{
+				"estimates": v,
+				"totals":    sumEstimates(estimates),
+			})
+		}
+		return output.WriteJSON(w, v)
 	case output.ModePlain:
-		headers := []string{"ID", "Number", "Client", "Subject", "Amount", "State", "Issue Date"}
 		rows := make([][]string, len(estimates))
 		for i, e := range estimates {
-			rows[i] = []string{
-				strconv.FormatInt(e.ID, 10),
-				e.Number,
-				e.Client.Name,
-				e.Subject,
-				fmt.Sprintf("%.2f %s", e.Amount, e.Currency),
-				e.State,
-				e.IssueDate,
+			rows[i] = estimateRow(e, days, i)
+		}
+		if summary {
+			for _, t := range sumEstimates(estimates) {
+				total := make([]string, len(headers))
+				total[0], total[4] = "TOTAL", fmt.Sprintf("%.2f %s", t.Amount, t.Currency)
+				rows = append(rows, total)
 			}
 		}
 		return output.WriteTSV(w, headers, rows)
 	default:
-		t := output.NewTable(w, "ID", "Number", "Client", "Subject", "Amount", "State", "Issue Date")
-		for _, e := range estimates {
-			t.AddRow(
-				strconv.FormatInt(e.ID, 10),
-				e.Number,
-				e.Client.Name,
-				e.Subject,
-				fmt.Sprintf("%.2f %s", e.Amount, e.Currency),
-				e.State,
-				e.IssueDate,
-			)
+		if days != nil && len(estimates) == 0 {
+			fmt.Fprintln(w, "No sent estimates that old")
+			return nil
+		}
+		t := output.NewTable(w, headers...).SetAlign(output.AlignRight, 4).SetDateColumns(6)
+		if days != nil {
+			t.SetAlign(output.AlignRight, 7)
+		}
+		for i, e := range estimates {
+			t.AddRow(estimateRow(e, days, i)...)
+		}
+		if summary {
+			addAmountTotals(t, 4, sumEstimates(estimates))
 		}
 		return t.Render()
 	}
 }
 
+// estimateRow returns the cells of the i-th estimate, with its days since
+// issue when days is not nil.
+func estimateRow(e api.Estimate, days []int, i int) []string {
+	row := []string{
+		strconv.FormatInt(e.ID, 10),
+		e.Number,
+		e.Client.Name,
+		e.Subject,
+		fmt.Sprintf("%.2f %s", e.Amount, e.Currency),
+		e.State,
+		e.IssueDate,
+	}
+	if days != nil {
+		row = append(row, strconv.Itoa(days[i]))
+	}
+	return row
+}
+
 // outputEstimate writes a single estimate in the specified format.
 func outputEstimate(w io.Writer, estimate *api.Estimate, mode output.Mode) error {
 	switch mode {
 	case output.ModeJSON:
 		return output.WriteJSON(w, estimate)
 	case output.ModePlain:
-		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%.2f\t%s\t%s\n",
-			estimate.ID, estimate.Number, estimate.Client.Name, estimate.Subject,
-			estimate.Amount, estimate.State, estimate.IssueDate)
-		return nil
+		return output.WriteTSV(w, nil, [][]string{{
+			strconv.FormatInt(estimate.ID, 10), estimate.Number, estimate.Client.Name, estimate.Subject,
+			fmt.Sprintf("%.2f", estimate.Amount), estimate.State, estimate.IssueDate,
+		}})
 	default:
 		fmt.Fprintf(w, "ID:          %d\n", estimate.ID)
 		fmt.Fprintf(w, "Number:      %s\n", estimate.Number)
@@ -538,7 +718,7 @@ func outputEstimate(w io.Writer, estimate *api.Estimate, mode output.Mode) error
 		fmt.Fprintf(w, "Amount:      %.2f %s\n", estimate.Amount, estimate.Currency)
 		fmt.Fprintf(w, "State:       %s\n", estimate.State)
 		if estimate.IssueDate != "" {
-			fmt.Fprintf(w, "Issue Date:  %s\n", estimate.IssueDate)
+			fmt.Fprintf(w, "Issue Date:  %s\n", output.FormatDate(estimate.IssueDate))
 		}
 		if estimate.PurchaseOrder != "" {
 			fmt.Fprintf(w, "PO Number:   %s\n", estimate.PurchaseOrder)
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dedene/harvest-cli/internal/api"
+	"github.com/dedene/harvest-cli/internal/auth"
+	"github.com/dedene/harvest-cli/internal/dateparse"
+	"github.com/dedene/harvest-cli/internal/output"
+)
+
+func TestSortEstimates(t *testing.T) {
+	estimates := func() []api.Estimate {
+		return []api.Estimate{
+			{ID: 1, Amount: 500, State: "sent", IssueDate: "2024-02-01"},
+			{ID: 2, Amount: 1500, State: "draft", IssueDate: "2024-01-15"},
+			{ID: 3, Amount: 500, State: "accepted", IssueDate: "2024-03-01"},
+		}
+	}
+
+	tests := []struct {
+		by   string
+		want []int64
+	}{
+		{"", []int64{1, 2, 3}},
+		{"amount", []int64{2, 1, 3}},
+		{"issue-date", []int64{3, 1, 2}},
+		{"state", []int64{3, 2, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.by, func(t *testing.T) {
+			got := estimates()
+			sortEstimates(got, tt.by)
+			for i, id := range tt.want {
+				if got[i].ID != id {
+					t.Fatalf("sortEstimates(%q) order = %v, want %v", tt.by, estimateIDs(got), tt.want)
+				}
+			}
+		})
+	}
+}
+
+func estimateIDs(estimates []api.Estimate) []int64 {
+	ids := make([]int64, len(estimates))
+	for i, e := range estimates {
+		ids[i] = e.ID
+	}
+	return ids
+}
+
+func TestEstimatesList_SortBeforeMaxItems(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(auth.PATEnvToken, "pat")
+	t.Setenv(auth.PATEnvAccountID, "1")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("per_page"); got == "1" {
+			t.Errorf("per_page = %s; a sorted list must fetch every estimate", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"estimates":[{"id":1,"amount":10},{"id":2,"amount":30},{"id":3,"amount":20}],"total_pages":1,"page":1}`))
+	}))
+	defer srv.Close()
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"estimates", "list", "--sort", "amount", "--max-items", "1", "--json", "--api-base-url", srv.URL}
+	if err := Execute(args, &stdout, &stderr); err != nil {
+		t.Fatalf("Execute() error = %v, stderr: %s", err, stderr.String())
+	}
+	var got []api.Estimate
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatalf("stdout is not JSON: %v\n%s", err, stdout.String())
+	}
+	if len(got) != 1 || got[0].ID != 2 {
+		t.Errorf("estimates = %+v, want only the largest, #2", got)
+	}
+}
+
+func TestOutputEstimates_Summary(t *testing.T) {
+	estimates := []api.Estimate{
+		{ID: 1, Amount: 500, Currency: "USD"},
+		{ID: 2, Amount: 250, Currency: "EUR"},
+		{ID: 3, Amount: 1000, Currency: "USD"},
+	}
+
+	var buf bytes.Buffer
+	if err := outputEstimates(&buf, estimates, nil, output.ModeTable, true); err != nil {
+		t.Fatalf("outputEstimates() error = %v", err)
+	}
+	out := buf.String()
+	eur := strings.Index(out, "Total                            250.00 EUR")
+	usd := strings.Index(out, "Total                           1500.00 USD")
+	if eur < 0 || usd < eur {
+		t.Errorf("expected EUR then USD totals, got: %s", out)
+	}
+
+	buf.Reset()
+	if err := outputEstimates(&buf, estimates, nil, output.ModeJSON, true); err != nil {
+		t.Fatalf("outputEstimates() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), `"currency": "USD"`) || !strings.Contains(buf.String(), `"amount": 1500`) {
+		t.Errorf("JSON summary missing totals, got: %s", buf.String())
+	}
+}
+
+func TestStaleEstimates(t *testing.T) {
+	today := time.Date(2024, 5, 31, 0, 0, 0, 0, time.Local)
+
+	for _, tt := range []struct {
+		value string
+		want  string
+	}{
+		{"30d", "2024-05-01"},
+		{"2w", "2024-05-17"},
+		{"2024-04-15", "2024-04-15"},
+	} {
+		got, err := estimateCutoff(tt.value, today)
+		if err != nil || dateparse.FormatDate(got) != tt.want {
+			t.Errorf("estimateCutoff(%q) = %v, %v; want %s", tt.value, got, err, tt.want)
+		}
+	}
+	if _, err := estimateCutoff("soon", today); err == nil {
+		t.Error("estimateCutoff(\"soon\") should fail")
+	}
+
+	estimates := []api.Estimate{
+		{ID: 1, IssueDate: "2024-04-20"},
+		{ID: 2, IssueDate: "2024-05-01"},
+		{ID: 3, IssueDate: "2024-03-01"},
+		{ID: 4, IssueDate: ""},
+	}
+	cutoff, _ := estimateCutoff("30d", today)
+	stale, days, err := staleEstimates(estimates, cutoff, today, true)
+	if err != nil {
+		t.Fatalf("staleEstimates() error = %v", err)
+	}
+	if len(stale) != 2 || stale[0].ID != 3 || days[0] != 91 || stale[1].ID != 1 || days[1] != 41 {
+		t.Errorf("staleEstimates() = %+v, %v; want #3 (91 days) then #1 (41 days)", stale, days)
+	}
+
+	var buf bytes.Buffer
+	if err := outputEstimates(&buf, stale, days, output.ModePlain, true); err != nil {
+		t.Fatalf("outputEstimates() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if !strings.HasSuffix(lines[0], "\tIssue Date\tDays") || !strings.HasSuffix(lines[1], "\t2024-03-01\t91") {
+		t.Errorf("plain output = %q, want the days since issue column", buf.String())
+	}
+	if last := lines[len(lines)-1]; strings.Count(last, "\t") != 7 {
+		t.Errorf("plain total = %q, want it as wide as the Days header", last)
+	}
+
+	buf.Reset()
+	if err := outputEstimates(&buf, stale, days, output.ModeJSON, false); err != nil {
+		t.Fatalf("outputEstimates() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), `"days_since_issue": 91`) {
+		t.Errorf("JSON output = %s, want days_since_issue", buf.String())
+	}
+
+	buf.Reset()
+	if err := outputEstimates(&buf, nil, []int{}, output.ModeTable, false); err != nil {
+		t.Fatalf("outputEstimates() error = %v", err)
+	}
+	if buf.String() != "No sent estimates that old\n" {
+		t.Errorf("empty table output = %q", buf.String())
+	}
+}
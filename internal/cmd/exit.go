@@ -2,9 +2,12 @@ package cmd
 
 import (
 	"errors"
+	"io"
+	"net/http"
 
 	"github.com/dedene/harvest-cli/internal/api"
 	"github.com/dedene/harvest-cli/internal/auth"
+	"github.com/dedene/harvest-cli/internal/output"
 )
 
 // Exit codes follow standard conventions:
@@ -74,3 +77,40 @@ func ExitCode(err error) int {
 	// Default
 	return 1
 }
+
+// errorJSON is the structured form of a command error in --json mode.
+type errorJSON struct {
+	Message string            `json:"message"`
+	Code    int               `json:"code"`
+	Status  int               `json:"status,omitempty"`
+	Fields  map[string]string `json:"fields,omitempty"`
+}
+
+// writeErrorJSON writes err as {"error": {...}} so JSON consumers can parse
+// failures too. Code is the process exit code; Status is the HTTP status of
+// a failed API request.
+func writeErrorJSON(w io.Writer, err error) error {
+	e := errorJSON{
+		Message: err.Error(),
+		Code:    ExitCode(err),
+	}
+
+	var apiErr *api.APIError
+	if errors.As(err, &apiErr) {
+		e.Status = apiErr.StatusCode
+	}
+
+	var valErr *api.ValidationError
+	if errors.As(err, &valErr) {
+		e.Status = http.StatusUnprocessableEntity
+		e.Fields = valErr.Fields
+	}
+
+	var rateLimitErr *api.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		e.Status = http.StatusTooManyRequests
+	}
+
+	// --fields selects keys of command results, not of the error envelope
+	return output.WriteJSONUnfiltered(w, map[string]errorJSON{"error": e})
+}
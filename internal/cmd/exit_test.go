@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/oauth2"
+
+	"github.com/dedene/harvest-cli/internal/api"
+)
+
+func TestWriteErrorJSON_ValidationError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"message":"Invalid","errors":{"name":"is required","email":"is taken"}}`))
+	}))
+	defer srv.Close()
+
+	client := api.NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"}), 12345, "test@example.com", srv.URL)
+	_, err := client.CreateClient(context.Background(), &api.ClientInput{})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	err = fmt.Errorf("create client: %w", err)
+
+	var buf bytes.Buffer
+	if err := writeErrorJSON(&buf, err); err != nil {
+		t.Fatalf("writeErrorJSON() error = %v", err)
+	}
+
+	var got struct {
+		Error errorJSON `json:"error"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, buf.String())
+	}
+	if got.Error.Status != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d, want 422", got.Error.Status)
+	}
+	if got.Error.Code != ExitCode(err) {
+		t.Errorf("code = %d, want %d", got.Error.Code, ExitCode(err))
+	}
+	if got.Error.Fields["name"] != "is required" || got.Error.Fields["email"] != "is taken" {
+		t.Errorf("fields = %v", got.Error.Fields)
+	}
+	if got.Error.Message != err.Error() {
+		t.Errorf("message = %q, want %q", got.Error.Message, err.Error())
+	}
+}
+
+func TestWriteErrorJSON_APIError(t *testing.T) {
+	var buf bytes.Buffer
+	err := &api.APIError{StatusCode: http.StatusNotFound, Message: "not found"}
+	if err := writeErrorJSON(&buf, err); err != nil {
+		t.Fatalf("writeErrorJSON() error = %v", err)
+	}
+
+	var got map[string]map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not JSON: %v", err)
+	}
+	if got["error"]["status"] != float64(404) || got["error"]["code"] != float64(4) {
+		t.Errorf("error = %v, want status 404 and code 4", got["error"])
+	}
+	if _, ok := got["error"]["fields"]; ok {
+		t.Error("fields should be omitted for non-validation errors")
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"io"
 	"os"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/dedene/harvest-cli/internal/api"
@@ -23,45 +22,55 @@ type ExpensesCmd struct {
 	Edit       ExpensesEditCmd       `cmd:"" help:"Update an expense"`
 	Remove     ExpensesRemoveCmd     `cmd:"" help:"Delete an expense"`
 	Receipt    ExpensesReceiptCmd    `cmd:"" help:"Upload receipt to expense"`
-	Categories ExpensesCategoriesCmd `cmd:"" help:"List expense categories"`
+	Categories ExpensesCategoriesCmd `cmd:"" help:"Manage expense categories"`
 }
 
 // ExpensesListCmd lists expenses with filters.
 type ExpensesListCmd struct {
-	User          string `help:"Filter by user ID or 'me'"`
+	User          string `help:"Filter by user ID, email or 'me'"`
 	HarvestClient string `help:"Filter by client ID or name" name:"harvest-client" short:"c"`
 	Project       string `help:"Filter by project ID or name" short:"p"`
 	Billed        bool   `help:"Only billed expenses"`
 	Unbilled      bool   `help:"Only unbilled expenses"`
+	Billable      bool   `help:"Only billable expenses"`
+	NonBillable   bool   `help:"Only non-billable expenses" name:"non-billable"`
 	UpdatedSince  string `help:"Filter by updated since (ISO datetime)"`
-	From          string `help:"Start date (YYYY-MM-DD or 'today')" short:"f"`
-	To            string `help:"End date" short:"t"`
+	From          string `help:"Start date (YYYY-MM-DD or 'today')" short:"f" aliases:"since"`
+	To            string `help:"End date" short:"t" aliases:"until"`
+	Summary       bool   `help:"Append total cost and billable cost"`
+	NDJSON        bool   `help:"Output one JSON object per line" name:"ndjson"`
+	PagingFlags   `embed:""`
 }
 
 func (c *ExpensesListCmd) Run(cli *CLI) error {
+	if err := c.PagingFlags.validate(); err != nil {
+		return err
+	}
+
+	isBilled, err := flagFilter(c.Billed, c.Unbilled, "billed", "unbilled")
+	if err != nil {
+		return err
+	}
+	billable, err := flagFilter(c.Billable, c.NonBillable, "billable", "non-billable")
+	if err != nil {
+		return err
+	}
+
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
 
-	opts := api.ExpenseListOptions{}
+	opts := api.ExpenseListOptions{IsBilled: isBilled}
 
 	// Parse user filter
 	if c.User != "" {
-		if c.User == "me" {
-			me, err := client.GetMe(ctx)
-			if err != nil {
-				return fmt.Errorf("get current user: %w", err)
-			}
-			opts.UserID = me.ID
-		} else {
-			id, err := strconv.ParseInt(c.User, 10, 64)
-			if err != nil {
-				return fmt.Errorf("invalid user ID: %s", c.User)
-			}
-			opts.UserID = id
+		userID, err := resolveUserID(ctx, client, c.User)
+		if err != nil {
+			return err
 		}
+		opts.UserID = userID
 	}
 
 	// Parse client filter
@@ -82,15 +91,6 @@ func (c *ExpensesListCmd) Run(cli *CLI) error {
 		opts.ProjectID = projectID
 	}
 
-	// Handle billed/unbilled filters
-	if c.Billed {
-		t := true
-		opts.IsBilled = &t
-	} else if c.Unbilled {
-		f := false
-		opts.IsBilled = &f
-	}
-
 	// Parse date filters
 	if c.UpdatedSince != "" {
 		t, err := dateparse.Parse(c.UpdatedSince)
@@ -100,28 +100,37 @@ func (c *ExpensesListCmd) Run(cli *CLI) error {
 		opts.UpdatedSince = t.Format("2006-01-02T15:04:05Z")
 	}
 
-	if c.From != "" {
-		t, err := dateparse.Parse(c.From)
-		if err != nil {
-			return fmt.Errorf("invalid from date: %w", err)
-		}
-		opts.From = dateparse.FormatDate(t)
+	if opts.From, opts.To, err = parseDateRange(c.From, c.To); err != nil {
+		return err
 	}
 
-	if c.To != "" {
-		t, err := dateparse.Parse(c.To)
-		if err != nil {
-			return fmt.Errorf("invalid to date: %w", err)
-		}
-		opts.To = dateparse.FormatDate(t)
+	opts.PerPage = c.PerPage
+	// The billable filter runs here, so --max-items is applied after it
+	if billable == nil {
+		opts.MaxItems = c.MaxItems
 	}
-
 	expenses, err := client.ListAllExpenses(ctx, opts)
 	if err != nil {
 		return fmt.Errorf("list expenses: %w", err)
 	}
 
-	return outputExpenses(os.Stdout, expenses, output.ModeFromFlags(cli.JSON, cli.Plain))
+	// The API has no billable filter
+	expenses = filterByBillable(expenses, billable, func(e api.Expense) bool { return e.Billable })
+	expenses = limitItems(expenses, c.MaxItems)
+
+	if c.NDJSON {
+		return output.WriteNDJSON(cli.Stdout, expenses)
+	}
+
+	// Expenses carry no currency, so totals use their client's currency
+	var currencies map[int64]string
+	if c.Summary {
+		if currencies, err = clientCurrencies(ctx, client); err != nil {
+			return err
+		}
+		loadCurrencyFormat(ctx, cli, client)
+	}
+	return outputExpenses(cli.Stdout, expenses, output.ModeFromFlags(cli.JSON, cli.Plain), c.Summary, currencies)
 }
 
 // ExpensesShowCmd shows a single expense.
@@ -131,7 +140,7 @@ type ExpensesShowCmd struct {
 
 func (c *ExpensesShowCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -141,24 +150,24 @@ func (c *ExpensesShowCmd) Run(cli *CLI) error {
 		return fmt.Errorf("get expense: %w", err)
 	}
 
-	return outputExpense(os.Stdout, expense, output.ModeFromFlags(cli.JSON, cli.Plain))
+	return outputExpense(cli.Stdout, expense, output.ModeFromFlags(cli.JSON, cli.Plain))
 }
 
 // ExpensesAddCmd creates a new expense.
 type ExpensesAddCmd struct {
-	Project   string  `help:"Project ID or name" short:"p" required:""`
-	Category  string  `help:"Expense category ID or name" required:""`
-	Date      string  `help:"Date (default: today)" short:"d"`
-	TotalCost float64 `help:"Total cost amount" required:""`
-	Notes     string  `help:"Notes" short:"n"`
-	Units     int     `help:"Units (for unit-based categories)"`
-	Billable  *bool   `help:"Whether expense is billable"`
-	Receipt   string  `help:"Path to receipt file"`
+	Project   string   `help:"Project ID or name" short:"p" required:""`
+	Category  string   `help:"Expense category ID or name" required:""`
+	Date      string   `help:"Date (default: today)" short:"d"`
+	TotalCost *float64 `help:"Total cost amount (required unless the category is unit-based)"`
+	Notes     string   `help:"Notes" short:"n"`
+	Units     int      `help:"Units (required for unit-based categories)"`
+	Billable  *bool    `help:"Whether expense is billable"`
+	Receipt   string   `help:"Path to receipt file"`
 }
 
 func (c *ExpensesAddCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -168,15 +177,18 @@ func (c *ExpensesAddCmd) Run(cli *CLI) error {
 		return err
 	}
 
-	categoryID, err := resolveExpenseCategoryID(ctx, client, c.Category)
+	category, err := resolveExpenseCategory(ctx, client, c.Category)
 	if err != nil {
 		return err
 	}
+	if err := checkExpenseAmount(category, c.Units, c.TotalCost); err != nil {
+		return err
+	}
 
 	input := &api.ExpenseInput{
 		ProjectID:         projectID,
-		ExpenseCategoryID: categoryID,
-		TotalCost:         &c.TotalCost,
+		ExpenseCategoryID: category.ID,
+		TotalCost:         c.TotalCost,
 	}
 
 	// Parse date
@@ -215,11 +227,11 @@ func (c *ExpensesAddCmd) Run(cli *CLI) error {
 		}
 	}
 
-	if cli.JSON {
-		return output.WriteJSON(os.Stdout, expense)
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, expense)
 	}
 
-	fmt.Fprintf(os.Stdout, "Created expense #%d: %s - %.2f on %s\n",
+	printSuccess(cli, expense.ID, "Created expense #%d: %s - %.2f on %s\n",
 		expense.ID, expense.ExpenseCategory.Name, expense.TotalCost, expense.SpentDate)
 	return nil
 }
@@ -235,15 +247,26 @@ type ExpensesEditCmd struct {
 	Units         int     `help:"Units (for unit-based categories)"`
 	Billable      *bool   `help:"Whether expense is billable"`
 	DeleteReceipt bool    `help:"Delete the attached receipt"`
+	AllowLocked   bool    `help:"Attempt the update even if the expense is locked or billed" name:"allow-locked"`
 }
 
 func (c *ExpensesEditCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
 
+	if !c.AllowLocked {
+		current, err := client.GetExpense(ctx, c.ID)
+		if err != nil {
+			return fmt.Errorf("get expense: %w", err)
+		}
+		if reason := expenseLockReason(*current); reason != "" {
+			return lockedError("expense", c.ID, reason)
+		}
+	}
+
 	input := &api.ExpenseInput{}
 	hasChanges := false
 
@@ -309,24 +332,25 @@ func (c *ExpensesEditCmd) Run(cli *CLI) error {
 		return fmt.Errorf("update expense: %w", err)
 	}
 
-	if cli.JSON {
-		return output.WriteJSON(os.Stdout, expense)
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, expense)
 	}
 
-	fmt.Fprintf(os.Stdout, "Updated expense #%d: %s - %.2f\n",
+	printSuccess(cli, expense.ID, "Updated expense #%d: %s - %.2f\n",
 		expense.ID, expense.ExpenseCategory.Name, expense.TotalCost)
 	return nil
 }
 
 // ExpensesRemoveCmd deletes an expense.
 type ExpensesRemoveCmd struct {
-	ID    int64 `arg:"" help:"Expense ID"`
-	Force bool  `help:"Skip confirmation" short:"f"`
+	ID          int64 `arg:"" help:"Expense ID"`
+	Force       bool  `help:"Skip confirmation" short:"f"`
+	AllowLocked bool  `help:"Attempt the delete even if the expense is locked or billed" name:"allow-locked"`
 }
 
 func (c *ExpensesRemoveCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -336,6 +360,9 @@ func (c *ExpensesRemoveCmd) Run(cli *CLI) error {
 	if err != nil {
 		return fmt.Errorf("get expense: %w", err)
 	}
+	if reason := expenseLockReason(*expense); reason != "" && !c.AllowLocked {
+		return lockedError("expense", c.ID, reason)
+	}
 
 	if !c.Force {
 		msg := fmt.Sprintf("Delete expense #%d (%s - %.2f on %s)?",
@@ -343,13 +370,13 @@ func (c *ExpensesRemoveCmd) Run(cli *CLI) error {
 		confirmed, err := ui.ConfirmPrompt(msg)
 		if err != nil {
 			if err == ui.ErrCanceled {
-				fmt.Fprintln(os.Stderr, "Canceled")
+				fmt.Fprintln(cli.Stderr, "Canceled")
 				return nil
 			}
 			return err
 		}
 		if !confirmed {
-			fmt.Fprintln(os.Stderr, "Aborted")
+			fmt.Fprintln(cli.Stderr, "Aborted")
 			return nil
 		}
 	}
@@ -358,10 +385,16 @@ func (c *ExpensesRemoveCmd) Run(cli *CLI) error {
 		return fmt.Errorf("delete expense: %w", err)
 	}
 
-	fmt.Fprintf(os.Stdout, "Deleted expense #%d\n", c.ID)
+	printSuccess(cli, 0, "Deleted expense #%d\n", c.ID)
 	return nil
 }
 
+// expenseLockReason explains why an expense cannot be changed, or returns
+// "" if it can.
+func expenseLockReason(e api.Expense) string {
+	return lockReason(e.IsLocked, e.IsBilled, e.LockedReason)
+}
+
 // ExpensesReceiptCmd uploads a receipt to an expense.
 type ExpensesReceiptCmd struct {
 	ID      int64  `arg:"" help:"Expense ID"`
@@ -370,7 +403,7 @@ type ExpensesReceiptCmd struct {
 
 func (c *ExpensesReceiptCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -385,25 +418,33 @@ func (c *ExpensesReceiptCmd) Run(cli *CLI) error {
 		return fmt.Errorf("upload receipt: %w", err)
 	}
 
-	if cli.JSON {
-		return output.WriteJSON(os.Stdout, expense)
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, expense)
 	}
 
-	fmt.Fprintf(os.Stdout, "Uploaded receipt to expense #%d\n", expense.ID)
+	printSuccess(cli, 0, "Uploaded receipt to expense #%d\n", expense.ID)
 	if expense.Receipt != nil {
-		fmt.Fprintf(os.Stdout, "  File: %s\n", expense.Receipt.FileName)
+		printSuccess(cli, 0, "  File: %s\n", expense.Receipt.FileName)
 	}
 	return nil
 }
 
-// ExpensesCategoriesCmd lists expense categories.
+// ExpensesCategoriesCmd groups expense category subcommands.
 type ExpensesCategoriesCmd struct {
+	List   ExpenseCategoriesListCmd   `cmd:"" default:"withargs" help:"List expense categories"`
+	Add    ExpenseCategoriesAddCmd    `cmd:"" help:"Create an expense category"`
+	Edit   ExpenseCategoriesEditCmd   `cmd:"" help:"Update an expense category"`
+	Remove ExpenseCategoriesRemoveCmd `cmd:"" help:"Delete an expense category"`
+}
+
+// ExpenseCategoriesListCmd lists expense categories.
+type ExpenseCategoriesListCmd struct {
 	Active *bool `help:"Filter by active status"`
 }
 
-func (c *ExpensesCategoriesCmd) Run(cli *CLI) error {
+func (c *ExpenseCategoriesListCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -417,7 +458,138 @@ func (c *ExpensesCategoriesCmd) Run(cli *CLI) error {
 		return fmt.Errorf("list expense categories: %w", err)
 	}
 
-	return outputExpenseCategories(os.Stdout, categories, output.ModeFromFlags(cli.JSON, cli.Plain))
+	return outputExpenseCategories(cli.Stdout, categories, output.ModeFromFlags(cli.JSON, cli.Plain))
+}
+
+// ExpenseCategoriesAddCmd creates a new expense category.
+type ExpenseCategoriesAddCmd struct {
+	Name      string   `arg:"" help:"Category name"`
+	UnitName  *string  `help:"Unit name for unit-priced categories (e.g., mile)" name:"unit-name"`
+	UnitPrice *float64 `help:"Price per unit" name:"unit-price"`
+	Active    *bool    `help:"Is active (default: true)"`
+}
+
+func (c *ExpenseCategoriesAddCmd) Run(cli *CLI) error {
+	ctx := context.Background()
+	client, err := NewClientFromFlags(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	input := &api.ExpenseCategoryInput{
+		Name:      c.Name,
+		UnitName:  c.UnitName,
+		UnitPrice: c.UnitPrice,
+		IsActive:  c.Active,
+	}
+
+	category, err := client.CreateExpenseCategory(ctx, input)
+	if err != nil {
+		return fmt.Errorf("create expense category: %w", err)
+	}
+
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, category)
+	}
+
+	printSuccess(cli, category.ID, "Created expense category #%d: %s\n", category.ID, category.Name)
+	return nil
+}
+
+// ExpenseCategoriesEditCmd updates an existing expense category.
+type ExpenseCategoriesEditCmd struct {
+	ID        int64    `arg:"" help:"Expense category ID"`
+	Name      string   `help:"Category name"`
+	UnitName  *string  `help:"Unit name for unit-priced categories (e.g., mile)" name:"unit-name"`
+	UnitPrice *float64 `help:"Price per unit" name:"unit-price"`
+	Active    *bool    `help:"Is active"`
+}
+
+func (c *ExpenseCategoriesEditCmd) Run(cli *CLI) error {
+	ctx := context.Background()
+	client, err := NewClientFromFlags(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	input := &api.ExpenseCategoryInput{}
+	hasChanges := false
+
+	if c.Name != "" {
+		input.Name = c.Name
+		hasChanges = true
+	}
+	if c.UnitName != nil {
+		input.UnitName = c.UnitName
+		hasChanges = true
+	}
+	if c.UnitPrice != nil {
+		input.UnitPrice = c.UnitPrice
+		hasChanges = true
+	}
+	if c.Active != nil {
+		input.IsActive = c.Active
+		hasChanges = true
+	}
+
+	if !hasChanges {
+		return fmt.Errorf("no changes specified")
+	}
+
+	category, err := client.UpdateExpenseCategory(ctx, c.ID, input)
+	if err != nil {
+		return fmt.Errorf("update expense category: %w", err)
+	}
+
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, category)
+	}
+
+	printSuccess(cli, category.ID, "Updated expense category #%d: %s\n", category.ID, category.Name)
+	return nil
+}
+
+// ExpenseCategoriesRemoveCmd deletes an expense category.
+type ExpenseCategoriesRemoveCmd struct {
+	ID    int64 `arg:"" help:"Expense category ID"`
+	Force bool  `help:"Skip confirmation" short:"f"`
+}
+
+func (c *ExpenseCategoriesRemoveCmd) Run(cli *CLI) error {
+	ctx := context.Background()
+	client, err := NewClientFromFlags(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	// Get category details for confirmation
+	category, err := client.GetExpenseCategory(ctx, c.ID)
+	if err != nil {
+		return fmt.Errorf("get expense category: %w", err)
+	}
+
+	if !c.Force {
+		msg := fmt.Sprintf("Delete expense category #%d (%s)?", category.ID, category.Name)
+		confirmed, err := ui.ConfirmPrompt(msg)
+		if err != nil {
+			if err == ui.ErrCanceled {
+				fmt.Fprintln(cli.Stderr, "Canceled")
+				return nil
+			}
+			return err
+		}
+		if !confirmed {
+			fmt.Fprintln(cli.Stderr, "Aborted")
+			return nil
+		}
+	}
+
+	if err := client.DeleteExpenseCategory(ctx, c.ID); err != nil {
+		return fmt.Errorf("delete expense category: %w", err)
+	}
+
+	printSuccess(cli, 0, "Deleted expense category #%d\n", c.ID)
+	return nil
 }
 
 // resolveExpenseCategoryID resolves a category identifier (ID or name) to an ID.
@@ -427,35 +599,124 @@ func resolveExpenseCategoryID(ctx context.Context, client *api.Client, identifie
 		return id, nil
 	}
 
-	// Search by name
+	category, err := findExpenseCategory(ctx, client, identifier)
+	if err != nil {
+		return 0, err
+	}
+	return category.ID, nil
+}
+
+// resolveExpenseCategory resolves a category identifier (ID or name) to the
+// full category, so callers can tell unit-based categories apart.
+func resolveExpenseCategory(ctx context.Context, client *api.Client, identifier string) (*api.ExpenseCategory, error) {
+	if id, err := strconv.ParseInt(identifier, 10, 64); err == nil {
+		category, err := client.GetExpenseCategory(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("get expense category: %w", err)
+		}
+		return category, nil
+	}
+	return findExpenseCategory(ctx, client, identifier)
+}
+
+// findExpenseCategory looks a category up by name.
+func findExpenseCategory(ctx context.Context, client *api.Client, name string) (*api.ExpenseCategory, error) {
 	categories, err := client.ListAllExpenseCategories(ctx, api.ExpenseCategoryListOptions{})
 	if err != nil {
-		return 0, fmt.Errorf("list expense categories: %w", err)
+		return nil, fmt.Errorf("list expense categories: %w", err)
 	}
 
-	identifierLower := strings.ToLower(identifier)
-	for _, cat := range categories {
-		if strings.ToLower(cat.Name) == identifierLower {
-			return cat.ID, nil
+	records := make([]namedRecord, len(categories))
+	for i, cat := range categories {
+		records[i] = namedRecord{ID: cat.ID, Name: cat.Name, Active: cat.IsActive}
+	}
+	id, err := lookupByName("expense category", name, records)
+	if err != nil {
+		return nil, err
+	}
+	for i := range categories {
+		if categories[i].ID == id {
+			return &categories[i], nil
 		}
 	}
+	return nil, fmt.Errorf("expense category not found: %s", name)
+}
 
-	return 0, fmt.Errorf("expense category not found: %s", identifier)
+// isUnitBased reports whether Harvest prices the category per unit.
+func isUnitBased(category *api.ExpenseCategory) bool {
+	return category.UnitPrice != nil || category.UnitName != nil
+}
+
+// checkExpenseAmount validates --units and --total-cost against the
+// category. Harvest computes the total of unit-based categories as units x
+// unit price, so those take --units only; other categories need a total.
+func checkExpenseAmount(category *api.ExpenseCategory, units int, totalCost *float64) error {
+	if !isUnitBased(category) {
+		if totalCost == nil {
+			return fmt.Errorf("--total-cost is required for category %q", category.Name)
+		}
+		return nil
+	}
+
+	unit := "unit"
+	if category.UnitName != nil && *category.UnitName != "" {
+		unit = *category.UnitName
+	}
+	if totalCost != nil {
+		return fmt.Errorf("category %q is priced per %s; pass --units instead of --total-cost", category.Name, unit)
+	}
+	if units <= 0 {
+		return fmt.Errorf("category %q is priced per %s; --units is required", category.Name, unit)
+	}
+	return nil
+}
+
+// expenseTotals summarizes the costs of expenses in one currency.
+type expenseTotals struct {
+	Currency     string  `json:"currency"`
+	TotalCost    float64 `json:"total_cost"`
+	BillableCost float64 `json:"billable_cost"`
+}
+
+// sumExpenses totals cost and billable cost per currency, sorted by
+// currency code. currencies maps client IDs to their currency.
+func sumExpenses(expenses []api.Expense, currencies map[int64]string) []expenseTotals {
+	sum := func(value func(api.Expense) float64) []amountTotal {
+		return sumAmounts(expenses, func(e api.Expense) (string, float64) { return currencies[e.Client.ID], value(e) })
+	}
+	costs := sum(func(e api.Expense) float64 { return e.TotalCost })
+	billable := sum(func(e api.Expense) float64 {
+		if !e.Billable {
+			return 0
+		}
+		return e.TotalCost
+	})
+
+	// Both share the currencies of expenses, in the same order
+	totals := make([]expenseTotals, len(costs))
+	for i, c := range costs {
+		totals[i] = expenseTotals{Currency: c.Currency, TotalCost: c.Amount, BillableCost: billable[i].Amount}
+	}
+	return totals
 }
 
 // outputExpenses writes expenses in the specified format.
-func outputExpenses(w io.Writer, expenses []api.Expense, mode output.Mode) error {
+// When summary is set, totals per currency follow the rows (or the JSON
+// totals array); currencies maps client IDs to their currency.
+func outputExpenses(w io.Writer, expenses []api.Expense, mode output.Mode, summary bool, currencies map[int64]string) error {
 	switch mode {
 	case output.ModeJSON:
+		if summary {
+			return output.WriteJSON(w, map[string]any{
+				"entries": expenses,
+				"totals":  sumExpenses(expenses, currencies),
+			})
+		}
 		return output.WriteJSON(w, expenses)
 	case output.ModePlain:
 		headers := []string{"ID", "Date", "Project", "Category", "Cost", "Billed", "Notes"}
 		rows := make([][]string, len(expenses))
 		for i, e := range expenses {
-			notes := e.Notes
-			if len(notes) > 30 {
-				notes = notes[:27] + "..."
-			}
 			rows[i] = []string{
 				strconv.FormatInt(e.ID, 10),
 				e.SpentDate,
@@ -463,17 +724,18 @@ func outputExpenses(w io.Writer, expenses []api.Expense, mode output.Mode) error
 				e.ExpenseCategory.Name,
 				fmt.Sprintf("%.2f", e.TotalCost),
 				strconv.FormatBool(e.IsBilled),
-				notes,
+				e.Notes,
+			}
+		}
+		if summary {
+			for _, t := range sumExpenses(expenses, currencies) {
+				rows = append(rows, []string{"TOTAL", "", "", "", fmt.Sprintf("%.2f %s", t.TotalCost, t.Currency), "", ""})
 			}
 		}
 		return output.WriteTSV(w, headers, rows)
 	default:
-		t := output.NewTable(w, "ID", "Date", "Project", "Category", "Cost", "Billed", "Notes")
+		t := output.NewTable(w, "ID", "Date", "Project", "Category", "Cost", "Billed", "Notes").SetAlign(output.AlignRight, 4).SetDateColumns(1)
 		for _, e := range expenses {
-			notes := e.Notes
-			if len(notes) > 30 {
-				notes = notes[:27] + "..."
-			}
 			t.AddRow(
 				strconv.FormatInt(e.ID, 10),
 				e.SpentDate,
@@ -481,9 +743,15 @@ func outputExpenses(w io.Writer, expenses []api.Expense, mode output.Mode) error
 				e.ExpenseCategory.Name,
 				fmt.Sprintf("%.2f", e.TotalCost),
 				strconv.FormatBool(e.IsBilled),
-				notes,
+				e.Notes,
 			)
 		}
+		if summary {
+			for _, total := range sumExpenses(expenses, currencies) {
+				t.AddFooter("Total", "", "", "", formatAmount(total.TotalCost, total.Currency))
+				t.AddFooter("Billable", "", "", "", formatAmount(total.BillableCost, total.Currency))
+			}
+		}
 		return t.Render()
 	}
 }
@@ -494,13 +762,13 @@ func outputExpense(w io.Writer, e *api.Expense, mode output.Mode) error {
 	case output.ModeJSON:
 		return output.WriteJSON(w, e)
 	case output.ModePlain:
-		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%.2f\t%t\t%s\n",
-			e.ID, e.SpentDate, e.Project.Name, e.ExpenseCategory.Name,
-			e.TotalCost, e.IsBilled, e.Notes)
-		return nil
+		return output.WriteTSV(w, nil, [][]string{{
+			strconv.FormatInt(e.ID, 10), e.SpentDate, e.Project.Name, e.ExpenseCategory.Name,
+			fmt.Sprintf("%.2f", e.TotalCost), strconv.FormatBool(e.IsBilled), e.Notes,
+		}})
 	default:
 		fmt.Fprintf(w, "ID:       %d\n", e.ID)
-		fmt.Fprintf(w, "Date:     %s\n", e.SpentDate)
+		fmt.Fprintf(w, "Date:     %s\n", output.FormatDate(e.SpentDate))
 		fmt.Fprintf(w, "Project:  %s\n", e.Project.Name)
 		fmt.Fprintf(w, "Client:   %s\n", e.Client.Name)
 		fmt.Fprintf(w, "Category: %s\n", e.ExpenseCategory.Name)
@@ -552,7 +820,7 @@ func outputExpenseCategories(w io.Writer, categories []api.ExpenseCategory, mode
 		}
 		return output.WriteTSV(w, headers, rows)
 	default:
-		t := output.NewTable(w, "ID", "Name", "Active", "Unit Name", "Unit Price")
+		t := output.NewTable(w, "ID", "Name", "Active", "Unit Name", "Unit Price").SetAlign(output.AlignRight, 4)
 		for _, c := range categories {
 			unitName := ""
 			if c.UnitName != nil {
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dedene/harvest-cli/internal/api"
+	"github.com/dedene/harvest-cli/internal/auth"
+	"github.com/dedene/harvest-cli/internal/output"
+)
+
+func TestCheckExpenseAmount(t *testing.T) {
+	miles := "mile"
+	price := 0.65
+	mileage := &api.ExpenseCategory{Name: "Mileage", UnitName: &miles, UnitPrice: &price}
+	meals := &api.ExpenseCategory{Name: "Meals"}
+	cost := 42.5
+
+	tests := []struct {
+		name      string
+		category  *api.ExpenseCategory
+		units     int
+		totalCost *float64
+		wantErr   string
+	}{
+		{"unit-based with units", mileage, 120, nil, ""},
+		{"unit-based without units", mileage, 0, nil, "priced per mile; --units is required"},
+		{"unit-based with total cost", mileage, 120, &cost, "pass --units instead of --total-cost"},
+		{"unit price only", &api.ExpenseCategory{Name: "Per diem", UnitPrice: &price}, 0, nil, "priced per unit"},
+		{"amount with total cost", meals, 0, &cost, ""},
+		{"amount without total cost", meals, 0, nil, `--total-cost is required for category "Meals"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkExpenseAmount(tt.category, tt.units, tt.totalCost)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("checkExpenseAmount() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("checkExpenseAmount() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSumExpenses(t *testing.T) {
+	expenses := []api.Expense{
+		{TotalCost: 100, Billable: true, Client: api.ClientRef{ID: 1}},
+		{TotalCost: 40, Client: api.ClientRef{ID: 2}},
+		{TotalCost: 25.5, Billable: true, Client: api.ClientRef{ID: 1}},
+		{TotalCost: 10, Billable: true, Client: api.ClientRef{ID: 2}},
+	}
+	currencies := map[int64]string{1: "USD", 2: "EUR"}
+
+	got := sumExpenses(expenses, currencies)
+	want := []expenseTotals{
+		{Currency: "EUR", TotalCost: 50, BillableCost: 10},
+		{Currency: "USD", TotalCost: 125.5, BillableCost: 125.5},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("sumExpenses() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("totals[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestOutputExpenses_Summary(t *testing.T) {
+	expenses := []api.Expense{
+		{ID: 1, SpentDate: "2024-05-01", TotalCost: 100, Billable: true, Client: api.ClientRef{ID: 1}},
+		{ID: 2, SpentDate: "2024-05-02", TotalCost: 40, Client: api.ClientRef{ID: 2}},
+	}
+	currencies := map[int64]string{1: "USD", 2: "EUR"}
+
+	var buf bytes.Buffer
+	if err := outputExpenses(&buf, expenses, output.ModeJSON, true, currencies); err != nil {
+		t.Fatalf("outputExpenses(JSON) error = %v", err)
+	}
+	var got struct {
+		Totals []expenseTotals `json:"totals"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, buf.String())
+	}
+	if len(got.Totals) != 2 || got.Totals[0] != (expenseTotals{Currency: "EUR", TotalCost: 40}) {
+		t.Errorf("JSON totals = %+v", got.Totals)
+	}
+
+	buf.Reset()
+	if err := outputExpenses(&buf, expenses, output.ModePlain, true, currencies); err != nil {
+		t.Fatalf("outputExpenses(plain) error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 5 || lines[3] != "TOTAL\t\t\t\t40.00 EUR\t\t" || lines[4] != "TOTAL\t\t\t\t100.00 USD\t\t" {
+		t.Errorf("plain output = %q, want a TOTAL row per currency", buf.String())
+	}
+
+	buf.Reset()
+	if err := outputExpenses(&buf, expenses, output.ModeTable, true, currencies); err != nil {
+		t.Fatalf("outputExpenses(table) error = %v", err)
+	}
+	table := buf.String()
+	for _, want := range []string{"40.00 EUR", "100.00 USD", "Billable"} {
+		if !strings.Contains(table, want) {
+			t.Errorf("table missing %q:\n%s", want, table)
+		}
+	}
+	if strings.Contains(table, "Total:") {
+		t.Errorf("table totals should be footer rows, got:\n%s", table)
+	}
+}
+
+func TestExpenseCategoriesActiveFlag(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(auth.PATEnvToken, "pat")
+	t.Setenv(auth.PATEnvAccountID, "1")
+
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		data, _ := io.ReadAll(r.Body)
+		body = r.Method + " " + r.URL.Path + " " + string(data)
+		_, _ = w.Write([]byte(`{"id":42,"name":"Mileage"}`))
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"edit", "42", "--active=false"}, `PATCH /expense_categories/42 {"is_active":false}`},
+		{[]string{"edit", "42", "--active"}, `PATCH /expense_categories/42 {"is_active":true}`},
+		{[]string{"add", "Mileage", "--active=false"}, `POST /expense_categories {"name":"Mileage","is_active":false}`},
+		{[]string{"add", "Mileage"}, `POST /expense_categories {"name":"Mileage"}`},
+	}
+	for _, tt := range tests {
+		body = ""
+		var stdout, stderr bytes.Buffer
+		args := append([]string{"expenses", "categories"}, tt.args...)
+		if err := Execute(append(args, "--api-base-url", srv.URL), &stdout, &stderr); err != nil {
+			t.Fatalf("Execute(%v) error = %v, stderr: %s", tt.args, err, stderr.String())
+		}
+		if strings.TrimSpace(body) != tt.want {
+			t.Errorf("Execute(%v) sent %q, want %q", tt.args, body, tt.want)
+		}
+	}
+}
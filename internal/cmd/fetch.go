@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"context"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// maxParallelFetches caps the requests fetchAll has in flight, so a view
+// never spends much of Harvest's rate limit at once.
+const maxParallelFetches = 4
+
+// fetchAll runs independent API calls concurrently and returns the first
+// error. The context given to the calls is canceled once one of them fails.
+// Requests still go through the client's rate limiter and retries.
+func fetchAll(ctx context.Context, fetches ...func(context.Context) error) error {
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(maxParallelFetches)
+	for _, fetch := range fetches {
+		g.Go(func() error { return fetch(ctx) })
+	}
+	return g.Wait()
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dedene/harvest-cli/internal/auth"
+)
+
+// overlapServer answers project show requests, holding each one until
+// want requests are in flight at once (or a timeout passes) so a test can
+// tell concurrent fetches from sequential ones.
+type overlapServer struct {
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+	want        int
+	release     chan struct{}
+}
+
+func (s *overlapServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.inFlight++
+	s.maxInFlight = max(s.maxInFlight, s.inFlight)
+	if s.inFlight == s.want {
+		close(s.release)
+	}
+	s.mu.Unlock()
+
+	select {
+	case <-s.release:
+	case <-time.After(2 * time.Second):
+	}
+
+	s.mu.Lock()
+	s.inFlight--
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	switch r.URL.Path {
+	case "/projects/7":
+		_, _ = w.Write([]byte(`{"id":7,"name":"Website"}`))
+	case "/projects/7/task_assignments":
+		_, _ = w.Write([]byte(`{"task_assignments":[{"id":1,"task":{"id":3,"name":"Design"}}],"total_pages":1,"page":1}`))
+	case "/projects/7/user_assignments":
+		_, _ = w.Write([]byte(`{"user_assignments":[{"id":2,"user":{"id":4,"name":"Ada"}}],"total_pages":1,"page":1}`))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestProjectsShowFetchesConcurrently(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(auth.PATEnvToken, "pat")
+	t.Setenv(auth.PATEnvAccountID, "1")
+
+	srv := &overlapServer{want: 3, release: make(chan struct{})}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"projects", "show", "7", "--with-tasks", "--with-users", "--json", "--api-base-url", ts.URL}
+	if err := Execute(args, &stdout, &stderr); err != nil {
+		t.Fatalf("Execute() error = %v, stderr: %s", err, stderr.String())
+	}
+	if srv.maxInFlight != 3 {
+		t.Errorf("max requests in flight = %d, want 3", srv.maxInFlight)
+	}
+	for _, want := range []string{`"name": "Website"`, `"name": "Design"`, `"name": "Ada"`} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("stdout = %s, want %s", stdout.String(), want)
+		}
+	}
+}
+
+func TestFetchAll(t *testing.T) {
+	errBoom := errors.New("boom")
+	canceled := make(chan struct{})
+	err := fetchAll(context.Background(),
+		func(context.Context) error { return errBoom },
+		func(ctx context.Context) error {
+			<-ctx.Done()
+			close(canceled)
+			return ctx.Err()
+		},
+	)
+	if !errors.Is(err, errBoom) {
+		t.Errorf("fetchAll() error = %v, want the first failure", err)
+	}
+	select {
+	case <-canceled:
+	default:
+		t.Error("fetchAll() did not cancel the other fetches after a failure")
+	}
+}
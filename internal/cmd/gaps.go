@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dedene/harvest-cli/internal/api"
+	"github.com/dedene/harvest-cli/internal/dateparse"
+	"github.com/dedene/harvest-cli/internal/output"
+)
+
+// TimeGapsCmd lists days in a range with no time, or less than --min-hours.
+type TimeGapsCmd struct {
+	From         string  `help:"Start date (required)" short:"f" required:"" aliases:"since"`
+	To           string  `help:"End date (default: today)" short:"t" aliases:"until"`
+	User         string  `help:"User ID, email or 'me'" default:"me"`
+	MinHours     float64 `help:"Also report days with fewer hours than this" name:"min-hours"`
+	SkipWeekends bool    `help:"Ignore Saturdays and Sundays" name:"skip-weekends"`
+	Holidays     string  `help:"File of dates to ignore, one YYYY-MM-DD per line ('#' starts a comment)" type:"path"`
+}
+
+// dayGap is a day with less time logged than expected.
+type dayGap struct {
+	Date      string  `json:"date"`
+	Weekday   string  `json:"weekday"`
+	Hours     float64 `json:"hours"`
+	Shortfall float64 `json:"shortfall"`
+}
+
+func (c *TimeGapsCmd) Run(cli *CLI) error {
+	if c.MinHours < 0 {
+		return fmt.Errorf("--min-hours must not be negative")
+	}
+
+	to := c.To
+	if to == "" {
+		to = "today"
+	}
+	from, to, err := parseDateRange(c.From, to)
+	if err != nil {
+		return err
+	}
+
+	var holidays map[string]bool
+	if c.Holidays != "" {
+		if holidays, err = loadHolidays(c.Holidays); err != nil {
+			return err
+		}
+	}
+
+	ctx := context.Background()
+	client, err := NewClientFromFlags(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	userID, err := resolveUserID(ctx, client, c.User)
+	if err != nil {
+		return err
+	}
+
+	entries, err := client.ListAllTimeEntries(ctx, api.TimeEntryListOptions{From: from, To: to, UserID: userID})
+	if err != nil {
+		return fmt.Errorf("list time entries: %w", err)
+	}
+
+	start, _ := time.Parse("2006-01-02", from)
+	end, _ := time.Parse("2006-01-02", to)
+	gaps := findGaps(start, end, hoursByDay(entries), c.MinHours, c.SkipWeekends, holidays)
+
+	return outputGaps(cli.Stdout, gaps, from, to, output.ModeFromFlags(cli.JSON, cli.Plain))
+}
+
+// hoursByDay sums entry hours per spent date.
+func hoursByDay(entries []api.TimeEntry) map[string]float64 {
+	hours := make(map[string]float64)
+	for _, e := range entries {
+		hours[e.SpentDate] += e.Hours
+	}
+	return hours
+}
+
+// findGaps walks each day from start to end inclusive and returns those with
+// no hours, or fewer than minHours when it is set. Weekends (when skipped)
+// and holidays are not reported.
+func findGaps(start, end time.Time, hours map[string]float64, minHours float64, skipWeekends bool, holidays map[string]bool) []dayGap {
+	gaps := []dayGap{}
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		if skipWeekends && (day.Weekday() == time.Saturday || day.Weekday() == time.Sunday) {
+			continue
+		}
+		date := dateparse.FormatDate(day)
+		if holidays[date] {
+			continue
+		}
+
+		logged := hours[date]
+		if logged > 0 && logged >= minHours {
+			continue
+		}
+		gaps = append(gaps, dayGap{
+			Date:      date,
+			Weekday:   day.Weekday().String(),
+			Hours:     logged,
+			Shortfall: max(minHours-logged, 0),
+		})
+	}
+	return gaps
+}
+
+// loadHolidays reads a file of YYYY-MM-DD dates. Anything after the date on
+// a line, blank lines, and lines starting with '#' are ignored.
+func loadHolidays(path string) (map[string]bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open holidays file: %w", err)
+	}
+	defer f.Close()
+
+	holidays := make(map[string]bool)
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", fields[0]); err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid date %q (want YYYY-MM-DD)", path, n, fields[0])
+		}
+		holidays[fields[0]] = true
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read holidays file: %w", err)
+	}
+	return holidays, nil
+}
+
+// outputGaps writes the gap days in the specified format.
+func outputGaps(w io.Writer, gaps []dayGap, from, to string, mode output.Mode) error {
+	switch mode {
+	case output.ModeJSON:
+		return output.WriteJSON(w, gaps)
+	case output.ModePlain:
+		headers := []string{"Date", "Weekday", "Hours", "Shortfall"}
+		rows := make([][]string, len(gaps))
+		for i, g := range gaps {
+			rows[i] = []string{g.Date, g.Weekday, fmt.Sprintf("%.2f", g.Hours), fmt.Sprintf("%.2f", g.Shortfall)}
+		}
+		return output.WriteTSV(w, headers, rows)
+	default:
+		if len(gaps) == 0 {
+			fmt.Fprintf(w, "No gaps from %s to %s\n", from, to)
+			return nil
+		}
+		t := output.NewTable(w, "Date", "Day", "Hours", "Shortfall").SetAlign(output.AlignRight, 2, 3).SetDateColumns(0)
+		var shortfall float64
+		for _, g := range gaps {
+			t.AddRow(g.Date, g.Weekday[:3], fmt.Sprintf("%.2f", g.Hours), fmt.Sprintf("%.2f", g.Shortfall))
+			shortfall += g.Shortfall
+		}
+		if err := t.Render(); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "\n%d days with gaps", len(gaps))
+		if shortfall > 0 {
+			fmt.Fprintf(w, ", %.2fh short", shortfall)
+		}
+		fmt.Fprintln(w)
+		return nil
+	}
+}
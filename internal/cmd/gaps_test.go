@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dedene/harvest-cli/internal/api"
+)
+
+func TestHoursByDay(t *testing.T) {
+	hours := hoursByDay([]api.TimeEntry{
+		{SpentDate: "2024-03-04", Hours: 2},
+		{SpentDate: "2024-03-05", Hours: 8},
+		{SpentDate: "2024-03-04", Hours: 1.5},
+	})
+	if len(hours) != 2 || hours["2024-03-04"] != 3.5 || hours["2024-03-05"] != 8 {
+		t.Errorf("hoursByDay() = %v", hours)
+	}
+}
+
+func TestFindGaps(t *testing.T) {
+	// Friday 2024-03-01 through Tuesday 2024-03-05
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+	hours := map[string]float64{"2024-03-01": 8, "2024-03-04": 5}
+
+	dates := func(gaps []dayGap) []string {
+		out := make([]string, len(gaps))
+		for i, g := range gaps {
+			out[i] = g.Date
+		}
+		return out
+	}
+
+	tests := []struct {
+		name         string
+		minHours     float64
+		skipWeekends bool
+		holidays     map[string]bool
+		want         []string
+	}{
+		{name: "empty days", want: []string{"2024-03-02", "2024-03-03", "2024-03-05"}},
+		{name: "skip weekends", skipWeekends: true, want: []string{"2024-03-05"}},
+		{name: "min hours", minHours: 6, skipWeekends: true, want: []string{"2024-03-04", "2024-03-05"}},
+		{name: "holidays", skipWeekends: true, holidays: map[string]bool{"2024-03-05": true}, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := dates(findGaps(start, end, hours, tt.minHours, tt.skipWeekends, tt.holidays))
+			if len(got) != len(tt.want) {
+				t.Fatalf("findGaps() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("findGaps() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+
+	gaps := findGaps(start, end, hours, 6, true, nil)
+	if gaps[0].Weekday != "Monday" || gaps[0].Hours != 5 || gaps[0].Shortfall != 1 {
+		t.Errorf("gaps[0] = %+v, want Monday 5h short 1h", gaps[0])
+	}
+}
+
+func TestLoadHolidays(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "holidays.txt")
+	data := "# 2024 holidays\n2024-12-25 Christmas\n\n2024-12-26\n"
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	holidays, err := loadHolidays(path)
+	if err != nil {
+		t.Fatalf("loadHolidays() error = %v", err)
+	}
+	if len(holidays) != 2 || !holidays["2024-12-25"] || !holidays["2024-12-26"] {
+		t.Errorf("loadHolidays() = %v", holidays)
+	}
+
+	bad := filepath.Join(dir, "bad.txt")
+	if err := os.WriteFile(bad, []byte("2024-12-25\nDec 26\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadHolidays(bad); err == nil || err.Error() != bad+`:2: invalid date "Dec" (want YYYY-MM-DD)` {
+		t.Errorf("loadHolidays() error = %v", err)
+	}
+}
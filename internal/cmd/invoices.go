@@ -4,9 +4,13 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"math"
 	"os"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/dedene/harvest-cli/internal/api"
 	"github.com/dedene/harvest-cli/internal/dateparse"
@@ -16,16 +20,19 @@ import (
 
 // InvoicesCmd groups invoice subcommands.
 type InvoicesCmd struct {
-	List       InvoicesListCmd       `cmd:"" help:"List invoices"`
-	Show       InvoicesShowCmd       `cmd:"" help:"Show an invoice"`
-	Add        InvoicesAddCmd        `cmd:"" help:"Create an invoice"`
-	Edit       InvoicesEditCmd       `cmd:"" help:"Update an invoice"`
-	Remove     InvoicesRemoveCmd     `cmd:"" help:"Delete an invoice"`
-	Send       InvoicesSendCmd       `cmd:"" help:"Send invoice via email"`
-	MarkSent   InvoicesMarkSentCmd   `cmd:"" name:"mark-sent" help:"Mark invoice as sent"`
-	MarkClosed InvoicesMarkClosedCmd `cmd:"" name:"mark-closed" help:"Mark invoice as closed"`
-	MarkDraft  InvoicesMarkDraftCmd  `cmd:"" name:"mark-draft" help:"Mark invoice as draft"`
-	Payments   InvoicePaymentsCmd    `cmd:"" help:"Manage invoice payments"`
+	List           InvoicesListCmd           `cmd:"" help:"List invoices"`
+	Show           InvoicesShowCmd           `cmd:"" help:"Show an invoice"`
+	Add            InvoicesAddCmd            `cmd:"" help:"Create an invoice"`
+	CreateFromTime InvoicesCreateFromTimeCmd `cmd:"" name:"create-from-time" help:"Create a draft invoice from a client's billable time"`
+	Edit           InvoicesEditCmd           `cmd:"" help:"Update an invoice"`
+	Remove         InvoicesRemoveCmd         `cmd:"" help:"Delete an invoice"`
+	Send           InvoicesSendCmd           `cmd:"" help:"Send invoice via email"`
+	MarkSent       InvoicesMarkSentCmd       `cmd:"" name:"mark-sent" help:"Mark invoice as sent"`
+	MarkClosed     InvoicesMarkClosedCmd     `cmd:"" name:"mark-closed" help:"Mark invoice as closed"`
+	MarkDraft      InvoicesMarkDraftCmd      `cmd:"" name:"mark-draft" help:"Mark invoice as draft"`
+	MarkPaid       InvoicesMarkPaidCmd       `cmd:"" name:"mark-paid" help:"Record a payment for the full amount due"`
+	Payments       InvoicePaymentsCmd        `cmd:"" help:"Manage invoice payments"`
+	Aging          InvoicesAgingCmd          `cmd:"" help:"Show outstanding amounts by days overdue"`
 }
 
 // InvoicesListCmd lists invoices with filters.
@@ -34,13 +41,32 @@ type InvoicesListCmd struct {
 	Project       string `help:"Filter by project ID or name" short:"p"`
 	State         string `help:"Filter by state: draft, open, paid, closed" default:"" enum:",draft,open,paid,closed"`
 	UpdatedSince  string `help:"Filter by updated since date"`
-	From          string `help:"Filter by issue date from" short:"f"`
-	To            string `help:"Filter by issue date to" short:"t"`
+	From          string `help:"Filter by issue date from" short:"f" aliases:"since"`
+	To            string `help:"Filter by issue date to" short:"t" aliases:"until"`
+	Overdue       bool   `help:"Only open invoices past their due date, most overdue first"`
+	NDJSON        bool   `help:"Output one JSON object per line" name:"ndjson"`
+	FiscalFlags   `embed:""`
+	PagingFlags   `embed:""`
+}
+
+// overdueInvoice is an open invoice past its due date, with its aging
+// bucket.
+type overdueInvoice struct {
+	api.Invoice
+	DaysOverdue int    `json:"days_overdue"`
+	Bucket      string `json:"aging_bucket"`
 }
 
 func (c *InvoicesListCmd) Run(cli *CLI) error {
+	if err := c.PagingFlags.validate(); err != nil {
+		return err
+	}
+	if c.Overdue && c.State != "" && c.State != "open" {
+		return fmt.Errorf("--overdue only applies to open invoices; drop --state %s", c.State)
+	}
+
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -73,28 +99,46 @@ func (c *InvoicesListCmd) Run(cli *CLI) error {
 		opts.UpdatedSince = t.Format("2006-01-02T15:04:05Z")
 	}
 
-	if c.From != "" {
-		t, err := dateparse.Parse(c.From)
-		if err != nil {
-			return fmt.Errorf("invalid from date: %w", err)
-		}
-		opts.From = dateparse.FormatDate(t)
+	if opts.From, opts.To, err = issueDateRange(c.From, c.To, c.FiscalFlags); err != nil {
+		return err
 	}
 
-	if c.To != "" {
-		t, err := dateparse.Parse(c.To)
-		if err != nil {
-			return fmt.Errorf("invalid to date: %w", err)
-		}
-		opts.To = dateparse.FormatDate(t)
+	opts.PerPage = c.PerPage
+	opts.MaxItems = c.MaxItems
+	if c.Overdue {
+		// The API has no overdue filter: fetch every open invoice, then
+		// filter and limit here.
+		opts.State = "open"
+		opts.MaxItems = 0
 	}
-
 	invoices, err := client.ListAllInvoices(ctx, opts)
 	if err != nil {
 		return fmt.Errorf("list invoices: %w", err)
 	}
 
-	return outputInvoices(os.Stdout, invoices, output.ModeFromFlags(cli.JSON, cli.Plain))
+	if c.Overdue {
+		today, err := dateparse.Parse("today")
+		if err != nil {
+			return err
+		}
+		overdue, err := overdueInvoices(invoices, today)
+		if err != nil {
+			return err
+		}
+		overdue = limitItems(overdue, c.MaxItems)
+		if c.NDJSON {
+			return output.WriteNDJSON(cli.Stdout, overdue)
+		}
+		loadCurrencyFormat(ctx, cli, client)
+		return outputOverdueInvoices(cli.Stdout, overdue, output.ModeFromFlags(cli.JSON, cli.Plain))
+	}
+
+	if c.NDJSON {
+		return output.WriteNDJSON(cli.Stdout, invoices)
+	}
+
+	loadCurrencyFormat(ctx, cli, client)
+	return outputInvoices(cli.Stdout, invoices, output.ModeFromFlags(cli.JSON, cli.Plain))
 }
 
 // InvoicesShowCmd shows a single invoice.
@@ -104,7 +148,7 @@ type InvoicesShowCmd struct {
 
 func (c *InvoicesShowCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -114,15 +158,18 @@ func (c *InvoicesShowCmd) Run(cli *CLI) error {
 		return fmt.Errorf("get invoice: %w", err)
 	}
 
-	return outputInvoice(os.Stdout, invoice, output.ModeFromFlags(cli.JSON, cli.Plain))
+	loadCurrencyFormat(ctx, cli, client)
+	return outputInvoice(cli.Stdout, invoice, output.ModeFromFlags(cli.JSON, cli.Plain))
 }
 
 // InvoicesAddCmd creates a new invoice.
 type InvoicesAddCmd struct {
-	HarvestClient string  `help:"Client ID or name (required)" name:"harvest-client" short:"c" required:""`
+	HarvestClient string  `help:"Client ID or name (required unless --from-estimate)" name:"harvest-client" short:"c"`
+	FromEstimate  int64   `help:"Seed the invoice with an estimate's client, line items and terms" name:"from-estimate"`
 	Number        string  `help:"Invoice number"`
 	Subject       string  `help:"Invoice subject"`
 	Notes         string  `help:"Invoice notes"`
+	NotesFile     string  `help:"Read invoice notes from a file ('-' for stdin)" name:"notes-file"`
 	IssueDate     string  `help:"Issue date (default: today)"`
 	DueDate       string  `help:"Due date"`
 	PaymentTerm   string  `help:"Payment term: upon receipt, net 15, net 30, net 45, net 60, custom" default:"" enum:",upon receipt,net 15,net 30,net 45,net 60,custom"`
@@ -134,21 +181,45 @@ type InvoicesAddCmd struct {
 }
 
 func (c *InvoicesAddCmd) Run(cli *CLI) error {
-	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	if c.HarvestClient == "" && c.FromEstimate == 0 {
+		return fmt.Errorf("--harvest-client is required unless --from-estimate is given")
+	}
+	notes, err := readNotes(cli, c.Notes, c.NotesFile)
 	if err != nil {
 		return err
 	}
+	c.Notes = notes
 
-	clientID, err := resolveClientID(ctx, client, c.HarvestClient)
+	ctx := context.Background()
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
 
+	var clientID int64
+	if c.HarvestClient != "" {
+		clientID, err = resolveClientID(ctx, client, c.HarvestClient)
+		if err != nil {
+			return err
+		}
+	}
+
 	input := &api.InvoiceInput{
 		ClientID: clientID,
 	}
 
+	if c.FromEstimate != 0 {
+		estimate, err := client.GetEstimate(ctx, c.FromEstimate)
+		if err != nil {
+			return fmt.Errorf("get estimate %d: %w", c.FromEstimate, err)
+		}
+		if clientID != 0 && estimate.Client.ID != clientID {
+			return fmt.Errorf("estimate #%d belongs to client %q, not the given --harvest-client",
+				estimate.ID, estimate.Client.Name)
+		}
+		input = invoiceInputFromEstimate(estimate)
+	}
+
 	if c.Number != "" {
 		input.Number = &c.Number
 	}
@@ -158,21 +229,8 @@ func (c *InvoicesAddCmd) Run(cli *CLI) error {
 	if c.Notes != "" {
 		input.Notes = &c.Notes
 	}
-	if c.IssueDate != "" {
-		t, err := dateparse.Parse(c.IssueDate)
-		if err != nil {
-			return fmt.Errorf("invalid issue_date: %w", err)
-		}
-		d := dateparse.FormatDate(t)
-		input.IssueDate = &d
-	}
-	if c.DueDate != "" {
-		t, err := dateparse.Parse(c.DueDate)
-		if err != nil {
-			return fmt.Errorf("invalid due_date: %w", err)
-		}
-		d := dateparse.FormatDate(t)
-		input.DueDate = &d
+	if err := setInvoiceDates(input, c.IssueDate, c.DueDate); err != nil {
+		return err
 	}
 	if c.PaymentTerm != "" {
 		input.PaymentTerm = &c.PaymentTerm
@@ -198,21 +256,191 @@ func (c *InvoicesAddCmd) Run(cli *CLI) error {
 		return fmt.Errorf("create invoice: %w", err)
 	}
 
-	if cli.JSON {
-		return output.WriteJSON(os.Stdout, invoice)
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, invoice)
 	}
 
-	fmt.Fprintf(os.Stdout, "Created invoice #%d: %s (%.2f %s)\n",
+	printSuccess(cli, invoice.ID, "Created invoice #%d: %s (%.2f %s)\n",
 		invoice.ID, invoice.Number, invoice.Amount, invoice.Currency)
 	return nil
 }
 
+// invoiceInputFromEstimate seeds a new invoice with an estimate's client,
+// terms and line items, linking it back to the estimate.
+func invoiceInputFromEstimate(e *api.Estimate) *api.InvoiceInput {
+	input := &api.InvoiceInput{
+		ClientID:   e.Client.ID,
+		EstimateID: &e.ID,
+		Tax:        e.Tax,
+		Tax2:       e.Tax2,
+		Discount:   e.Discount,
+	}
+	if e.Subject != "" {
+		input.Subject = &e.Subject
+	}
+	if e.Notes != "" {
+		input.Notes = &e.Notes
+	}
+	if e.Currency != "" {
+		input.Currency = &e.Currency
+	}
+	if e.PurchaseOrder != "" {
+		input.PurchaseOrder = &e.PurchaseOrder
+	}
+	for _, li := range e.LineItems {
+		input.LineItems = append(input.LineItems, api.InvoiceLineItemInput{
+			Kind:        li.Kind,
+			Description: &li.Description,
+			Quantity:    &li.Quantity,
+			UnitPrice:   &li.UnitPrice,
+			Taxed:       &li.Taxed,
+			Taxed2:      &li.Taxed2,
+		})
+	}
+	return input
+}
+
+// InvoicesCreateFromTimeCmd creates a draft invoice with line items
+// imported from the client's billable time in a date range.
+type InvoicesCreateFromTimeCmd struct {
+	HarvestClient string   `help:"Client ID or name" name:"harvest-client" short:"c" required:""`
+	From          string   `help:"Import time from this date" short:"f" required:"" aliases:"since"`
+	To            string   `help:"Import time up to this date" short:"t" required:"" aliases:"until"`
+	SummaryType   string   `help:"Line item grouping: project, task, people, detailed" name:"summary-type" default:"project" enum:"project,task,people,detailed"`
+	Project       []string `help:"Only import time for these projects (ID or name; default: all of the client's active projects)" short:"p"`
+	Subject       string   `help:"Invoice subject"`
+	Notes         string   `help:"Invoice notes"`
+	IssueDate     string   `help:"Issue date (default: today)"`
+	DueDate       string   `help:"Due date"`
+	PaymentTerm   string   `help:"Payment term: upon receipt, net 15, net 30, net 45, net 60, custom" default:"" enum:",upon receipt,net 15,net 30,net 45,net 60,custom"`
+	PurchaseOrder string   `help:"Purchase order number"`
+}
+
+func (c *InvoicesCreateFromTimeCmd) Run(cli *CLI) error {
+	from, to, err := parseDateRange(c.From, c.To)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	client, err := NewClientFromFlags(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	clientID, err := resolveClientID(ctx, client, c.HarvestClient)
+	if err != nil {
+		return err
+	}
+	projectIDs, err := invoiceProjectIDs(ctx, client, clientID, c.Project)
+	if err != nil {
+		return err
+	}
+
+	input := &api.InvoiceInput{
+		ClientID: clientID,
+		LineItemsImport: &api.InvoiceLineItemsImport{
+			ProjectIDs: projectIDs,
+			Time:       &api.InvoiceTimeImport{SummaryType: c.SummaryType, From: from, To: to},
+		},
+	}
+	if c.Subject != "" {
+		input.Subject = &c.Subject
+	}
+	if c.Notes != "" {
+		input.Notes = &c.Notes
+	}
+	if err := setInvoiceDates(input, c.IssueDate, c.DueDate); err != nil {
+		return err
+	}
+	if c.PaymentTerm != "" {
+		input.PaymentTerm = &c.PaymentTerm
+	}
+	if c.PurchaseOrder != "" {
+		input.PurchaseOrder = &c.PurchaseOrder
+	}
+
+	// Harvest creates the draft and imports the time in one request.
+	invoice, err := client.CreateInvoice(ctx, input)
+	if err != nil {
+		return fmt.Errorf("create invoice: %w", err)
+	}
+	if cli.DryRun {
+		return nil
+	}
+
+	if len(invoice.LineItems) == 0 {
+		fmt.Fprintf(cli.Stderr, "No uninvoiced billable time from %s to %s; the draft has no line items\n", from, to)
+	}
+
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, invoice)
+	}
+
+	loadCurrencyFormat(ctx, cli, client)
+	printSuccess(cli, invoice.ID, "Created draft invoice #%d for %s: %d line items, total %s\n",
+		invoice.ID, invoice.Client.Name, len(invoice.LineItems), formatAmount(invoice.Amount, invoice.Currency))
+	return nil
+}
+
+// setInvoiceDates parses the --issue-date and --due-date values into input,
+// leaving the dates of input alone when a value is empty.
+func setInvoiceDates(input *api.InvoiceInput, issueDate, dueDate string) error {
+	if issueDate != "" {
+		t, err := dateparse.Parse(issueDate)
+		if err != nil {
+			return fmt.Errorf("invalid issue_date: %w", err)
+		}
+		d := dateparse.FormatDate(t)
+		input.IssueDate = &d
+	}
+	if dueDate != "" {
+		t, err := dateparse.Parse(dueDate)
+		if err != nil {
+			return fmt.Errorf("invalid due_date: %w", err)
+		}
+		d := dateparse.FormatDate(t)
+		input.DueDate = &d
+	}
+	return nil
+}
+
+// invoiceProjectIDs resolves the projects to import time from. With no
+// inputs it returns all of the client's active projects.
+func invoiceProjectIDs(ctx context.Context, client *api.Client, clientID int64, inputs []string) ([]int64, error) {
+	if len(inputs) == 0 {
+		projects, err := client.ListAllProjects(ctx, api.ProjectListOptions{ClientID: clientID, IsActive: boolPtr(true)})
+		if err != nil {
+			return nil, fmt.Errorf("list projects: %w", err)
+		}
+		if len(projects) == 0 {
+			return nil, fmt.Errorf("client has no active projects to invoice")
+		}
+		ids := make([]int64, len(projects))
+		for i, p := range projects {
+			ids[i] = p.ID
+		}
+		return ids, nil
+	}
+
+	ids := make([]int64, len(inputs))
+	for i, input := range inputs {
+		id, err := resolveProjectID(ctx, client, input)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
 // InvoicesEditCmd updates an existing invoice.
 type InvoicesEditCmd struct {
 	ID            int64   `arg:"" help:"Invoice ID"`
 	Number        string  `help:"Invoice number"`
 	Subject       string  `help:"Invoice subject"`
 	Notes         string  `help:"Invoice notes"`
+	NotesFile     string  `help:"Read invoice notes from a file ('-' for stdin)" name:"notes-file"`
 	IssueDate     string  `help:"Issue date"`
 	DueDate       string  `help:"Due date"`
 	PaymentTerm   string  `help:"Payment term"`
@@ -221,11 +449,35 @@ type InvoicesEditCmd struct {
 	Tax2          float64 `help:"Tax2 percentage"`
 	Discount      float64 `help:"Discount percentage"`
 	PurchaseOrder string  `help:"Purchase order number"`
+
+	AddTime     bool     `help:"Append line items for uninvoiced billable time from --from to --to" name:"add-time" aliases:"add-line-from-time"`
+	Project     []string `help:"With --add-time, only import time for these projects (ID or name; default: all of the client's active projects)" short:"p"`
+	From        string   `help:"With --add-time, import time from this date" short:"f" aliases:"since"`
+	To          string   `help:"With --add-time, import time up to this date" short:"t" aliases:"until"`
+	SummaryType string   `help:"With --add-time, line item grouping: project, task, people, detailed" name:"summary-type" default:"project" enum:"project,task,people,detailed"`
 }
 
 func (c *InvoicesEditCmd) Run(cli *CLI) error {
+	notes, err := readNotes(cli, c.Notes, c.NotesFile)
+	if err != nil {
+		return err
+	}
+	c.Notes = notes
+
+	var from, to string
+	if c.AddTime {
+		if c.From == "" || c.To == "" {
+			return fmt.Errorf("--add-time needs --from and --to")
+		}
+		if from, to, err = parseDateRange(c.From, c.To); err != nil {
+			return err
+		}
+	} else if len(c.Project) > 0 || c.From != "" || c.To != "" {
+		return fmt.Errorf("--project, --from and --to only apply with --add-time")
+	}
+
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -245,22 +497,10 @@ func (c *InvoicesEditCmd) Run(cli *CLI) error {
 		input.Notes = &c.Notes
 		hasChanges = true
 	}
-	if c.IssueDate != "" {
-		t, err := dateparse.Parse(c.IssueDate)
-		if err != nil {
-			return fmt.Errorf("invalid issue_date: %w", err)
-		}
-		d := dateparse.FormatDate(t)
-		input.IssueDate = &d
-		hasChanges = true
+	if err := setInvoiceDates(input, c.IssueDate, c.DueDate); err != nil {
+		return err
 	}
-	if c.DueDate != "" {
-		t, err := dateparse.Parse(c.DueDate)
-		if err != nil {
-			return fmt.Errorf("invalid due_date: %w", err)
-		}
-		d := dateparse.FormatDate(t)
-		input.DueDate = &d
+	if c.IssueDate != "" || c.DueDate != "" {
 		hasChanges = true
 	}
 	if c.PaymentTerm != "" {
@@ -288,6 +528,26 @@ func (c *InvoicesEditCmd) Run(cli *CLI) error {
 		hasChanges = true
 	}
 
+	// Imported time becomes line items added to the existing ones.
+	var before *api.Invoice
+	if c.AddTime {
+		if before, err = client.GetInvoice(ctx, c.ID); err != nil {
+			return fmt.Errorf("get invoice: %w", err)
+		}
+		if before.State != "draft" {
+			return fmt.Errorf("invoice #%d is %s; time can only be added to draft invoices", c.ID, before.State)
+		}
+		projectIDs, err := invoiceProjectIDs(ctx, client, before.Client.ID, c.Project)
+		if err != nil {
+			return err
+		}
+		input.LineItemsImport = &api.InvoiceLineItemsImport{
+			ProjectIDs: projectIDs,
+			Time:       &api.InvoiceTimeImport{SummaryType: c.SummaryType, From: from, To: to},
+		}
+		hasChanges = true
+	}
+
 	if !hasChanges {
 		return fmt.Errorf("no changes specified")
 	}
@@ -296,12 +556,25 @@ func (c *InvoicesEditCmd) Run(cli *CLI) error {
 	if err != nil {
 		return fmt.Errorf("update invoice: %w", err)
 	}
+	if cli.DryRun && before != nil {
+		return nil
+	}
+
+	if before != nil && len(invoice.LineItems) == len(before.LineItems) {
+		fmt.Fprintf(cli.Stderr, "No uninvoiced billable time from %s to %s; no line items added\n", from, to)
+	}
 
-	if cli.JSON {
-		return output.WriteJSON(os.Stdout, invoice)
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, invoice)
 	}
 
-	fmt.Fprintf(os.Stdout, "Updated invoice #%d: %s\n", invoice.ID, invoice.Number)
+	if before != nil {
+		loadCurrencyFormat(ctx, cli, client)
+		printSuccess(cli, invoice.ID, "Updated invoice #%d: %s, added %d line items, total %s\n",
+			invoice.ID, invoice.Number, len(invoice.LineItems)-len(before.LineItems), formatAmount(invoice.Amount, invoice.Currency))
+		return nil
+	}
+	printSuccess(cli, invoice.ID, "Updated invoice #%d: %s\n", invoice.ID, invoice.Number)
 	return nil
 }
 
@@ -313,7 +586,7 @@ type InvoicesRemoveCmd struct {
 
 func (c *InvoicesRemoveCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -329,13 +602,13 @@ func (c *InvoicesRemoveCmd) Run(cli *CLI) error {
 		confirmed, err := ui.ConfirmPrompt(msg)
 		if err != nil {
 			if err == ui.ErrCanceled {
-				fmt.Fprintln(os.Stderr, "Canceled")
+				fmt.Fprintln(cli.Stderr, "Canceled")
 				return nil
 			}
 			return err
 		}
 		if !confirmed {
-			fmt.Fprintln(os.Stderr, "Aborted")
+			fmt.Fprintln(cli.Stderr, "Aborted")
 			return nil
 		}
 	}
@@ -344,7 +617,7 @@ func (c *InvoicesRemoveCmd) Run(cli *CLI) error {
 		return fmt.Errorf("delete invoice: %w", err)
 	}
 
-	fmt.Fprintf(os.Stdout, "Deleted invoice #%d\n", c.ID)
+	printSuccess(cli, 0, "Deleted invoice #%d\n", c.ID)
 	return nil
 }
 
@@ -360,7 +633,7 @@ type InvoicesSendCmd struct {
 
 func (c *InvoicesSendCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -400,11 +673,11 @@ func (c *InvoicesSendCmd) Run(cli *CLI) error {
 		return fmt.Errorf("send invoice: %w", err)
 	}
 
-	if cli.JSON {
-		return output.WriteJSON(os.Stdout, msg)
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, msg)
 	}
 
-	fmt.Fprintf(os.Stdout, "Invoice sent (message #%d)\n", msg.ID)
+	printSuccess(cli, 0, "Invoice sent (message #%d)\n", msg.ID)
 	return nil
 }
 
@@ -415,7 +688,7 @@ type InvoicesMarkSentCmd struct {
 
 func (c *InvoicesMarkSentCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -434,11 +707,11 @@ func (c *InvoicesMarkSentCmd) Run(cli *CLI) error {
 		return fmt.Errorf("get invoice: %w", err)
 	}
 
-	if cli.JSON {
-		return output.WriteJSON(os.Stdout, invoice)
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, invoice)
 	}
 
-	fmt.Fprintf(os.Stdout, "Marked invoice #%d as sent (state: %s)\n", invoice.ID, invoice.State)
+	printSuccess(cli, 0, "Marked invoice #%d as sent (state: %s)\n", invoice.ID, invoice.State)
 	return nil
 }
 
@@ -449,7 +722,7 @@ type InvoicesMarkClosedCmd struct {
 
 func (c *InvoicesMarkClosedCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -468,11 +741,11 @@ func (c *InvoicesMarkClosedCmd) Run(cli *CLI) error {
 		return fmt.Errorf("get invoice: %w", err)
 	}
 
-	if cli.JSON {
-		return output.WriteJSON(os.Stdout, invoice)
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, invoice)
 	}
 
-	fmt.Fprintf(os.Stdout, "Marked invoice #%d as closed (state: %s)\n", invoice.ID, invoice.State)
+	printSuccess(cli, 0, "Marked invoice #%d as closed (state: %s)\n", invoice.ID, invoice.State)
 	return nil
 }
 
@@ -483,7 +756,7 @@ type InvoicesMarkDraftCmd struct {
 
 func (c *InvoicesMarkDraftCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -502,29 +775,263 @@ func (c *InvoicesMarkDraftCmd) Run(cli *CLI) error {
 		return fmt.Errorf("get invoice: %w", err)
 	}
 
-	if cli.JSON {
-		return output.WriteJSON(os.Stdout, invoice)
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, invoice)
 	}
 
-	fmt.Fprintf(os.Stdout, "Marked invoice #%d as draft (state: %s)\n", invoice.ID, invoice.State)
+	printSuccess(cli, 0, "Marked invoice #%d as draft (state: %s)\n", invoice.ID, invoice.State)
 	return nil
 }
 
+// InvoicesMarkPaidCmd records a payment for the invoice's full due amount.
+type InvoicesMarkPaidCmd struct {
+	ID    int64  `arg:"" help:"Invoice ID"`
+	Date  string `help:"Payment date (default: today)"`
+	Notes string `help:"Payment notes"`
+}
+
+func (c *InvoicesMarkPaidCmd) Run(cli *CLI) error {
+	ctx := context.Background()
+	client, err := NewClientFromFlags(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	input := &api.InvoicePaymentInput{Notes: c.Notes}
+	if c.Date != "" {
+		t, err := dateparse.Parse(c.Date)
+		if err != nil {
+			return fmt.Errorf("invalid date: %w", err)
+		}
+		input.PaidDate = dateparse.FormatDate(t)
+	}
+
+	invoice, err := client.GetInvoice(ctx, c.ID)
+	if err != nil {
+		return fmt.Errorf("get invoice: %w", err)
+	}
+	if err := checkPayable(invoice); err != nil {
+		return err
+	}
+
+	if cli.DryRun {
+		fmt.Fprintf(cli.Stderr, "Dry run - would record a payment of %.2f %s for invoice #%d\n",
+			invoice.DueAmount, invoice.Currency, invoice.ID)
+		return nil
+	}
+
+	invoice, payment, err := payInvoice(ctx, client, invoice, input)
+	if err != nil {
+		return err
+	}
+
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, invoice)
+	}
+
+	printSuccess(cli, payment.ID, "Recorded payment #%d of %.2f %s; invoice #%d is %s\n",
+		payment.ID, payment.Amount, invoice.Currency, invoice.ID, invoice.State)
+	return nil
+}
+
+// checkPayable rejects invoices that are already paid or have nothing due.
+func checkPayable(invoice *api.Invoice) error {
+	if invoice.State == "paid" {
+		return fmt.Errorf("invoice #%d is already paid", invoice.ID)
+	}
+	if invoice.DueAmount <= 0 {
+		return fmt.Errorf("invoice #%d has no amount due", invoice.ID)
+	}
+	return nil
+}
+
+// payInvoice records a payment of the invoice's due amount and returns the
+// refreshed invoice, failing if Harvest did not move it to paid.
+func payInvoice(ctx context.Context, client *api.Client, invoice *api.Invoice, input *api.InvoicePaymentInput) (*api.Invoice, *api.InvoicePayment, error) {
+	input.Amount = invoice.DueAmount
+	payment, err := client.CreateInvoicePayment(ctx, invoice.ID, input)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create payment: %w", err)
+	}
+
+	updated, err := client.GetInvoice(ctx, invoice.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get invoice: %w", err)
+	}
+	if updated.State != "paid" {
+		return nil, nil, fmt.Errorf("recorded payment #%d but invoice #%d is %s, not paid",
+			payment.ID, updated.ID, updated.State)
+	}
+	return updated, payment, nil
+}
+
+// InvoicesAgingCmd shows an accounts receivable aging breakdown of open
+// invoices.
+type InvoicesAgingCmd struct {
+	HarvestClient string `help:"Filter by client ID or name" name:"harvest-client" short:"c"`
+}
+
+func (c *InvoicesAgingCmd) Run(cli *CLI) error {
+	ctx := context.Background()
+	client, err := NewClientFromFlags(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	opts := api.InvoiceListOptions{State: "open"}
+	if c.HarvestClient != "" {
+		clientID, err := resolveClientID(ctx, client, c.HarvestClient)
+		if err != nil {
+			return err
+		}
+		opts.ClientID = clientID
+	}
+
+	invoices, err := client.ListAllInvoices(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("list invoices: %w", err)
+	}
+
+	rows, err := invoiceAging(invoices, time.Now())
+	if err != nil {
+		return err
+	}
+
+	loadCurrencyFormat(ctx, cli, client)
+	return outputInvoiceAging(cli.Stdout, rows, output.ModeFromFlags(cli.JSON, cli.Plain))
+}
+
+// agingBuckets are the aging report buckets, in display order.
+var agingBuckets = []string{"Current", "1-30", "31-60", "61-90", "90+"}
+
+// agingRow totals the outstanding amount of one bucket in one currency.
+type agingRow struct {
+	Bucket   string  `json:"bucket"`
+	Currency string  `json:"currency"`
+	Invoices int     `json:"invoices"`
+	Amount   float64 `json:"amount"`
+}
+
+// agingBucket returns the bucket for an invoice that is daysOverdue days
+// past its due date. Invoices not yet due are current.
+func agingBucket(daysOverdue int) string {
+	switch {
+	case daysOverdue <= 0:
+		return "Current"
+	case daysOverdue <= 30:
+		return "1-30"
+	case daysOverdue <= 60:
+		return "31-60"
+	case daysOverdue <= 90:
+		return "61-90"
+	default:
+		return "90+"
+	}
+}
+
+// daysOverdue returns how many days past dueDate today is. Invoices without
+// a due date are never overdue.
+func daysOverdue(dueDate string, today time.Time) (int, error) {
+	if dueDate == "" {
+		return 0, nil
+	}
+	due, err := time.Parse("2006-01-02", dueDate)
+	if err != nil {
+		return 0, fmt.Errorf("invalid due date %q: %w", dueDate, err)
+	}
+	day := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	return int(day.Sub(due).Hours() / 24), nil
+}
+
+// overdueInvoices keeps the invoices whose due date is before today, most
+// days overdue first.
+func overdueInvoices(invoices []api.Invoice, today time.Time) ([]overdueInvoice, error) {
+	overdue := []overdueInvoice{}
+	for _, inv := range invoices {
+		days, err := daysOverdue(inv.DueDate, today)
+		if err != nil {
+			return nil, err
+		}
+		if days <= 0 {
+			continue
+		}
+		overdue = append(overdue, overdueInvoice{Invoice: inv, DaysOverdue: days, Bucket: agingBucket(days)})
+	}
+	sort.SliceStable(overdue, func(i, j int) bool {
+		return overdue[i].DaysOverdue > overdue[j].DaysOverdue
+	})
+	return overdue, nil
+}
+
+// invoiceAging buckets the outstanding amounts of invoices by days overdue
+// as of today. Rows are ordered by bucket, then currency; empty buckets are
+// left out and currencies are never added together.
+func invoiceAging(invoices []api.Invoice, today time.Time) ([]agingRow, error) {
+	byKey := make(map[[2]string]*agingRow)
+	for _, inv := range invoices {
+		days, err := daysOverdue(inv.DueDate, today)
+		if err != nil {
+			return nil, fmt.Errorf("invoice %d: %w", inv.ID, err)
+		}
+		key := [2]string{agingBucket(days), inv.Currency}
+		row, ok := byKey[key]
+		if !ok {
+			row = &agingRow{Bucket: key[0], Currency: key[1]}
+			byKey[key] = row
+		}
+		row.Invoices++
+		row.Amount += inv.DueAmount
+	}
+
+	rows := make([]agingRow, 0, len(byKey))
+	for _, row := range byKey {
+		rows = append(rows, *row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		bi := slices.Index(agingBuckets, rows[i].Bucket)
+		bj := slices.Index(agingBuckets, rows[j].Bucket)
+		if bi != bj {
+			return bi < bj
+		}
+		return rows[i].Currency < rows[j].Currency
+	})
+	return rows, nil
+}
+
 // InvoicePaymentsCmd manages invoice payments.
 type InvoicePaymentsCmd struct {
-	List   InvoicePaymentsListCmd   `cmd:"" help:"List payments for an invoice"`
+	List   InvoicePaymentsListCmd   `cmd:"" help:"List payments for an invoice, or across invoices with --all"`
 	Add    InvoicePaymentsAddCmd    `cmd:"" help:"Add a payment to an invoice"`
 	Remove InvoicePaymentsRemoveCmd `cmd:"" help:"Remove a payment from an invoice"`
+	Import InvoicePaymentsImportCmd `cmd:"" help:"Record payments from a CSV file"`
 }
 
-// InvoicePaymentsListCmd lists payments for an invoice.
+// InvoicePaymentsListCmd lists payments for an invoice, or with --all for
+// every invoice in a date range.
 type InvoicePaymentsListCmd struct {
-	InvoiceID int64 `arg:"" help:"Invoice ID"`
+	InvoiceID int64  `arg:"" optional:"" help:"Invoice ID"`
+	All       bool   `help:"List payments across all invoices (requires --from and --to)"`
+	From      string `help:"With --all: payments on or after this date" short:"f" aliases:"since"`
+	To        string `help:"With --all: payments on or before this date" short:"t" aliases:"until"`
+	NDJSON    bool   `help:"Output one JSON object per line" name:"ndjson"`
 }
 
 func (c *InvoicePaymentsListCmd) Run(cli *CLI) error {
+	if c.All {
+		if c.InvoiceID != 0 {
+			return fmt.Errorf("--all cannot be combined with an invoice ID")
+		}
+		return c.runAll(cli)
+	}
+	if c.InvoiceID == 0 {
+		return fmt.Errorf("invoice ID required (or use --all --from --to)")
+	}
+	if c.From != "" || c.To != "" {
+		return fmt.Errorf("--from and --to require --all")
+	}
+
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -534,7 +1041,110 @@ func (c *InvoicePaymentsListCmd) Run(cli *CLI) error {
 		return fmt.Errorf("list payments: %w", err)
 	}
 
-	return outputInvoicePayments(os.Stdout, payments, output.ModeFromFlags(cli.JSON, cli.Plain))
+	if c.NDJSON {
+		return output.WriteNDJSON(cli.Stdout, payments)
+	}
+
+	return outputInvoicePayments(cli.Stdout, payments, output.ModeFromFlags(cli.JSON, cli.Plain))
+}
+
+// runAll collects payments across invoices. Harvest only lists payments per
+// invoice, so this fetches payments for every invoice that has any.
+func (c *InvoicePaymentsListCmd) runAll(cli *CLI) error {
+	if c.From == "" || c.To == "" {
+		return fmt.Errorf("--all requires --from and --to")
+	}
+	from, to, err := parseDateRange(c.From, c.To)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	client, err := NewClientFromFlags(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	// Invoices issued after the range cannot have been paid within it
+	invoices, err := client.ListAllInvoices(ctx, api.InvoiceListOptions{To: to})
+	if err != nil {
+		return fmt.Errorf("list invoices: %w", err)
+	}
+
+	// Each invoice slot is written by one fetch, keeping rows in invoice order
+	paid := make([][]paymentRow, len(invoices))
+	var fetches []func(context.Context) error
+	for i, inv := range invoices {
+		if !hasPayments(inv) {
+			continue
+		}
+		fetches = append(fetches, func(ctx context.Context) error {
+			if err := client.WaitForCapacity(ctx); err != nil {
+				return err
+			}
+			payments, err := client.ListAllInvoicePayments(ctx, inv.ID, api.InvoicePaymentListOptions{})
+			if err != nil {
+				return fmt.Errorf("list payments for invoice #%d: %w", inv.ID, err)
+			}
+			paid[i] = paymentsInRange(inv, payments, from, to)
+			return nil
+		})
+	}
+	if err := fetchAll(ctx, fetches...); err != nil {
+		return err
+	}
+
+	var rows []paymentRow
+	for _, r := range paid {
+		rows = append(rows, r...)
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].PaidDate < rows[j].PaidDate })
+
+	if c.NDJSON {
+		return output.WriteNDJSON(cli.Stdout, rows)
+	}
+
+	loadCurrencyFormat(ctx, cli, client)
+	return outputPaymentRows(cli.Stdout, rows, output.ModeFromFlags(cli.JSON, cli.Plain))
+}
+
+// paymentRow is a payment together with the invoice it was made against.
+type paymentRow struct {
+	ID            int64   `json:"id"`
+	PaidDate      string  `json:"paid_date"`
+	InvoiceID     int64   `json:"invoice_id"`
+	InvoiceNumber string  `json:"invoice_number"`
+	Client        string  `json:"client"`
+	Amount        float64 `json:"amount"`
+	Currency      string  `json:"currency"`
+	Notes         string  `json:"notes"`
+}
+
+// hasPayments reports whether any payment was recorded against inv.
+func hasPayments(inv api.Invoice) bool {
+	return inv.State != "draft" && inv.DueAmount < inv.Amount
+}
+
+// paymentsInRange returns the payments of inv paid between from and to
+// (YYYY-MM-DD, inclusive).
+func paymentsInRange(inv api.Invoice, payments []api.InvoicePayment, from, to string) []paymentRow {
+	var rows []paymentRow
+	for _, p := range payments {
+		if p.PaidDate < from || p.PaidDate > to {
+			continue
+		}
+		rows = append(rows, paymentRow{
+			ID:            p.ID,
+			PaidDate:      p.PaidDate,
+			InvoiceID:     inv.ID,
+			InvoiceNumber: inv.Number,
+			Client:        inv.Client.Name,
+			Amount:        p.Amount,
+			Currency:      inv.Currency,
+			Notes:         p.Notes,
+		})
+	}
+	return rows
 }
 
 // InvoicePaymentsAddCmd adds a payment to an invoice.
@@ -547,7 +1157,7 @@ type InvoicePaymentsAddCmd struct {
 
 func (c *InvoicePaymentsAddCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -570,11 +1180,11 @@ func (c *InvoicePaymentsAddCmd) Run(cli *CLI) error {
 		return fmt.Errorf("create payment: %w", err)
 	}
 
-	if cli.JSON {
-		return output.WriteJSON(os.Stdout, payment)
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, payment)
 	}
 
-	fmt.Fprintf(os.Stdout, "Created payment #%d: %.2f on %s\n",
+	printSuccess(cli, payment.ID, "Created payment #%d: %.2f on %s\n",
 		payment.ID, payment.Amount, payment.PaidDate)
 	return nil
 }
@@ -588,7 +1198,7 @@ type InvoicePaymentsRemoveCmd struct {
 
 func (c *InvoicePaymentsRemoveCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -598,13 +1208,13 @@ func (c *InvoicePaymentsRemoveCmd) Run(cli *CLI) error {
 		confirmed, err := ui.ConfirmPrompt(msg)
 		if err != nil {
 			if err == ui.ErrCanceled {
-				fmt.Fprintln(os.Stderr, "Canceled")
+				fmt.Fprintln(cli.Stderr, "Canceled")
 				return nil
 			}
 			return err
 		}
 		if !confirmed {
-			fmt.Fprintln(os.Stderr, "Aborted")
+			fmt.Fprintln(cli.Stderr, "Aborted")
 			return nil
 		}
 	}
@@ -613,10 +1223,296 @@ func (c *InvoicePaymentsRemoveCmd) Run(cli *CLI) error {
 		return fmt.Errorf("delete payment: %w", err)
 	}
 
-	fmt.Fprintf(os.Stdout, "Deleted payment #%d\n", c.PaymentID)
+	printSuccess(cli, 0, "Deleted payment #%d\n", c.PaymentID)
+	return nil
+}
+
+// InvoicePaymentsImportCmd records payments from a CSV file, such as a bank
+// export. With the global --dry-run flag, it previews payments without
+// recording them.
+type InvoicePaymentsImportCmd struct {
+	File          string `arg:"" help:"CSV file with invoice_number, amount and optional paid_date, notes columns"`
+	StrictHeaders bool   `help:"Reject unknown CSV columns instead of ignoring them" name:"strict-headers"`
+}
+
+// paymentImportColumns are the CSV columns understood by the payment importer.
+var paymentImportColumns = []string{"invoice_number", "amount", "paid_date", "notes"}
+
+// paymentImportRequiredColumns must be present in every payment import file.
+var paymentImportRequiredColumns = []string{"invoice_number", "amount"}
+
+// paymentImportRow is a validated payment import row.
+type paymentImportRow struct {
+	LineNum int
+	Number  string
+	Input   *api.InvoicePaymentInput
+	Invoice *api.Invoice
+}
+
+func (c *InvoicePaymentsImportCmd) Run(cli *CLI) error {
+	f, err := os.Open(c.File)
+	if err != nil {
+		return fmt.Errorf("open file: %w", err)
+	}
+	defer f.Close()
+
+	records, err := readImportCSV(f, paymentImportColumns, paymentImportRequiredColumns, c.StrictHeaders)
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		fmt.Fprintln(cli.Stdout, "No payments to import")
+		return nil
+	}
+
+	rows, err := parsePaymentImportRows(records)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	client, err := NewClientFromFlags(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	// One listing resolves every invoice number in the file
+	invoices, err := client.ListAllInvoices(ctx, api.InvoiceListOptions{})
+	if err != nil {
+		return fmt.Errorf("list invoices: %w", err)
+	}
+	if err := resolvePaymentInvoices(rows, invoices); err != nil {
+		return err
+	}
+	for _, w := range overpaymentWarnings(rows) {
+		fmt.Fprintln(cli.Stderr, w)
+	}
+
+	fmt.Fprintf(cli.Stdout, "%d payments will be recorded\n\n", len(rows))
+
+	if cli.DryRun {
+		fmt.Fprintln(cli.Stdout, "Dry run - preview of payments:")
+		for i, r := range rows {
+			fmt.Fprintf(cli.Stdout, "  %d. Invoice %s (#%d): %.2f %s", i+1, r.Number, r.Invoice.ID, r.Input.Amount, r.Invoice.Currency)
+			if r.Input.PaidDate != "" {
+				fmt.Fprintf(cli.Stdout, " on %s", r.Input.PaidDate)
+			}
+			fmt.Fprintln(cli.Stdout)
+		}
+		return nil
+	}
+
+	// Record payments one by one with progress. Retries are reported so a
+	// throttled import does not look stuck.
+	recorded, row := 0, 0
+	client.SetRetryHook(func(ev api.RetryEvent) {
+		fmt.Fprintln(cli.Stderr, describeRetry(ev, row, len(rows)))
+	})
+	defer client.SetRetryHook(nil)
+
+	var failures []string
+	for i, r := range rows {
+		row = i + 1
+		payment, err := client.CreateInvoicePayment(ctx, r.Invoice.ID, r.Input)
+		if err != nil {
+			msg := fmt.Sprintf("line %d: invoice %s: %v", r.LineNum, r.Number, err)
+			fmt.Fprintf(cli.Stderr, "Error recording payment %d: %s\n", i+1, msg)
+			failures = append(failures, msg)
+			continue
+		}
+		recorded++
+		fmt.Fprintf(cli.Stdout, "[%d/%d] Recorded #%d: invoice %s, %.2f %s on %s\n",
+			i+1, len(rows), payment.ID, r.Number, payment.Amount, r.Invoice.Currency, payment.PaidDate)
+	}
+
+	fmt.Fprintf(cli.Stdout, "\nImport complete: %d/%d payments recorded\n", recorded, len(rows))
+	if len(failures) > 0 {
+		fmt.Fprintf(cli.Stdout, "Failed:\n  %s\n", strings.Join(failures, "\n  "))
+	}
+	return nil
+}
+
+// parsePaymentImportRows validates payment import rows, reporting every
+// invalid row at once.
+func parsePaymentImportRows(records []csvRecord) ([]paymentImportRow, error) {
+	var rows []paymentImportRow
+	var errs []string
+
+	for _, rec := range records {
+		number := rec.get("invoice_number")
+		if number == "" {
+			errs = append(errs, fmt.Sprintf("line %d: invoice_number is required", rec.LineNum))
+			continue
+		}
+		amount, err := strconv.ParseFloat(rec.get("amount"), 64)
+		// ParseFloat accepts NaN and Inf, which no payment can be
+		if err != nil || math.IsNaN(amount) || math.IsInf(amount, 0) || amount <= 0 {
+			errs = append(errs, fmt.Sprintf("line %d: invalid amount %q", rec.LineNum, rec.get("amount")))
+			continue
+		}
+		input := &api.InvoicePaymentInput{Amount: amount, Notes: rec.get("notes")}
+		if paid := rec.get("paid_date"); paid != "" {
+			t, err := dateparse.Parse(paid)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("line %d: invalid paid_date %q", rec.LineNum, paid))
+				continue
+			}
+			input.PaidDate = dateparse.FormatDate(t)
+		}
+		rows = append(rows, paymentImportRow{LineNum: rec.LineNum, Number: number, Input: input})
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("validation errors:\n  %s", strings.Join(errs, "\n  "))
+	}
+	return rows, nil
+}
+
+// resolvePaymentInvoices sets the invoice of each row from its number,
+// reporting every unknown number at once.
+func resolvePaymentInvoices(rows []paymentImportRow, invoices []api.Invoice) error {
+	byNumber := make(map[string]*api.Invoice, len(invoices))
+	for i := range invoices {
+		byNumber[strings.ToLower(invoices[i].Number)] = &invoices[i]
+	}
+
+	var errs []string
+	for i := range rows {
+		inv, ok := byNumber[strings.ToLower(rows[i].Number)]
+		if !ok {
+			errs = append(errs, fmt.Sprintf("line %d: invoice not found: %s", rows[i].LineNum, rows[i].Number))
+			continue
+		}
+		rows[i].Invoice = inv
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("validation errors:\n  %s", strings.Join(errs, "\n  "))
+	}
 	return nil
 }
 
+// overpaymentWarnings describes the rows that would pay more than is still
+// due on their invoice, counting earlier rows for the same invoice.
+func overpaymentWarnings(rows []paymentImportRow) []string {
+	due := make(map[int64]float64)
+	var warnings []string
+	for _, r := range rows {
+		remaining, ok := due[r.Invoice.ID]
+		if !ok {
+			remaining = r.Invoice.DueAmount
+		}
+		// Compare in cents so rounding noise is not an overpayment
+		if math.Round(r.Input.Amount*100) > math.Round(remaining*100) {
+			warnings = append(warnings, fmt.Sprintf("Warning: line %d: payment of %.2f %s exceeds the %.2f due on invoice %s",
+				r.LineNum, r.Input.Amount, r.Invoice.Currency, max(remaining, 0), r.Number))
+		}
+		due[r.Invoice.ID] = remaining - r.Input.Amount
+	}
+	return warnings
+}
+
+// outputInvoiceAging writes an aging report in the specified format. The
+// table has one amount column per currency and ends with a totals row.
+func outputInvoiceAging(w io.Writer, rows []agingRow, mode output.Mode) error {
+	switch mode {
+	case output.ModeJSON:
+		return output.WriteJSON(w, rows)
+	case output.ModePlain:
+		headers := []string{"Bucket", "Currency", "Invoices", "Amount"}
+		tsv := make([][]string, len(rows))
+		for i, r := range rows {
+			tsv[i] = []string{r.Bucket, r.Currency, strconv.Itoa(r.Invoices), fmt.Sprintf("%.2f", r.Amount)}
+		}
+		return output.WriteTSV(w, headers, tsv)
+	default:
+		if len(rows) == 0 {
+			fmt.Fprintln(w, "No open invoices")
+			return nil
+		}
+
+		var currencies []string
+		counts := make(map[string]int)
+		amounts := make(map[[2]string]float64)
+		totals := make(map[string]float64)
+		invoices := 0
+		for _, r := range rows {
+			if !slices.Contains(currencies, r.Currency) {
+				currencies = append(currencies, r.Currency)
+			}
+			counts[r.Bucket] += r.Invoices
+			amounts[[2]string{r.Bucket, r.Currency}] += r.Amount
+			totals[r.Currency] += r.Amount
+			invoices += r.Invoices
+		}
+		sort.Strings(currencies)
+
+		t := output.NewTable(w, append([]string{"Bucket", "Invoices"}, currencies...)...)
+		for i := range len(currencies) + 1 {
+			t.SetAlign(output.AlignRight, i+1)
+		}
+		for _, bucket := range agingBuckets {
+			row := []string{bucket, strconv.Itoa(counts[bucket])}
+			for _, cur := range currencies {
+				row = append(row, formatAmount(amounts[[2]string{bucket, cur}], cur))
+			}
+			t.AddRow(row...)
+		}
+		total := []string{"Total", strconv.Itoa(invoices)}
+		for _, cur := range currencies {
+			total = append(total, formatAmount(totals[cur], cur))
+		}
+		t.AddRow(total...)
+		return t.Render()
+	}
+}
+
+// outputPaymentRows writes payments across invoices, with a total per
+// currency below the table.
+func outputPaymentRows(w io.Writer, rows []paymentRow, mode output.Mode) error {
+	switch mode {
+	case output.ModeJSON:
+		if rows == nil {
+			rows = []paymentRow{}
+		}
+		return output.WriteJSON(w, rows)
+	case output.ModePlain:
+		headers := []string{"ID", "PaidDate", "InvoiceID", "Invoice", "Client", "Amount", "Currency", "Notes"}
+		tsv := make([][]string, len(rows))
+		for i, r := range rows {
+			tsv[i] = []string{
+				strconv.FormatInt(r.ID, 10),
+				r.PaidDate,
+				strconv.FormatInt(r.InvoiceID, 10),
+				r.InvoiceNumber,
+				r.Client,
+				fmt.Sprintf("%.2f", r.Amount),
+				r.Currency,
+				r.Notes,
+			}
+		}
+		return output.WriteTSV(w, headers, tsv)
+	default:
+		if len(rows) == 0 {
+			fmt.Fprintln(w, "No payments found")
+			return nil
+		}
+
+		t := output.NewTable(w, "Paid Date", "Invoice", "Client", "Amount", "Notes").SetAlign(output.AlignRight, 3).SetDateColumns(0)
+		for _, r := range rows {
+			t.AddRow(
+				r.PaidDate,
+				r.InvoiceNumber,
+				r.Client,
+				formatAmount(r.Amount, r.Currency),
+				r.Notes,
+			)
+		}
+		addAmountTotals(t, 3, sumAmounts(rows, func(r paymentRow) (string, float64) { return r.Currency, r.Amount }))
+		return t.Render()
+	}
+}
+
 // outputInvoices writes invoices in the specified format.
 func outputInvoices(w io.Writer, invoices []api.Invoice, mode output.Mode) error {
 	switch mode {
@@ -638,14 +1534,14 @@ func outputInvoices(w io.Writer, invoices []api.Invoice, mode output.Mode) error
 		}
 		return output.WriteTSV(w, headers, rows)
 	default:
-		t := output.NewTable(w, "ID", "Number", "Client", "Amount", "Due", "State", "Issue Date")
+		t := output.NewTable(w, "ID", "Number", "Client", "Amount", "Due", "State", "Issue Date").SetAlign(output.AlignRight, 3, 4).SetDateColumns(6)
 		for _, inv := range invoices {
 			t.AddRow(
 				strconv.FormatInt(inv.ID, 10),
 				inv.Number,
 				inv.Client.Name,
-				fmt.Sprintf("%.2f %s", inv.Amount, inv.Currency),
-				fmt.Sprintf("%.2f", inv.DueAmount),
+				formatAmount(inv.Amount, inv.Currency),
+				formatAmount(inv.DueAmount, inv.Currency),
 				inv.State,
 				inv.IssueDate,
 			)
@@ -654,24 +1550,67 @@ func outputInvoices(w io.Writer, invoices []api.Invoice, mode output.Mode) error
 	}
 }
 
+// outputOverdueInvoices writes overdue invoices in the specified format.
+func outputOverdueInvoices(w io.Writer, invoices []overdueInvoice, mode output.Mode) error {
+	switch mode {
+	case output.ModeJSON:
+		return output.WriteJSON(w, invoices)
+	case output.ModePlain:
+		headers := []string{"ID", "Number", "Client", "Due", "Currency", "DueDate", "DaysOverdue", "Bucket"}
+		rows := make([][]string, len(invoices))
+		for i, inv := range invoices {
+			rows[i] = []string{
+				strconv.FormatInt(inv.ID, 10),
+				inv.Number,
+				inv.Client.Name,
+				fmt.Sprintf("%.2f", inv.DueAmount),
+				inv.Currency,
+				inv.DueDate,
+				strconv.Itoa(inv.DaysOverdue),
+				inv.Bucket,
+			}
+		}
+		return output.WriteTSV(w, headers, rows)
+	default:
+		if len(invoices) == 0 {
+			fmt.Fprintln(w, "No overdue invoices")
+			return nil
+		}
+		t := output.NewTable(w, "ID", "Number", "Client", "Due", "Due Date", "Days", "Aging").SetAlign(output.AlignRight, 3, 5).SetDateColumns(4)
+		for _, inv := range invoices {
+			t.AddRow(
+				strconv.FormatInt(inv.ID, 10),
+				inv.Number,
+				inv.Client.Name,
+				formatAmount(inv.DueAmount, inv.Currency),
+				inv.DueDate,
+				strconv.Itoa(inv.DaysOverdue),
+				inv.Bucket,
+			)
+		}
+		return t.Render()
+	}
+}
+
 // outputInvoice writes a single invoice in the specified format.
 func outputInvoice(w io.Writer, inv *api.Invoice, mode output.Mode) error {
 	switch mode {
 	case output.ModeJSON:
 		return output.WriteJSON(w, inv)
 	case output.ModePlain:
-		fmt.Fprintf(w, "%d\t%s\t%s\t%.2f\t%.2f\t%s\t%s\n",
-			inv.ID, inv.Number, inv.Client.Name, inv.Amount, inv.DueAmount, inv.State, inv.IssueDate)
-		return nil
+		return output.WriteTSV(w, nil, [][]string{{
+			strconv.FormatInt(inv.ID, 10), inv.Number, inv.Client.Name,
+			fmt.Sprintf("%.2f", inv.Amount), fmt.Sprintf("%.2f", inv.DueAmount), inv.State, inv.IssueDate,
+		}})
 	default:
 		fmt.Fprintf(w, "ID:          %d\n", inv.ID)
 		fmt.Fprintf(w, "Number:      %s\n", inv.Number)
 		fmt.Fprintf(w, "Client:      %s\n", inv.Client.Name)
-		fmt.Fprintf(w, "Amount:      %.2f %s\n", inv.Amount, inv.Currency)
-		fmt.Fprintf(w, "Due Amount:  %.2f %s\n", inv.DueAmount, inv.Currency)
+		fmt.Fprintf(w, "Amount:      %s\n", formatAmount(inv.Amount, inv.Currency))
+		fmt.Fprintf(w, "Due Amount:  %s\n", formatAmount(inv.DueAmount, inv.Currency))
 		fmt.Fprintf(w, "State:       %s\n", inv.State)
-		fmt.Fprintf(w, "Issue Date:  %s\n", inv.IssueDate)
-		fmt.Fprintf(w, "Due Date:    %s\n", inv.DueDate)
+		fmt.Fprintf(w, "Issue Date:  %s\n", output.FormatDate(inv.IssueDate))
+		fmt.Fprintf(w, "Due Date:    %s\n", output.FormatDate(inv.DueDate))
 		if inv.Subject != "" {
 			fmt.Fprintf(w, "Subject:     %s\n", inv.Subject)
 		}
@@ -714,17 +1653,13 @@ func outputInvoicePayments(w io.Writer, payments []api.InvoicePayment, mode outp
 		}
 		return output.WriteTSV(w, headers, rows)
 	default:
-		t := output.NewTable(w, "ID", "Amount", "Paid Date", "Notes")
+		t := output.NewTable(w, "ID", "Amount", "Paid Date", "Notes").SetAlign(output.AlignRight, 1).SetDateColumns(2)
 		for _, p := range payments {
-			notes := p.Notes
-			if len(notes) > 30 {
-				notes = notes[:27] + "..."
-			}
 			t.AddRow(
 				strconv.FormatInt(p.ID, 10),
 				fmt.Sprintf("%.2f", p.Amount),
 				p.PaidDate,
-				notes,
+				p.Notes,
 			)
 		}
 		return t.Render()
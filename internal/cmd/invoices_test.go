@@ -0,0 +1,600 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/dedene/harvest-cli/internal/api"
+	"github.com/dedene/harvest-cli/internal/auth"
+	"github.com/dedene/harvest-cli/internal/output"
+)
+
+func TestAgingBucket(t *testing.T) {
+	tests := []struct {
+		days int
+		want string
+	}{
+		{-10, "Current"},
+		{0, "Current"},
+		{1, "1-30"},
+		{30, "1-30"},
+		{31, "31-60"},
+		{60, "31-60"},
+		{61, "61-90"},
+		{90, "61-90"},
+		{91, "90+"},
+		{400, "90+"},
+	}
+
+	for _, tt := range tests {
+		if got := agingBucket(tt.days); got != tt.want {
+			t.Errorf("agingBucket(%d) = %q, want %q", tt.days, got, tt.want)
+		}
+	}
+}
+
+func TestInvoiceAging(t *testing.T) {
+	today := time.Date(2024, 3, 31, 15, 30, 0, 0, time.Local)
+	invoices := []api.Invoice{
+		{ID: 1, DueAmount: 100, Currency: "USD", DueDate: "2024-04-15"},
+		{ID: 2, DueAmount: 50, Currency: "USD", DueDate: "2024-03-31"},
+		{ID: 3, DueAmount: 200, Currency: "USD", DueDate: "2024-03-01"},
+		{ID: 4, DueAmount: 75, Currency: "EUR", DueDate: "2024-03-15"},
+		{ID: 5, DueAmount: 300, Currency: "USD", DueDate: "2023-12-01"},
+		{ID: 6, DueAmount: 25, Currency: "USD"},
+	}
+
+	rows, err := invoiceAging(invoices, today)
+	if err != nil {
+		t.Fatalf("invoiceAging() error = %v", err)
+	}
+
+	want := []agingRow{
+		{Bucket: "Current", Currency: "USD", Invoices: 3, Amount: 175},
+		{Bucket: "1-30", Currency: "EUR", Invoices: 1, Amount: 75},
+		{Bucket: "1-30", Currency: "USD", Invoices: 1, Amount: 200},
+		{Bucket: "90+", Currency: "USD", Invoices: 1, Amount: 300},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("rows = %+v, want %+v", rows, want)
+	}
+	for i := range want {
+		if rows[i] != want[i] {
+			t.Errorf("rows[%d] = %+v, want %+v", i, rows[i], want[i])
+		}
+	}
+
+	if _, err := invoiceAging([]api.Invoice{{ID: 7, DueDate: "soon"}}, today); err == nil {
+		t.Error("invoiceAging() should reject an invalid due date")
+	}
+}
+
+func TestOverdueInvoices(t *testing.T) {
+	today := time.Date(2024, 3, 31, 15, 30, 0, 0, time.Local)
+	invoices := []api.Invoice{
+		{ID: 1, DueDate: "2024-04-15"},
+		{ID: 2, DueDate: "2024-03-31"},
+		{ID: 3, DueDate: "2024-03-01"},
+		{ID: 4, DueDate: "2023-12-01"},
+		{ID: 5},
+		{ID: 6, DueDate: "2024-03-30"},
+	}
+
+	overdue, err := overdueInvoices(invoices, today)
+	if err != nil {
+		t.Fatalf("overdueInvoices() error = %v", err)
+	}
+
+	want := []struct {
+		id     int64
+		days   int
+		bucket string
+	}{
+		{4, 121, "90+"},
+		{3, 30, "1-30"},
+		{6, 1, "1-30"},
+	}
+	if len(overdue) != len(want) {
+		t.Fatalf("overdue = %+v, want %d invoices", overdue, len(want))
+	}
+	for i, w := range want {
+		if overdue[i].ID != w.id || overdue[i].DaysOverdue != w.days || overdue[i].Bucket != w.bucket {
+			t.Errorf("overdue[%d] = #%d %d days %s, want #%d %d days %s",
+				i, overdue[i].ID, overdue[i].DaysOverdue, overdue[i].Bucket, w.id, w.days, w.bucket)
+		}
+	}
+}
+
+func TestInvoicesListOverdue(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(auth.PATEnvToken, "pat")
+	t.Setenv(auth.PATEnvAccountID, "1")
+
+	var query string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/company" {
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		query = r.URL.Query().Get("state")
+		_, _ = w.Write([]byte(`{"invoices":[
+			{"id":1,"due_date":"2000-01-10","due_amount":10},
+			{"id":2,"due_date":"2999-01-01","due_amount":20},
+			{"id":3,"due_date":"2000-01-01","due_amount":30}
+		],"total_pages":1}`))
+	}))
+	defer srv.Close()
+
+	var stdout, stderr bytes.Buffer
+	if err := Execute([]string{"invoices", "list", "--overdue", "--plain", "--api-base-url", srv.URL}, &stdout, &stderr); err != nil {
+		t.Fatalf("Execute() error = %v, stderr: %s", err, stderr.String())
+	}
+	if query != "open" {
+		t.Errorf("state = %q, want open", query)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[1], "3\t") || !strings.HasPrefix(lines[2], "1\t") {
+		t.Errorf("stdout = %q, want invoices 3 then 1", stdout.String())
+	}
+
+	err := Execute([]string{"invoices", "list", "--overdue", "--state", "paid", "--api-base-url", srv.URL}, &stdout, &stderr)
+	if err == nil || !strings.Contains(err.Error(), "--overdue") {
+		t.Errorf("Execute() error = %v, want --overdue with --state paid rejected", err)
+	}
+}
+
+func TestOutputInvoiceAging_TotalsRow(t *testing.T) {
+	rows := []agingRow{
+		{Bucket: "Current", Currency: "USD", Invoices: 2, Amount: 150},
+		{Bucket: "31-60", Currency: "USD", Invoices: 1, Amount: 50},
+	}
+
+	var buf bytes.Buffer
+	if err := outputInvoiceAging(&buf, rows, output.ModeTable); err != nil {
+		t.Fatalf("outputInvoiceAging() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	last := lines[len(lines)-1]
+	if !strings.Contains(last, "Total") || !strings.Contains(last, "200.00") {
+		t.Errorf("last line = %q, want totals row with 200.00", last)
+	}
+}
+
+func TestInvoiceInputFromEstimate(t *testing.T) {
+	tax := 21.0
+	estimate := &api.Estimate{
+		ID:       55,
+		Client:   api.ClientRef{ID: 8, Name: "Acme"},
+		Subject:  "Website redesign",
+		Currency: "EUR",
+		Tax:      &tax,
+		LineItems: []api.EstimateLineItem{
+			{Kind: "Service", Description: "Design", Quantity: 10, UnitPrice: 100, Taxed: true},
+			{Kind: "Product", Description: "Hosting", Quantity: 1, UnitPrice: 50},
+		},
+	}
+
+	input := invoiceInputFromEstimate(estimate)
+
+	if input.ClientID != 8 || input.EstimateID == nil || *input.EstimateID != 55 {
+		t.Errorf("client/estimate = %d/%v, want 8/55", input.ClientID, input.EstimateID)
+	}
+	if input.Subject == nil || *input.Subject != "Website redesign" || input.Tax == nil || *input.Tax != 21 {
+		t.Errorf("terms not copied: %+v", input)
+	}
+	if input.Notes != nil {
+		t.Errorf("Notes = %q, want nil for empty estimate notes", *input.Notes)
+	}
+	if len(input.LineItems) != 2 {
+		t.Fatalf("len(LineItems) = %d, want 2", len(input.LineItems))
+	}
+	first, second := input.LineItems[0], input.LineItems[1]
+	if *first.Description != "Design" || *first.Quantity != 10 || !*first.Taxed {
+		t.Errorf("LineItems[0] = %+v", first)
+	}
+	if *second.Description != "Hosting" || *second.UnitPrice != 50 || *second.Taxed {
+		t.Errorf("LineItems[1] = %+v", second)
+	}
+}
+
+func TestInvoicesAddRequiresClientOrEstimate(t *testing.T) {
+	err := (&InvoicesAddCmd{}).Run(&CLI{})
+	if err == nil || !strings.Contains(err.Error(), "--from-estimate") {
+		t.Errorf("Run() error = %v, want missing client error", err)
+	}
+}
+
+func TestPaymentsInRange(t *testing.T) {
+	inv := api.Invoice{
+		ID:       7,
+		Number:   "INV-7",
+		Currency: "EUR",
+		Client:   api.ClientRef{Name: "Acme"},
+	}
+	payments := []api.InvoicePayment{
+		{ID: 1, PaidDate: "2023-12-31", Amount: 100},
+		{ID: 2, PaidDate: "2024-01-01", Amount: 200, Notes: "wire"},
+		{ID: 3, PaidDate: "2024-01-31", Amount: 300},
+		{ID: 4, PaidDate: "2024-02-01", Amount: 400},
+	}
+
+	rows := paymentsInRange(inv, payments, "2024-01-01", "2024-01-31")
+	if len(rows) != 2 || rows[0].ID != 2 || rows[1].ID != 3 {
+		t.Fatalf("paymentsInRange() = %+v, want payments 2 and 3", rows)
+	}
+	want := paymentRow{
+		ID:            2,
+		PaidDate:      "2024-01-01",
+		InvoiceID:     7,
+		InvoiceNumber: "INV-7",
+		Client:        "Acme",
+		Amount:        200,
+		Currency:      "EUR",
+		Notes:         "wire",
+	}
+	if rows[0] != want {
+		t.Errorf("rows[0] = %+v, want %+v", rows[0], want)
+	}
+}
+
+func TestHasPayments(t *testing.T) {
+	tests := []struct {
+		name string
+		inv  api.Invoice
+		want bool
+	}{
+		{"unpaid", api.Invoice{State: "open", Amount: 100, DueAmount: 100}, false},
+		{"partially paid", api.Invoice{State: "open", Amount: 100, DueAmount: 40}, true},
+		{"paid", api.Invoice{State: "paid", Amount: 100}, true},
+		{"draft", api.Invoice{State: "draft", Amount: 100}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasPayments(tt.inv); got != tt.want {
+				t.Errorf("hasPayments() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInvoicePaymentsListAll(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(auth.PATEnvToken, "pat")
+	t.Setenv(auth.PATEnvAccountID, "1")
+
+	var mu sync.Mutex
+	var fetched []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/invoices":
+			_, _ = w.Write([]byte(`{"invoices":[
+				{"id":1,"number":"2024-1","amount":500,"due_amount":0,"currency":"EUR","state":"paid"},
+				{"id":2,"number":"2024-2","amount":100,"due_amount":100,"currency":"EUR","state":"open"},
+				{"id":3,"number":"2024-3","amount":300,"due_amount":100,"currency":"EUR","state":"open"}],"total_pages":1,"page":1}`))
+		case "/invoices/1/payments":
+			mu.Lock()
+			fetched = append(fetched, r.URL.Path)
+			mu.Unlock()
+			_, _ = w.Write([]byte(`{"invoice_payments":[{"id":10,"amount":500,"paid_date":"2024-05-03"}],"total_pages":1,"page":1}`))
+		case "/invoices/3/payments":
+			mu.Lock()
+			fetched = append(fetched, r.URL.Path)
+			mu.Unlock()
+			_, _ = w.Write([]byte(`{"invoice_payments":[{"id":30,"amount":200,"paid_date":"2024-05-03"}],"total_pages":1,"page":1}`))
+		default:
+			t.Errorf("unexpected request %s; invoices without payments should be skipped", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"invoices", "payments", "list", "--all", "--from", "2024-05-01", "--to", "2024-05-31", "--json", "--api-base-url", srv.URL}
+	if err := Execute(args, &stdout, &stderr); err != nil {
+		t.Fatalf("Execute() error = %v\n%s", err, stderr.String())
+	}
+	if len(fetched) != 2 {
+		t.Errorf("fetched %v, want payments of invoices 1 and 3", fetched)
+	}
+
+	var rows []paymentRow
+	if err := json.Unmarshal(stdout.Bytes(), &rows); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, stdout.String())
+	}
+	if len(rows) != 2 || rows[0].ID != 10 || rows[1].ID != 30 {
+		t.Errorf("rows = %+v, want payments 10 and 30 in invoice order", rows)
+	}
+}
+
+func TestCheckPayable(t *testing.T) {
+	tests := []struct {
+		name    string
+		invoice api.Invoice
+		wantErr string
+	}{
+		{"open", api.Invoice{ID: 1, State: "open", DueAmount: 100}, ""},
+		{"paid", api.Invoice{ID: 2, State: "paid"}, "already paid"},
+		{"nothing due", api.Invoice{ID: 3, State: "open"}, "no amount due"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkPayable(&tt.invoice)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("checkPayable() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("checkPayable() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPayInvoice(t *testing.T) {
+	var paid api.InvoicePaymentInput
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			_ = json.NewDecoder(r.Body).Decode(&paid)
+			_, _ = w.Write([]byte(`{"id":9,"amount":250.5,"paid_date":"2024-03-01"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":7,"state":"paid","due_amount":0,"currency":"EUR"}`))
+	}))
+	defer srv.Close()
+
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"})
+	client := api.NewClientWithBaseURL(ts, 12345, "test@example.com", srv.URL)
+
+	invoice := &api.Invoice{ID: 7, State: "open", DueAmount: 250.5, Currency: "EUR"}
+	input := &api.InvoicePaymentInput{PaidDate: "2024-03-01", Notes: "Wire"}
+	updated, payment, err := payInvoice(context.Background(), client, invoice, input)
+	if err != nil {
+		t.Fatalf("payInvoice() error = %v", err)
+	}
+	if paid.Amount != 250.5 || paid.PaidDate != "2024-03-01" || paid.Notes != "Wire" {
+		t.Errorf("payment input = %+v", paid)
+	}
+	if payment.ID != 9 || updated.State != "paid" {
+		t.Errorf("payInvoice() = %+v, %+v", updated, payment)
+	}
+}
+
+func TestInvoicesCreateFromTime(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(auth.PATEnvToken, "pat")
+	t.Setenv(auth.PATEnvAccountID, "1")
+
+	var created map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/clients":
+			_, _ = w.Write([]byte(`{"clients":[{"id":5,"name":"Acme"}],"total_pages":1,"page":1}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/projects":
+			if q := r.URL.Query(); q.Get("client_id") != "5" || q.Get("is_active") != "true" {
+				t.Errorf("projects query = %q", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"projects":[{"id":10},{"id":11}],"total_pages":1,"page":1}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/invoices":
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			_, _ = w.Write([]byte(`{"id":99,"client":{"id":5,"name":"Acme"},"amount":1200,"currency":"EUR","state":"draft",
+				"line_items":[{"kind":"Service","amount":800},{"kind":"Service","amount":400}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"invoices", "create-from-time", "-c", "Acme", "--since", "2024-03-01", "--until", "2024-03-31",
+		"--summary-type", "task", "--issue-date", "2024-04-01", "--due-date", "2024-05-01", "--api-base-url", srv.URL}
+	if err := Execute(args, &stdout, &stderr); err != nil {
+		t.Fatalf("Execute() error = %v, stderr: %s", err, stderr.String())
+	}
+
+	want := `{"client_id":5,"due_date":"2024-05-01","issue_date":"2024-04-01",` +
+		`"line_items_import":{"project_ids":[10,11],"time":{"from":"2024-03-01","summary_type":"task","to":"2024-03-31"}}}`
+	got, _ := json.Marshal(created)
+	if string(got) != want {
+		t.Errorf("request = %s, want %s", got, want)
+	}
+	if !strings.HasPrefix(stdout.String(), "Created draft invoice #99 for Acme: 2 line items, total ") {
+		t.Errorf("stdout = %q", stdout.String())
+	}
+}
+
+func TestInvoicesEditAddTime(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(auth.PATEnvToken, "pat")
+	t.Setenv(auth.PATEnvAccountID, "1")
+
+	var updated map[string]any
+	state := "draft"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/invoices/99":
+			_, _ = w.Write([]byte(`{"id":99,"number":"2024-7","client":{"id":5,"name":"Acme"},"amount":800,"currency":"EUR",
+				"state":"` + state + `","line_items":[{"kind":"Service","amount":800}]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/projects":
+			if q := r.URL.Query(); q.Get("client_id") != "5" {
+				t.Errorf("projects query = %q", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"projects":[{"id":10}],"total_pages":1,"page":1}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/invoices/99":
+			_ = json.NewDecoder(r.Body).Decode(&updated)
+			_, _ = w.Write([]byte(`{"id":99,"number":"2024-7","client":{"id":5,"name":"Acme"},"amount":1200,"currency":"EUR","state":"draft",
+				"line_items":[{"kind":"Service","amount":800},{"kind":"Service","amount":400}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"invoices", "edit", "99", "--add-time", "--from", "2024-04-01", "--to", "2024-04-30",
+		"--subject", "April", "--api-base-url", srv.URL}
+	if err := Execute(args, &stdout, &stderr); err != nil {
+		t.Fatalf("Execute() error = %v, stderr: %s", err, stderr.String())
+	}
+
+	want := `{"line_items_import":{"project_ids":[10],"time":{"from":"2024-04-01","summary_type":"project","to":"2024-04-30"}},"subject":"April"}`
+	got, _ := json.Marshal(updated)
+	if string(got) != want {
+		t.Errorf("request = %s, want %s", got, want)
+	}
+	if !strings.HasPrefix(stdout.String(), "Updated invoice #99: 2024-7, added 1 line items, total ") {
+		t.Errorf("stdout = %q", stdout.String())
+	}
+
+	for _, tt := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--add-time", "--from", "2024-04-01"}, "--add-time needs --from and --to"},
+		{[]string{"--from", "2024-04-01", "--subject", "x"}, "only apply with --add-time"},
+		{[]string{"--add-time", "--since", "2024-04-30", "--until", "2024-04-01"}, "--from 2024-04-30 is after --to 2024-04-01"},
+	} {
+		stdout.Reset()
+		stderr.Reset()
+		err := Execute(append([]string{"invoices", "edit", "99", "--api-base-url", srv.URL}, tt.args...), &stdout, &stderr)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Execute(%v) error = %v, want %q", tt.args, err, tt.want)
+		}
+	}
+
+	state = "open"
+	updated = nil
+	err := Execute(append([]string{"invoices", "edit", "99", "--api-base-url", srv.URL}, "--add-time", "--from", "2024-04-01", "--to", "2024-04-30"), &stdout, &stderr)
+	if err == nil || !strings.Contains(err.Error(), "draft") || updated != nil {
+		t.Errorf("Execute() on an open invoice error = %v, updated = %v; want a draft-only error and no update", err, updated)
+	}
+}
+
+func TestInvoicePaymentsImport(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(auth.PATEnvToken, "pat")
+	t.Setenv(auth.PATEnvAccountID, "1")
+
+	var posted []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/invoices":
+			_, _ = w.Write([]byte(`{"invoices":[
+				{"id":1,"number":"2024-1","due_amount":500,"currency":"EUR","state":"open"},
+				{"id":2,"number":"2024-2","due_amount":100,"currency":"EUR","state":"open"}],"total_pages":1,"page":1}`))
+		case r.Method == http.MethodPost:
+			var in api.InvoicePaymentInput
+			_ = json.NewDecoder(r.Body).Decode(&in)
+			posted = append(posted, fmt.Sprintf("%s %.2f %s %s", r.URL.Path, in.Amount, in.PaidDate, in.Notes))
+			if r.URL.Path == "/invoices/2/payments" {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				_, _ = w.Write([]byte(`{"message":"Amount is too large"}`))
+				return
+			}
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"id":70,"amount":%g,"paid_date":%q}`, in.Amount, in.PaidDate)))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "payments.csv")
+	csv := "invoice_number,amount,paid_date,notes\n" +
+		"2024-1,300,2024-05-02,wire\n" +
+		"2024-1,250,2024-05-03,\n" +
+		"2024-2,50,2024-05-03,\n"
+	if err := os.WriteFile(path, []byte(csv), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if err := Execute([]string{"invoices", "payments", "import", path, "--dry-run", "--api-base-url", srv.URL}, &stdout, &stderr); err != nil {
+		t.Fatalf("dry run error = %v, stderr: %s", err, stderr.String())
+	}
+	if len(posted) != 0 || !strings.Contains(stdout.String(), "3 payments will be recorded") {
+		t.Errorf("dry run posted %v, stdout %q", posted, stdout.String())
+	}
+	if want := "line 3: payment of 250.00 EUR exceeds the 200.00 due on invoice 2024-1"; !strings.Contains(stderr.String(), want) {
+		t.Errorf("stderr = %q, want %q", stderr.String(), want)
+	}
+
+	stdout.Reset()
+	stderr.Reset()
+	if err := Execute([]string{"invoices", "payments", "import", path, "--api-base-url", srv.URL}, &stdout, &stderr); err != nil {
+		t.Fatalf("Execute() error = %v, stderr: %s", err, stderr.String())
+	}
+	want := []string{
+		"/invoices/1/payments 300.00 2024-05-02 wire",
+		"/invoices/1/payments 250.00 2024-05-03 ",
+		"/invoices/2/payments 50.00 2024-05-03 ",
+	}
+	if strings.Join(posted, "|") != strings.Join(want, "|") {
+		t.Errorf("posted = %q, want %q", posted, want)
+	}
+	out := stdout.String()
+	for _, w := range []string{"[1/3] Recorded #70: invoice 2024-1, 300.00 EUR on 2024-05-02", "2/3 payments recorded", "line 4: invoice 2024-2:"} {
+		if !strings.Contains(out, w) {
+			t.Errorf("stdout = %q, want %q", out, w)
+		}
+	}
+
+	bad := filepath.Join(t.TempDir(), "bad.csv")
+	if err := os.WriteFile(bad, []byte("invoice_number,amount\n2024-9,10\n2024-1,abc\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	err := Execute([]string{"invoices", "payments", "import", bad, "--api-base-url", srv.URL}, &stdout, &stderr)
+	if err == nil || !strings.Contains(err.Error(), `line 3: invalid amount "abc"`) {
+		t.Errorf("Execute() error = %v, want the invalid amount reported", err)
+	}
+}
+
+func TestParsePaymentImportRows(t *testing.T) {
+	colMap := map[string]int{"invoice_number": 0, "amount": 1}
+	tests := []struct {
+		amount  string
+		wantErr bool
+	}{
+		{amount: "120.50"},
+		{amount: "0", wantErr: true},
+		{amount: "-5", wantErr: true},
+		{amount: "abc", wantErr: true},
+		{amount: "NaN", wantErr: true},
+		{amount: "Inf", wantErr: true},
+		{amount: "-Inf", wantErr: true},
+	}
+	for _, tt := range tests {
+		records := []csvRecord{{LineNum: 2, fields: []string{"2024-1", tt.amount}, colMap: colMap}}
+		rows, err := parsePaymentImportRows(records)
+		if tt.wantErr {
+			if err == nil || !strings.Contains(err.Error(), fmt.Sprintf("line 2: invalid amount %q", tt.amount)) {
+				t.Errorf("parsePaymentImportRows(%q) error = %v, want invalid amount", tt.amount, err)
+			}
+			continue
+		}
+		if err != nil || len(rows) != 1 || rows[0].Input.Amount != 120.50 {
+			t.Errorf("parsePaymentImportRows(%q) = %+v, %v", tt.amount, rows, err)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dedene/harvest-cli/internal/ui"
+)
+
+// includeArchived makes name lookups treat archived records like active ones;
+// Execute sets it from --include-archived. lookupWarnings receives the note
+// printed when a lookup falls back to an archived record.
+var (
+	includeArchived bool
+	lookupWarnings  io.Writer = io.Discard
+)
+
+// pickMatch lets the user choose between the records an ambiguous name
+// matches. Execute sets it when stdin is a terminal; when nil, ambiguity is
+// an error listing the candidates.
+var pickMatch func(kind, input string, matches []namedRecord) (*namedRecord, error)
+
+// namedRecord is a project, client, task or expense category as seen by a
+// name lookup.
+type namedRecord struct {
+	ID     int64
+	Name   string
+	Code   string // matched exactly, like the name; projects only
+	Detail string // shown beside the name when picking, e.g. the client
+	Active bool
+}
+
+// lookupByName resolves input to the ID of one of records. Active records
+// are searched first and archived ones only when none match, with a warning;
+// --include-archived searches both together. Ambiguous names are handled as
+// matchName describes.
+func lookupByName(kind, input string, records []namedRecord) (int64, error) {
+	pools := [][]namedRecord{records}
+	if !includeArchived {
+		var active, archived []namedRecord
+		for _, r := range records {
+			if r.Active {
+				active = append(active, r)
+			} else {
+				archived = append(archived, r)
+			}
+		}
+		pools = [][]namedRecord{active, archived}
+	}
+
+	for i, pool := range pools {
+		rec, err := matchName(kind, input, pool)
+		if err != nil {
+			return 0, err
+		}
+		if rec == nil {
+			continue
+		}
+		if i > 0 {
+			fmt.Fprintf(lookupWarnings, "Warning: using archived %s %s (#%d); pass --include-archived to silence this\n", kind, rec.Name, rec.ID)
+		}
+		return rec.ID, nil
+	}
+	return 0, fmt.Errorf("%s not found: %s", kind, input)
+}
+
+// matchName finds the record named input, ignoring case. Exact name or code
+// matches win over names containing input; several matches at the same level
+// are ambiguous and go to pickMatch, or are an error without a terminal. It
+// returns nil when nothing matches.
+func matchName(kind, input string, records []namedRecord) (*namedRecord, error) {
+	lower := strings.ToLower(input)
+	var exact, partial []namedRecord
+	for _, r := range records {
+		name := strings.ToLower(r.Name)
+		switch {
+		case name == lower || (r.Code != "" && strings.EqualFold(r.Code, input)):
+			exact = append(exact, r)
+		case strings.Contains(name, lower):
+			partial = append(partial, r)
+		}
+	}
+
+	for _, matches := range [][]namedRecord{exact, partial} {
+		switch len(matches) {
+		case 0:
+			continue
+		case 1:
+			return &matches[0], nil
+		}
+		if pickMatch != nil {
+			return pickMatch(kind, input, matches)
+		}
+		candidates := make([]string, len(matches))
+		for i, m := range matches {
+			candidates[i] = fmt.Sprintf("%s (#%d)", m.Name, m.ID)
+		}
+		return nil, fmt.Errorf("%s %q is ambiguous, it matches: %s; use the ID or a more specific name",
+			kind, input, strings.Join(candidates, ", "))
+	}
+	return nil, nil
+}
+
+// pickNamedRecord shows a picker over the ambiguous matches.
+func pickNamedRecord(kind, input string, matches []namedRecord) (*namedRecord, error) {
+	title := fmt.Sprintf("Which %s did you mean by %q?", kind, input)
+	if kind == "project" {
+		items := make([]ui.ProjectItem, len(matches))
+		for i, m := range matches {
+			items[i] = ui.ProjectItem{ProjectID: m.ID, ProjectName: m.Name, ClientName: m.Detail, Code: m.Code}
+		}
+		selected, err := ui.PickProject(title, items)
+		if err != nil {
+			return nil, err
+		}
+		if selected == nil {
+			return nil, ui.ErrCanceled
+		}
+		return recordByID(matches, selected.ProjectID), nil
+	}
+
+	items := make([]ui.PickerItem, len(matches))
+	for i, m := range matches {
+		items[i] = ui.NamedItem{ItemID: m.ID, Name: m.Name, Detail: m.Detail}
+	}
+	selected, err := ui.NewPicker(title, items).Run()
+	if err != nil {
+		return nil, err
+	}
+	if selected == nil {
+		return nil, ui.ErrCanceled
+	}
+	return recordByID(matches, selected.ID()), nil
+}
+
+// recordByID returns the record with the given ID.
+func recordByID(records []namedRecord, id int64) *namedRecord {
+	for i := range records {
+		if records[i].ID == id {
+			return &records[i]
+		}
+	}
+	return nil
+}
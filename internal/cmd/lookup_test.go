@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/oauth2"
+
+	"github.com/dedene/harvest-cli/internal/api"
+)
+
+func TestLookupByName(t *testing.T) {
+	records := []namedRecord{
+		{ID: 1, Name: "Website Redesign", Code: "WEB", Active: true},
+		{ID: 2, Name: "Website Hosting", Active: true},
+		{ID: 3, Name: "Mobile App", Active: true},
+		{ID: 4, Name: "Mobile App", Active: false},
+		{ID: 5, Name: "Legacy Portal", Active: false},
+		{ID: 6, Name: "Legacy Portal v2", Active: false},
+	}
+
+	tests := []struct {
+		input    string
+		archived bool
+		want     int64
+		wantErr  string
+		wantWarn bool
+	}{
+		{input: "website redesign", want: 1},
+		{input: "web", want: 1}, // code match beats the two names containing "web"
+		{input: "hosting", want: 2},
+		{input: "website", wantErr: "Website Redesign (#1), Website Hosting (#2)"},
+		{input: "MOBILE APP", want: 3}, // the active one wins
+		{input: "legacy portal", want: 5, wantWarn: true},
+		{input: "legacy", wantErr: "ambiguous"},
+		{input: "legacy portal", archived: true, want: 5},
+		{input: "mobile app", archived: true, wantErr: "Mobile App (#3), Mobile App (#4)"},
+		{input: "nothing", wantErr: "project not found: nothing"},
+	}
+	t.Cleanup(func() { includeArchived, lookupWarnings = false, io.Discard })
+	for _, tt := range tests {
+		var warn bytes.Buffer
+		includeArchived, lookupWarnings = tt.archived, &warn
+
+		got, err := lookupByName("project", tt.input, records)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("lookupByName(%q, archived=%t) error = %v, want %q", tt.input, tt.archived, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("lookupByName(%q, archived=%t) = %d, %v; want %d", tt.input, tt.archived, got, err, tt.want)
+		}
+		if hasWarn := strings.Contains(warn.String(), "archived project"); hasWarn != tt.wantWarn {
+			t.Errorf("lookupByName(%q, archived=%t) warning = %q", tt.input, tt.archived, warn.String())
+		}
+	}
+}
+
+func TestResolveByNameAmbiguity(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/projects":
+			_, _ = w.Write([]byte(`{"projects":[
+				{"id":1,"name":"Website","is_active":true},
+				{"id":2,"name":"Website Redesign","is_active":true},
+				{"id":3,"name":"Website Hosting","is_active":true},
+				{"id":4,"name":"Mobile App","is_active":true}],"total_pages":1,"page":1}`))
+		case "/clients":
+			_, _ = w.Write([]byte(`{"clients":[
+				{"id":10,"name":"Acme Corp","is_active":true},
+				{"id":11,"name":"Acme Labs","is_active":true},
+				{"id":12,"name":"Globex","is_active":true}],"total_pages":1,"page":1}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"})
+	client := api.NewClientWithBaseURL(ts, 12345, "test@example.com", srv.URL)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		resolve func(string) (int64, error)
+		input   string
+		want    int64
+		wantErr string
+	}{
+		{"project exact match wins", func(s string) (int64, error) { return resolveProjectID(ctx, client, s) }, "website", 1, ""},
+		{"project single partial", func(s string) (int64, error) { return resolveProjectID(ctx, client, s) }, "mobile", 4, ""},
+		{"project multi match", func(s string) (int64, error) { return resolveProjectID(ctx, client, s) }, "web", 0, "Website (#1), Website Redesign (#2), Website Hosting (#3)"},
+		{"client exact match wins", func(s string) (int64, error) { return resolveClientID(ctx, client, s) }, "GLOBEX", 12, ""},
+		{"client single partial", func(s string) (int64, error) { return resolveClientID(ctx, client, s) }, "labs", 11, ""},
+		{"client multi match", func(s string) (int64, error) { return resolveClientID(ctx, client, s) }, "acme", 0, `client "acme" is ambiguous`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.resolve(tt.input)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("got %d, %v; want %d", got, err, tt.want)
+			}
+		})
+	}
+
+	t.Run("picker chooses on a terminal", func(t *testing.T) {
+		var offered []int64
+		pickMatch = func(kind, input string, matches []namedRecord) (*namedRecord, error) {
+			for _, m := range matches {
+				offered = append(offered, m.ID)
+			}
+			return &matches[1], nil
+		}
+		t.Cleanup(func() { pickMatch = nil })
+
+		got, err := resolveClientID(ctx, client, "acme")
+		if err != nil || got != 11 {
+			t.Fatalf("resolveClientID() = %d, %v; want 11", got, err)
+		}
+		if len(offered) != 2 || offered[0] != 10 || offered[1] != 11 {
+			t.Errorf("picker offered %v, want [10 11]", offered)
+		}
+	})
+}
+
+func TestFindProjectAssignment(t *testing.T) {
+	assignment := func(id int64, name string) api.ProjectAssignment {
+		var a api.ProjectAssignment
+		a.IsActive = true
+		a.Project.ID = id
+		a.Project.Name = name
+		return a
+	}
+	assignments := []api.ProjectAssignment{
+		assignment(1, "Website"),
+		assignment(2, "Website Redesign"),
+		assignment(3, "Internal"),
+	}
+
+	tests := []struct {
+		search  string
+		want    int64
+		wantErr string
+	}{
+		{"2", 2, ""},
+		{"WEBSITE", 1, ""},
+		{"intern", 3, ""},
+		{"site", 0, "Website (#1), Website Redesign (#2)"},
+		{"mobile", 0, "project not found: mobile"},
+	}
+	for _, tt := range tests {
+		got, err := findProjectAssignment(assignments, tt.search)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("findProjectAssignment(%q) error = %v, want %q", tt.search, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got.Project.ID != tt.want {
+			t.Errorf("findProjectAssignment(%q) = %+v, %v; want project %d", tt.search, got, err, tt.want)
+		}
+	}
+}
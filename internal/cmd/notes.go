@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/dedene/harvest-cli/internal/config"
+)
+
+// readNotes returns the notes given with --notes, or read from the
+// --notes-file path ("-" reads stdin). Trailing newlines are dropped.
+func readNotes(cli *CLI, notes, file string) (string, error) {
+	if file == "" {
+		return notes, nil
+	}
+	if notes != "" {
+		return "", fmt.Errorf("--notes and --notes-file cannot be combined")
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+	if file == "-" {
+		data, err = io.ReadAll(cli.Stdin)
+	} else {
+		data, err = os.ReadFile(config.ExpandPath(file))
+	}
+	if err != nil {
+		return "", fmt.Errorf("read notes file: %w", err)
+	}
+
+	body := strings.TrimRight(string(data), "\r\n")
+	if strings.TrimSpace(body) == "" {
+		return "", fmt.Errorf("notes file %s is empty", file)
+	}
+	return body, nil
+}
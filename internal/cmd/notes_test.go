@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadNotes(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "notes.md")
+	if err := os.WriteFile(path, []byte("Thanks for your business.\n\nPayment via SEPA.\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	empty := filepath.Join(dir, "empty.md")
+	if err := os.WriteFile(empty, []byte("\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		notes   string
+		file    string
+		stdin   string
+		want    string
+		wantErr string
+	}{
+		{name: "inline", notes: "Net 30", want: "Net 30"},
+		{name: "file", file: path, want: "Thanks for your business.\n\nPayment via SEPA."},
+		{name: "stdin", file: "-", stdin: "From a pipe\n", want: "From a pipe"},
+		{name: "both", notes: "x", file: path, wantErr: "cannot be combined"},
+		{name: "missing", file: filepath.Join(dir, "nope.md"), wantErr: "read notes file"},
+		{name: "empty", file: empty, wantErr: "is empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli := &CLI{Stdin: strings.NewReader(tt.stdin)}
+			got, err := readNotes(cli, tt.notes, tt.file)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("readNotes() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readNotes() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("readNotes() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
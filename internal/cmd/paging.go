@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/dedene/harvest-cli/internal/api"
+)
+
+// PagingFlags control how list commands page through Harvest results.
+type PagingFlags struct {
+	PerPage  int `help:"Records per API page (1-100, default 100)" name:"per-page"`
+	MaxItems int `help:"Stop after this many records" name:"max-items"`
+}
+
+// validate checks the paging flags against the limits of the Harvest API.
+func (p PagingFlags) validate() error {
+	if p.PerPage != 0 && (p.PerPage < 1 || p.PerPage > api.DefaultPerPage) {
+		return fmt.Errorf("--per-page must be between 1 and %d", api.DefaultPerPage)
+	}
+	if p.MaxItems < 0 {
+		return fmt.Errorf("--max-items must not be negative")
+	}
+	return nil
+}
+
+// limitItems truncates items to max, for lists filtered after fetching:
+// those fetch without --max-items so the cap applies to what is shown.
+func limitItems[T any](items []T, max int) []T {
+	if max > 0 && len(items) > max {
+		return items[:max]
+	}
+	return items
+}
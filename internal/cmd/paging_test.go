@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dedene/harvest-cli/internal/auth"
+)
+
+func TestPagingFlagsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		flags   PagingFlags
+		wantErr bool
+	}{
+		{"defaults", PagingFlags{}, false},
+		{"min per page", PagingFlags{PerPage: 1}, false},
+		{"max per page", PagingFlags{PerPage: 100}, false},
+		{"per page too large", PagingFlags{PerPage: 101}, true},
+		{"negative per page", PagingFlags{PerPage: -5}, true},
+		{"max items", PagingFlags{MaxItems: 10}, false},
+		{"negative max items", PagingFlags{MaxItems: -1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.flags.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMaxItemsAfterClientFilter(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(auth.PATEnvToken, "pat")
+	t.Setenv(auth.PATEnvAccountID, "1")
+
+	var query string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		query = r.URL.RawQuery
+		_, _ = w.Write([]byte(`{"time_entries":[
+			{"id":1,"spent_date":"2024-05-01","hours":1,"billable":false,"project":{"id":1,"name":"Website"},"task":{"id":2,"name":"Design"}},
+			{"id":2,"spent_date":"2024-05-02","hours":2,"billable":true,"project":{"id":1,"name":"Website"},"task":{"id":2,"name":"Design"}},
+			{"id":3,"spent_date":"2024-05-03","hours":3,"billable":true,"project":{"id":1,"name":"Website"},"task":{"id":2,"name":"Design"}},
+			{"id":4,"spent_date":"2024-05-04","hours":4,"billable":true,"project":{"id":1,"name":"Website"},"task":{"id":2,"name":"Design"}}],
+			"total_pages":1,"page":1}`))
+	}))
+	defer srv.Close()
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"time", "list", "--billable", "--max-items", "2", "--plain", "--api-base-url", srv.URL}
+	if err := Execute(args, &stdout, &stderr); err != nil {
+		t.Fatalf("Execute() error = %v\n%s", err, stderr.String())
+	}
+	if strings.Contains(query, "per_page=2") {
+		t.Errorf("query = %q, want the cap applied after filtering, not to the request", query)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[1], "2\t") || !strings.HasPrefix(lines[2], "3\t") {
+		t.Errorf("output = %q, want billable entries 2 and 3", stdout.String())
+	}
+}
+
+func TestLimitItems(t *testing.T) {
+	items := []int{1, 2, 3}
+	if got := limitItems(items, 2); len(got) != 2 {
+		t.Errorf("limitItems(2) = %v", got)
+	}
+	if got := limitItems(items, 0); len(got) != 3 {
+		t.Errorf("limitItems(0) = %v, want no limit", got)
+	}
+	if got := limitItems(items, 5); len(got) != 3 {
+		t.Errorf("limitItems(5) = %v", got)
+	}
+}
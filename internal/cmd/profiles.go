@@ -0,0 +1,287 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/dedene/harvest-cli/internal/config"
+	"github.com/dedene/harvest-cli/internal/output"
+)
+
+// ConfigProfileCmd manages named profiles: bundles of account, default
+// project and task, output format and color.
+type ConfigProfileCmd struct {
+	List   ConfigProfileListCmd   `cmd:"" default:"1" help:"List profiles"`
+	Create ConfigProfileCreateCmd `cmd:"" help:"Create or replace a profile"`
+	Use    ConfigProfileUseCmd    `cmd:"" help:"Apply a profile to every run"`
+	Remove ConfigProfileRemoveCmd `cmd:"" help:"Delete a profile"`
+}
+
+// profileKeys are the settings a profile can hold, in display order.
+var profileKeys = []string{"account", "project", "task", "output", "color"}
+
+// outputFormats are the values of a profile's output setting and of the
+// default_output config setting.
+var outputFormats = []string{"table", "json", "plain", "csv", "markdown"}
+
+// ConfigProfileCreateCmd stores a profile from key=value settings.
+type ConfigProfileCreateCmd struct {
+	Name     string   `arg:"" help:"Profile name"`
+	Settings []string `arg:"" optional:"" help:"Settings as key=value: account, project, task, output (table, json, plain, csv, markdown), color (auto, always, never)"`
+	Force    bool     `help:"Replace an existing profile"`
+}
+
+func (c *ConfigProfileCreateCmd) Run(cli *CLI) error {
+	profile, err := parseProfileSettings(c.Settings)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+	if _, exists := cfg.Profiles[c.Name]; exists && !c.Force {
+		return fmt.Errorf("profile %q already exists; use --force to replace it", c.Name)
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = make(map[string]config.Profile)
+	}
+	cfg.Profiles[c.Name] = profile
+
+	if err := config.WriteConfig(cfg); err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+	fmt.Fprintf(cli.Stdout, "Saved profile %s\n", c.Name)
+	return nil
+}
+
+// parseProfileSettings builds a profile from key=value settings.
+func parseProfileSettings(settings []string) (config.Profile, error) {
+	var p config.Profile
+	for _, s := range settings {
+		key, value, ok := strings.Cut(s, "=")
+		if !ok {
+			return p, fmt.Errorf("invalid setting %q (want key=value)", s)
+		}
+		switch normalizeConfigKey(key) {
+		case "account":
+			p.Account = value
+		case "project":
+			p.Project = value
+		case "task":
+			p.Task = value
+		case "output":
+			if !slices.Contains(outputFormats, value) {
+				return p, fmt.Errorf("invalid output %q (use %s)", value, strings.Join(outputFormats, ", "))
+			}
+			p.Output = value
+		case "color":
+			if err := validateConfigValue("color", value); err != nil {
+				return p, err
+			}
+			p.Color = value
+		default:
+			return p, fmt.Errorf("unknown profile setting %q (use %s)", key, strings.Join(profileKeys, ", "))
+		}
+	}
+	return p, nil
+}
+
+// ConfigProfileUseCmd makes a profile the default for every run.
+type ConfigProfileUseCmd struct {
+	Name  string `arg:"" optional:"" help:"Profile name"`
+	Clear bool   `help:"Stop applying a profile by default"`
+}
+
+func (c *ConfigProfileUseCmd) Run(cli *CLI) error {
+	if (c.Name == "") == !c.Clear {
+		return fmt.Errorf("give a profile name or --clear")
+	}
+
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+	if c.Name != "" {
+		if _, ok := cfg.Profiles[c.Name]; !ok {
+			return unknownProfileError(cfg, c.Name)
+		}
+	}
+	cfg.ActiveProfile = c.Name
+
+	if err := config.WriteConfig(cfg); err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+	if c.Clear {
+		fmt.Fprintln(cli.Stdout, "No profile in use")
+		return nil
+	}
+	fmt.Fprintf(cli.Stdout, "Using profile %s\n", c.Name)
+	return nil
+}
+
+// ConfigProfileListCmd lists the stored profiles.
+type ConfigProfileListCmd struct{}
+
+// profileInfo is a profile as listed.
+type profileInfo struct {
+	Name   string `json:"name"`
+	Active bool   `json:"active"`
+	config.Profile
+}
+
+func (c *ConfigProfileListCmd) Run(cli *CLI) error {
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+
+	names := make([]string, 0, len(cfg.Profiles))
+	for name := range cfg.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	profiles := make([]profileInfo, len(names))
+	for i, name := range names {
+		profiles[i] = profileInfo{Name: name, Active: name == cfg.ActiveProfile, Profile: cfg.Profiles[name]}
+	}
+	return outputProfiles(cli.Stdout, profiles, output.ModeFromFlags(cli.JSON, cli.Plain))
+}
+
+// outputProfiles writes profiles in the specified format.
+func outputProfiles(w io.Writer, profiles []profileInfo, mode output.Mode) error {
+	switch mode {
+	case output.ModeJSON:
+		return output.WriteJSON(w, profiles)
+	case output.ModePlain:
+		headers := []string{"Name", "Active", "Account", "Project", "Task", "Output", "Color"}
+		rows := make([][]string, len(profiles))
+		for i, p := range profiles {
+			rows[i] = []string{p.Name, fmt.Sprintf("%t", p.Active), p.Account, p.Project, p.Task, p.Output, p.Color}
+		}
+		return output.WriteTSV(w, headers, rows)
+	default:
+		if len(profiles) == 0 {
+			fmt.Fprintln(w, "No profiles. Create one with: harvest config profile create <name> account=... output=...")
+			return nil
+		}
+		t := output.NewTable(w, "", "Name", "Account", "Project", "Task", "Output", "Color")
+		for _, p := range profiles {
+			mark := ""
+			if p.Active {
+				mark = "*"
+			}
+			t.AddRow(mark, p.Name, orDash(p.Account), orDash(p.Project), orDash(p.Task), orDash(p.Output), orDash(p.Color))
+		}
+		return t.Render()
+	}
+}
+
+// ConfigProfileRemoveCmd deletes a profile.
+type ConfigProfileRemoveCmd struct {
+	Name string `arg:"" help:"Profile name"`
+}
+
+func (c *ConfigProfileRemoveCmd) Run(cli *CLI) error {
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+	if _, ok := cfg.Profiles[c.Name]; !ok {
+		return unknownProfileError(cfg, c.Name)
+	}
+	delete(cfg.Profiles, c.Name)
+	if cfg.ActiveProfile == c.Name {
+		cfg.ActiveProfile = ""
+	}
+
+	if err := config.WriteConfig(cfg); err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+	fmt.Fprintf(cli.Stdout, "Removed profile %s\n", c.Name)
+	return nil
+}
+
+func unknownProfileError(cfg *config.File, name string) error {
+	if len(cfg.Profiles) == 0 {
+		return fmt.Errorf("unknown profile %q; no profiles are defined", name)
+	}
+	names := make([]string, 0, len(cfg.Profiles))
+	for n := range cfg.Profiles {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return fmt.Errorf("unknown profile %q (available: %s)", name, strings.Join(names, ", "))
+}
+
+// applyProfile fills in the settings of the --profile profile, or else the
+// active one, that were not given as flags. An unknown --profile is a usage
+// error; a stale active_profile is reported and ignored so config commands
+// keep working. The profile's output format is applied by defaultOutputMode.
+func applyProfile(cli *CLI) error {
+	cli.profile = nil
+
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		if cli.Profile != "" {
+			return fmt.Errorf("read config: %w", err)
+		}
+		return nil
+	}
+	name := cli.Profile
+	if name == "" {
+		name = cfg.ActiveProfile
+	}
+	if name == "" {
+		return nil
+	}
+	profile, ok := cfg.Profiles[name]
+	if !ok {
+		if cli.Profile != "" {
+			return &ExitError{Code: 2, Err: unknownProfileError(cfg, name)}
+		}
+		fmt.Fprintf(cli.Stderr, "Warning: active profile %q does not exist; ignoring it\n", name)
+		return nil
+	}
+	cli.profile = &profile
+
+	// HARVEST_ACCOUNT is as explicit as --account.
+	if profile.Account != "" && cli.Account == "" && os.Getenv(config.EnvAccount) == "" {
+		cli.Account = profile.Account
+	}
+	if profile.Color != "" && cli.Color == "auto" && !cli.NoColor {
+		cli.Color = profile.Color
+	}
+	return nil
+}
+
+// defaultOutputMode returns the output mode used when no --json or --plain
+// flag is given: --markdown, --table or --csv, then the profile's output,
+// then the default_output config setting, then a table.
+func defaultOutputMode(cli *CLI) output.Mode {
+	switch {
+	case cli.Markdown:
+		return output.ModeMarkdown
+	case cli.Table:
+		return output.ModeTable
+	case cli.CSV:
+		return output.ModeCSV
+	}
+	format := ""
+	if cli.profile != nil {
+		format = cli.profile.Output
+	}
+	if format == "" {
+		if cfg, err := config.ReadConfig(); err == nil {
+			format = cfg.DefaultOutput
+		}
+	}
+	mode, _ := output.ParseMode(format)
+	return mode
+}
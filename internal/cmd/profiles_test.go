@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/dedene/harvest-cli/internal/config"
+	"github.com/dedene/harvest-cli/internal/output"
+)
+
+func TestParseProfileSettings(t *testing.T) {
+	got, err := parseProfileSettings([]string{"account=work", "project=Website", "task=Design", "output=json", "color=never"})
+	if err != nil {
+		t.Fatalf("parseProfileSettings() error = %v", err)
+	}
+	want := config.Profile{Account: "work", Project: "Website", Task: "Design", Output: "json", Color: "never"}
+	if got != want {
+		t.Errorf("parseProfileSettings() = %+v, want %+v", got, want)
+	}
+
+	for _, bad := range []string{"account", "output=xml", "color=red", "week_start=monday"} {
+		if _, err := parseProfileSettings([]string{bad}); err == nil {
+			t.Errorf("parseProfileSettings(%q) should fail", bad)
+		}
+	}
+}
+
+func TestApplyProfile(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(config.EnvAccount, "")
+
+	cfg := &config.File{
+		ActiveProfile: "work",
+		Profiles: map[string]config.Profile{
+			"work":     {Account: "work@example.com", Output: "plain", Color: "never"},
+			"personal": {Account: "me@example.com", Output: "json"},
+		},
+	}
+	if err := config.WriteConfig(cfg); err != nil {
+		t.Fatalf("WriteConfig() error = %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		flags       RootFlags
+		wantAccount string
+		wantMode    output.Mode
+		wantColor   string
+	}{
+		{name: "active profile", flags: RootFlags{Color: "auto"}, wantAccount: "work@example.com", wantMode: output.ModePlain, wantColor: "never"},
+		{name: "--profile wins", flags: RootFlags{Profile: "personal", Color: "auto"}, wantAccount: "me@example.com", wantMode: output.ModeJSON, wantColor: "auto"},
+		{name: "flags win", flags: RootFlags{Account: "other", Table: true, Color: "always"}, wantAccount: "other", wantMode: output.ModeTable, wantColor: "always"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli := &CLI{RootFlags: tt.flags}
+			if err := applyProfile(cli); err != nil {
+				t.Fatalf("applyProfile() error = %v", err)
+			}
+			mode := runOutputMode(t, cli)
+			if cli.Account != tt.wantAccount || mode != tt.wantMode || cli.Color != tt.wantColor {
+				t.Errorf("flags = account %q, mode %v, color %q; want %q, %v, %q",
+					cli.Account, mode, cli.Color, tt.wantAccount, tt.wantMode, tt.wantColor)
+			}
+		})
+	}
+
+	cli := &CLI{RootFlags: RootFlags{Profile: "nope"}}
+	if err := applyProfile(cli); err == nil || !strings.Contains(err.Error(), "available: personal, work") {
+		t.Errorf("applyProfile() error = %v, want unknown profile", err)
+	}
+}
+
+func TestApplyProfile_DefaultOutput(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	cfg := &config.File{
+		DefaultOutput: "json",
+		Profiles:      map[string]config.Profile{"plain": {Output: "plain"}, "bare": {Account: "me@example.com"}},
+	}
+	if err := config.WriteConfig(cfg); err != nil {
+		t.Fatalf("WriteConfig() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		flags RootFlags
+		want  output.Mode
+	}{
+		{name: "config default", flags: RootFlags{}, want: output.ModeJSON},
+		{name: "--plain wins", flags: RootFlags{Plain: true}, want: output.ModePlain},
+		{name: "--table wins", flags: RootFlags{Table: true}, want: output.ModeTable},
+		{name: "--markdown wins", flags: RootFlags{Markdown: true}, want: output.ModeMarkdown},
+		{name: "--csv writes plain output", flags: RootFlags{CSV: true}, want: output.ModePlain},
+		{name: "profile output wins", flags: RootFlags{Profile: "plain"}, want: output.ModePlain},
+		{name: "profile without output", flags: RootFlags{Profile: "bare"}, want: output.ModeJSON},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli := &CLI{RootFlags: tt.flags}
+			if err := applyProfile(cli); err != nil {
+				t.Fatalf("applyProfile() error = %v", err)
+			}
+			if mode := runOutputMode(t, cli); mode != tt.want {
+				t.Errorf("mode = %v, want %v", mode, tt.want)
+			}
+			if cli.JSON || (cli.Plain && !tt.flags.Plain) {
+				t.Error("the default output format should not set --json or --plain")
+			}
+		})
+	}
+}
+
+// runOutputMode returns the output mode a run with cli's flags uses.
+func runOutputMode(t *testing.T, cli *CLI) output.Mode {
+	t.Helper()
+	output.SetDefaultMode(defaultOutputMode(cli))
+	t.Cleanup(func() { output.SetDefaultMode(output.ModeTable) })
+	return output.ModeFromFlags(cli.JSON, cli.Plain)
+}
+
+func TestConfigProfileCommands(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	run := func(args ...string) (string, error) {
+		var stdout, stderr bytes.Buffer
+		err := Execute(args, &stdout, &stderr)
+		return stdout.String(), err
+	}
+
+	if _, err := run("config", "profile", "create", "work", "output=json", "project=Website"); err != nil {
+		t.Fatalf("create error = %v", err)
+	}
+	if _, err := run("config", "profile", "create", "work", "output=plain"); err == nil {
+		t.Error("create should refuse to replace an existing profile without --force")
+	}
+	if _, err := run("config", "profile", "use", "work"); err != nil {
+		t.Fatalf("use error = %v", err)
+	}
+
+	out, err := run("version")
+	if err != nil {
+		t.Fatalf("version error = %v", err)
+	}
+	if !strings.HasPrefix(out, "{") {
+		t.Errorf("version output = %q, want JSON from the active profile", out)
+	}
+
+	if _, err := run("config", "profile", "remove", "work"); err != nil {
+		t.Fatalf("remove error = %v", err)
+	}
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		t.Fatalf("ReadConfig() error = %v", err)
+	}
+	if cfg.ActiveProfile != "" || len(cfg.Profiles) != 0 {
+		t.Errorf("config = %+v, want the profile removed and no active profile", cfg)
+	}
+}
@@ -4,7 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"os"
+	"math"
 	"strconv"
 
 	"github.com/dedene/harvest-cli/internal/api"
@@ -15,11 +15,13 @@ import (
 
 // ProjectsCmd groups project subcommands.
 type ProjectsCmd struct {
-	List   ProjectsListCmd   `cmd:"" help:"List all projects"`
-	Show   ProjectsShowCmd   `cmd:"" help:"Show a project"`
-	Add    ProjectsAddCmd    `cmd:"" help:"Create a project"`
-	Edit   ProjectsEditCmd   `cmd:"" help:"Update a project"`
-	Remove ProjectsRemoveCmd `cmd:"" help:"Delete a project"`
+	List      ProjectsListCmd      `cmd:"" help:"List all projects"`
+	Show      ProjectsShowCmd      `cmd:"" help:"Show a project"`
+	Add       ProjectsAddCmd       `cmd:"" help:"Create a project"`
+	Edit      ProjectsEditCmd      `cmd:"" help:"Update a project"`
+	Remove    ProjectsRemoveCmd    `cmd:"" help:"Delete a project"`
+	Archive   ProjectsArchiveCmd   `cmd:"" help:"Deactivate a project"`
+	Unarchive ProjectsUnarchiveCmd `cmd:"" help:"Reactivate an archived project"`
 }
 
 // ProjectsListCmd lists projects with filters.
@@ -27,11 +29,30 @@ type ProjectsListCmd struct {
 	Active        string `help:"Filter by active status: true, false, all" default:"all" enum:"true,false,all"`
 	HarvestClient string `help:"Filter by client ID or name" name:"harvest-client" short:"c"`
 	UpdatedSince  string `help:"Filter by updated since date"`
+	HasBudget     bool   `help:"Only projects with a budget" name:"has-budget"`
+	OverBudget    bool   `help:"Only projects that have spent more than their budget, with utilization (one extra reports API call)" name:"over-budget"`
+	NDJSON        bool   `help:"Output one JSON object per line" name:"ndjson"`
+	PagingFlags   `embed:""`
+}
+
+// projectBudget is a project joined with its budget report row, as listed
+// by projects list --over-budget.
+type projectBudget struct {
+	api.Project
+	BudgetSpent       float64 `json:"budget_spent"`
+	BudgetRemaining   float64 `json:"budget_remaining"`
+	BudgetUsedPercent float64 `json:"budget_used_percent"`
+
+	report api.ProjectBudgetReportResult
 }
 
 func (c *ProjectsListCmd) Run(cli *CLI) error {
+	if err := c.PagingFlags.validate(); err != nil {
+		return err
+	}
+
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -64,32 +85,167 @@ func (c *ProjectsListCmd) Run(cli *CLI) error {
 		opts.UpdatedSince = t.Format("2006-01-02T15:04:05Z")
 	}
 
+	opts.PerPage = c.PerPage
+	if !c.HasBudget && !c.OverBudget {
+		// Budget filters run here, so they fetch everything and limit after
+		opts.MaxItems = c.MaxItems
+	}
 	projects, err := client.ListAllProjects(ctx, opts)
 	if err != nil {
 		return fmt.Errorf("list projects: %w", err)
 	}
 
-	return outputProjects(os.Stdout, projects, output.ModeFromFlags(cli.JSON, cli.Plain))
+	if c.HasBudget {
+		projects = filterProjectsWithBudget(projects)
+	}
+
+	mode := output.ModeFromFlags(cli.JSON, cli.Plain)
+	if c.OverBudget {
+		// Spent amounts are only in the budget report, so join on project ID.
+		report, err := client.ListAllProjectBudgetReport(ctx, api.ProjectBudgetReportOptions{IsActive: opts.IsActive})
+		if err != nil {
+			return fmt.Errorf("get budget report: %w", err)
+		}
+		if warn := client.WarnIfNearReportsLimit(); warn != "" {
+			fmt.Fprintln(cli.Stderr, warn)
+		}
+
+		over := limitItems(overBudgetProjects(projects, report), c.MaxItems)
+		if c.NDJSON {
+			return output.WriteNDJSON(cli.Stdout, over)
+		}
+		loadCurrencyFormat(ctx, cli, client)
+		return outputProjectBudgets(cli.Stdout, over, mode)
+	}
+
+	projects = limitItems(projects, c.MaxItems)
+	if c.NDJSON {
+		return output.WriteNDJSON(cli.Stdout, projects)
+	}
+
+	return outputProjects(cli.Stdout, projects, mode)
+}
+
+// filterProjectsWithBudget keeps projects with an hours or cost budget.
+func filterProjectsWithBudget(projects []api.Project) []api.Project {
+	filtered := make([]api.Project, 0, len(projects))
+	for _, p := range projects {
+		if p.Budget != nil || p.CostBudget != nil {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered
+}
+
+// overBudgetProjects joins projects with budget report rows and keeps
+// those that have spent more than their budget, in project order.
+func overBudgetProjects(projects []api.Project, report []api.ProjectBudgetReportResult) []projectBudget {
+	byID := make(map[int64]api.ProjectBudgetReportResult, len(report))
+	for _, r := range report {
+		byID[r.ProjectID] = r
+	}
+
+	over := []projectBudget{}
+	for _, p := range projects {
+		r, ok := byID[p.ID]
+		if !ok || r.BudgetRemaining >= 0 {
+			continue
+		}
+		used, ok := budgetUsedPercent(r)
+		if !ok {
+			continue
+		}
+		over = append(over, projectBudget{
+			Project:           p,
+			BudgetSpent:       r.BudgetSpent,
+			BudgetRemaining:   r.BudgetRemaining,
+			BudgetUsedPercent: math.Round(used*10) / 10,
+			report:            r,
+		})
+	}
+	return over
 }
 
 // ProjectsShowCmd shows a single project.
 type ProjectsShowCmd struct {
-	ID int64 `arg:"" help:"Project ID"`
+	ID        int64 `arg:"" help:"Project ID"`
+	WithTasks bool  `help:"Include the project's task assignments" name:"with-tasks"`
+	WithUsers bool  `help:"Include the project's user assignments" name:"with-users"`
+}
+
+// projectDetail is a project with its assignments, as shown by
+// projects show --with-tasks/--with-users.
+type projectDetail struct {
+	*api.Project
+	TaskAssignments []api.TaskAssignment `json:"task_assignments,omitempty"`
+	UserAssignments []api.UserAssignment `json:"user_assignments,omitempty"`
 }
 
 func (c *ProjectsShowCmd) Run(cli *CLI) error {
+	mode := output.ModeFromFlags(cli.JSON, cli.Plain)
+	if mode == output.ModePlain && (c.WithTasks || c.WithUsers) {
+		// A project and its assignments have different columns, which
+		// do not fit in one TSV
+		return fmt.Errorf("--with-tasks and --with-users cannot be combined with --plain; use --json")
+	}
+
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
 
-	project, err := client.GetProject(ctx, c.ID)
-	if err != nil {
-		return fmt.Errorf("get project: %w", err)
+	if !c.WithTasks && !c.WithUsers {
+		project, err := client.GetProject(ctx, c.ID)
+		if err != nil {
+			return fmt.Errorf("get project: %w", err)
+		}
+		return outputProject(cli.Stdout, project, mode)
+	}
+
+	// The project and its assignments are independent: fetch them together
+	var detail projectDetail
+	fetches := []func(context.Context) error{
+		func(ctx context.Context) error {
+			project, err := client.GetProject(ctx, c.ID)
+			if err != nil {
+				return fmt.Errorf("get project: %w", err)
+			}
+			detail.Project = project
+			return nil
+		},
+	}
+	if c.WithTasks {
+		fetches = append(fetches, func(ctx context.Context) error {
+			tasks, err := client.ListAllTaskAssignments(ctx, c.ID, api.AssignmentListOptions{})
+			if err != nil {
+				return fmt.Errorf("list task assignments: %w", err)
+			}
+			detail.TaskAssignments = tasks
+			if tasks == nil {
+				detail.TaskAssignments = []api.TaskAssignment{}
+			}
+			return nil
+		})
+	}
+	if c.WithUsers {
+		fetches = append(fetches, func(ctx context.Context) error {
+			users, err := client.ListAllUserAssignments(ctx, c.ID, api.AssignmentListOptions{})
+			if err != nil {
+				return fmt.Errorf("list user assignments: %w", err)
+			}
+			detail.UserAssignments = users
+			if users == nil {
+				detail.UserAssignments = []api.UserAssignment{}
+			}
+			return nil
+		})
+	}
+	if err := fetchAll(ctx, fetches...); err != nil {
+		return err
 	}
 
-	return outputProject(os.Stdout, project, output.ModeFromFlags(cli.JSON, cli.Plain))
+	return outputProjectDetail(cli.Stdout, detail, mode)
 }
 
 // ProjectsAddCmd creates a new project.
@@ -110,7 +266,7 @@ type ProjectsAddCmd struct {
 
 func (c *ProjectsAddCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -178,11 +334,11 @@ func (c *ProjectsAddCmd) Run(cli *CLI) error {
 		return fmt.Errorf("create project: %w", err)
 	}
 
-	if cli.JSON {
-		return output.WriteJSON(os.Stdout, project)
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, project)
 	}
 
-	fmt.Fprintf(os.Stdout, "Created project #%d: %s\n", project.ID, project.Name)
+	printSuccess(cli, project.ID, "Created project #%d: %s\n", project.ID, project.Name)
 	return nil
 }
 
@@ -206,7 +362,7 @@ type ProjectsEditCmd struct {
 
 func (c *ProjectsEditCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -305,11 +461,11 @@ func (c *ProjectsEditCmd) Run(cli *CLI) error {
 		return fmt.Errorf("update project: %w", err)
 	}
 
-	if cli.JSON {
-		return output.WriteJSON(os.Stdout, project)
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, project)
 	}
 
-	fmt.Fprintf(os.Stdout, "Updated project #%d: %s\n", project.ID, project.Name)
+	printSuccess(cli, project.ID, "Updated project #%d: %s\n", project.ID, project.Name)
 	return nil
 }
 
@@ -321,7 +477,7 @@ type ProjectsRemoveCmd struct {
 
 func (c *ProjectsRemoveCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -337,13 +493,13 @@ func (c *ProjectsRemoveCmd) Run(cli *CLI) error {
 		confirmed, err := ui.ConfirmPrompt(msg)
 		if err != nil {
 			if err == ui.ErrCanceled {
-				fmt.Fprintln(os.Stderr, "Canceled")
+				fmt.Fprintln(cli.Stderr, "Canceled")
 				return nil
 			}
 			return err
 		}
 		if !confirmed {
-			fmt.Fprintln(os.Stderr, "Aborted")
+			fmt.Fprintln(cli.Stderr, "Aborted")
 			return nil
 		}
 	}
@@ -352,7 +508,49 @@ func (c *ProjectsRemoveCmd) Run(cli *CLI) error {
 		return fmt.Errorf("delete project: %w", err)
 	}
 
-	fmt.Fprintf(os.Stdout, "Deleted project #%d\n", c.ID)
+	printSuccess(cli, 0, "Deleted project #%d\n", c.ID)
+	return nil
+}
+
+// ProjectsArchiveCmd deactivates a project.
+type ProjectsArchiveCmd struct {
+	ID int64 `arg:"" help:"Project ID"`
+}
+
+func (c *ProjectsArchiveCmd) Run(cli *CLI) error {
+	return setProjectActive(cli, c.ID, false)
+}
+
+// ProjectsUnarchiveCmd reactivates an archived project.
+type ProjectsUnarchiveCmd struct {
+	ID int64 `arg:"" help:"Project ID"`
+}
+
+func (c *ProjectsUnarchiveCmd) Run(cli *CLI) error {
+	return setProjectActive(cli, c.ID, true)
+}
+
+// setProjectActive archives or unarchives a project by updating only is_active.
+func setProjectActive(cli *CLI, id int64, active bool) error {
+	ctx := context.Background()
+	client, err := NewClientFromFlags(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	project, err := client.UpdateProject(ctx, id, &api.ProjectInput{IsActive: &active})
+	if err != nil {
+		return fmt.Errorf("update project: %w", err)
+	}
+	if cli.DryRun {
+		return nil
+	}
+
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, project)
+	}
+
+	printSuccess(cli, project.ID, "%s project #%d: %s\n", archiveVerb(active), project.ID, project.Name)
 	return nil
 }
 
@@ -399,15 +597,68 @@ func outputProjects(w io.Writer, projects []api.Project, mode output.Mode) error
 	}
 }
 
+// archiveVerb describes an is_active change in success messages.
+func archiveVerb(active bool) string {
+	if active {
+		return "Unarchived"
+	}
+	return "Archived"
+}
+
+// outputProjectBudgets writes over-budget projects with their budget
+// utilization in the specified format.
+func outputProjectBudgets(w io.Writer, projects []projectBudget, mode output.Mode) error {
+	switch mode {
+	case output.ModeJSON:
+		return output.WriteJSON(w, projects)
+	case output.ModePlain:
+		headers := []string{"ID", "Name", "Client", "BudgetBy", "Budget", "Spent", "Remaining", "PercentUsed"}
+		rows := make([][]string, len(projects))
+		for i, p := range projects {
+			rows[i] = []string{
+				strconv.FormatInt(p.ID, 10),
+				p.Name,
+				p.Client.Name,
+				p.report.BudgetBy,
+				fmt.Sprintf("%.2f", *p.report.Budget),
+				fmt.Sprintf("%.2f", p.BudgetSpent),
+				fmt.Sprintf("%.2f", p.BudgetRemaining),
+				fmt.Sprintf("%.1f", p.BudgetUsedPercent),
+			}
+		}
+		return output.WriteTSV(w, headers, rows)
+	default:
+		if len(projects) == 0 {
+			fmt.Fprintln(w, "No projects over budget")
+			return nil
+		}
+		t := output.NewTable(w, "ID", "Name", "Client", "Budget", "Spent", "Remaining", "% Used").SetAlign(output.AlignRight, 3, 4, 5, 6)
+		for _, p := range projects {
+			currency := p.Client.Currency
+			t.AddRow(
+				strconv.FormatInt(p.ID, 10),
+				p.Name,
+				p.Client.Name,
+				formatBudgetValue(*p.report.Budget, p.report.BudgetBy, currency),
+				formatBudgetValue(p.BudgetSpent, p.report.BudgetBy, currency),
+				formatBudgetValue(p.BudgetRemaining, p.report.BudgetBy, currency),
+				fmt.Sprintf("%.0f%%", p.BudgetUsedPercent),
+			)
+		}
+		return t.Render()
+	}
+}
+
 // outputProject writes a single project in the specified format.
 func outputProject(w io.Writer, project *api.Project, mode output.Mode) error {
 	switch mode {
 	case output.ModeJSON:
 		return output.WriteJSON(w, project)
 	case output.ModePlain:
-		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%t\t%t\n",
-			project.ID, project.Name, project.Client.Name, project.Code, project.IsActive, project.IsBillable)
-		return nil
+		return output.WriteTSV(w, nil, [][]string{{
+			strconv.FormatInt(project.ID, 10), project.Name, project.Client.Name, project.Code,
+			strconv.FormatBool(project.IsActive), strconv.FormatBool(project.IsBillable),
+		}})
 	default:
 		fmt.Fprintf(w, "ID:       %d\n", project.ID)
 		fmt.Fprintf(w, "Name:     %s\n", project.Name)
@@ -429,11 +680,78 @@ func outputProject(w io.Writer, project *api.Project, mode output.Mode) error {
 			fmt.Fprintf(w, "Notes:    %s\n", project.Notes)
 		}
 		if project.StartsOn != nil {
-			fmt.Fprintf(w, "Starts:   %s\n", *project.StartsOn)
+			fmt.Fprintf(w, "Starts:   %s\n", output.FormatDate(*project.StartsOn))
 		}
 		if project.EndsOn != nil {
-			fmt.Fprintf(w, "Ends:     %s\n", *project.EndsOn)
+			fmt.Fprintf(w, "Ends:     %s\n", output.FormatDate(*project.EndsOn))
+		}
+		return nil
+	}
+}
+
+// outputProjectDetail writes a project followed by its task and user
+// assignments. JSON nests the assignments in the project object; table
+// output adds one section per kind of assignment. Plain output is not
+// supported, as the sections do not fit in one TSV.
+func outputProjectDetail(w io.Writer, detail projectDetail, mode output.Mode) error {
+	switch mode {
+	case output.ModeJSON:
+		return output.WriteJSON(w, detail)
+	case output.ModePlain:
+		return fmt.Errorf("project assignments have no plain output; use --json")
+	}
+	if err := outputProject(w, detail.Project, mode); err != nil {
+		return err
+	}
+
+	if detail.TaskAssignments != nil {
+		headers := []string{"Task ID", "Task", "Billable", "Active", "Hourly Rate"}
+		rows := make([][]string, len(detail.TaskAssignments))
+		for i, ta := range detail.TaskAssignments {
+			rows[i] = []string{
+				strconv.FormatInt(ta.Task.ID, 10),
+				ta.Task.Name,
+				yesNo(ta.Billable),
+				yesNo(ta.IsActive),
+				formatRate(ta.HourlyRate),
+			}
+		}
+		if err := writeProjectSection(w, "Tasks", headers, rows); err != nil {
+			return err
 		}
+	}
+
+	if detail.UserAssignments != nil {
+		headers := []string{"User ID", "User", "Manager", "Active", "Hourly Rate"}
+		rows := make([][]string, len(detail.UserAssignments))
+		for i, ua := range detail.UserAssignments {
+			rows[i] = []string{
+				strconv.FormatInt(ua.User.ID, 10),
+				ua.User.Name,
+				yesNo(ua.IsProjectManager),
+				yesNo(ua.IsActive),
+				formatRate(ua.HourlyRate),
+			}
+		}
+		if err := writeProjectSection(w, "Users", headers, rows); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeProjectSection writes one titled block of assignments below a
+// project, separated by a blank line.
+func writeProjectSection(w io.Writer, title string, headers []string, rows [][]string) error {
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%s:\n", title)
+	if len(rows) == 0 {
+		fmt.Fprintln(w, "  (none)")
 		return nil
 	}
+	t := output.NewTable(w, headers...)
+	for _, row := range rows {
+		t.AddRow(row...)
+	}
+	return t.Render()
 }
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dedene/harvest-cli/internal/api"
+	"github.com/dedene/harvest-cli/internal/auth"
+	"github.com/dedene/harvest-cli/internal/output"
+)
+
+func TestOutputProjectDetail_JSONNestsAssignments(t *testing.T) {
+	detail := projectDetail{
+		Project:         &api.Project{ID: 42, Name: "Website"},
+		TaskAssignments: []api.TaskAssignment{{ID: 1, Task: api.TaskRef{ID: 10, Name: "Design"}}},
+	}
+
+	var buf bytes.Buffer
+	if err := outputProjectDetail(&buf, detail, output.ModeJSON); err != nil {
+		t.Fatalf("outputProjectDetail() error = %v", err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if got["id"] != float64(42) || got["name"] != "Website" {
+		t.Errorf("project fields missing from top level: %v", got)
+	}
+	tasks, ok := got["task_assignments"].([]any)
+	if !ok || len(tasks) != 1 {
+		t.Errorf("task_assignments = %v, want 1 assignment", got["task_assignments"])
+	}
+	if _, ok := got["user_assignments"]; ok {
+		t.Error("user_assignments should be omitted when not requested")
+	}
+}
+
+func TestOutputProjectDetail_Table(t *testing.T) {
+	rate := 95.0
+	detail := projectDetail{
+		Project:         &api.Project{ID: 42, Name: "Website"},
+		TaskAssignments: []api.TaskAssignment{},
+		UserAssignments: []api.UserAssignment{{IsActive: true, HourlyRate: &rate, User: api.UserRef{ID: 7, Name: "Ada"}}},
+	}
+
+	var buf bytes.Buffer
+	if err := outputProjectDetail(&buf, detail, output.ModeTable); err != nil {
+		t.Fatalf("outputProjectDetail() error = %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"Name:     Website", "Tasks:\n  (none)", "Users:", "Ada", "95.00"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestProjectsShow_PlainWithAssignments(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(auth.PATEnvToken, "pat")
+	t.Setenv(auth.PATEnvAccountID, "1")
+
+	for _, flag := range []string{"--with-tasks", "--with-users"} {
+		var stdout, stderr bytes.Buffer
+		err := Execute([]string{"projects", "show", "7", flag, "--plain"}, &stdout, &stderr)
+		if err == nil || !strings.Contains(err.Error(), "cannot be combined with --plain") {
+			t.Errorf("Execute(%s --plain) error = %v, want the combination rejected", flag, err)
+		}
+		if stdout.Len() != 0 {
+			t.Errorf("Execute(%s --plain) wrote %q, want nothing", flag, stdout.String())
+		}
+	}
+}
+
+func TestFilterProjectsWithBudget(t *testing.T) {
+	hours, cost := 100.0, 5000.0
+	projects := []api.Project{
+		{ID: 1, Budget: &hours},
+		{ID: 2},
+		{ID: 3, CostBudget: &cost},
+	}
+
+	got := filterProjectsWithBudget(projects)
+	if len(got) != 2 || got[0].ID != 1 || got[1].ID != 3 {
+		t.Errorf("filterProjectsWithBudget() = %v, want projects 1 and 3", got)
+	}
+}
+
+func TestOverBudgetProjects(t *testing.T) {
+	budget := 100.0
+	projects := []api.Project{{ID: 1, Name: "Over"}, {ID: 2, Name: "Under"}, {ID: 3, Name: "No report"}}
+	report := []api.ProjectBudgetReportResult{
+		{ProjectID: 2, Budget: &budget, BudgetSpent: 40, BudgetRemaining: 60},
+		{ProjectID: 1, Budget: &budget, BudgetSpent: 125, BudgetRemaining: -25},
+		{ProjectID: 4, Budget: &budget, BudgetSpent: 200, BudgetRemaining: -100},
+	}
+
+	got := overBudgetProjects(projects, report)
+	if len(got) != 1 {
+		t.Fatalf("overBudgetProjects() = %v, want 1 project", got)
+	}
+	if got[0].Name != "Over" || got[0].BudgetSpent != 125 || got[0].BudgetUsedPercent != 125 {
+		t.Errorf("overBudgetProjects()[0] = %+v", got[0])
+	}
+
+	var buf bytes.Buffer
+	if err := outputProjectBudgets(&buf, got, output.ModeJSON); err != nil {
+		t.Fatalf("outputProjectBudgets() error = %v", err)
+	}
+	var rows []map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &rows); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if rows[0]["name"] != "Over" || rows[0]["budget_remaining"] != float64(-25) {
+		t.Errorf("JSON row = %v, want project fields merged with budget figures", rows[0])
+	}
+}
+
+func TestArchiveCommands(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(auth.PATEnvToken, "pat")
+	t.Setenv(auth.PATEnvAccountID, "1")
+
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got = r.Method + " " + r.URL.Path + " " + string(body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":7,"name":"Thing","first_name":"Ada","last_name":"Lovelace"}`))
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		args    []string
+		request string
+		stdout  string
+	}{
+		{[]string{"projects", "archive", "7"}, `PATCH /projects/7 {"is_active":false}`, "Archived project #7: Thing\n"},
+		{[]string{"projects", "unarchive", "7"}, `PATCH /projects/7 {"is_active":true}`, "Unarchived project #7: Thing\n"},
+		{[]string{"clients", "archive", "7"}, `PATCH /clients/7 {"is_active":false}`, "Archived client #7: Thing\n"},
+		{[]string{"tasks", "unarchive", "7"}, `PATCH /tasks/7 {"is_active":true}`, "Unarchived task #7: Thing\n"},
+		{[]string{"users", "archive", "7"}, `PATCH /users/7 {"is_active":false}`, "Archived user #7: Ada Lovelace\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if err := Execute(append(tt.args, "--api-base-url", srv.URL), &stdout, &stderr); err != nil {
+				t.Fatalf("Execute() error = %v, stderr: %s", err, stderr.String())
+			}
+			if strings.TrimSpace(got) != tt.request {
+				t.Errorf("request = %q, want %q", got, tt.request)
+			}
+			if stdout.String() != tt.stdout {
+				t.Errorf("stdout = %q, want %q", stdout.String(), tt.stdout)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/dedene/harvest-cli/internal/api"
+	"github.com/dedene/harvest-cli/internal/config"
+)
+
+// Kinds of queued work.
+const (
+	queueKindAdd  = "add"
+	queueKindStop = "stop"
+)
+
+// queuedEntry is work recorded with --offline and replayed by
+// 'harvest sync push'. Project and task are kept as given, so names are
+// resolved against Harvest at push time. A stop uses QueuedAt as the time
+// the timer should have stopped.
+type queuedEntry struct {
+	ID        string              `json:"id"`
+	Kind      string              `json:"kind"`
+	AccountID int64               `json:"account_id"`
+	QueuedAt  time.Time           `json:"queued_at"`
+	Project   string              `json:"project,omitempty"`
+	Task      string              `json:"task,omitempty"`
+	Input     *api.TimeEntryInput `json:"input,omitempty"`
+}
+
+// newQueuedEntry returns a queue item of the given kind, stamped now.
+func newQueuedEntry(accountID int64, kind string) queuedEntry {
+	now := time.Now()
+	return queuedEntry{
+		ID:        strconv.FormatInt(now.UnixNano(), 36),
+		Kind:      kind,
+		AccountID: accountID,
+		QueuedAt:  now.UTC(),
+	}
+}
+
+// queuePath returns the offline queue file in the config state dir.
+func queuePath() string {
+	return filepath.Join(config.StateDir(), "queue.json")
+}
+
+// readQueue loads the offline queue, returning nil if it does not exist.
+func readQueue(path string) ([]queuedEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read queue: %w", err)
+	}
+	var queue []queuedEntry
+	if err := json.Unmarshal(data, &queue); err != nil {
+		return nil, fmt.Errorf("parse queue %s: %w", path, err)
+	}
+	return queue, nil
+}
+
+// writeQueue replaces the offline queue, removing the file once it is empty.
+func writeQueue(path string, queue []queuedEntry) error {
+	if len(queue) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("write queue: %w", err)
+		}
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("create queue dir: %w", err)
+	}
+	data, err := json.MarshalIndent(queue, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode queue: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("write queue: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("write queue: %w", err)
+	}
+	return nil
+}
+
+// appendQueue adds an item to the end of the offline queue.
+func appendQueue(path string, item queuedEntry) error {
+	queue, err := readQueue(path)
+	if err != nil {
+		return err
+	}
+	return writeQueue(path, append(queue, item))
+}
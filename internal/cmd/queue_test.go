@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/dedene/harvest-cli/internal/api"
+)
+
+func TestQueueRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "queue.json")
+
+	queue, err := readQueue(path)
+	if err != nil || queue != nil {
+		t.Fatalf("readQueue(missing) = %v, %v; want nil, nil", queue, err)
+	}
+
+	hours := 1.5
+	add := newQueuedEntry(42, queueKindAdd)
+	add.Project, add.Task = "Acme", "Dev"
+	add.Input = &api.TimeEntryInput{SpentDate: "2024-01-15", Hours: &hours}
+	if err := appendQueue(path, add); err != nil {
+		t.Fatalf("appendQueue() error = %v", err)
+	}
+	if err := appendQueue(path, newQueuedEntry(42, queueKindStop)); err != nil {
+		t.Fatalf("appendQueue() error = %v", err)
+	}
+
+	queue, err = readQueue(path)
+	if err != nil {
+		t.Fatalf("readQueue() error = %v", err)
+	}
+	if len(queue) != 2 || queue[0].Project != "Acme" || *queue[0].Input.Hours != 1.5 || queue[1].Kind != queueKindStop {
+		t.Errorf("readQueue() = %+v", queue)
+	}
+
+	if err := writeQueue(path, nil); err != nil {
+		t.Fatalf("writeQueue(nil) error = %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("empty queue should remove the file, stat err = %v", err)
+	}
+}
+
+func TestPusherAdd(t *testing.T) {
+	queuedAt := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		existing   string
+		wantStatus string
+		wantID     int64
+	}{
+		{"creates", `[]`, pushCreated, 200},
+		{"older entry is not a duplicate", `[{"id":100,"hours":2,"notes":"Review","created_at":"2024-01-15T08:00:00Z"}]`, pushCreated, 200},
+		{"already pushed", `[{"id":100,"hours":2,"notes":"Review","created_at":"2024-01-15T10:00:00Z"}]`, pushDuplicate, 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created bool
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case r.URL.Path == "/users/me":
+					_, _ = w.Write([]byte(`{"id":7}`))
+				case r.URL.Path == "/time_entries" && r.Method == http.MethodGet:
+					_, _ = w.Write([]byte(`{"time_entries":` + tt.existing + `,"page":1}`))
+				case r.URL.Path == "/time_entries" && r.Method == http.MethodPost:
+					created = true
+					_, _ = w.Write([]byte(`{"id":200,"hours":2}`))
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			defer srv.Close()
+
+			client := api.NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"}), 12345, "test@example.com", srv.URL)
+			p := &pusher{client: client, resolver: newIDResolver(client)}
+
+			hours, notes := 2.0, "Review"
+			item := queuedEntry{ID: "q1", Kind: queueKindAdd, QueuedAt: queuedAt, Project: "1", Task: "2",
+				Input: &api.TimeEntryInput{SpentDate: "2024-01-15", Hours: &hours, Notes: &notes}}
+
+			result := p.push(context.Background(), item)
+			if result.Status != tt.wantStatus || result.EntryID != tt.wantID {
+				t.Errorf("push() = %+v, want status %s and entry %d", result, tt.wantStatus, tt.wantID)
+			}
+			if created != (tt.wantStatus == pushCreated) {
+				t.Errorf("created = %v, want %v", created, tt.wantStatus == pushCreated)
+			}
+		})
+	}
+}
+
+func TestPusherStopWithoutTimer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"time_entries":[],"page":1}`))
+	}))
+	defer srv.Close()
+
+	client := api.NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"}), 12345, "test@example.com", srv.URL)
+	p := &pusher{client: client, resolver: newIDResolver(client)}
+
+	result := p.push(context.Background(), newQueuedEntry(12345, queueKindStop))
+	if result.Status != pushSkipped {
+		t.Errorf("push() = %+v, want skipped", result)
+	}
+}
+
+func TestPusherStopTrimsToQueuedTime(t *testing.T) {
+	queuedAt := time.Now().UTC().Add(-time.Hour).Truncate(time.Minute)
+	spentDate := queuedAt.Format("2006-01-02")
+
+	tests := []struct {
+		name        string
+		startedTime string
+		want        string
+	}{
+		{name: "duration", want: `"hours":`},
+		{name: "timestamp", startedTime: "8:00am", want: `"ended_time":"` + queuedAt.Format("3:04pm") + `"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := `{"id":7,"spent_date":"` + spentDate + `","hours":3,"started_time":"` + tt.startedTime + `",
+				"project":{"id":1,"name":"Website"},"task":{"id":2,"name":"Design"}}`
+			var update string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/time_entries":
+					_, _ = w.Write([]byte(`{"time_entries":[` + entry + `],"page":1}`))
+				case r.Method == http.MethodGet && r.URL.Path == "/users/me":
+					_, _ = w.Write([]byte(`{"id":1,"timezone":"UTC"}`))
+				case r.Method == http.MethodPatch && r.URL.Path == "/time_entries/7/stop":
+					_, _ = w.Write([]byte(entry))
+				case r.Method == http.MethodPatch && r.URL.Path == "/time_entries/7":
+					body, _ := io.ReadAll(r.Body)
+					update = string(body)
+					_, _ = w.Write([]byte(entry))
+				default:
+					t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+					http.NotFound(w, r)
+				}
+			}))
+			defer srv.Close()
+
+			client := api.NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"}), 12345, "test@example.com", srv.URL)
+			p := &pusher{client: client, resolver: newIDResolver(client)}
+
+			item := newQueuedEntry(12345, queueKindStop)
+			item.QueuedAt = queuedAt
+			if result := p.push(context.Background(), item); result.Status != pushStopped {
+				t.Fatalf("push() = %+v, want stopped", result)
+			}
+			if !strings.Contains(update, tt.want) {
+				t.Errorf("update body = %s, want %s", update, tt.want)
+			}
+		})
+	}
+}
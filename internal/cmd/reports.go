@@ -4,11 +4,12 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"os"
+	"slices"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/dedene/harvest-cli/internal/api"
-	"github.com/dedene/harvest-cli/internal/dateparse"
 	"github.com/dedene/harvest-cli/internal/output"
 )
 
@@ -16,93 +17,184 @@ import (
 type ReportsCmd struct {
 	Time       ReportsTimeCmd       `cmd:"" help:"Time reports"`
 	Expenses   ReportsExpensesCmd   `cmd:"" help:"Expense reports"`
+	Detailed   ReportsDetailedCmd   `cmd:"" help:"Detailed per-entry time report"`
 	Uninvoiced ReportsUninvoicedCmd `cmd:"" help:"Uninvoiced amounts report"`
 	Budget     ReportsBudgetCmd     `cmd:"" help:"Project budget report"`
 }
 
+// ReportFileFlags let a report write its rows to a file for spreadsheets.
+type ReportFileFlags struct {
+	Output string `help:"Write the report to a .csv or .xlsx file instead of stdout" short:"o" type:"path"`
+}
+
+// validate rejects output files in formats the report cannot write.
+func (f ReportFileFlags) validate() error {
+	if f.Output == "" {
+		return nil
+	}
+	return output.CheckFileFormat(f.Output)
+}
+
+// write saves the report rows to the output file and reports it on stderr.
+func (f ReportFileFlags) write(cli *CLI, headers []string, rows [][]string) error {
+	if err := output.WriteFile(f.Output, headers, rows); err != nil {
+		return err
+	}
+	fmt.Fprintf(cli.Stderr, "Wrote %d rows to %s\n", len(rows), f.Output)
+	return nil
+}
+
 // ReportsTimeCmd generates time reports.
+// Combining --project with --by=team scopes the team report to that project.
+// With --exclude-project or --exclude-task the report is summed from the
+// time entries, as Harvest's reports only filter positively.
 type ReportsTimeCmd struct {
-	By   string `help:"Group by: clients, projects, tasks, team" default:"projects" enum:"clients,projects,tasks,team"`
-	From string `help:"Start date (required)" short:"f" required:""`
-	To   string `help:"End date (required)" short:"t" required:""`
+	By      string `help:"Group by: clients, projects, tasks, team, day" default:"projects" enum:"clients,projects,tasks,team,day"`
+	From    string `help:"Start date (required)" short:"f" required:"" aliases:"since"`
+	To      string `help:"End date (required)" short:"t" required:"" aliases:"until"`
+	Project string `help:"Scope report to a project ID or name (with --by=team: per-person hours on that project)" short:"p"`
+	User    string `help:"Scope report to a user ID, email or 'me'" short:"u"`
+
+	ExcludeFlags    `embed:""`
+	ReportFileFlags `embed:""`
 }
 
 func (c *ReportsTimeCmd) Run(cli *CLI) error {
+	if err := c.ReportFileFlags.validate(); err != nil {
+		return err
+	}
+
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
 
 	// Parse dates
-	fromDate, err := dateparse.Parse(c.From)
+	from, to, err := parseDateRange(c.From, c.To)
 	if err != nil {
-		return fmt.Errorf("invalid from date: %w", err)
-	}
-	toDate, err := dateparse.Parse(c.To)
-	if err != nil {
-		return fmt.Errorf("invalid to date: %w", err)
+		return err
 	}
 
 	opts := api.ReportListOptions{
-		From: dateparse.FormatDate(fromDate),
-		To:   dateparse.FormatDate(toDate),
+		From: from,
+		To:   to,
 	}
 
-	var results []api.TimeReportResult
+	if c.Project != "" {
+		projectID, err := resolveProjectID(ctx, client, c.Project)
+		if err != nil {
+			return err
+		}
+		opts.ProjectID = projectID
+	}
 
-	switch c.By {
-	case "clients":
-		results, err = client.ListAllTimeReportsByClients(ctx, opts)
-	case "projects":
-		results, err = client.ListAllTimeReportsByProjects(ctx, opts)
-	case "tasks":
-		results, err = client.ListAllTimeReportsByTasks(ctx, opts)
-	case "team":
-		results, err = client.ListAllTimeReportsByTeam(ctx, opts)
-	default:
-		return fmt.Errorf("invalid group by: %s", c.By)
+	if c.User != "" {
+		userID, err := resolveUserID(ctx, client, c.User)
+		if err != nil {
+			return err
+		}
+		opts.UserID = userID
 	}
 
+	exclusion, err := c.ExcludeFlags.resolve(ctx, client)
 	if err != nil {
-		return fmt.Errorf("get time report: %w", err)
+		return err
 	}
 
-	// Warn if approaching rate limit
-	if warn := client.WarnIfNearReportsLimit(); warn != "" {
-		fmt.Fprintln(os.Stderr, warn)
+	var results []api.TimeReportResult
+
+	// Harvest has no daily report and its reports cannot leave projects or
+	// tasks out, so those sum the individual entries instead
+	if c.By == "day" || exclusion != nil {
+		entries, err := client.ListAllTimeEntries(ctx, api.TimeEntryListOptions{
+			From:      opts.From,
+			To:        opts.To,
+			ProjectID: opts.ProjectID,
+			UserID:    opts.UserID,
+		})
+		if err != nil {
+			return fmt.Errorf("list time entries: %w", err)
+		}
+		entries, excluded := exclusion.filter(entries)
+		reportExcluded(cli, exclusion, excluded)
+
+		if c.By == "day" {
+			days := aggregateByDay(entries)
+			if c.Output != "" {
+				headers, rows := dailyReportRows(days)
+				return c.ReportFileFlags.write(cli, headers, rows)
+			}
+			return outputDailyReport(cli.Stdout, days, output.ModeFromFlags(cli.JSON, cli.Plain))
+		}
+
+		currencies, err := clientCurrencies(ctx, client)
+		if err != nil {
+			return err
+		}
+		results = aggregateTimeReport(entries, c.By, currencies)
+	} else {
+		switch c.By {
+		case "clients":
+			results, err = client.ListAllTimeReportsByClients(ctx, opts)
+		case "projects":
+			results, err = client.ListAllTimeReportsByProjects(ctx, opts)
+		case "tasks":
+			results, err = client.ListAllTimeReportsByTasks(ctx, opts)
+		case "team":
+			results, err = client.ListAllTimeReportsByTeam(ctx, opts)
+		default:
+			return fmt.Errorf("invalid group by: %s", c.By)
+		}
+
+		if err != nil {
+			return fmt.Errorf("get time report: %w", err)
+		}
+
+		// Warn if approaching rate limit
+		if warn := client.WarnIfNearReportsLimit(); warn != "" {
+			fmt.Fprintln(cli.Stderr, warn)
+		}
 	}
 
-	return outputTimeReport(os.Stdout, results, c.By, output.ModeFromFlags(cli.JSON, cli.Plain))
+	if c.Output != "" {
+		headers, rows := timeReportRows(results, c.By)
+		return c.ReportFileFlags.write(cli, headers, rows)
+	}
+
+	loadCurrencyFormat(ctx, cli, client)
+	return outputTimeReport(cli.Stdout, results, c.By, output.ModeFromFlags(cli.JSON, cli.Plain))
 }
 
 // ReportsExpensesCmd generates expense reports.
 type ReportsExpensesCmd struct {
 	By   string `help:"Group by: clients, projects, categories, team" default:"projects" enum:"clients,projects,categories,team"`
-	From string `help:"Start date (required)" short:"f" required:""`
-	To   string `help:"End date (required)" short:"t" required:""`
+	From string `help:"Start date (required)" short:"f" required:"" aliases:"since"`
+	To   string `help:"End date (required)" short:"t" required:"" aliases:"until"`
+
+	ReportFileFlags `embed:""`
 }
 
 func (c *ReportsExpensesCmd) Run(cli *CLI) error {
+	if err := c.ReportFileFlags.validate(); err != nil {
+		return err
+	}
+
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
 
 	// Parse dates
-	fromDate, err := dateparse.Parse(c.From)
-	if err != nil {
-		return fmt.Errorf("invalid from date: %w", err)
-	}
-	toDate, err := dateparse.Parse(c.To)
+	from, to, err := parseDateRange(c.From, c.To)
 	if err != nil {
-		return fmt.Errorf("invalid to date: %w", err)
+		return err
 	}
 
 	opts := api.ReportListOptions{
-		From: dateparse.FormatDate(fromDate),
-		To:   dateparse.FormatDate(toDate),
+		From: from,
+		To:   to,
 	}
 
 	var results []api.ExpenseReportResult
@@ -126,38 +218,149 @@ func (c *ReportsExpensesCmd) Run(cli *CLI) error {
 
 	// Warn if approaching rate limit
 	if warn := client.WarnIfNearReportsLimit(); warn != "" {
-		fmt.Fprintln(os.Stderr, warn)
+		fmt.Fprintln(cli.Stderr, warn)
+	}
+
+	if c.Output != "" {
+		headers, rows := expenseReportRows(results, c.By)
+		return c.ReportFileFlags.write(cli, headers, rows)
+	}
+
+	loadCurrencyFormat(ctx, cli, client)
+	return outputExpenseReport(cli.Stdout, results, c.By, output.ModeFromFlags(cli.JSON, cli.Plain))
+}
+
+// ReportsDetailedCmd lists individual time entries for a date range.
+type ReportsDetailedCmd struct {
+	From          string `help:"Start date (required)" short:"f" required:"" aliases:"since"`
+	To            string `help:"End date (required)" short:"t" required:"" aliases:"until"`
+	Project       string `help:"Filter by project ID or name" short:"p"`
+	User          string `help:"Filter by user ID, email or 'me'" short:"u"`
+	HarvestClient string `help:"Filter by client ID or name" name:"harvest-client" short:"c"`
+	BillableOnly  bool   `help:"Only billable entries" name:"billable-only"`
+	Summary       bool   `help:"Append total hours and billable hours"`
+	NDJSON        bool   `help:"Output one JSON object per line" name:"ndjson"`
+
+	ExcludeFlags    `embed:""`
+	ReportFileFlags `embed:""`
+}
+
+func (c *ReportsDetailedCmd) Run(cli *CLI) error {
+	if err := c.ReportFileFlags.validate(); err != nil {
+		return err
+	}
+	if c.Output != "" && c.NDJSON {
+		return fmt.Errorf("--output cannot be combined with --ndjson")
+	}
+
+	ctx := context.Background()
+	client, err := NewClientFromFlags(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	// Parse dates
+	from, to, err := parseDateRange(c.From, c.To)
+	if err != nil {
+		return err
+	}
+
+	opts := api.TimeEntryListOptions{
+		From: from,
+		To:   to,
+	}
+
+	if c.Project != "" {
+		projectID, err := resolveProjectID(ctx, client, c.Project)
+		if err != nil {
+			return err
+		}
+		opts.ProjectID = projectID
+	}
+
+	if c.User != "" {
+		userID, err := resolveUserID(ctx, client, c.User)
+		if err != nil {
+			return err
+		}
+		opts.UserID = userID
 	}
 
-	return outputExpenseReport(os.Stdout, results, c.By, output.ModeFromFlags(cli.JSON, cli.Plain))
+	if c.HarvestClient != "" {
+		clientID, err := resolveClientID(ctx, client, c.HarvestClient)
+		if err != nil {
+			return err
+		}
+		opts.ClientID = clientID
+	}
+
+	exclusion, err := c.ExcludeFlags.resolve(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	entries, err := client.ListAllTimeEntries(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("list time entries: %w", err)
+	}
+	entries, excluded := exclusion.filter(entries)
+	reportExcluded(cli, exclusion, excluded)
+
+	if c.BillableOnly {
+		billable := entries[:0]
+		for _, e := range entries {
+			if e.Billable {
+				billable = append(billable, e)
+			}
+		}
+		entries = billable
+	}
+
+	if c.NDJSON {
+		return output.WriteNDJSON(cli.Stdout, entries)
+	}
+	if c.Output != "" {
+		headers, rows := detailedReportRows(entries, c.Summary)
+		return c.ReportFileFlags.write(cli, headers, rows)
+	}
+
+	return outputDetailedReport(cli.Stdout, entries, output.ModeFromFlags(cli.JSON, cli.Plain), c.Summary)
 }
 
 // ReportsUninvoicedCmd generates uninvoiced amounts report.
 type ReportsUninvoicedCmd struct {
-	From string `help:"Start date (required)" short:"f" required:""`
-	To   string `help:"End date (required)" short:"t" required:""`
+	From      string  `help:"Start date (required)" short:"f" required:"" aliases:"since"`
+	To        string  `help:"End date (required)" short:"t" required:"" aliases:"until"`
+	MinAmount float64 `help:"Hide projects with less than this uninvoiced amount (in their own currency)" name:"min-amount"`
+	Sort      string  `help:"Sort by amount (per currency, largest first), hours (largest first), project or client" enum:"amount,hours,project,client" default:"amount"`
+	Summary   bool    `help:"Append the total hours, expenses and amount per currency"`
+
+	ReportFileFlags `embed:""`
 }
 
 func (c *ReportsUninvoicedCmd) Run(cli *CLI) error {
+	if err := c.ReportFileFlags.validate(); err != nil {
+		return err
+	}
+	if c.MinAmount < 0 {
+		return fmt.Errorf("--min-amount must not be negative")
+	}
+
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
 
 	// Parse dates
-	fromDate, err := dateparse.Parse(c.From)
-	if err != nil {
-		return fmt.Errorf("invalid from date: %w", err)
-	}
-	toDate, err := dateparse.Parse(c.To)
+	from, to, err := parseDateRange(c.From, c.To)
 	if err != nil {
-		return fmt.Errorf("invalid to date: %w", err)
+		return err
 	}
 
 	opts := api.ReportListOptions{
-		From: dateparse.FormatDate(fromDate),
-		To:   dateparse.FormatDate(toDate),
+		From: from,
+		To:   to,
 	}
 
 	results, err := client.ListAllUninvoicedReport(ctx, opts)
@@ -167,25 +370,66 @@ func (c *ReportsUninvoicedCmd) Run(cli *CLI) error {
 
 	// Warn if approaching rate limit
 	if warn := client.WarnIfNearReportsLimit(); warn != "" {
-		fmt.Fprintln(os.Stderr, warn)
+		fmt.Fprintln(cli.Stderr, warn)
+	}
+
+	results, hidden := filterUninvoiced(results, c.MinAmount)
+	if hidden > 0 {
+		fmt.Fprintf(cli.Stderr, "Hid %d projects under %.2f uninvoiced\n", hidden, c.MinAmount)
+	}
+	sortUninvoiced(results, c.Sort)
+
+	if c.Output != "" {
+		headers, rows := uninvoicedReportRows(results)
+		return c.ReportFileFlags.write(cli, headers, rows)
 	}
 
-	return outputUninvoicedReport(os.Stdout, results, output.ModeFromFlags(cli.JSON, cli.Plain))
+	loadCurrencyFormat(ctx, cli, client)
+	return outputUninvoicedReport(cli.Stdout, results, output.ModeFromFlags(cli.JSON, cli.Plain), c.Summary)
 }
 
 // ReportsBudgetCmd generates project budget report.
 type ReportsBudgetCmd struct {
-	Active   bool `help:"Only active projects"`
-	Inactive bool `help:"Only inactive projects"`
+	Active        bool     `help:"Only active projects"`
+	Inactive      bool     `help:"Only inactive projects"`
+	Over          *float64 `help:"Only projects that have used more than this percent of their budget (e.g., 80)"`
+	BudgetType    string   `help:"Only projects budgeted in hours or money (fees/cost)" name:"budget-type" enum:",hours,money" default:""`
+	HarvestClient string   `help:"Only projects for this client ID or name" name:"harvest-client" short:"c"`
+	Project       string   `help:"Only this project ID or name" short:"p"`
+
+	ReportFileFlags `embed:""`
 }
 
 func (c *ReportsBudgetCmd) Run(cli *CLI) error {
+	if c.Over != nil && *c.Over < 0 {
+		return fmt.Errorf("--over must not be negative")
+	}
+	if err := c.ReportFileFlags.validate(); err != nil {
+		return err
+	}
+
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
 
+	// The budget report endpoint has no client or project filter, so the
+	// results are filtered after fetching.
+	var clientID, projectID int64
+	if c.HarvestClient != "" {
+		clientID, err = resolveClientID(ctx, client, c.HarvestClient)
+		if err != nil {
+			return err
+		}
+	}
+	if c.Project != "" {
+		projectID, err = resolveProjectID(ctx, client, c.Project)
+		if err != nil {
+			return err
+		}
+	}
+
 	opts := api.ProjectBudgetReportOptions{}
 
 	if c.Active {
@@ -203,10 +447,125 @@ func (c *ReportsBudgetCmd) Run(cli *CLI) error {
 
 	// Warn if approaching rate limit
 	if warn := client.WarnIfNearReportsLimit(); warn != "" {
-		fmt.Fprintln(os.Stderr, warn)
+		fmt.Fprintln(cli.Stderr, warn)
 	}
 
-	return outputBudgetReport(os.Stdout, results, output.ModeFromFlags(cli.JSON, cli.Plain))
+	if clientID != 0 || projectID != 0 {
+		results = filterBudgetScope(results, clientID, projectID)
+	}
+	if c.Over != nil {
+		results = filterBudgetOver(results, *c.Over)
+	}
+	if c.BudgetType != "" {
+		results = filterBudgetType(results, c.BudgetType == "money")
+	}
+
+	mode := output.ModeFromFlags(cli.JSON, cli.Plain)
+	var currencies map[int64]string
+	if (mode != output.ModeJSON || c.Output != "") && slices.ContainsFunc(results, func(r api.ProjectBudgetReportResult) bool { return budgetIsMoney(r.BudgetBy) }) {
+		currencies, err = clientCurrencies(ctx, client)
+		if err != nil {
+			return err
+		}
+		loadCurrencyFormat(ctx, cli, client)
+	}
+
+	if c.Output != "" {
+		headers, rows := budgetReportRows(results, currencies)
+		return c.ReportFileFlags.write(cli, headers, rows)
+	}
+	return outputBudgetReport(cli.Stdout, results, currencies, mode)
+}
+
+// budgetLabels describe Harvest's budget_by values.
+var budgetLabels = map[string]string{
+	"project":      "Project hours",
+	"project_cost": "Project fees",
+	"task":         "Hours per task",
+	"task_fees":    "Fees per task",
+	"person":       "Hours per person",
+	"none":         "No budget",
+}
+
+// budgetIsMoney reports whether a budget_by value is an amount of money
+// rather than hours.
+func budgetIsMoney(budgetBy string) bool {
+	return budgetBy == "project_cost" || budgetBy == "task_fees"
+}
+
+// filterBudgetScope keeps projects for clientID and projectID. A zero ID
+// matches everything.
+func filterBudgetScope(results []api.ProjectBudgetReportResult, clientID, projectID int64) []api.ProjectBudgetReportResult {
+	filtered := make([]api.ProjectBudgetReportResult, 0, len(results))
+	for _, r := range results {
+		if (clientID == 0 || r.ClientID == clientID) && (projectID == 0 || r.ProjectID == projectID) {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
+
+// filterBudgetType keeps projects budgeted in money, or in hours when money
+// is false. Projects without a budget are dropped.
+func filterBudgetType(results []api.ProjectBudgetReportResult, money bool) []api.ProjectBudgetReportResult {
+	filtered := make([]api.ProjectBudgetReportResult, 0, len(results))
+	for _, r := range results {
+		if r.BudgetBy != "none" && budgetIsMoney(r.BudgetBy) == money {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
+
+// clientCurrencies maps client IDs to their invoice currency, which is the
+// currency of fee and cost budgets.
+func clientCurrencies(ctx context.Context, client *api.Client) (map[int64]string, error) {
+	clients, err := client.ListAllClients(ctx, api.ClientListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("list clients: %w", err)
+	}
+	currencies := make(map[int64]string, len(clients))
+	for _, c := range clients {
+		currencies[c.ID] = c.Currency
+	}
+	return currencies, nil
+}
+
+// formatBudgetValue formats a budget figure as an amount for money budgets
+// and as hours otherwise.
+func formatBudgetValue(v float64, budgetBy, currency string) string {
+	if budgetIsMoney(budgetBy) {
+		return formatAmount(v, currency)
+	}
+	return fmt.Sprintf("%.2fh", v)
+}
+
+// budgetUsedPercent returns the share of the budget spent, in percent. It
+// reports false for projects without a budget.
+func budgetUsedPercent(r api.ProjectBudgetReportResult) (float64, bool) {
+	if r.Budget == nil || *r.Budget <= 0 {
+		return 0, false
+	}
+	return r.BudgetSpent / *r.Budget * 100, true
+}
+
+// budgetRemainingPercent returns the share of the budget left, in percent,
+// which is negative once a project is over budget.
+func budgetRemainingPercent(r api.ProjectBudgetReportResult) (float64, bool) {
+	used, ok := budgetUsedPercent(r)
+	return 100 - used, ok
+}
+
+// filterBudgetOver keeps projects that have used more than percent of their
+// budget. Projects without a budget are dropped.
+func filterBudgetOver(results []api.ProjectBudgetReportResult, percent float64) []api.ProjectBudgetReportResult {
+	filtered := make([]api.ProjectBudgetReportResult, 0, len(results))
+	for _, r := range results {
+		if used, ok := budgetUsedPercent(r); ok && used > percent {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
 }
 
 // outputTimeReport writes time report results in the specified format.
@@ -215,16 +574,15 @@ func outputTimeReport(w io.Writer, results []api.TimeReportResult, groupBy strin
 	case output.ModeJSON:
 		return output.WriteJSON(w, results)
 	case output.ModePlain:
-		return outputTimeReportTSV(w, results, groupBy)
+		headers, rows := timeReportRows(results, groupBy)
+		return output.WriteTSV(w, headers, rows)
 	default:
 		return outputTimeReportTable(w, results, groupBy)
 	}
 }
 
-func outputTimeReportTSV(w io.Writer, results []api.TimeReportResult, groupBy string) error {
-	var headers []string
-	var rows [][]string
-
+// timeReportRows returns the plain/file columns of a time report.
+func timeReportRows(results []api.TimeReportResult, groupBy string) (headers []string, rows [][]string) {
 	switch groupBy {
 	case "clients":
 		headers = []string{"ClientID", "Client", "TotalHours", "BillableHours", "BillableAmount", "Currency"}
@@ -277,15 +635,16 @@ func outputTimeReportTSV(w io.Writer, results []api.TimeReportResult, groupBy st
 		}
 	}
 
-	return output.WriteTSV(w, headers, rows)
+	return headers, rows
 }
 
 func outputTimeReportTable(w io.Writer, results []api.TimeReportResult, groupBy string) error {
 	var t *output.Table
+	hoursCol := 2
 
 	switch groupBy {
 	case "clients":
-		t = output.NewTable(w, "ID", "Client", "Total Hours", "Billable Hours", "Billable Amount")
+		t = output.NewTable(w, "ID", "Client", "Total Hours", "Billable Hours", "Billable Amount").SetAlign(output.AlignRight, 2, 3, 4)
 		for _, r := range results {
 			t.AddRow(
 				strconv.FormatInt(r.ClientID, 10),
@@ -296,30 +655,31 @@ func outputTimeReportTable(w io.Writer, results []api.TimeReportResult, groupBy
 			)
 		}
 	case "projects":
-		t = output.NewTable(w, "ID", "Project", "Client", "Total Hours", "Billable Hours", "Billable Amount")
+		t = output.NewTable(w, "ID", "Project", "Client", "Total Hours", "Billable Hours", "Billable Amount").SetAlign(output.AlignRight, 3, 4, 5)
+		hoursCol = 3
 		for _, r := range results {
 			t.AddRow(
 				strconv.FormatInt(r.ProjectID, 10),
-				truncate(r.ProjectName, 25),
-				truncate(r.ClientName, 20),
+				r.ProjectName,
+				r.ClientName,
 				fmt.Sprintf("%.2f", r.TotalHours),
 				fmt.Sprintf("%.2f", r.BillableHours),
 				formatAmount(r.BillableAmount, r.Currency),
 			)
 		}
 	case "tasks":
-		t = output.NewTable(w, "ID", "Task", "Total Hours", "Billable Hours", "Billable Amount")
+		t = output.NewTable(w, "ID", "Task", "Total Hours", "Billable Hours", "Billable Amount").SetAlign(output.AlignRight, 2, 3, 4)
 		for _, r := range results {
 			t.AddRow(
 				strconv.FormatInt(r.TaskID, 10),
-				truncate(r.TaskName, 30),
+				r.TaskName,
 				fmt.Sprintf("%.2f", r.TotalHours),
 				fmt.Sprintf("%.2f", r.BillableHours),
 				formatAmount(r.BillableAmount, r.Currency),
 			)
 		}
 	case "team":
-		t = output.NewTable(w, "ID", "User", "Total Hours", "Billable Hours", "Billable Amount")
+		t = output.NewTable(w, "ID", "User", "Total Hours", "Billable Hours", "Billable Amount").SetAlign(output.AlignRight, 2, 3, 4)
 		for _, r := range results {
 			t.AddRow(
 				strconv.FormatInt(r.UserID, 10),
@@ -331,25 +691,158 @@ func outputTimeReportTable(w io.Writer, results []api.TimeReportResult, groupBy
 		}
 	}
 
+	addTimeReportTotals(t, hoursCol, sumByCurrency(results))
 	return t.Render()
 }
 
+// aggregateTimeReport sums entries into time report results grouped like
+// Harvest's report of the same name, one result per group and currency.
+// Billable amounts are hours times the entry's billable rate; currencies
+// maps client IDs to their currency. Results are sorted by name.
+func aggregateTimeReport(entries []api.TimeEntry, groupBy string, currencies map[int64]string) []api.TimeReportResult {
+	type groupKey struct {
+		id       int64
+		currency string
+	}
+	groups := make(map[groupKey]*api.TimeReportResult)
+	var results []*api.TimeReportResult
+	for _, e := range entries {
+		currency := currencies[e.Client.ID]
+		r := api.TimeReportResult{Currency: currency}
+		var id int64
+		switch groupBy {
+		case "clients":
+			id, r.ClientID, r.ClientName = e.Client.ID, e.Client.ID, e.Client.Name
+		case "projects":
+			id, r.ProjectID, r.ProjectName = e.Project.ID, e.Project.ID, e.Project.Name
+			r.ClientID, r.ClientName = e.Client.ID, e.Client.Name
+		case "tasks":
+			id, r.TaskID, r.TaskName = e.Task.ID, e.Task.ID, e.Task.Name
+		case "team":
+			id, r.UserID, r.UserName = e.User.ID, e.User.ID, e.User.Name
+		}
+
+		g, ok := groups[groupKey{id, currency}]
+		if !ok {
+			g = &r
+			groups[groupKey{id, currency}] = g
+			results = append(results, g)
+		}
+		g.TotalHours += e.Hours
+		if e.Billable {
+			g.BillableHours += e.Hours
+			if e.BillableRate != nil {
+				g.BillableAmount += e.Hours * *e.BillableRate
+			}
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		for _, k := range [][2]string{
+			{a.ClientName, b.ClientName}, {a.ProjectName, b.ProjectName},
+			{a.TaskName, b.TaskName}, {a.UserName, b.UserName}, {a.Currency, b.Currency},
+		} {
+			if k[0] != k[1] {
+				return k[0] < k[1]
+			}
+		}
+		return false
+	})
+
+	out := make([]api.TimeReportResult, len(results))
+	for i, r := range results {
+		out[i] = *r
+	}
+	return out
+}
+
+// dailyHours is the time logged on one day.
+type dailyHours struct {
+	Date          string  `json:"date"`
+	TotalHours    float64 `json:"total_hours"`
+	BillableHours float64 `json:"billable_hours"`
+}
+
+// aggregateByDay sums entry hours per spent date, sorted by date. Days
+// without entries are left out.
+func aggregateByDay(entries []api.TimeEntry) []dailyHours {
+	byDate := make(map[string]*dailyHours)
+	for _, e := range entries {
+		d, ok := byDate[e.SpentDate]
+		if !ok {
+			d = &dailyHours{Date: e.SpentDate}
+			byDate[e.SpentDate] = d
+		}
+		d.TotalHours += e.Hours
+		if e.Billable {
+			d.BillableHours += e.Hours
+		}
+	}
+
+	days := make([]dailyHours, 0, len(byDate))
+	for _, d := range byDate {
+		days = append(days, *d)
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Date < days[j].Date })
+	return days
+}
+
+// dailyReportRows returns the plain/file columns of a daily report.
+func dailyReportRows(days []dailyHours) (headers []string, rows [][]string) {
+	rows = make([][]string, len(days))
+	for i, d := range days {
+		rows[i] = []string{
+			d.Date,
+			fmt.Sprintf("%.2f", d.TotalHours),
+			fmt.Sprintf("%.2f", d.BillableHours),
+		}
+	}
+	return []string{"Date", "TotalHours", "BillableHours"}, rows
+}
+
+// outputDailyReport writes per-day hours in the specified format.
+func outputDailyReport(w io.Writer, days []dailyHours, mode output.Mode) error {
+	switch mode {
+	case output.ModeJSON:
+		return output.WriteJSON(w, days)
+	case output.ModePlain:
+		headers, rows := dailyReportRows(days)
+		return output.WriteTSV(w, headers, rows)
+	default:
+		t := output.NewTable(w, "Date", "Total Hours", "Billable Hours").SetAlign(output.AlignRight, 1, 2).SetDateColumns(0)
+		var total, billable float64
+		for _, d := range days {
+			t.AddRow(
+				d.Date,
+				fmt.Sprintf("%.2f", d.TotalHours),
+				fmt.Sprintf("%.2f", d.BillableHours),
+			)
+			total += d.TotalHours
+			billable += d.BillableHours
+		}
+		if len(days) > 0 {
+			t.AddFooter("Total", fmt.Sprintf("%.2f", total), fmt.Sprintf("%.2f", billable))
+		}
+		return t.Render()
+	}
+}
+
 // outputExpenseReport writes expense report results in the specified format.
 func outputExpenseReport(w io.Writer, results []api.ExpenseReportResult, groupBy string, mode output.Mode) error {
 	switch mode {
 	case output.ModeJSON:
 		return output.WriteJSON(w, results)
 	case output.ModePlain:
-		return outputExpenseReportTSV(w, results, groupBy)
+		headers, rows := expenseReportRows(results, groupBy)
+		return output.WriteTSV(w, headers, rows)
 	default:
 		return outputExpenseReportTable(w, results, groupBy)
 	}
 }
 
-func outputExpenseReportTSV(w io.Writer, results []api.ExpenseReportResult, groupBy string) error {
-	var headers []string
-	var rows [][]string
-
+// expenseReportRows returns the plain/file columns of an expense report.
+func expenseReportRows(results []api.ExpenseReportResult, groupBy string) (headers []string, rows [][]string) {
 	switch groupBy {
 	case "clients":
 		headers = []string{"ClientID", "Client", "TotalAmount", "BillableAmount", "Currency"}
@@ -398,15 +891,16 @@ func outputExpenseReportTSV(w io.Writer, results []api.ExpenseReportResult, grou
 		}
 	}
 
-	return output.WriteTSV(w, headers, rows)
+	return headers, rows
 }
 
 func outputExpenseReportTable(w io.Writer, results []api.ExpenseReportResult, groupBy string) error {
 	var t *output.Table
+	amountCol := 2
 
 	switch groupBy {
 	case "clients":
-		t = output.NewTable(w, "ID", "Client", "Total Amount", "Billable Amount")
+		t = output.NewTable(w, "ID", "Client", "Total Amount", "Billable Amount").SetAlign(output.AlignRight, 2, 3)
 		for _, r := range results {
 			t.AddRow(
 				strconv.FormatInt(r.ClientID, 10),
@@ -416,28 +910,29 @@ func outputExpenseReportTable(w io.Writer, results []api.ExpenseReportResult, gr
 			)
 		}
 	case "projects":
-		t = output.NewTable(w, "ID", "Project", "Client", "Total Amount", "Billable Amount")
+		t = output.NewTable(w, "ID", "Project", "Client", "Total Amount", "Billable Amount").SetAlign(output.AlignRight, 3, 4)
+		amountCol = 3
 		for _, r := range results {
 			t.AddRow(
 				strconv.FormatInt(r.ProjectID, 10),
-				truncate(r.ProjectName, 25),
-				truncate(r.ClientName, 20),
+				r.ProjectName,
+				r.ClientName,
 				formatAmount(r.TotalAmount, r.Currency),
 				formatAmount(r.BillableAmount, r.Currency),
 			)
 		}
 	case "categories":
-		t = output.NewTable(w, "ID", "Category", "Total Amount", "Billable Amount")
+		t = output.NewTable(w, "ID", "Category", "Total Amount", "Billable Amount").SetAlign(output.AlignRight, 2, 3)
 		for _, r := range results {
 			t.AddRow(
 				strconv.FormatInt(r.ExpenseCategoryID, 10),
-				truncate(r.ExpenseCategoryName, 30),
+				r.ExpenseCategoryName,
 				formatAmount(r.TotalAmount, r.Currency),
 				formatAmount(r.BillableAmount, r.Currency),
 			)
 		}
 	case "team":
-		t = output.NewTable(w, "ID", "User", "Total Amount", "Billable Amount")
+		t = output.NewTable(w, "ID", "User", "Total Amount", "Billable Amount").SetAlign(output.AlignRight, 2, 3)
 		for _, r := range results {
 			t.AddRow(
 				strconv.FormatInt(r.UserID, 10),
@@ -448,89 +943,405 @@ func outputExpenseReportTable(w io.Writer, results []api.ExpenseReportResult, gr
 		}
 	}
 
+	addExpenseReportTotals(t, amountCol, sumByCurrency(results))
 	return t.Render()
 }
 
-// outputUninvoicedReport writes uninvoiced report results in the specified format.
-func outputUninvoicedReport(w io.Writer, results []api.UninvoicedReportResult, mode output.Mode) error {
+// currencyTotal aggregates report results sharing a single currency.
+type currencyTotal struct {
+	Currency       string
+	Hours          float64
+	BillableHours  float64
+	TotalAmount    float64
+	BillableAmount float64
+}
+
+// sumByCurrency totals report results per currency, sorted by currency code.
+// Amounts in different currencies are never added together.
+func sumByCurrency[T api.TimeReportResult | api.ExpenseReportResult](results []T) []currencyTotal {
+	byCurrency := make(map[string]*currencyTotal)
+	var order []string
+
+	for _, r := range results {
+		var currency string
+		var hours, billableHours, totalAmount, billableAmount float64
+		switch v := any(r).(type) {
+		case api.TimeReportResult:
+			currency = v.Currency
+			hours, billableHours = v.TotalHours, v.BillableHours
+			billableAmount = v.BillableAmount
+		case api.ExpenseReportResult:
+			currency = v.Currency
+			totalAmount, billableAmount = v.TotalAmount, v.BillableAmount
+		}
+
+		ct, ok := byCurrency[currency]
+		if !ok {
+			ct = &currencyTotal{Currency: currency}
+			byCurrency[currency] = ct
+			order = append(order, currency)
+		}
+		ct.Hours += hours
+		ct.BillableHours += billableHours
+		ct.TotalAmount += totalAmount
+		ct.BillableAmount += billableAmount
+	}
+
+	sort.Strings(order)
+	totals := make([]currencyTotal, len(order))
+	for i, currency := range order {
+		totals[i] = *byCurrency[currency]
+	}
+	return totals
+}
+
+// amountTotal is the sum of amounts in one currency.
+type amountTotal struct {
+	Currency string  `json:"currency"`
+	Amount   float64 `json:"amount"`
+}
+
+// sumAmounts totals amounts per currency, sorted by currency code.
+func sumAmounts[T any](items []T, amount func(T) (currency string, value float64)) []amountTotal {
+	byCurrency := make(map[string]float64)
+	var order []string
+	for _, item := range items {
+		currency, value := amount(item)
+		if _, ok := byCurrency[currency]; !ok {
+			order = append(order, currency)
+		}
+		byCurrency[currency] += value
+	}
+
+	sort.Strings(order)
+	totals := make([]amountTotal, len(order))
+	for i, currency := range order {
+		totals[i] = amountTotal{Currency: currency, Amount: byCurrency[currency]}
+	}
+	return totals
+}
+
+// addAmountTotals adds one footer row per currency to t, labeled in the
+// first column, with the total in column col.
+func addAmountTotals(t *output.Table, col int, totals []amountTotal) {
+	for _, total := range totals {
+		cells := make([]string, col+1)
+		cells[0] = "Total"
+		cells[col] = formatAmount(total.Amount, total.Currency)
+		t.AddFooter(cells...)
+	}
+}
+
+// addTimeReportTotals adds one footer row per currency to a time report
+// table whose hours, billable hours and billable amount start at column
+// hoursCol.
+func addTimeReportTotals(t *output.Table, hoursCol int, totals []currencyTotal) {
+	for _, ct := range totals {
+		cells := make([]string, hoursCol+3)
+		cells[0] = "Total"
+		cells[hoursCol] = fmt.Sprintf("%.2f", ct.Hours)
+		cells[hoursCol+1] = fmt.Sprintf("%.2f", ct.BillableHours)
+		cells[hoursCol+2] = formatAmount(ct.BillableAmount, ct.Currency)
+		t.AddFooter(cells...)
+	}
+}
+
+// addExpenseReportTotals adds one footer row per currency to an expense
+// report table whose total and billable amounts start at column amountCol.
+func addExpenseReportTotals(t *output.Table, amountCol int, totals []currencyTotal) {
+	for _, ct := range totals {
+		cells := make([]string, amountCol+2)
+		cells[0] = "Total"
+		cells[amountCol] = formatAmount(ct.TotalAmount, ct.Currency)
+		cells[amountCol+1] = formatAmount(ct.BillableAmount, ct.Currency)
+		t.AddFooter(cells...)
+	}
+}
+
+// detailedReportRows returns the plain/file columns of a detailed report,
+// with a TOTAL row when summary is set.
+func detailedReportRows(entries []api.TimeEntry, summary bool) (headers []string, rows [][]string) {
+	headers = []string{"Date", "User", "Client", "Project", "Task", "Hours", "Billable", "Notes"}
+	rows = make([][]string, len(entries))
+	for i, e := range entries {
+		rows[i] = []string{
+			e.SpentDate,
+			e.User.Name,
+			e.Client.Name,
+			e.Project.Name,
+			e.Task.Name,
+			fmt.Sprintf("%.2f", e.Hours),
+			strconv.FormatBool(e.Billable),
+			e.Notes,
+		}
+	}
+	if summary {
+		totals := sumTimeEntries(entries)
+		rows = append(rows, []string{"TOTAL", "", "", "", "", fmt.Sprintf("%.2f", totals.Hours), "", ""})
+	}
+	return headers, rows
+}
+
+// outputDetailedReport writes per-entry time report rows in the specified format.
+// JSON output shares the time entry list shape, including summary totals.
+func outputDetailedReport(w io.Writer, entries []api.TimeEntry, mode output.Mode, summary bool) error {
 	switch mode {
 	case output.ModeJSON:
-		return output.WriteJSON(w, results)
+		return outputTimeEntries(w, entries, mode, summary, false)
 	case output.ModePlain:
-		headers := []string{"ProjectID", "Project", "Client", "UninvoicedHours", "UninvoicedExpenses", "UninvoicedAmount", "Currency"}
-		rows := make([][]string, len(results))
-		for i, r := range results {
-			rows[i] = []string{
-				strconv.FormatInt(r.ProjectID, 10),
-				r.ProjectName,
-				r.ClientName,
-				fmt.Sprintf("%.2f", r.UninvoicedHours),
-				fmt.Sprintf("%.2f", r.UninvoicedExpenses),
-				fmt.Sprintf("%.2f", r.UninvoicedAmount),
-				r.Currency,
+		headers, rows := detailedReportRows(entries, summary)
+		return output.WriteTSV(w, headers, rows)
+	default:
+		t := output.NewTable(w, "Date", "User", "Client", "Project", "Task", "Hours", "Billable", "Notes").SetAlign(output.AlignRight, 5).SetDateColumns(0)
+		for _, e := range entries {
+			billable := "No"
+			if e.Billable {
+				billable = "Yes"
 			}
+			t.AddRow(
+				e.SpentDate,
+				e.User.Name,
+				e.Client.Name,
+				e.Project.Name,
+				e.Task.Name,
+				fmt.Sprintf("%.2f", e.Hours),
+				billable,
+				e.Notes,
+			)
+		}
+		if err := t.Render(); err != nil {
+			return err
+		}
+		if summary {
+			writeTimeTotals(w, sumTimeEntries(entries), false)
+		}
+		return nil
+	}
+}
+
+// filterUninvoiced drops projects with less than minAmount uninvoiced and
+// returns how many were dropped.
+func filterUninvoiced(results []api.UninvoicedReportResult, minAmount float64) ([]api.UninvoicedReportResult, int) {
+	if minAmount <= 0 {
+		return results, 0
+	}
+	kept := make([]api.UninvoicedReportResult, 0, len(results))
+	for _, r := range results {
+		if r.UninvoicedAmount >= minAmount {
+			kept = append(kept, r)
+		}
+	}
+	return kept, len(results) - len(kept)
+}
+
+// sortUninvoiced orders uninvoiced report rows by the given --sort key.
+// Amounts are not converted between currencies, so sorting by amount groups
+// the rows by currency code first.
+func sortUninvoiced(results []api.UninvoicedReportResult, by string) {
+	switch by {
+	case "amount":
+		sort.SliceStable(results, func(i, j int) bool {
+			if results[i].Currency != results[j].Currency {
+				return results[i].Currency < results[j].Currency
+			}
+			return results[i].UninvoicedAmount > results[j].UninvoicedAmount
+		})
+	case "hours":
+		sort.SliceStable(results, func(i, j int) bool { return results[i].UninvoicedHours > results[j].UninvoicedHours })
+	case "project":
+		sort.SliceStable(results, func(i, j int) bool {
+			return strings.ToLower(results[i].ProjectName) < strings.ToLower(results[j].ProjectName)
+		})
+	case "client":
+		sort.SliceStable(results, func(i, j int) bool {
+			return strings.ToLower(results[i].ClientName) < strings.ToLower(results[j].ClientName)
+		})
+	}
+}
+
+// uninvoicedTotal is the sum of an uninvoiced report in one currency.
+type uninvoicedTotal struct {
+	Currency string  `json:"currency"`
+	Hours    float64 `json:"uninvoiced_hours"`
+	Expenses float64 `json:"uninvoiced_expenses"`
+	Amount   float64 `json:"uninvoiced_amount"`
+}
+
+// sumUninvoiced totals an uninvoiced report per currency, sorted by
+// currency code.
+func sumUninvoiced(results []api.UninvoicedReportResult) []uninvoicedTotal {
+	sum := func(value func(api.UninvoicedReportResult) float64) []amountTotal {
+		return sumAmounts(results, func(r api.UninvoicedReportResult) (string, float64) { return r.Currency, value(r) })
+	}
+	hours := sum(func(r api.UninvoicedReportResult) float64 { return r.UninvoicedHours })
+	expenses := sum(func(r api.UninvoicedReportResult) float64 { return r.UninvoicedExpenses })
+	amounts := sum(func(r api.UninvoicedReportResult) float64 { return r.UninvoicedAmount })
+
+	// All three share the currencies of results, in the same order
+	totals := make([]uninvoicedTotal, len(amounts))
+	for i, a := range amounts {
+		totals[i] = uninvoicedTotal{Currency: a.Currency, Hours: hours[i].Amount, Expenses: expenses[i].Amount, Amount: a.Amount}
+	}
+	return totals
+}
+
+// uninvoicedReportRows returns the plain/file columns of an uninvoiced report.
+func uninvoicedReportRows(results []api.UninvoicedReportResult) (headers []string, rows [][]string) {
+	headers = []string{"ProjectID", "Project", "Client", "UninvoicedHours", "UninvoicedExpenses", "UninvoicedAmount", "Currency"}
+	rows = make([][]string, len(results))
+	for i, r := range results {
+		rows[i] = []string{
+			strconv.FormatInt(r.ProjectID, 10),
+			r.ProjectName,
+			r.ClientName,
+			fmt.Sprintf("%.2f", r.UninvoicedHours),
+			fmt.Sprintf("%.2f", r.UninvoicedExpenses),
+			fmt.Sprintf("%.2f", r.UninvoicedAmount),
+			r.Currency,
+		}
+	}
+	return headers, rows
+}
+
+// outputUninvoicedReport writes uninvoiced report results in the specified
+// format. With summary, totals per currency follow the rows.
+func outputUninvoicedReport(w io.Writer, results []api.UninvoicedReportResult, mode output.Mode, summary bool) error {
+	switch mode {
+	case output.ModeJSON:
+		if summary {
+			return output.WriteJSON(w, map[string]any{
+				"results": results,
+				"totals":  sumUninvoiced(results),
+			})
+		}
+		return output.WriteJSON(w, results)
+	case output.ModePlain:
+		headers, rows := uninvoicedReportRows(results)
+		if !summary {
+			return output.WriteTSV(w, headers, rows)
+		}
+		for _, t := range sumUninvoiced(results) {
+			rows = append(rows, []string{"TOTAL", "", "",
+				fmt.Sprintf("%.2f", t.Hours), fmt.Sprintf("%.2f", t.Expenses), fmt.Sprintf("%.2f", t.Amount), t.Currency})
 		}
 		return output.WriteTSV(w, headers, rows)
 	default:
-		t := output.NewTable(w, "ID", "Project", "Client", "Uninv. Hours", "Uninv. Expenses", "Uninv. Amount")
+		t := output.NewTable(w, "ID", "Project", "Client", "Uninv. Hours", "Uninv. Expenses", "Uninv. Amount").SetAlign(output.AlignRight, 3, 4, 5)
 		for _, r := range results {
 			t.AddRow(
 				strconv.FormatInt(r.ProjectID, 10),
-				truncate(r.ProjectName, 20),
-				truncate(r.ClientName, 15),
+				r.ProjectName,
+				r.ClientName,
 				fmt.Sprintf("%.2f", r.UninvoicedHours),
 				formatAmount(r.UninvoicedExpenses, r.Currency),
 				formatAmount(r.UninvoicedAmount, r.Currency),
 			)
 		}
+		if !summary {
+			return t.Render()
+		}
+		for _, total := range sumUninvoiced(results) {
+			t.AddFooter(
+				"Total",
+				"",
+				"",
+				fmt.Sprintf("%.2f", total.Hours),
+				formatAmount(total.Expenses, total.Currency),
+				formatAmount(total.Amount, total.Currency),
+			)
+		}
 		return t.Render()
 	}
 }
 
+// budgetReportRows returns the plain/file columns of a budget report. Unit,
+// RemainingPct and UsedPct come last so scripts reading the older columns by
+// position keep working.
+func budgetReportRows(results []api.ProjectBudgetReportResult, currencies map[int64]string) (headers []string, rows [][]string) {
+	headers = []string{"ProjectID", "Project", "Client", "BudgetBy", "Budget", "Spent", "Remaining", "Active", "Unit", "RemainingPct", "UsedPct"}
+	rows = make([][]string, len(results))
+	for i, r := range results {
+		unit := "hours"
+		if budgetIsMoney(r.BudgetBy) {
+			unit = currencies[r.ClientID]
+		}
+		budget := "-"
+		if r.Budget != nil {
+			budget = fmt.Sprintf("%.2f", *r.Budget)
+		}
+		used, remaining := "", ""
+		if pct, ok := budgetUsedPercent(r); ok {
+			used = fmt.Sprintf("%.1f", pct)
+		}
+		if pct, ok := budgetRemainingPercent(r); ok {
+			remaining = fmt.Sprintf("%.1f", pct)
+		}
+		rows[i] = []string{
+			strconv.FormatInt(r.ProjectID, 10),
+			r.ProjectName,
+			r.ClientName,
+			r.BudgetBy,
+			budget,
+			fmt.Sprintf("%.2f", r.BudgetSpent),
+			fmt.Sprintf("%.2f", r.BudgetRemaining),
+			strconv.FormatBool(r.IsActive),
+			unit,
+			remaining,
+			used,
+		}
+	}
+	return headers, rows
+}
+
 // outputBudgetReport writes budget report results in the specified format.
-func outputBudgetReport(w io.Writer, results []api.ProjectBudgetReportResult, mode output.Mode) error {
+// Fee and cost budgets are shown in the client's currency (from currencies,
+// keyed by client ID) and hour budgets in hours.
+func outputBudgetReport(w io.Writer, results []api.ProjectBudgetReportResult, currencies map[int64]string, mode output.Mode) error {
 	switch mode {
 	case output.ModeJSON:
 		return output.WriteJSON(w, results)
 	case output.ModePlain:
-		headers := []string{"ProjectID", "Project", "Client", "BudgetBy", "Budget", "Spent", "Remaining", "Active"}
-		rows := make([][]string, len(results))
-		for i, r := range results {
-			budget := "-"
-			if r.Budget != nil {
-				budget = fmt.Sprintf("%.2f", *r.Budget)
-			}
-			rows[i] = []string{
-				strconv.FormatInt(r.ProjectID, 10),
-				r.ProjectName,
-				r.ClientName,
-				r.BudgetBy,
-				budget,
-				fmt.Sprintf("%.2f", r.BudgetSpent),
-				fmt.Sprintf("%.2f", r.BudgetRemaining),
-				strconv.FormatBool(r.IsActive),
-			}
-		}
+		headers, rows := budgetReportRows(results, currencies)
 		return output.WriteTSV(w, headers, rows)
 	default:
-		t := output.NewTable(w, "ID", "Project", "Client", "Budget By", "Budget", "Spent", "Remaining", "Active")
+		colors := output.DefaultColors()
+		t := output.NewTable(w, "ID", "Project", "Client", "Budget By", "Budget", "Spent", "Remaining", "% Used", "% Left", "Active").SetAlign(output.AlignRight, 4, 5, 6, 7, 8)
 		for _, r := range results {
+			currency := currencies[r.ClientID]
+			budgetBy := budgetLabels[r.BudgetBy]
+			if budgetBy == "" {
+				budgetBy = r.BudgetBy
+			}
 			budget := "-"
 			if r.Budget != nil {
-				budget = fmt.Sprintf("%.2f", *r.Budget)
+				budget = formatBudgetValue(*r.Budget, r.BudgetBy, currency)
+			}
+			used, remaining := "-", "-"
+			if pct, ok := budgetUsedPercent(r); ok {
+				used = fmt.Sprintf("%.0f%%", pct)
+			}
+			if pct, ok := budgetRemainingPercent(r); ok {
+				remaining = fmt.Sprintf("%.0f%%", pct)
 			}
 			active := "No"
 			if r.IsActive {
 				active = "Yes"
 			}
-			t.AddRow(
+			// Highlight over-budget projects
+			var style func(string) string
+			if r.BudgetRemaining < 0 {
+				style = colors.Error
+			}
+			t.AddStyledRow(style,
 				strconv.FormatInt(r.ProjectID, 10),
-				truncate(r.ProjectName, 20),
-				truncate(r.ClientName, 15),
-				r.BudgetBy,
+				r.ProjectName,
+				r.ClientName,
+				budgetBy,
 				budget,
-				fmt.Sprintf("%.2f", r.BudgetSpent),
-				fmt.Sprintf("%.2f", r.BudgetRemaining),
+				formatBudgetValue(r.BudgetSpent, r.BudgetBy, currency),
+				formatBudgetValue(r.BudgetRemaining, r.BudgetBy, currency),
+				used,
+				remaining,
 				active,
 			)
 		}
@@ -538,21 +1349,36 @@ func outputBudgetReport(w io.Writer, results []api.ProjectBudgetReportResult, mo
 	}
 }
 
+// currencyFormat holds the account's currency conventions once loaded by
+// loadCurrencyFormat. When nil, formatAmount prints "%.2f CUR".
+var currencyFormat *output.CurrencyFormat
+
+// loadCurrencyFormat fetches company settings so table and markdown output
+// format amounts the way the account does. JSON and plain output keep raw
+// numbers, and any failure keeps the plain fallback.
+func loadCurrencyFormat(ctx context.Context, cli *CLI, client *api.Client) {
+	if mode := output.ModeFromFlags(cli.JSON, cli.Plain); mode != output.ModeTable && mode != output.ModeMarkdown {
+		return
+	}
+	company, err := getCompany(ctx, cli, client)
+	if err != nil {
+		return
+	}
+	currencyFormat = &output.CurrencyFormat{
+		SymbolDisplay:      company.CurrencySymbolDisplay,
+		CodeDisplay:        company.CurrencyCodeDisplay,
+		DecimalSymbol:      company.DecimalSymbol,
+		ThousandsSeparator: company.ThousandsSeparator,
+	}
+}
+
 // formatAmount formats an amount with currency.
 func formatAmount(amount float64, currency string) string {
+	if currencyFormat != nil {
+		return currencyFormat.Format(amount, currency)
+	}
 	if currency == "" {
 		return fmt.Sprintf("%.2f", amount)
 	}
 	return fmt.Sprintf("%.2f %s", amount, currency)
 }
-
-// truncate shortens a string to max length with ellipsis.
-func truncate(s string, max int) string {
-	if len(s) <= max {
-		return s
-	}
-	if max <= 3 {
-		return s[:max]
-	}
-	return s[:max-3] + "..."
-}
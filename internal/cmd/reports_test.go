@@ -0,0 +1,374 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dedene/harvest-cli/internal/api"
+	"github.com/dedene/harvest-cli/internal/auth"
+	"github.com/dedene/harvest-cli/internal/output"
+)
+
+func TestSumByCurrency_TimeResults(t *testing.T) {
+	results := []api.TimeReportResult{
+		{TotalHours: 10, BillableHours: 8, BillableAmount: 800, Currency: "USD"},
+		{TotalHours: 5, BillableHours: 5, BillableAmount: 450, Currency: "EUR"},
+		{TotalHours: 2, BillableHours: 1, BillableAmount: 100, Currency: "USD"},
+	}
+
+	totals := sumByCurrency(results)
+	if len(totals) != 2 {
+		t.Fatalf("len(totals) = %d, want 2", len(totals))
+	}
+
+	// Sorted by currency code
+	if totals[0].Currency != "EUR" || totals[1].Currency != "USD" {
+		t.Errorf("currencies = %s, %s; want EUR, USD", totals[0].Currency, totals[1].Currency)
+	}
+
+	usd := totals[1]
+	if usd.Hours != 12 || usd.BillableHours != 9 || usd.BillableAmount != 900 {
+		t.Errorf("USD totals = %+v", usd)
+	}
+}
+
+func TestSumByCurrency_ExpenseResults(t *testing.T) {
+	results := []api.ExpenseReportResult{
+		{TotalAmount: 100, BillableAmount: 60, Currency: "GBP"},
+		{TotalAmount: 50, BillableAmount: 50, Currency: "GBP"},
+	}
+
+	totals := sumByCurrency(results)
+	if len(totals) != 1 {
+		t.Fatalf("len(totals) = %d, want 1", len(totals))
+	}
+	if totals[0].TotalAmount != 150 || totals[0].BillableAmount != 110 {
+		t.Errorf("GBP totals = %+v", totals[0])
+	}
+}
+
+func TestSumByCurrency_Empty(t *testing.T) {
+	if totals := sumByCurrency([]api.TimeReportResult{}); len(totals) != 0 {
+		t.Errorf("len(totals) = %d, want 0", len(totals))
+	}
+}
+
+func TestOutputTimeReportTable_Totals(t *testing.T) {
+	results := []api.TimeReportResult{
+		{ClientID: 1, ClientName: "Acme", TotalHours: 1, BillableAmount: 90, Currency: "EUR"},
+		{ClientID: 2, ClientName: "Globex", TotalHours: 2, BillableHours: 2, BillableAmount: 200, Currency: "USD"},
+	}
+
+	var buf bytes.Buffer
+	if err := outputTimeReportTable(&buf, results, "clients"); err != nil {
+		t.Fatalf("outputTimeReportTable() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 7 {
+		t.Fatalf("got %d lines, want header, separator, 2 rows, separator and 2 totals:\n%s", len(lines), buf.String())
+	}
+	for _, line := range lines[5:] {
+		if !strings.HasPrefix(line, "Total") || len(line) != len(lines[2]) {
+			t.Errorf("total %q should be a footer aligned with %q", line, lines[2])
+		}
+	}
+	if !strings.Contains(lines[5], "90.00 EUR") || !strings.Contains(lines[6], "200.00 USD") {
+		t.Errorf("totals = %q, want one per currency", lines[5:])
+	}
+}
+
+func TestFilterBudgetOver(t *testing.T) {
+	budget := func(v float64) *float64 { return &v }
+	results := []api.ProjectBudgetReportResult{
+		{ProjectID: 1, Budget: budget(100), BudgetSpent: 95},
+		{ProjectID: 2, Budget: budget(100), BudgetSpent: 50},
+		{ProjectID: 3, Budget: nil, BudgetSpent: 500},
+		{ProjectID: 4, Budget: budget(0), BudgetSpent: 10},
+		{ProjectID: 5, Budget: budget(200), BudgetSpent: 260},
+	}
+
+	filtered := filterBudgetOver(results, 80)
+	if len(filtered) != 2 || filtered[0].ProjectID != 1 || filtered[1].ProjectID != 5 {
+		t.Errorf("filtered = %+v, want projects 1 and 5", filtered)
+	}
+
+	if _, ok := budgetUsedPercent(results[2]); ok {
+		t.Error("budgetUsedPercent() should skip projects without a budget")
+	}
+	if pct, _ := budgetUsedPercent(results[4]); pct != 130 {
+		t.Errorf("budgetUsedPercent() = %v, want 130", pct)
+	}
+}
+
+func TestFilterBudgetType(t *testing.T) {
+	results := []api.ProjectBudgetReportResult{
+		{ProjectID: 1, BudgetBy: "project"},
+		{ProjectID: 2, BudgetBy: "project_cost"},
+		{ProjectID: 3, BudgetBy: "task_fees"},
+		{ProjectID: 4, BudgetBy: "none"},
+		{ProjectID: 5, BudgetBy: "person"},
+	}
+
+	money := filterBudgetType(results, true)
+	if len(money) != 2 || money[0].ProjectID != 2 || money[1].ProjectID != 3 {
+		t.Errorf("money budgets = %+v, want projects 2 and 3", money)
+	}
+	hours := filterBudgetType(results, false)
+	if len(hours) != 2 || hours[0].ProjectID != 1 || hours[1].ProjectID != 5 {
+		t.Errorf("hour budgets = %+v, want projects 1 and 5", hours)
+	}
+}
+
+func TestFilterBudgetScope(t *testing.T) {
+	results := []api.ProjectBudgetReportResult{
+		{ProjectID: 1, ClientID: 10},
+		{ProjectID: 2, ClientID: 20},
+		{ProjectID: 3, ClientID: 10},
+	}
+
+	if got := filterBudgetScope(results, 10, 0); len(got) != 2 || got[0].ProjectID != 1 || got[1].ProjectID != 3 {
+		t.Errorf("client 10 = %+v, want projects 1 and 3", got)
+	}
+	if got := filterBudgetScope(results, 0, 2); len(got) != 1 || got[0].ProjectID != 2 {
+		t.Errorf("project 2 = %+v, want project 2", got)
+	}
+	if got := filterBudgetScope(results, 10, 2); len(got) != 0 {
+		t.Errorf("client 10, project 2 = %+v, want none", got)
+	}
+}
+
+func TestOutputBudgetReport_Units(t *testing.T) {
+	budget := func(v float64) *float64 { return &v }
+	results := []api.ProjectBudgetReportResult{
+		{ProjectID: 1, ClientID: 10, BudgetBy: "project", Budget: budget(100), BudgetSpent: 25, BudgetRemaining: 75},
+		{ProjectID: 2, ClientID: 20, BudgetBy: "project_cost", Budget: budget(5000), BudgetSpent: 5500, BudgetRemaining: -500},
+	}
+	currencies := map[int64]string{10: "USD", 20: "EUR"}
+
+	var buf bytes.Buffer
+	if err := outputBudgetReport(&buf, results, currencies, output.ModeTable); err != nil {
+		t.Fatalf("outputBudgetReport() error = %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"Project hours", "100.00h", "75.00h", "75%", "Project fees", "5000.00 EUR", "-500.00 EUR", "-10%"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("table missing %q, got: %s", want, out)
+		}
+	}
+
+	buf.Reset()
+	if err := outputBudgetReport(&buf, results, currencies, output.ModePlain); err != nil {
+		t.Fatalf("outputBudgetReport() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if want := "2\t\t\tproject_cost\t5000.00\t5500.00\t-500.00\tfalse\tEUR\t-10.0\t110.0"; lines[2] != want {
+		t.Errorf("plain row = %q, want %q", lines[2], want)
+	}
+}
+
+func TestUninvoicedReport_FilterSortTotals(t *testing.T) {
+	results := []api.UninvoicedReportResult{
+		{ProjectID: 1, ProjectName: "Beta", ClientName: "Zeta", Currency: "EUR", UninvoicedHours: 2, UninvoicedAmount: 200},
+		{ProjectID: 2, ProjectName: "alpha", ClientName: "Acme", Currency: "USD", UninvoicedHours: 10, UninvoicedExpenses: 50, UninvoicedAmount: 1500},
+		{ProjectID: 3, ProjectName: "Gamma", ClientName: "Acme", Currency: "EUR", UninvoicedHours: 0.5, UninvoicedAmount: 40},
+		{ProjectID: 4, ProjectName: "Delta", ClientName: "Mid", Currency: "EUR", UninvoicedHours: 8, UninvoicedExpenses: 20, UninvoicedAmount: 900},
+	}
+
+	kept, hidden := filterUninvoiced(results, 100)
+	if hidden != 1 || len(kept) != 3 {
+		t.Fatalf("filterUninvoiced() kept %d, hid %d; want 3 and 1", len(kept), hidden)
+	}
+
+	ids := func(rs []api.UninvoicedReportResult) []int64 {
+		out := make([]int64, len(rs))
+		for i, r := range rs {
+			out[i] = r.ProjectID
+		}
+		return out
+	}
+	for _, tt := range []struct {
+		by   string
+		want []int64
+	}{
+		{"amount", []int64{4, 1, 2}}, // EUR before USD
+		{"hours", []int64{2, 4, 1}},
+		{"project", []int64{2, 1, 4}},
+		{"client", []int64{2, 4, 1}},
+	} {
+		sorted := append([]api.UninvoicedReportResult(nil), kept...)
+		sortUninvoiced(sorted, tt.by)
+		if got := ids(sorted); !slices.Equal(got, tt.want) {
+			t.Errorf("sortUninvoiced(%s) = %v, want %v", tt.by, got, tt.want)
+		}
+	}
+
+	totals := sumUninvoiced(kept)
+	if len(totals) != 2 || totals[0] != (uninvoicedTotal{Currency: "EUR", Hours: 10, Expenses: 20, Amount: 1100}) ||
+		totals[1] != (uninvoicedTotal{Currency: "USD", Hours: 10, Expenses: 50, Amount: 1500}) {
+		t.Errorf("sumUninvoiced() = %+v", totals)
+	}
+
+	var buf bytes.Buffer
+	if err := outputUninvoicedReport(&buf, kept, output.ModePlain, false); err != nil {
+		t.Fatalf("outputUninvoicedReport() error = %v", err)
+	}
+	if strings.Contains(buf.String(), "TOTAL") {
+		t.Errorf("plain output without --summary = %q, want no TOTAL rows", buf.String())
+	}
+
+	buf.Reset()
+	if err := outputUninvoicedReport(&buf, kept, output.ModePlain, true); err != nil {
+		t.Fatalf("outputUninvoicedReport() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if want := "TOTAL\t\t\t10.00\t20.00\t1100.00\tEUR"; lines[len(lines)-2] != want {
+		t.Errorf("plain total = %q, want %q", lines[len(lines)-2], want)
+	}
+
+	capture := &output.TableCapture{}
+	if err := outputUninvoicedReport(capture, kept, output.ModeTable, true); err != nil {
+		t.Fatalf("outputUninvoicedReport(table) error = %v", err)
+	}
+	if len(capture.Tables) != 1 {
+		t.Fatalf("rendered %d tables, want 1", len(capture.Tables))
+	}
+	if tbl := capture.Tables[0]; len(tbl.Rows) != len(kept) || len(tbl.Footers) != 2 || tbl.Footers[0][0] != "Total" {
+		t.Errorf("table rows = %v, footers = %v, want the totals as footers", tbl.Rows, tbl.Footers)
+	}
+}
+
+func TestAggregateByDay(t *testing.T) {
+	entries := []api.TimeEntry{
+		{SpentDate: "2024-01-03", Hours: 2, Billable: true},
+		{SpentDate: "2024-01-01", Hours: 1.5, Billable: false},
+		{SpentDate: "2024-01-03", Hours: 3.25, Billable: false},
+		{SpentDate: "2024-01-01", Hours: 4, Billable: true},
+	}
+
+	days := aggregateByDay(entries)
+	want := []dailyHours{
+		{Date: "2024-01-01", TotalHours: 5.5, BillableHours: 4},
+		{Date: "2024-01-03", TotalHours: 5.25, BillableHours: 2},
+	}
+	if len(days) != len(want) {
+		t.Fatalf("aggregateByDay() = %+v, want %+v", days, want)
+	}
+	for i := range want {
+		if days[i] != want[i] {
+			t.Errorf("days[%d] = %+v, want %+v", i, days[i], want[i])
+		}
+	}
+
+	if days := aggregateByDay(nil); len(days) != 0 {
+		t.Errorf("aggregateByDay(nil) = %+v, want empty", days)
+	}
+}
+
+func TestAggregateTimeReport(t *testing.T) {
+	rate := 100.0
+	entries := []api.TimeEntry{
+		{Hours: 2, Billable: true, BillableRate: &rate, Client: api.ClientRef{ID: 1, Name: "Acme"}, Task: api.TaskRef{ID: 20, Name: "Design"}},
+		{Hours: 1, Client: api.ClientRef{ID: 2, Name: "Globex"}, Task: api.TaskRef{ID: 20, Name: "Design"}},
+		{Hours: 3, Billable: true, BillableRate: &rate, Client: api.ClientRef{ID: 1, Name: "Acme"}, Task: api.TaskRef{ID: 10, Name: "Admin"}},
+		{Hours: 1.5, Billable: true, Client: api.ClientRef{ID: 1, Name: "Acme"}, Task: api.TaskRef{ID: 20, Name: "Design"}},
+	}
+	currencies := map[int64]string{1: "EUR", 2: "USD"}
+
+	got := aggregateTimeReport(entries, "tasks", currencies)
+	want := []api.TimeReportResult{
+		{TaskID: 10, TaskName: "Admin", TotalHours: 3, BillableHours: 3, BillableAmount: 300, Currency: "EUR"},
+		{TaskID: 20, TaskName: "Design", TotalHours: 3.5, BillableHours: 3.5, BillableAmount: 200, Currency: "EUR"},
+		{TaskID: 20, TaskName: "Design", TotalHours: 1, Currency: "USD"},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("aggregateTimeReport(tasks) = %+v, want %+v", got, want)
+	}
+
+	got = aggregateTimeReport(entries, "clients", currencies)
+	if len(got) != 2 || got[0].ClientName != "Acme" || got[0].TotalHours != 6.5 || got[1].ClientName != "Globex" {
+		t.Errorf("aggregateTimeReport(clients) = %+v", got)
+	}
+}
+
+func TestReportsTime_Exclude(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(auth.PATEnvToken, "pat")
+	t.Setenv(auth.PATEnvAccountID, "1")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/projects":
+			_, _ = w.Write([]byte(`{"projects":[{"id":2,"name":"Internal","is_active":true}],"total_pages":1,"page":1}`))
+		case "/time_entries":
+			_, _ = w.Write([]byte(`{"time_entries":[
+				{"id":1,"hours":2,"billable":true,"billable_rate":50,"client":{"id":1,"name":"Acme"},"project":{"id":1,"name":"Website"},"task":{"id":3,"name":"Design"}},
+				{"id":2,"hours":5,"client":{"id":1,"name":"Acme"},"project":{"id":2,"name":"Internal"},"task":{"id":4,"name":"Admin"}}],
+				"total_pages":1,"page":1}`))
+		case "/clients":
+			_, _ = w.Write([]byte(`{"clients":[{"id":1,"name":"Acme","currency":"EUR"}],"total_pages":1,"page":1}`))
+		default:
+			t.Errorf("unexpected request %s; exclusions cannot use the report endpoints", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"reports", "time", "--by", "clients", "-f", "2024-05-01", "-t", "2024-05-31",
+		"--exclude-project", "Internal", "--plain", "--api-base-url", srv.URL}
+	if err := Execute(args, &stdout, &stderr); err != nil {
+		t.Fatalf("Execute() error = %v\n%s", err, stderr.String())
+	}
+	if want := "1\tAcme\t2.00\t2.00\t100.00\tEUR"; !strings.Contains(stdout.String(), want) {
+		t.Errorf("stdout = %q, want %q", stdout.String(), want)
+	}
+	if !strings.Contains(stderr.String(), "Excluded 1 entries") {
+		t.Errorf("stderr = %q, want the excluded count", stderr.String())
+	}
+}
+
+func TestReportFileFlags_Write(t *testing.T) {
+	entries := []api.TimeEntry{
+		{SpentDate: "2024-01-02", Hours: 1.5, Billable: true, Notes: "Design, review"},
+		{SpentDate: "2024-01-03", Hours: 2},
+	}
+	entries[0].Project.Name = "Website"
+
+	path := filepath.Join(t.TempDir(), "detailed.csv")
+	flags := ReportFileFlags{Output: path}
+	if err := flags.validate(); err != nil {
+		t.Fatalf("validate() error = %v", err)
+	}
+
+	var stderr bytes.Buffer
+	headers, rows := detailedReportRows(entries, true)
+	if err := flags.write(&CLI{Stderr: &stderr}, headers, rows); err != nil {
+		t.Fatalf("write() error = %v", err)
+	}
+	if got := stderr.String(); got != "Wrote 3 rows to "+path+"\n" {
+		t.Errorf("stderr = %q", got)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "Date,User,Client,Project,Task,Hours,Billable,Notes\n" +
+		"2024-01-02,,,Website,,1.50,true,\"Design, review\"\n" +
+		"2024-01-03,,,,,2.00,false,\n" +
+		"TOTAL,,,,,3.50,,\n"
+	if string(data) != want {
+		t.Errorf("file = %q, want %q", data, want)
+	}
+
+	if err := (ReportFileFlags{Output: "report.txt"}).validate(); err == nil {
+		t.Error("validate() should reject a .txt output file")
+	}
+}
@@ -3,28 +3,75 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"io"
+	"net/url"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/alecthomas/kong"
+	"golang.org/x/term"
 
+	"github.com/dedene/harvest-cli/internal/api"
+	"github.com/dedene/harvest-cli/internal/auth"
+	"github.com/dedene/harvest-cli/internal/config"
 	"github.com/dedene/harvest-cli/internal/errfmt"
+	"github.com/dedene/harvest-cli/internal/output"
+	"github.com/dedene/harvest-cli/internal/ui"
 )
 
 // RootFlags are global flags available to all commands.
 type RootFlags struct {
-	Account   string `help:"Account email or alias" short:"a" env:"HARVESTCLI_ACCOUNT"`
-	AccountID int64  `help:"Harvest account ID override" env:"HARVESTCLI_ACCOUNT_ID"`
-	Client    string `help:"OAuth client name override"`
-	JSON      bool   `help:"Output as JSON" short:"j"`
-	Plain     bool   `help:"Output as TSV (plain text)"`
-	Verbose   bool   `help:"Verbose output" short:"v"`
-	Color     string `help:"Color output: auto, always, never" default:"auto" enum:"auto,always,never"`
+	Account         string   `help:"Account email or alias" short:"a" env:"HARVESTCLI_ACCOUNT"`
+	Profile         string   `help:"Apply a named profile of settings (see 'harvest config profile')" env:"HARVESTCLI_PROFILE"`
+	AccountID       int64    `help:"Harvest account ID override" env:"HARVESTCLI_ACCOUNT_ID"`
+	Client          string   `help:"OAuth client name override"`
+	AllAccounts     bool     `help:"Run a read command across every authenticated account" name:"all-accounts"`
+	JSON            bool     `help:"Output as JSON" short:"j"`
+	JSONCompact     bool     `help:"Output as compact single-line JSON (implies --json)" name:"json-compact"`
+	Plain           bool     `help:"Output as TSV (plain text)"`
+	CSV             bool     `help:"Output as CSV (plain output separated by commas)" name:"csv"`
+	Fields          []string `help:"Keep only these JSON keys, comma-separated; dot paths select nested keys (implies --json)" placeholder:"KEY,..."`
+	Markdown        bool     `help:"Output tables as GitHub-flavored markdown"`
+	Table           bool     `help:"Output as a table, overriding a profile or default_output format"`
+	Verbose         int      `help:"Log HTTP requests to stderr (-vv adds headers and error bodies)" short:"v" type:"counter"`
+	Quiet           bool     `help:"Print only IDs on success" short:"q"`
+	DryRun          bool     `help:"Print mutating requests instead of sending them" name:"dry-run"`
+	Yes             bool     `help:"Assume yes for confirmation prompts" short:"y" env:"HARVEST_ASSUME_YES"`
+	WeekStart       string   `help:"First day of the week (overrides account setting)" name:"week-start" enum:",monday,tuesday,wednesday,thursday,friday,saturday,sunday" default:""`
+	Color           string   `help:"Color output: auto, always, never" default:"auto" enum:"auto,always,never"`
+	NoColor         bool     `help:"Disable colored output (same as --color=never)" name:"no-color"`
+	NoTruncate      bool     `help:"Show full values instead of fitting tables to the terminal width" name:"no-truncate"`
+	DateFormat      string   `help:"Show dates in tables as iso, account (the company's format) or a pattern like DD/MM/YYYY" name:"date-format" placeholder:"FORMAT"`
+	KeyringBackend  string   `help:"Keyring backend: auto, keychain, file, secret-service or wincred (overrides HARVESTCLI_KEYRING_BACKEND)" name:"keyring-backend" enum:",auto,keychain,file,secret-service,wincred" default:""`
+	IncludeArchived bool     `help:"Match archived projects, clients, tasks and expense categories by name like active ones" name:"include-archived"`
+
+	MaxRetries     *int          `help:"Max retries for rate-limited and server errors (0 disables)" env:"HARVESTCLI_MAX_RETRIES"`
+	RetryBaseDelay time.Duration `help:"Initial retry backoff delay (e.g. 500ms)" name:"retry-base-delay" env:"HARVESTCLI_RETRY_BASE_DELAY"`
+	Timeout        time.Duration `help:"Per-request timeout (e.g. 30s)" env:"HARVESTCLI_TIMEOUT"`
+	APIBaseURL     string        `help:"Harvest API base URL, for proxies and mock servers" name:"api-base-url" env:"HARVEST_API_BASE_URL" default:"${api_base_url}"`
 }
 
 // CLI is the root command structure.
 type CLI struct {
 	RootFlags `embed:""`
 
+	// Stdout and Stderr receive command output. Execute wires them to the
+	// streams passed in by main; tests substitute buffers. Stdin is read by
+	// flags that accept "-".
+	Stdin  io.Reader `kong:"-"`
+	Stdout io.Writer `kong:"-"`
+	Stderr io.Writer `kong:"-"`
+
+	// Build is the version metadata of the running binary.
+	Build BuildInfo `kong:"-"`
+
+	// company caches the account's company settings for this run.
+	company *api.Company
+
+	// profile is the profile applied to this run, if any.
+	profile *config.Profile
+
 	Version    kong.VersionFlag `help:"Print version and exit"`
 	VersionCmd VersionCmd       `cmd:"" name:"version" help:"Show version information"`
 	Auth       AuthCmd          `cmd:"" help:"Authentication commands"`
@@ -40,19 +87,47 @@ type CLI struct {
 	Invoices   InvoicesCmd      `cmd:"" help:"Invoice commands"`
 	Reports    ReportsCmd       `cmd:"" help:"Report commands"`
 	Company    CompanyCmd       `cmd:"" help:"Show company information"`
+	Whoami     WhoamiCmd        `cmd:"" help:"Show the authenticated user and account"`
 	Approvals  ApprovalsCmd     `cmd:"" help:"Approval workflow commands"`
 	Bulk       BulkCmd          `cmd:"" help:"Bulk import/export operations"`
+	Sync       SyncCmd          `cmd:"" help:"Mirror Harvest data locally and push entries queued offline"`
 	Completion CompletionCmd    `cmd:"" help:"Generate shell completions"`
 	Dashboard  DashboardCmd     `cmd:"" help:"Show weekly time tracking summary"`
+	Doctor     DoctorCmd        `cmd:"" help:"Check config, keyring, credentials and connectivity"`
+}
+
+// printSuccess writes a human-readable success line to stdout. With --quiet,
+// only the affected ID is printed, or nothing when id is zero.
+func printSuccess(cli *CLI, id int64, format string, args ...any) {
+	if cli.Quiet {
+		if id != 0 {
+			fmt.Fprintln(cli.Stdout, id)
+		}
+		return
+	}
+	fmt.Fprintf(cli.Stdout, format, args...)
+}
+
+// jsonOutput reports whether this run writes JSON, from --json or the
+// default output format.
+func (c *CLI) jsonOutput() bool {
+	return output.ModeFromFlags(c.JSON, c.Plain) == output.ModeJSON
+}
+
+// stdinIsTerminal reports whether the command can prompt on stdin.
+func stdinIsTerminal(cli *CLI) bool {
+	f, ok := cli.Stdin.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
 }
 
 type exitPanic struct{ code int }
 
-// Execute parses args and runs the appropriate command.
-func Execute(args []string) (err error) {
-	parser, err := newParser()
+// Execute parses args and runs the appropriate command, writing output to
+// stdout and stderr.
+func Execute(args []string, stdout, stderr io.Writer) (err error) {
+	parser, cli, err := newParser(stdout, stderr)
 	if err != nil {
-		_, _ = fmt.Fprintln(os.Stderr, err)
+		_, _ = fmt.Fprintln(stderr, err)
 		return err
 	}
 
@@ -75,22 +150,142 @@ func Execute(args []string) (err error) {
 		args = []string{"--help"}
 	}
 
+	ui.SetOutput(stderr)
+
 	kctx, err := parser.Parse(args)
+	if err == nil {
+		err = applyProfile(cli)
+	}
+	if err == nil {
+		err = checkAPIBaseURL(cli.APIBaseURL)
+	}
+	if err == nil {
+		err = applyDateFormat(&cli.RootFlags)
+	}
 	if err != nil {
 		parsedErr := wrapParseError(err)
-		_, _ = fmt.Fprintln(os.Stderr, parsedErr)
+		_, _ = fmt.Fprintln(stderr, parsedErr)
 		return parsedErr
 	}
 
-	err = kctx.Run()
+	if cli.JSONCompact {
+		cli.JSON = true
+		output.SetCompactJSON(true)
+	}
+	if len(cli.Fields) > 0 {
+		cli.JSON = true
+	}
+	output.SetJSONFields(cli.Fields)
+
+	mode := defaultOutputMode(cli)
+	output.SetDefaultMode(mode)
+	// An explicit --plain keeps tabs over a csv default
+	output.SetPlainCSV(mode == output.ModeCSV && !cli.Plain)
+	maxWidth := 0
+	if !cli.NoTruncate {
+		maxWidth = output.TerminalWidth(stdout)
+	}
+	output.SetMaxWidth(maxWidth)
+	ui.SetAssumeYes(cli.Yes)
+	includeArchived = cli.IncludeArchived
+	lookupWarnings = stderr
+	pickMatch = nil
+	if stdinIsTerminal(cli) {
+		pickMatch = pickNamedRecord
+	}
+	output.SetDefaultColors(output.NewColorsFor(stdout, colorMode(&cli.RootFlags)))
+
+	if cli.AllAccounts {
+		err = runAllAccounts(kctx, cli)
+	} else {
+		err = kctx.Run()
+	}
 	if err != nil {
-		_, _ = fmt.Fprintln(os.Stderr, errfmt.FormatError(err))
+		if cli.jsonOutput() {
+			_ = writeErrorJSON(stderr, err)
+		} else {
+			_, _ = fmt.Fprintln(stderr, errfmt.FormatError(err))
+		}
 		return err
 	}
 
 	return nil
 }
 
+// colorMode resolves the effective color mode from flags and config.
+// --no-color wins, then an explicit --color, then the config color setting.
+func colorMode(flags *RootFlags) string {
+	if flags.NoColor {
+		return "never"
+	}
+	if flags.Color != "auto" {
+		return flags.Color
+	}
+	if cfg, err := config.ReadConfig(); err == nil && cfg.Color != "" {
+		return cfg.Color
+	}
+	return flags.Color
+}
+
+// resolveDateFormat returns the effective date format: --date-format, then
+// the date_format config setting, then "iso".
+func resolveDateFormat(flags *RootFlags) string {
+	if flags.DateFormat != "" {
+		return flags.DateFormat
+	}
+	if cfg, err := config.ReadConfig(); err == nil && cfg.DateFormat != "" {
+		return cfg.DateFormat
+	}
+	return "iso"
+}
+
+// keyringOptions picks the keyring backend: --keyring-backend, then
+// HARVESTCLI_KEYRING_BACKEND, then the keyring_backend config setting.
+func keyringOptions(flags *RootFlags) []auth.OpenOption {
+	if flags != nil && flags.KeyringBackend != "" {
+		return []auth.OpenOption{auth.WithBackend(flags.KeyringBackend, "--keyring-backend")}
+	}
+	if os.Getenv(auth.KeyringBackendEnv) != "" {
+		return nil
+	}
+	if cfg, err := config.ReadConfig(); err == nil && cfg.KeyringBackend != "" {
+		return []auth.OpenOption{auth.WithBackend(cfg.KeyringBackend, "keyring_backend config setting")}
+	}
+	return nil
+}
+
+// openStore opens the keyring with the backend picked by keyringOptions.
+func openStore(flags *RootFlags) (auth.Store, error) {
+	return auth.OpenDefault(keyringOptions(flags)...)
+}
+
+// applyDateFormat sets the layout tables show dates in. The "account"
+// format needs the company settings, so it is applied once a client exists
+// (see applyAccountDateFormat).
+func applyDateFormat(flags *RootFlags) error {
+	output.SetDateLayout("")
+	format := resolveDateFormat(flags)
+	if strings.EqualFold(format, "iso") || strings.EqualFold(format, "account") {
+		return nil
+	}
+	layout, err := output.DateLayout(format)
+	if err != nil {
+		return &ExitError{Code: 2, Err: fmt.Errorf("--date-format: %w", err)}
+	}
+	output.SetDateLayout(layout)
+	return nil
+}
+
+// checkAPIBaseURL rejects --api-base-url values that are not absolute
+// http(s) URLs.
+func checkAPIBaseURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return &ExitError{Code: 2, Err: fmt.Errorf("--api-base-url %q must be an http or https URL", raw)}
+	}
+	return nil
+}
+
 func wrapParseError(err error) error {
 	if err == nil {
 		return nil
@@ -104,21 +299,22 @@ func wrapParseError(err error) error {
 	return err
 }
 
-func newParser() (*kong.Kong, error) {
-	cli := &CLI{}
+func newParser(stdout, stderr io.Writer) (*kong.Kong, *CLI, error) {
+	cli := &CLI{Stdin: os.Stdin, Stdout: stdout, Stderr: stderr, Build: buildInfo}
 	parser, err := kong.New(
 		cli,
 		kong.Name("harvest"),
 		kong.Description("Harvest time tracking CLI"),
-		kong.Vars{"version": VersionString()},
+		kong.Vars{"version": buildInfo.String(), "api_base_url": api.BaseURL},
 		kong.Exit(func(code int) { panic(exitPanic{code: code}) }),
+		kong.Writers(stdout, stderr),
 		kong.BindTo(cli, (*CLI)(nil)),
 		kong.Help(helpPrinter),
 		kong.ConfigureHelp(helpOptions()),
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return parser, nil
+	return parser, cli, nil
 }
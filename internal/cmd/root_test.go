@@ -0,0 +1,256 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dedene/harvest-cli/internal/auth"
+	"github.com/dedene/harvest-cli/internal/config"
+	"github.com/dedene/harvest-cli/internal/output"
+)
+
+func TestExecute_CapturesStdout(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	if err := Execute([]string{"version"}, &stdout, &stderr); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if !strings.HasPrefix(stdout.String(), "harvest ") {
+		t.Errorf("stdout = %q, want version line", stdout.String())
+	}
+	if stderr.Len() != 0 {
+		t.Errorf("stderr = %q, want empty", stderr.String())
+	}
+}
+
+func TestExecute_ParseErrorGoesToStderr(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	err := Execute([]string{"no-such-command"}, &stdout, &stderr)
+	if ExitCode(err) != 2 {
+		t.Errorf("ExitCode() = %d, want 2", ExitCode(err))
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("stdout = %q, want empty", stdout.String())
+	}
+	if stderr.Len() == 0 {
+		t.Error("stderr should contain the parse error")
+	}
+}
+
+func TestExecute_JSONErrorGoesToStderr(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	err := Execute([]string{"--json", "invoices", "payments", "list"}, &stdout, &stderr)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("stdout = %q, want empty", stdout.String())
+	}
+
+	var got map[string]errorJSON
+	if err := json.Unmarshal(stderr.Bytes(), &got); err != nil {
+		t.Fatalf("stderr is not JSON: %v\n%s", err, stderr.String())
+	}
+	if got["error"].Code != 1 || !strings.Contains(got["error"].Message, "invoice ID required") {
+		t.Errorf("error = %+v", got["error"])
+	}
+}
+
+func TestExecute_FieldsErrorEnvelope(t *testing.T) {
+	defer output.SetJSONFields(nil)
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(auth.PATEnvToken, "pat")
+	t.Setenv(auth.PATEnvAccountID, "1")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Not found"}`))
+	}))
+	defer srv.Close()
+
+	var stdout, stderr bytes.Buffer
+	err := Execute([]string{"--fields", "id", "projects", "show", "7", "--api-base-url", srv.URL}, &stdout, &stderr)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	var got map[string]errorJSON
+	if err := json.Unmarshal(stderr.Bytes(), &got); err != nil {
+		t.Fatalf("stderr is not JSON: %v\n%s", err, stderr.String())
+	}
+	if e, ok := got["error"]; !ok || e.Status != http.StatusNotFound || e.Message == "" {
+		t.Errorf("stderr = %s, want the full error envelope", stderr.String())
+	}
+}
+
+func TestExecute_Fields(t *testing.T) {
+	defer output.SetJSONFields(nil)
+	var stdout, stderr bytes.Buffer
+
+	if err := Execute([]string{"--fields", "go_version,version", "version"}, &stdout, &stderr); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !strings.HasPrefix(stdout.String(), "{\n  \"go_version\": ") {
+		t.Errorf("stdout = %q, want go_version first", stdout.String())
+	}
+	var got map[string]any
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatalf("stdout is not JSON: %v", err)
+	}
+	if len(got) != 2 || got["version"] == nil {
+		t.Errorf("got %v, want go_version and version only", got)
+	}
+
+	stdout.Reset()
+	stderr.Reset()
+	err := Execute([]string{"--fields", "nope", "version"}, &stdout, &stderr)
+	if err == nil || !strings.Contains(err.Error(), `unknown field "nope"`) {
+		t.Errorf("Execute() error = %v, want unknown field", err)
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("stdout = %q, want empty", stdout.String())
+	}
+}
+
+func TestPrintSuccess(t *testing.T) {
+	tests := []struct {
+		name  string
+		quiet bool
+		id    int64
+		want  string
+	}{
+		{name: "default", id: 42, want: "Created thing #42\n"},
+		{name: "quiet with id", quiet: true, id: 42, want: "42\n"},
+		{name: "quiet without id", quiet: true, id: 0, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			cli := &CLI{Stdout: &buf}
+			cli.Quiet = tt.quiet
+
+			printSuccess(cli, tt.id, "Created thing #%d\n", 42)
+
+			if buf.String() != tt.want {
+				t.Errorf("output = %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestExecute_DateFormat(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(auth.PATEnvToken, "pat")
+	t.Setenv(auth.PATEnvAccountID, "1")
+	defer output.SetDateLayout("")
+
+	companyCalls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/company" {
+			companyCalls++
+			_, _ = w.Write([]byte(`{"name":"Acme","date_format":"%d.%m.%Y"}`))
+			return
+		}
+		entry := `{"id":5,"spent_date":"2026-03-09","hours":1.5,"project":{"name":"Site"},"task":{"name":"Dev"}}`
+		switch r.URL.Path {
+		case "/time_entries":
+			_, _ = w.Write([]byte(`{"time_entries":[` + entry + `],"total_pages":1}`))
+		case "/tasks":
+			_, _ = w.Write([]byte(`{"tasks":[{"id":2,"name":"Dev","is_active":true}],"total_pages":1}`))
+		default:
+			_, _ = w.Write([]byte(entry))
+		}
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name      string
+		command   []string
+		args      []string
+		want      string
+		wantCalls int
+	}{
+		{name: "default", want: "Date:    2026-03-09\n"},
+		{name: "pattern", args: []string{"--date-format", "DD/MM/YYYY"}, want: "Date:    09/03/2026\n"},
+		{name: "account", args: []string{"--date-format", "account"}, want: "Date:    09.03.2026\n", wantCalls: 1},
+		{name: "account plain", args: []string{"--date-format", "account", "--plain"}, want: "5\t2026-03-09\t", wantCalls: 0},
+		{name: "account markdown", command: []string{"time", "list"}, args: []string{"--date-format", "account", "--markdown"}, want: "| 5 | 09.03.2026 |", wantCalls: 1},
+		{name: "account without dates", command: []string{"tasks", "list"}, args: []string{"--date-format", "account"}, want: "Dev", wantCalls: 0},
+		{name: "pattern json", args: []string{"--date-format", "DD/MM/YYYY", "--json"}, want: `"spent_date": "2026-03-09"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			companyCalls = 0
+			var stdout, stderr bytes.Buffer
+			command := tt.command
+			if command == nil {
+				command = []string{"time", "show", "5"}
+			}
+			args := append(append(command, "--api-base-url", srv.URL), tt.args...)
+			if err := Execute(args, &stdout, &stderr); err != nil {
+				t.Fatalf("Execute() error = %v, stderr: %s", err, stderr.String())
+			}
+			if !strings.Contains(stdout.String(), tt.want) {
+				t.Errorf("stdout = %q, want it to contain %q", stdout.String(), tt.want)
+			}
+			if companyCalls != tt.wantCalls {
+				t.Errorf("company requests = %d, want %d", companyCalls, tt.wantCalls)
+			}
+		})
+	}
+
+	var stdout, stderr bytes.Buffer
+	err := Execute([]string{"--date-format", "HH:MM", "version"}, &stdout, &stderr)
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != 2 {
+		t.Errorf("Execute() error = %v, want exit code 2 for an unsupported format", err)
+	}
+}
+
+func TestKeyringOptions(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(auth.KeyringBackendEnv, "")
+
+	if err := config.WriteConfig(&config.File{KeyringBackend: "file"}); err != nil {
+		t.Fatalf("WriteConfig() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		flag string
+		env  string
+		want string
+	}{
+		{name: "config", want: "file (from keyring_backend config setting)"},
+		{name: "env beats config", env: "secret-service", want: "secret-service (from HARVESTCLI_KEYRING_BACKEND)"},
+		{name: "flag beats env", flag: "wincred", env: "secret-service", want: "wincred (from --keyring-backend)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(auth.KeyringBackendEnv, tt.env)
+			got, err := auth.DescribeBackend(keyringOptions(&RootFlags{KeyringBackend: tt.flag})...)
+			if err != nil || got != tt.want {
+				t.Errorf("DescribeBackend() = %q, %v; want %q", got, err, tt.want)
+			}
+		})
+	}
+
+	var stdout, stderr bytes.Buffer
+	err := Execute([]string{"--keyring-backend", "gnome", "version"}, &stdout, &stderr)
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != 2 {
+		t.Errorf("Execute() error = %v, want exit code 2 for an unknown backend", err)
+	}
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/dedene/harvest-cli/internal/api"
+	"github.com/dedene/harvest-cli/internal/dateparse"
+	"github.com/dedene/harvest-cli/internal/output"
+)
+
+// TimeSummaryCmd prints your total hours for today, this week or this month.
+type TimeSummaryCmd struct {
+	Today bool `help:"Total for today (default)"`
+	Week  bool `help:"Total for this week"`
+	Month bool `help:"Total for this month"`
+}
+
+// periodSummary is the total of your hours in a period.
+type periodSummary struct {
+	Period        string  `json:"period"`
+	From          string  `json:"from"`
+	To            string  `json:"to"`
+	Hours         float64 `json:"hours"`
+	BillableHours float64 `json:"billable_hours"`
+}
+
+func (c *TimeSummaryCmd) Run(cli *CLI) error {
+	period, err := c.period()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	client, err := NewClientFromFlags(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	var weekStart time.Weekday
+	if period == "week" {
+		if weekStart, err = resolveWeekStart(ctx, cli, client); err != nil {
+			return err
+		}
+	}
+	from, to := periodRange(period, time.Now(), weekStart)
+
+	userID, err := resolveUserID(ctx, client, "me")
+	if err != nil {
+		return err
+	}
+	entries, err := client.ListAllTimeEntries(ctx, api.TimeEntryListOptions{From: from, To: to, UserID: userID})
+	if err != nil {
+		return fmt.Errorf("list time entries: %w", err)
+	}
+
+	totals := sumTimeEntries(entries)
+	summary := periodSummary{Period: period, From: from, To: to, Hours: totals.Hours, BillableHours: totals.BillableHours}
+	return outputPeriodSummary(cli.Stdout, summary, output.ModeFromFlags(cli.JSON, cli.Plain))
+}
+
+// period returns the period the flags select, defaulting to today.
+func (c *TimeSummaryCmd) period() (string, error) {
+	var periods []string
+	for _, p := range []struct {
+		set  bool
+		name string
+	}{{c.Today, "today"}, {c.Week, "week"}, {c.Month, "month"}} {
+		if p.set {
+			periods = append(periods, p.name)
+		}
+	}
+	switch len(periods) {
+	case 0:
+		return "today", nil
+	case 1:
+		return periods[0], nil
+	}
+	return "", fmt.Errorf("--today, --week and --month cannot be combined")
+}
+
+// periodRange returns the first and last date of the period containing now.
+func periodRange(period string, now time.Time, weekStart time.Weekday) (from, to string) {
+	switch period {
+	case "week":
+		return weekRange(now, weekStart)
+	case "month":
+		first := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+		return dateparse.FormatDate(first), dateparse.FormatDate(first.AddDate(0, 1, -1))
+	}
+	today := dateparse.FormatDate(now)
+	return today, today
+}
+
+// outputPeriodSummary writes a period's totals in the specified format.
+func outputPeriodSummary(w io.Writer, s periodSummary, mode output.Mode) error {
+	switch mode {
+	case output.ModeJSON:
+		return output.WriteJSON(w, s)
+	case output.ModePlain:
+		headers := []string{"Period", "From", "To", "Hours", "BillableHours"}
+		rows := [][]string{{s.Period, s.From, s.To, fmt.Sprintf("%.2f", s.Hours), fmt.Sprintf("%.2f", s.BillableHours)}}
+		return output.WriteTSV(w, headers, rows)
+	default:
+		label := "today"
+		if s.Period != "today" {
+			label = "this " + s.Period
+		}
+		fmt.Fprintf(w, "%.2fh %s (%.2fh billable)\n", s.Hours, label, s.BillableHours)
+		return nil
+	}
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dedene/harvest-cli/internal/auth"
+)
+
+func TestPeriodRange(t *testing.T) {
+	// Wednesday
+	now := time.Date(2024, 2, 14, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		period   string
+		from, to string
+	}{
+		{"today", "2024-02-14", "2024-02-14"},
+		{"week", "2024-02-12", "2024-02-18"},
+		{"month", "2024-02-01", "2024-02-29"},
+	}
+	for _, tt := range tests {
+		from, to := periodRange(tt.period, now, time.Monday)
+		if from != tt.from || to != tt.to {
+			t.Errorf("periodRange(%q) = %s..%s, want %s..%s", tt.period, from, to, tt.from, tt.to)
+		}
+	}
+}
+
+func TestTimeSummary(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(auth.PATEnvToken, "pat")
+	t.Setenv(auth.PATEnvAccountID, "1")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/users/me":
+			_, _ = w.Write([]byte(`{"id":7}`))
+		case "/time_entries":
+			if q := r.URL.Query(); q.Get("user_id") != "7" || q.Get("from") == "" || q.Get("from") != q.Get("to") {
+				t.Errorf("time_entries query = %q, want today for user 7", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"time_entries":[{"id":1,"hours":2.5,"billable":true},{"id":2,"hours":1}],"total_pages":1,"page":1}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	today := time.Now().Format("2006-01-02")
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{nil, "3.50h today (2.50h billable)\n"},
+		{[]string{"--json"}, "{\n  \"period\": \"today\",\n  \"from\": \"" + today + "\",\n  \"to\": \"" + today + "\",\n  \"hours\": 3.5,\n  \"billable_hours\": 2.5\n}\n"},
+		{[]string{"--plain"}, "Period\tFrom\tTo\tHours\tBillableHours\ntoday\t" + today + "\t" + today + "\t3.50\t2.50\n"},
+	}
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+		args := append([]string{"time", "summary", "--api-base-url", srv.URL}, tt.args...)
+		if err := Execute(args, &stdout, &stderr); err != nil {
+			t.Fatalf("Execute(%v) error = %v, stderr: %s", tt.args, err, stderr.String())
+		}
+		if stdout.String() != tt.want {
+			t.Errorf("Execute(%v) stdout = %q, want %q", tt.args, stdout.String(), tt.want)
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	if err := Execute([]string{"time", "summary", "--week", "--month", "--api-base-url", srv.URL}, &stdout, &stderr); err == nil {
+		t.Error("Execute() with --week and --month should fail")
+	}
+}
@@ -0,0 +1,513 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/dedene/harvest-cli/internal/api"
+	"github.com/dedene/harvest-cli/internal/config"
+	"github.com/dedene/harvest-cli/internal/output"
+)
+
+// SyncCmd groups the commands that move data between Harvest and local
+// files.
+type SyncCmd struct {
+	Pull SyncPullCmd `cmd:"" default:"withargs" help:"Mirror time entries, expenses and invoices to a local file"`
+	Push SyncPushCmd `cmd:"" help:"Create time entries queued with --offline"`
+}
+
+// SyncPullCmd mirrors time entries, expenses and invoices to a local store.
+// Each run only fetches records updated since the previous one.
+type SyncPullCmd struct {
+	Format string `help:"Store format: json or sqlite" enum:"json,sqlite" default:"json"`
+	Output string `help:"Store file path (default: sync-<account>.json, or .db with --format sqlite, in the config state dir)" short:"o"`
+	Full   bool   `help:"Ignore previous progress and fetch everything"`
+}
+
+// syncStore is the on-disk layout of a local mirror. Records are keyed by
+// ID, so deletions in Harvest are not reflected.
+type syncStore struct {
+	AccountID   int64           `json:"account_id"`
+	SyncedAt    time.Time       `json:"synced_at"`
+	TimeEntries []api.TimeEntry `json:"time_entries"`
+	Expenses    []api.Expense   `json:"expenses"`
+	Invoices    []api.Invoice   `json:"invoices"`
+}
+
+// syncResult reports what a sync run changed.
+type syncResult struct {
+	Path        string `json:"path"`
+	TimeEntries int    `json:"time_entries"`
+	Expenses    int    `json:"expenses"`
+	Invoices    int    `json:"invoices"`
+	Full        bool   `json:"full"`
+}
+
+func (c *SyncPullCmd) Run(cli *CLI) error {
+	ctx := context.Background()
+	client, err := NewClientFromFlags(ctx, cli)
+	if err != nil {
+		return err
+	}
+	accountID := client.AccountID()
+
+	read, write, ext := readSyncStore, writeSyncStore, ".json"
+	if c.Format == "sqlite" {
+		read, write, ext = readSQLiteSyncStore, writeSQLiteSyncStore, ".db"
+	}
+	path := c.Output
+	if path == "" {
+		path = filepath.Join(config.StateDir(), "sync-"+strconv.FormatInt(accountID, 10)+ext)
+	} else {
+		path = config.ExpandPath(path)
+	}
+
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return err
+	}
+	state := cfg.SyncStateFor(accountID)
+
+	store, err := read(path)
+	if err != nil {
+		return err
+	}
+	// Without a matching store, the recorded progress would skip everything
+	// that should be in it
+	full := c.Full || store == nil || store.AccountID != accountID
+	if full {
+		store = &syncStore{AccountID: accountID}
+		state = config.SyncState{}
+	}
+
+	entries, err := client.ListAllTimeEntries(ctx, api.TimeEntryListOptions{UpdatedSince: state.TimeEntries})
+	if err != nil {
+		return fmt.Errorf("list time entries: %w", err)
+	}
+	expenses, err := client.ListAllExpenses(ctx, api.ExpenseListOptions{UpdatedSince: state.Expenses})
+	if err != nil {
+		return fmt.Errorf("list expenses: %w", err)
+	}
+	invoices, err := client.ListAllInvoices(ctx, api.InvoiceListOptions{UpdatedSince: state.Invoices})
+	if err != nil {
+		return fmt.Errorf("list invoices: %w", err)
+	}
+
+	store.TimeEntries = mergeByID(store.TimeEntries, entries, func(e api.TimeEntry) int64 { return e.ID })
+	store.Expenses = mergeByID(store.Expenses, expenses, func(e api.Expense) int64 { return e.ID })
+	store.Invoices = mergeByID(store.Invoices, invoices, func(i api.Invoice) int64 { return i.ID })
+	store.SyncedAt = time.Now().UTC()
+
+	state.TimeEntries = highWaterMark(state.TimeEntries, entries, func(e api.TimeEntry) time.Time { return e.UpdatedAt })
+	state.Expenses = highWaterMark(state.Expenses, expenses, func(e api.Expense) time.Time { return e.UpdatedAt })
+	state.Invoices = highWaterMark(state.Invoices, invoices, func(i api.Invoice) time.Time { return i.UpdatedAt })
+
+	result := syncResult{
+		Path:        path,
+		TimeEntries: len(entries),
+		Expenses:    len(expenses),
+		Invoices:    len(invoices),
+		Full:        full,
+	}
+
+	if cli.DryRun {
+		fmt.Fprintf(cli.Stderr, "Dry run - not writing %s\n", path)
+	} else {
+		// Write the store before recording progress, so an interrupted run
+		// is fetched again rather than skipped
+		if err := write(path, store); err != nil {
+			return err
+		}
+		cfg.SetSyncStateFor(accountID, state)
+		if err := config.WriteConfig(cfg); err != nil {
+			return err
+		}
+	}
+
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, result)
+	}
+	if cli.Quiet {
+		return nil
+	}
+	fmt.Fprintf(cli.Stdout, "Synced %d time entries, %d expenses, %d invoices to %s\n",
+		result.TimeEntries, result.Expenses, result.Invoices, path)
+	return nil
+}
+
+// readSyncStore loads a local mirror, returning nil if it does not exist.
+func readSyncStore(path string) (*syncStore, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read sync store: %w", err)
+	}
+	var store syncStore
+	if err := json.Unmarshal(data, &store); err != nil {
+		return nil, fmt.Errorf("parse sync store %s: %w", path, err)
+	}
+	return &store, nil
+}
+
+// writeSyncStore writes a local mirror, replacing any previous file.
+func writeSyncStore(path string, store *syncStore) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("create sync dir: %w", err)
+	}
+	data, err := json.MarshalIndent(store, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode sync store: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("write sync store: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("write sync store: %w", err)
+	}
+	return nil
+}
+
+// mergeByID upserts updated records into existing ones, sorted by ID.
+func mergeByID[T any](existing, updated []T, id func(T) int64) []T {
+	byID := make(map[int64]T, len(existing)+len(updated))
+	for _, item := range existing {
+		byID[id(item)] = item
+	}
+	for _, item := range updated {
+		byID[id(item)] = item
+	}
+
+	merged := make([]T, 0, len(byID))
+	for _, item := range byID {
+		merged = append(merged, item)
+	}
+	sort.Slice(merged, func(i, j int) bool { return id(merged[i]) < id(merged[j]) })
+	return merged
+}
+
+// highWaterMark returns the newest updated_at among items as RFC 3339, or
+// prev when no item is newer.
+func highWaterMark[T any](prev string, items []T, updatedAt func(T) time.Time) string {
+	latest, _ := time.Parse(time.RFC3339, prev)
+	for _, item := range items {
+		if t := updatedAt(item); t.After(latest) {
+			latest = t
+		}
+	}
+	if latest.IsZero() {
+		return prev
+	}
+	return latest.UTC().Format(time.RFC3339)
+}
+
+// SyncPushCmd replays time entries and timer stops queued with --offline.
+type SyncPushCmd struct{}
+
+// Push outcomes. Failed items stay queued for the next push.
+const (
+	pushCreated   = "created"
+	pushStopped   = "stopped"
+	pushDuplicate = "duplicate"
+	pushSkipped   = "skipped"
+	pushFailed    = "failed"
+)
+
+// pushResult reports what happened to one queued item.
+type pushResult struct {
+	QueueID string `json:"queue_id"`
+	Kind    string `json:"kind"`
+	Status  string `json:"status"`
+	EntryID int64  `json:"entry_id,omitempty"`
+	Detail  string `json:"detail,omitempty"`
+}
+
+func (c *SyncPushCmd) Run(cli *CLI) error {
+	ctx := context.Background()
+	client, err := NewClientFromFlags(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	path := queuePath()
+	queue, err := readQueue(path)
+	if err != nil {
+		return err
+	}
+
+	// Items queued under another account wait until that account pushes
+	var mine, remaining []queuedEntry
+	for _, item := range queue {
+		if item.AccountID == client.AccountID() {
+			mine = append(mine, item)
+		} else {
+			remaining = append(remaining, item)
+		}
+	}
+	if len(remaining) > 0 {
+		fmt.Fprintf(cli.Stderr, "%d queued items belong to other accounts; push with --account to send them\n", len(remaining))
+	}
+	if len(mine) == 0 {
+		if cli.jsonOutput() {
+			return output.WriteJSON(cli.Stdout, []pushResult{})
+		}
+		fmt.Fprintln(cli.Stderr, "Nothing to push")
+		return nil
+	}
+
+	if cli.DryRun {
+		for _, item := range mine {
+			fmt.Fprintf(cli.Stderr, "  %s\n", describeQueued(item))
+		}
+		fmt.Fprintf(cli.Stderr, "Dry run - %d queued items not pushed\n", len(mine))
+		return nil
+	}
+
+	p := &pusher{client: client, resolver: newIDResolver(client)}
+	results := make([]pushResult, 0, len(mine))
+	failed := 0
+	for _, item := range mine {
+		result := p.push(ctx, item)
+		results = append(results, result)
+		if result.Status == pushFailed {
+			failed++
+			remaining = append(remaining, item)
+		}
+	}
+
+	if err := writeQueue(path, remaining); err != nil {
+		return err
+	}
+
+	if err := outputPushResults(cli.Stdout, results, output.ModeFromFlags(cli.JSON, cli.Plain)); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d queued items failed and remain queued", failed, len(mine))
+	}
+	return nil
+}
+
+// describeQueued summarizes a queued item for previews.
+func describeQueued(item queuedEntry) string {
+	switch item.Kind {
+	case queueKindStop:
+		return fmt.Sprintf("%s: stop timer at %s", item.ID, item.QueuedAt.Local().Format("2006-01-02 3:04pm"))
+	default:
+		desc := fmt.Sprintf("%s: %s - %s on %s", item.ID, item.Project, item.Task, item.Input.SpentDate)
+		if item.Input.Hours != nil && *item.Input.Hours > 0 {
+			desc += fmt.Sprintf(" (%.2fh)", *item.Input.Hours)
+		}
+		return desc
+	}
+}
+
+// pusher replays queued items against one account.
+type pusher struct {
+	client   *api.Client
+	resolver *idResolver
+	userID   int64
+}
+
+// push replays one queued item.
+func (p *pusher) push(ctx context.Context, item queuedEntry) pushResult {
+	result := pushResult{QueueID: item.ID, Kind: item.Kind}
+	var err error
+	switch item.Kind {
+	case queueKindAdd:
+		err = p.pushAdd(ctx, item, &result)
+	case queueKindStop:
+		err = p.pushStop(ctx, item, &result)
+	default:
+		err = fmt.Errorf("unknown queued kind %q", item.Kind)
+	}
+	if err != nil {
+		result.Status = pushFailed
+		result.Detail = err.Error()
+	}
+	return result
+}
+
+// pushAdd creates a queued time entry, unless an earlier push already did.
+func (p *pusher) pushAdd(ctx context.Context, item queuedEntry, result *pushResult) error {
+	if item.Input == nil {
+		return fmt.Errorf("queued entry has no time entry data")
+	}
+	projectID, err := p.resolver.projectID(ctx, item.Project)
+	if err != nil {
+		return err
+	}
+	taskID, err := p.resolver.taskID(ctx, projectID, item.Task)
+	if err != nil {
+		return err
+	}
+	input := *item.Input
+	input.ProjectID, input.TaskID = projectID, taskID
+
+	existing, err := p.findPushed(ctx, item, input)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		result.Status = pushDuplicate
+		result.EntryID = existing.ID
+		result.Detail = fmt.Sprintf("already created as #%d", existing.ID)
+		return nil
+	}
+
+	entry, err := p.client.CreateTimeEntry(ctx, &input)
+	if err != nil {
+		return fmt.Errorf("create time entry: %w", err)
+	}
+	result.Status = pushCreated
+	result.EntryID = entry.ID
+	result.Detail = fmt.Sprintf("%s - %s (%.2fh)", entry.Project.Name, entry.Task.Name, entry.Hours)
+	return nil
+}
+
+// findPushed looks for an entry created from item by an earlier push that
+// was interrupted before the queue was updated: one of your entries on the
+// same day, project and task, with the same notes and hours, created after
+// the item was queued.
+func (p *pusher) findPushed(ctx context.Context, item queuedEntry, input api.TimeEntryInput) (*api.TimeEntry, error) {
+	if p.userID == 0 {
+		me, err := p.client.GetMe(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("get current user: %w", err)
+		}
+		p.userID = me.ID
+	}
+
+	entries, err := p.client.ListAllTimeEntries(ctx, api.TimeEntryListOptions{
+		From:      input.SpentDate,
+		To:        input.SpentDate,
+		UserID:    p.userID,
+		ProjectID: input.ProjectID,
+		TaskID:    input.TaskID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list time entries: %w", err)
+	}
+
+	notes := ""
+	if input.Notes != nil {
+		notes = *input.Notes
+	}
+	for i, e := range entries {
+		if e.CreatedAt.Before(item.QueuedAt) || e.Notes != notes {
+			continue
+		}
+		if input.Hours != nil && math.Abs(e.Hours-*input.Hours) >= 0.01 {
+			continue
+		}
+		return &entries[i], nil
+	}
+	return nil, nil
+}
+
+// pushStop stops the running timer and trims it back to when the stop was
+// queued.
+func (p *pusher) pushStop(ctx context.Context, item queuedEntry, result *pushResult) error {
+	running, err := p.client.GetRunningTimeEntry(ctx)
+	if err != nil {
+		return fmt.Errorf("get running timer: %w", err)
+	}
+	if running == nil {
+		result.Status = pushSkipped
+		result.Detail = "no timer running"
+		return nil
+	}
+	if running.TimerStartedAt != nil && running.TimerStartedAt.After(item.QueuedAt) {
+		result.Status = pushSkipped
+		result.EntryID = running.ID
+		result.Detail = fmt.Sprintf("timer #%d started after the queued stop", running.ID)
+		return nil
+	}
+
+	stopped, err := p.client.StopTimeEntry(ctx, running.ID)
+	if err != nil {
+		return fmt.Errorf("stop timer: %w", err)
+	}
+	result.Status = pushStopped
+	result.EntryID = stopped.ID
+	result.Detail = fmt.Sprintf("%s - %s (%.2fh)", stopped.Project.Name, stopped.Task.Name, stopped.Hours)
+
+	// The timer kept running until now; remove the time after the queued stop
+	over := time.Since(item.QueuedAt).Hours()
+	if over < 0.01 {
+		return nil
+	}
+	input, err := p.trimInput(ctx, stopped, item.QueuedAt)
+	if err != nil {
+		result.Detail += fmt.Sprintf("; could not trim to the queued stop time: %v", err)
+		return nil
+	}
+	trimmed, err := p.client.UpdateTimeEntry(ctx, stopped.ID, input)
+	if err != nil {
+		result.Detail += fmt.Sprintf("; could not trim to the queued stop time: %v", err)
+		return nil
+	}
+	result.Detail = fmt.Sprintf("%s - %s (%.2fh)", trimmed.Project.Name, trimmed.Task.Name, trimmed.Hours)
+	return nil
+}
+
+// trimInput returns the update that ends a stopped timer at stopAt. Entries
+// with start and end times get their end time moved, since Harvest derives
+// their hours from the times; other entries get their hours reduced.
+func (p *pusher) trimInput(ctx context.Context, stopped *api.TimeEntry, stopAt time.Time) (*api.TimeEntryInput, error) {
+	if stopped.StartedTime == "" {
+		hours := math.Max(0, stopped.Hours-time.Since(stopAt).Hours())
+		return &api.TimeEntryInput{Hours: &hours}, nil
+	}
+
+	loc, err := harvestUserLocation(ctx, p.client)
+	if err != nil {
+		return nil, err
+	}
+	at := stopAt.In(loc)
+	if day := at.Format("2006-01-02"); day != stopped.SpentDate {
+		return nil, fmt.Errorf("stop was queued on %s, not on the entry's date %s", day, stopped.SpentDate)
+	}
+	ended := at.Format("3:04pm")
+	return &api.TimeEntryInput{EndedTime: &ended}, nil
+}
+
+// outputPushResults writes the outcome of each pushed item.
+func outputPushResults(w io.Writer, results []pushResult, mode output.Mode) error {
+	switch mode {
+	case output.ModeJSON:
+		return output.WriteJSON(w, results)
+	case output.ModePlain:
+		headers := []string{"QueueID", "Kind", "Status", "EntryID", "Detail"}
+		rows := make([][]string, len(results))
+		for i, r := range results {
+			rows[i] = []string{r.QueueID, r.Kind, r.Status, formatOptionalID(r.EntryID), r.Detail}
+		}
+		return output.WriteTSV(w, headers, rows)
+	default:
+		t := output.NewTable(w, "Queue ID", "Kind", "Status", "Entry", "Detail")
+		for _, r := range results {
+			t.AddRow(r.QueueID, r.Kind, r.Status, formatOptionalID(r.EntryID), r.Detail)
+		}
+		return t.Render()
+	}
+}
+
+// formatOptionalID formats an ID, or "" when it is zero.
+func formatOptionalID(id int64) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.FormatInt(id, 10)
+}
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	_ "modernc.org/sqlite" // registers the "sqlite" database/sql driver
+
+	"github.com/dedene/harvest-cli/internal/api"
+)
+
+// sqliteSyncSchema creates the tables of a SQLite mirror. Each record is
+// stored as its API JSON in data, next to the columns needed to find it;
+// query other fields with json_extract.
+const sqliteSyncSchema = `
+CREATE TABLE IF NOT EXISTS sync_meta (key TEXT PRIMARY KEY, value TEXT NOT NULL);
+CREATE TABLE IF NOT EXISTS time_entries (id INTEGER PRIMARY KEY, updated_at TEXT NOT NULL, data TEXT NOT NULL);
+CREATE TABLE IF NOT EXISTS expenses (id INTEGER PRIMARY KEY, updated_at TEXT NOT NULL, data TEXT NOT NULL);
+CREATE TABLE IF NOT EXISTS invoices (id INTEGER PRIMARY KEY, updated_at TEXT NOT NULL, data TEXT NOT NULL);
+`
+
+// readSQLiteSyncStore loads a SQLite mirror, returning nil if it does not
+// exist.
+func readSQLiteSyncStore(path string) (*syncStore, error) {
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read sync store: %w", err)
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("open sync store %s: %w", path, err)
+	}
+	defer db.Close()
+
+	var store syncStore
+	var accountID, syncedAt string
+	err = db.QueryRow(`SELECT value FROM sync_meta WHERE key = 'account_id'`).Scan(&accountID)
+	if err == nil {
+		err = db.QueryRow(`SELECT value FROM sync_meta WHERE key = 'synced_at'`).Scan(&syncedAt)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse sync store %s: %w", path, err)
+	}
+	if store.AccountID, err = strconv.ParseInt(accountID, 10, 64); err != nil {
+		return nil, fmt.Errorf("parse sync store %s: invalid account_id %q", path, accountID)
+	}
+	if store.SyncedAt, err = time.Parse(time.RFC3339Nano, syncedAt); err != nil {
+		return nil, fmt.Errorf("parse sync store %s: invalid synced_at %q", path, syncedAt)
+	}
+
+	if store.TimeEntries, err = readSQLiteRecords[api.TimeEntry](db, "time_entries"); err == nil {
+		if store.Expenses, err = readSQLiteRecords[api.Expense](db, "expenses"); err == nil {
+			store.Invoices, err = readSQLiteRecords[api.Invoice](db, "invoices")
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse sync store %s: %w", path, err)
+	}
+	return &store, nil
+}
+
+// readSQLiteRecords decodes the records of a mirror table, sorted by ID.
+func readSQLiteRecords[T any](db *sql.DB, table string) ([]T, error) {
+	rows, err := db.Query(`SELECT data FROM ` + table + ` ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []T
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		var record T
+		if err := json.Unmarshal(data, &record); err != nil {
+			return nil, fmt.Errorf("%s: %w", table, err)
+		}
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
+
+// writeSQLiteSyncStore writes a SQLite mirror in one transaction, replacing
+// the records of any previous run.
+func writeSQLiteSyncStore(path string, store *syncStore) (err error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("create sync dir: %w", err)
+	}
+	// Create the file first so the mirror is private like the JSON one
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return fmt.Errorf("write sync store: %w", err)
+	}
+	f.Close()
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return fmt.Errorf("open sync store %s: %w", path, err)
+	}
+	defer func() {
+		if cerr := db.Close(); err == nil && cerr != nil {
+			err = fmt.Errorf("write sync store: %w", cerr)
+		}
+	}()
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("write sync store: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // a no-op after Commit
+
+	if _, err := tx.Exec(sqliteSyncSchema); err != nil {
+		return fmt.Errorf("write sync store: %w", err)
+	}
+	meta := map[string]string{
+		"account_id": strconv.FormatInt(store.AccountID, 10),
+		"synced_at":  store.SyncedAt.UTC().Format(time.RFC3339Nano),
+	}
+	for key, value := range meta {
+		if _, err := tx.Exec(`INSERT OR REPLACE INTO sync_meta (key, value) VALUES (?, ?)`, key, value); err != nil {
+			return fmt.Errorf("write sync store: %w", err)
+		}
+	}
+
+	err = writeSQLiteRecords(tx, "time_entries", store.TimeEntries,
+		func(e api.TimeEntry) (int64, time.Time) { return e.ID, e.UpdatedAt })
+	if err == nil {
+		err = writeSQLiteRecords(tx, "expenses", store.Expenses,
+			func(e api.Expense) (int64, time.Time) { return e.ID, e.UpdatedAt })
+	}
+	if err == nil {
+		err = writeSQLiteRecords(tx, "invoices", store.Invoices,
+			func(i api.Invoice) (int64, time.Time) { return i.ID, i.UpdatedAt })
+	}
+	if err != nil {
+		return fmt.Errorf("write sync store: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("write sync store: %w", err)
+	}
+	return nil
+}
+
+// writeSQLiteRecords replaces the records of a mirror table.
+func writeSQLiteRecords[T any](tx *sql.Tx, table string, records []T, key func(T) (int64, time.Time)) error {
+	if _, err := tx.Exec(`DELETE FROM ` + table); err != nil {
+		return err
+	}
+	stmt, err := tx.Prepare(`INSERT INTO ` + table + ` (id, updated_at, data) VALUES (?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, record := range records {
+		data, err := json.Marshal(record)
+		if err != nil {
+			return fmt.Errorf("encode %s: %w", table, err)
+		}
+		id, updatedAt := key(record)
+		if _, err := stmt.Exec(id, updatedAt.UTC().Format(time.RFC3339), string(data)); err != nil {
+			return fmt.Errorf("%s #%d: %w", table, id, err)
+		}
+	}
+	return nil
+}
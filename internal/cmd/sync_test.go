@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dedene/harvest-cli/internal/api"
+)
+
+func TestMergeByID(t *testing.T) {
+	existing := []api.TimeEntry{{ID: 3, Notes: "old"}, {ID: 1, Notes: "keep"}}
+	updated := []api.TimeEntry{{ID: 3, Notes: "new"}, {ID: 2, Notes: "added"}}
+
+	merged := mergeByID(existing, updated, func(e api.TimeEntry) int64 { return e.ID })
+
+	want := []struct {
+		id    int64
+		notes string
+	}{{1, "keep"}, {2, "added"}, {3, "new"}}
+	if len(merged) != len(want) {
+		t.Fatalf("len(merged) = %d, want %d", len(merged), len(want))
+	}
+	for i, w := range want {
+		if merged[i].ID != w.id || merged[i].Notes != w.notes {
+			t.Errorf("merged[%d] = #%d %q, want #%d %q", i, merged[i].ID, merged[i].Notes, w.id, w.notes)
+		}
+	}
+}
+
+func TestHighWaterMark(t *testing.T) {
+	updatedAt := func(e api.Expense) time.Time { return e.UpdatedAt }
+	at := func(s string) time.Time {
+		v, _ := time.Parse(time.RFC3339, s)
+		return v
+	}
+	items := []api.Expense{
+		{UpdatedAt: at("2024-02-01T10:00:00Z")},
+		{UpdatedAt: at("2024-03-05T08:30:00+01:00")},
+	}
+
+	tests := []struct {
+		name  string
+		prev  string
+		items []api.Expense
+		want  string
+	}{
+		{"first sync", "", items, "2024-03-05T07:30:00Z"},
+		{"newer items", "2024-01-01T00:00:00Z", items, "2024-03-05T07:30:00Z"},
+		{"nothing newer", "2024-06-01T00:00:00Z", items, "2024-06-01T00:00:00Z"},
+		{"no items", "2024-01-01T00:00:00Z", nil, "2024-01-01T00:00:00Z"},
+		{"never synced", "", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := highWaterMark(tt.prev, tt.items, updatedAt); got != tt.want {
+				t.Errorf("highWaterMark() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSyncStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "sync.json")
+
+	store, err := readSyncStore(path)
+	if err != nil || store != nil {
+		t.Fatalf("readSyncStore(missing) = %v, %v; want nil, nil", store, err)
+	}
+
+	want := &syncStore{
+		AccountID:   42,
+		TimeEntries: []api.TimeEntry{{ID: 1, Hours: 1.5}},
+		Invoices:    []api.Invoice{{ID: 9, Number: "INV-9"}},
+	}
+	if err := writeSyncStore(path, want); err != nil {
+		t.Fatalf("writeSyncStore() error = %v", err)
+	}
+
+	got, err := readSyncStore(path)
+	if err != nil {
+		t.Fatalf("readSyncStore() error = %v", err)
+	}
+	if got.AccountID != 42 || len(got.TimeEntries) != 1 || got.TimeEntries[0].Hours != 1.5 ||
+		len(got.Invoices) != 1 || got.Invoices[0].Number != "INV-9" {
+		t.Errorf("readSyncStore() = %+v", got)
+	}
+}
+
+func TestSQLiteSyncStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "sync.db")
+
+	store, err := readSQLiteSyncStore(path)
+	if err != nil || store != nil {
+		t.Fatalf("readSQLiteSyncStore(missing) = %v, %v; want nil, nil", store, err)
+	}
+
+	syncedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	want := &syncStore{
+		AccountID:   42,
+		SyncedAt:    syncedAt,
+		TimeEntries: []api.TimeEntry{{ID: 2, Hours: 2}, {ID: 1, Hours: 1.5}},
+		Invoices:    []api.Invoice{{ID: 9, Number: "INV-9"}},
+	}
+	if err := writeSQLiteSyncStore(path, want); err != nil {
+		t.Fatalf("writeSQLiteSyncStore() error = %v", err)
+	}
+
+	got, err := readSQLiteSyncStore(path)
+	if err != nil {
+		t.Fatalf("readSQLiteSyncStore() error = %v", err)
+	}
+	if got.AccountID != 42 || !got.SyncedAt.Equal(syncedAt) ||
+		len(got.TimeEntries) != 2 || got.TimeEntries[0].ID != 1 || got.TimeEntries[0].Hours != 1.5 ||
+		len(got.Invoices) != 1 || got.Invoices[0].Number != "INV-9" {
+		t.Errorf("readSQLiteSyncStore() = %+v", got)
+	}
+
+	// A later run replaces the records rather than appending to them
+	want.TimeEntries = want.TimeEntries[:1]
+	want.Invoices = nil
+	if err := writeSQLiteSyncStore(path, want); err != nil {
+		t.Fatalf("writeSQLiteSyncStore() error = %v", err)
+	}
+	got, err = readSQLiteSyncStore(path)
+	if err != nil {
+		t.Fatalf("readSQLiteSyncStore() error = %v", err)
+	}
+	if len(got.TimeEntries) != 1 || got.TimeEntries[0].ID != 2 || len(got.Invoices) != 0 {
+		t.Errorf("readSQLiteSyncStore() after rewrite = %+v", got)
+	}
+}
@@ -4,7 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"os"
+	"slices"
 	"strconv"
 
 	"github.com/dedene/harvest-cli/internal/api"
@@ -15,22 +15,31 @@ import (
 
 // TasksCmd groups task subcommands.
 type TasksCmd struct {
-	List   TasksListCmd   `cmd:"" help:"List all tasks"`
-	Show   TasksShowCmd   `cmd:"" help:"Show a task"`
-	Add    TasksAddCmd    `cmd:"" help:"Create a task"`
-	Edit   TasksEditCmd   `cmd:"" help:"Update a task"`
-	Remove TasksRemoveCmd `cmd:"" help:"Delete a task"`
+	List      TasksListCmd      `cmd:"" help:"List all tasks"`
+	Show      TasksShowCmd      `cmd:"" help:"Show a task"`
+	Add       TasksAddCmd       `cmd:"" help:"Create a task"`
+	Edit      TasksEditCmd      `cmd:"" help:"Update a task"`
+	Remove    TasksRemoveCmd    `cmd:"" help:"Delete a task"`
+	Archive   TasksArchiveCmd   `cmd:"" help:"Deactivate a task"`
+	Unarchive TasksUnarchiveCmd `cmd:"" help:"Reactivate an archived task"`
+	Assign    TasksAssignCmd    `cmd:"" help:"Assign a task to many projects"`
 }
 
 // TasksListCmd lists tasks with filters.
 type TasksListCmd struct {
 	Active       *bool  `help:"Filter by active status"`
 	UpdatedSince string `help:"Filter by updated since (ISO datetime)"`
+	NDJSON       bool   `help:"Output one JSON object per line" name:"ndjson"`
+	PagingFlags  `embed:""`
 }
 
 func (c *TasksListCmd) Run(cli *CLI) error {
+	if err := c.PagingFlags.validate(); err != nil {
+		return err
+	}
+
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -46,12 +55,18 @@ func (c *TasksListCmd) Run(cli *CLI) error {
 		opts.UpdatedSince = t.Format("2006-01-02T15:04:05Z")
 	}
 
+	opts.PerPage = c.PerPage
+	opts.MaxItems = c.MaxItems
 	tasks, err := client.ListAllTasks(ctx, opts)
 	if err != nil {
 		return fmt.Errorf("list tasks: %w", err)
 	}
 
-	return outputTasks(os.Stdout, tasks, output.ModeFromFlags(cli.JSON, cli.Plain))
+	if c.NDJSON {
+		return output.WriteNDJSON(cli.Stdout, tasks)
+	}
+
+	return outputTasks(cli.Stdout, tasks, output.ModeFromFlags(cli.JSON, cli.Plain))
 }
 
 // TasksShowCmd shows a single task.
@@ -61,7 +76,7 @@ type TasksShowCmd struct {
 
 func (c *TasksShowCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -71,7 +86,7 @@ func (c *TasksShowCmd) Run(cli *CLI) error {
 		return fmt.Errorf("get task: %w", err)
 	}
 
-	return outputTask(os.Stdout, task, output.ModeFromFlags(cli.JSON, cli.Plain))
+	return outputTask(cli.Stdout, task, output.ModeFromFlags(cli.JSON, cli.Plain))
 }
 
 // TasksAddCmd creates a new task.
@@ -85,7 +100,7 @@ type TasksAddCmd struct {
 
 func (c *TasksAddCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -103,11 +118,11 @@ func (c *TasksAddCmd) Run(cli *CLI) error {
 		return fmt.Errorf("create task: %w", err)
 	}
 
-	if cli.JSON {
-		return output.WriteJSON(os.Stdout, task)
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, task)
 	}
 
-	fmt.Fprintf(os.Stdout, "Created task #%d: %s\n", task.ID, task.Name)
+	printSuccess(cli, task.ID, "Created task #%d: %s\n", task.ID, task.Name)
 	return nil
 }
 
@@ -123,7 +138,7 @@ type TasksEditCmd struct {
 
 func (c *TasksEditCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -161,11 +176,11 @@ func (c *TasksEditCmd) Run(cli *CLI) error {
 		return fmt.Errorf("update task: %w", err)
 	}
 
-	if cli.JSON {
-		return output.WriteJSON(os.Stdout, task)
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, task)
 	}
 
-	fmt.Fprintf(os.Stdout, "Updated task #%d: %s\n", task.ID, task.Name)
+	printSuccess(cli, task.ID, "Updated task #%d: %s\n", task.ID, task.Name)
 	return nil
 }
 
@@ -177,7 +192,7 @@ type TasksRemoveCmd struct {
 
 func (c *TasksRemoveCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -193,13 +208,13 @@ func (c *TasksRemoveCmd) Run(cli *CLI) error {
 		confirmed, err := ui.ConfirmPrompt(msg)
 		if err != nil {
 			if err == ui.ErrCanceled {
-				fmt.Fprintln(os.Stderr, "Canceled")
+				fmt.Fprintln(cli.Stderr, "Canceled")
 				return nil
 			}
 			return err
 		}
 		if !confirmed {
-			fmt.Fprintln(os.Stderr, "Aborted")
+			fmt.Fprintln(cli.Stderr, "Aborted")
 			return nil
 		}
 	}
@@ -208,10 +223,198 @@ func (c *TasksRemoveCmd) Run(cli *CLI) error {
 		return fmt.Errorf("delete task: %w", err)
 	}
 
-	fmt.Fprintf(os.Stdout, "Deleted task #%d\n", c.ID)
+	printSuccess(cli, 0, "Deleted task #%d\n", c.ID)
+	return nil
+}
+
+// TasksArchiveCmd deactivates a task.
+type TasksArchiveCmd struct {
+	ID int64 `arg:"" help:"Task ID"`
+}
+
+func (c *TasksArchiveCmd) Run(cli *CLI) error {
+	return setTaskActive(cli, c.ID, false)
+}
+
+// TasksUnarchiveCmd reactivates an archived task.
+type TasksUnarchiveCmd struct {
+	ID int64 `arg:"" help:"Task ID"`
+}
+
+func (c *TasksUnarchiveCmd) Run(cli *CLI) error {
+	return setTaskActive(cli, c.ID, true)
+}
+
+// setTaskActive archives or unarchives a task by updating only is_active.
+func setTaskActive(cli *CLI, id int64, active bool) error {
+	ctx := context.Background()
+	client, err := NewClientFromFlags(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	task, err := client.UpdateTask(ctx, id, &api.TaskInput{IsActive: &active})
+	if err != nil {
+		return fmt.Errorf("update task: %w", err)
+	}
+	if cli.DryRun {
+		return nil
+	}
+
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, task)
+	}
+
+	printSuccess(cli, task.ID, "%s task #%d: %s\n", archiveVerb(active), task.ID, task.Name)
 	return nil
 }
 
+// TasksAssignCmd assigns a task to several projects at once.
+type TasksAssignCmd struct {
+	ID                int64    `arg:"" help:"Task ID"`
+	Projects          []string `help:"Project ID or name (repeatable)" name:"project" short:"p" sep:"none"`
+	AllActiveProjects bool     `help:"Assign to every active project" name:"all-active-projects"`
+	Billable          *bool    `help:"Billable on these projects (default: the task's billable_by_default)"`
+	HourlyRate        *float64 `help:"Hourly rate on these projects" name:"hourly-rate"`
+	Force             bool     `help:"Skip confirmation" short:"f"`
+}
+
+// assignTarget is a project to assign a task to.
+type assignTarget struct {
+	ID   int64
+	Name string
+}
+
+func (c *TasksAssignCmd) Run(cli *CLI) error {
+	if (len(c.Projects) > 0) == c.AllActiveProjects {
+		return fmt.Errorf("specify either --project or --all-active-projects")
+	}
+	if c.HourlyRate != nil && *c.HourlyRate < 0 {
+		return fmt.Errorf("--hourly-rate must not be negative")
+	}
+
+	ctx := context.Background()
+	client, err := NewClientFromFlags(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	task, err := client.GetTask(ctx, c.ID)
+	if err != nil {
+		return fmt.Errorf("get task: %w", err)
+	}
+
+	targets, err := c.targets(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	// Skip projects that already have the task, active or not
+	var pending []assignTarget
+	var skipped []int64
+	for _, p := range targets {
+		assignments, err := client.ListAllTaskAssignments(ctx, p.ID, api.AssignmentListOptions{})
+		if err != nil {
+			return fmt.Errorf("list task assignments for %s: %w", p.Name, err)
+		}
+		if slices.ContainsFunc(assignments, func(ta api.TaskAssignment) bool { return ta.Task.ID == task.ID }) {
+			skipped = append(skipped, p.ID)
+			continue
+		}
+		pending = append(pending, p)
+	}
+
+	if len(pending) == 0 {
+		fmt.Fprintf(cli.Stderr, "%s is already assigned to all %d projects\n", task.Name, len(targets))
+		if cli.jsonOutput() {
+			return output.WriteJSON(cli.Stdout, map[string]any{"created": []int64{}, "skipped": skipped, "failed": []int64{}})
+		}
+		return nil
+	}
+
+	fmt.Fprintf(cli.Stderr, "Assign %s to %d projects (%d already assigned):\n", task.Name, len(pending), len(skipped))
+	for _, p := range pending {
+		fmt.Fprintf(cli.Stderr, "  #%d: %s\n", p.ID, p.Name)
+	}
+
+	if cli.DryRun {
+		fmt.Fprintln(cli.Stderr, "Dry run - no tasks assigned")
+		return nil
+	}
+
+	if !c.Force {
+		msg := fmt.Sprintf("Assign %s to %d projects?", task.Name, len(pending))
+		confirmed, err := ui.ConfirmPrompt(msg)
+		if err != nil {
+			if err == ui.ErrCanceled {
+				fmt.Fprintln(cli.Stderr, "Canceled")
+				return nil
+			}
+			return err
+		}
+		if !confirmed {
+			fmt.Fprintln(cli.Stderr, "Aborted")
+			return nil
+		}
+	}
+
+	input := &api.TaskAssignmentInput{TaskID: task.ID, Billable: c.Billable, HourlyRate: c.HourlyRate}
+	created, failed := []int64{}, []int64{}
+	for i, p := range pending {
+		if _, err := client.CreateTaskAssignment(ctx, p.ID, input); err != nil {
+			failed = append(failed, p.ID)
+			fmt.Fprintf(cli.Stderr, "[%d/%d] Error assigning to %s: %v\n", i+1, len(pending), p.Name, err)
+			continue
+		}
+		created = append(created, p.ID)
+		fmt.Fprintf(cli.Stderr, "[%d/%d] Assigned to %s\n", i+1, len(pending), p.Name)
+	}
+
+	if cli.jsonOutput() {
+		if skipped == nil {
+			skipped = []int64{}
+		}
+		if err := output.WriteJSON(cli.Stdout, map[string]any{"created": created, "skipped": skipped, "failed": failed}); err != nil {
+			return err
+		}
+	} else {
+		printSuccess(cli, 0, "Assigned %s to %d projects (%d skipped, %d failed)\n",
+			task.Name, len(created), len(skipped), len(failed))
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d assignments failed", len(failed), len(pending))
+	}
+	return nil
+}
+
+// targets returns the projects named by --project, or every active project.
+func (c *TasksAssignCmd) targets(ctx context.Context, client *api.Client) ([]assignTarget, error) {
+	if c.AllActiveProjects {
+		projects, err := client.ListAllProjects(ctx, api.ProjectListOptions{IsActive: boolPtr(true)})
+		if err != nil {
+			return nil, fmt.Errorf("list projects: %w", err)
+		}
+		targets := make([]assignTarget, len(projects))
+		for i, p := range projects {
+			targets[i] = assignTarget{ID: p.ID, Name: p.Name}
+		}
+		return targets, nil
+	}
+
+	resolver := newIDResolver(client)
+	var targets []assignTarget
+	for _, name := range c.Projects {
+		id, err := resolver.projectID(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.ContainsFunc(targets, func(t assignTarget) bool { return t.ID == id }) {
+			targets = append(targets, assignTarget{ID: id, Name: name})
+		}
+	}
+	return targets, nil
+}
+
 // outputTasks writes tasks in the specified format.
 func outputTasks(w io.Writer, tasks []api.Task, mode output.Mode) error {
 	switch mode {
@@ -236,7 +439,7 @@ func outputTasks(w io.Writer, tasks []api.Task, mode output.Mode) error {
 		}
 		return output.WriteTSV(w, headers, rows)
 	default:
-		t := output.NewTable(w, "ID", "Name", "Active", "Billable", "Default", "Rate")
+		t := output.NewTable(w, "ID", "Name", "Active", "Billable", "Default", "Rate").SetAlign(output.AlignRight, 5)
 		for _, task := range tasks {
 			rate := ""
 			if task.DefaultHourlyRate > 0 {
@@ -261,9 +464,11 @@ func outputTask(w io.Writer, task *api.Task, mode output.Mode) error {
 	case output.ModeJSON:
 		return output.WriteJSON(w, task)
 	case output.ModePlain:
-		fmt.Fprintf(w, "%d\t%s\t%t\t%t\t%t\t%.2f\n",
-			task.ID, task.Name, task.IsActive, task.BillableByDefault, task.IsDefault, task.DefaultHourlyRate)
-		return nil
+		return output.WriteTSV(w, nil, [][]string{{
+			strconv.FormatInt(task.ID, 10), task.Name, strconv.FormatBool(task.IsActive),
+			strconv.FormatBool(task.BillableByDefault), strconv.FormatBool(task.IsDefault),
+			fmt.Sprintf("%.2f", task.DefaultHourlyRate),
+		}})
 	default:
 		fmt.Fprintf(w, "ID:       %d\n", task.ID)
 		fmt.Fprintf(w, "Name:     %s\n", task.Name)
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/dedene/harvest-cli/internal/api"
+	"github.com/dedene/harvest-cli/internal/auth"
+)
+
+func TestTasksAssign(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(auth.PATEnvToken, "pat")
+	t.Setenv(auth.PATEnvAccountID, "1")
+
+	var posted []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/tasks/5":
+			_, _ = w.Write([]byte(`{"id":5,"name":"Design"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/projects/1/task_assignments":
+			// Project 1 already has the task
+			_, _ = w.Write([]byte(`{"task_assignments":[{"id":90,"task":{"id":5,"name":"Design"}}],"total_pages":1,"page":1}`))
+		case r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"task_assignments":[],"total_pages":1,"page":1}`))
+		case r.Method == http.MethodPost:
+			var in api.TaskAssignmentInput
+			_ = json.NewDecoder(r.Body).Decode(&in)
+			if in.TaskID != 5 {
+				t.Errorf("posted task_id = %d, want 5", in.TaskID)
+			}
+			posted = append(posted, r.URL.Path)
+			if r.URL.Path == "/projects/3/task_assignments" {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				_, _ = w.Write([]byte(`{"message":"Project is archived"}`))
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":91,"task":{"id":5,"name":"Design"}}`))
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"tasks", "assign", "5", "--project", "1", "--project", "2", "--project", "3",
+		"--force", "--json", "--api-base-url", srv.URL}
+	err := Execute(args, &stdout, &stderr)
+	if err == nil || err.Error() != "1 of 2 assignments failed" {
+		t.Errorf("Execute() error = %v, want 1 of 2 assignments failed", err)
+	}
+	if want := []string{"/projects/2/task_assignments", "/projects/3/task_assignments"}; !slices.Equal(posted, want) {
+		t.Errorf("posted to %v, want %v", posted, want)
+	}
+
+	var got struct {
+		Created []int64 `json:"created"`
+		Skipped []int64 `json:"skipped"`
+		Failed  []int64 `json:"failed"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatalf("stdout is not JSON: %v\n%s", err, stdout.String())
+	}
+	if !slices.Equal(got.Created, []int64{2}) || !slices.Equal(got.Skipped, []int64{1}) || !slices.Equal(got.Failed, []int64{3}) {
+		t.Errorf("result = %+v, want 2 created, 1 skipped and 3 failed", got)
+	}
+}
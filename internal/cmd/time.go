@@ -3,8 +3,8 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/dedene/harvest-cli/internal/api"
@@ -15,70 +15,83 @@ import (
 
 // TimeCmd groups time entry subcommands.
 type TimeCmd struct {
-	List   TimeListCmd   `cmd:"" help:"List time entries"`
-	Show   TimeShowCmd   `cmd:"" help:"Show a time entry"`
-	Add    TimeAddCmd    `cmd:"" help:"Create a time entry"`
-	Edit   TimeEditCmd   `cmd:"" help:"Update a time entry"`
-	Remove TimeRemoveCmd `cmd:"" help:"Delete a time entry"`
-	Log    TimeLogCmd    `cmd:"" help:"Quick time entry (wizard if no args)"`
+	List        TimeListCmd         `cmd:"" help:"List time entries"`
+	Show        TimeShowCmd         `cmd:"" help:"Show a time entry"`
+	Add         TimeAddCmd          `cmd:"" help:"Create a time entry"`
+	Edit        TimeEditCmd         `cmd:"" help:"Update a time entry"`
+	Remove      TimeRemoveCmd       `cmd:"" help:"Delete a time entry"`
+	Move        TimeMoveCmd         `cmd:"" help:"Move time entries to another project/task"`
+	Log         TimeLogCmd          `cmd:"" help:"Quick time entry (wizard if no args)"`
+	Last        TimeLastCmd         `cmd:"" help:"Show your most recent time entries"`
+	RestartLast TimerRestartLastCmd `cmd:"" name:"restart-last" help:"Restart your most recent time entry (same as 'timer restart-last')"`
+	SubmitDay   TimeSubmitDayCmd    `cmd:"" name:"submit-day" help:"Submit a day's entries for approval"`
+	Gaps        TimeGapsCmd         `cmd:"" help:"List days with no time, or less than --min-hours"`
+	Summary     TimeSummaryCmd      `cmd:"" help:"Show your total and billable hours for today, this week or this month"`
 }
 
 // TimeListCmd lists time entries with filters.
 type TimeListCmd struct {
-	From           string `help:"Start date (YYYY-MM-DD or 'today')" short:"f"`
-	To             string `help:"End date" short:"t"`
-	User           string `help:"Filter by user ID or 'me'"`
+	From           string `help:"Start date (YYYY-MM-DD or 'today')" short:"f" aliases:"since"`
+	To             string `help:"End date" short:"t" aliases:"until"`
+	User           string `help:"Filter by user ID, email or 'me'"`
 	Project        string `help:"Filter by project ID or name"`
 	HarvestClient  string `help:"Filter by client ID or name" name:"harvest-client" short:"c"`
 	Task           string `help:"Filter by task ID"`
+	ExtRefID       string `help:"Filter by external reference ID (e.g., JIRA-123)" name:"ext-ref-id"`
 	Billed         bool   `help:"Only billed entries"`
 	Unbilled       bool   `help:"Only unbilled entries"`
+	Billable       bool   `help:"Only billable entries"`
+	NonBillable    bool   `help:"Only non-billable entries" name:"non-billable"`
 	Running        bool   `help:"Only running timers"`
 	ApprovalStatus string `help:"Filter by approval status" enum:",unsubmitted,submitted,approved" default:""`
+	UpdatedSince   string `help:"Only entries updated since (ISO 8601 timestamp or date like 'today')"`
+	Summary        bool   `help:"Append total hours and billable hours"`
+	Rounded        bool   `help:"Add a column with the account's rounded hours (what gets invoiced)"`
+	Group          string `help:"Group entries by project, task or day, with subtotals" enum:",project,task,day" default:""`
+	NDJSON         bool   `help:"Output one JSON object per line" name:"ndjson"`
+	PagingFlags    `embed:""`
+	ExcludeFlags   `embed:""`
 }
 
 func (c *TimeListCmd) Run(cli *CLI) error {
+	if err := c.PagingFlags.validate(); err != nil {
+		return err
+	}
+	if c.Group != "" && c.NDJSON {
+		return fmt.Errorf("--group cannot be combined with --ndjson")
+	}
+
+	isBilled, err := flagFilter(c.Billed, c.Unbilled, "billed", "unbilled")
+	if err != nil {
+		return err
+	}
+	billable, err := flagFilter(c.Billable, c.NonBillable, "billable", "non-billable")
+	if err != nil {
+		return err
+	}
+
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
 
 	opts := api.TimeEntryListOptions{
 		ApprovalStatus: c.ApprovalStatus,
+		IsBilled:       isBilled,
 	}
 
-	// Parse date filters
-	if c.From != "" {
-		t, err := dateparse.Parse(c.From)
-		if err != nil {
-			return fmt.Errorf("invalid from date: %w", err)
-		}
-		opts.From = dateparse.FormatDate(t)
-	}
-	if c.To != "" {
-		t, err := dateparse.Parse(c.To)
-		if err != nil {
-			return fmt.Errorf("invalid to date: %w", err)
-		}
-		opts.To = dateparse.FormatDate(t)
+	if opts.From, opts.To, err = parseDateRange(c.From, c.To); err != nil {
+		return err
 	}
 
 	// Parse user filter
 	if c.User != "" {
-		if c.User == "me" {
-			me, err := client.GetMe(ctx)
-			if err != nil {
-				return fmt.Errorf("get current user: %w", err)
-			}
-			opts.UserID = me.ID
-		} else {
-			id, err := strconv.ParseInt(c.User, 10, 64)
-			if err != nil {
-				return fmt.Errorf("invalid user ID: %s", c.User)
-			}
-			opts.UserID = id
+		userID, err := resolveUserID(ctx, client, c.User)
+		if err != nil {
+			return err
 		}
+		opts.UserID = userID
 	}
 
 	// Parse project filter
@@ -108,37 +121,93 @@ func (c *TimeListCmd) Run(cli *CLI) error {
 		opts.TaskID = id
 	}
 
-	// Handle billed/unbilled filters
-	if c.Billed {
-		t := true
-		opts.IsBilled = &t
-	} else if c.Unbilled {
-		f := false
-		opts.IsBilled = &f
+	if c.UpdatedSince != "" {
+		opts.UpdatedSince, err = parseUpdatedSince(c.UpdatedSince)
+		if err != nil {
+			return err
+		}
 	}
 
+	opts.ExternalReferenceID = c.ExtRefID
+
 	// Handle running filter
 	if c.Running {
 		t := true
 		opts.IsRunning = &t
 	}
 
+	exclusion, err := c.ExcludeFlags.resolve(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	opts.PerPage = c.PerPage
+	// The billable and exclude filters run here, so --max-items is applied
+	// after them
+	if billable == nil && exclusion == nil {
+		opts.MaxItems = c.MaxItems
+	}
 	entries, err := client.ListAllTimeEntries(ctx, opts)
 	if err != nil {
 		return fmt.Errorf("list time entries: %w", err)
 	}
 
-	return outputTimeEntries(os.Stdout, entries, output.ModeFromFlags(cli.JSON, cli.Plain))
+	// The API has no billable filter
+	entries = filterByBillable(entries, billable, func(e api.TimeEntry) bool { return e.Billable })
+	entries, excluded := exclusion.filter(entries)
+	reportExcluded(cli, exclusion, excluded)
+	entries = limitItems(entries, c.MaxItems)
+
+	if c.NDJSON {
+		return output.WriteNDJSON(cli.Stdout, entries)
+	}
+
+	mode := output.ModeFromFlags(cli.JSON, cli.Plain)
+	if c.Group != "" {
+		return outputGroupedTimeEntries(cli.Stdout, entries, c.Group, mode, c.Summary, c.Rounded)
+	}
+	return outputTimeEntries(cli.Stdout, entries, mode, c.Summary, c.Rounded)
+}
+
+// TimeLastCmd shows the most recently updated time entries.
+type TimeLastCmd struct {
+	Count int    `arg:"" optional:"" help:"Number of entries to show" default:"5"`
+	User  string `help:"User ID, email or 'me'" default:"me"`
+}
+
+func (c *TimeLastCmd) Run(cli *CLI) error {
+	if c.Count < 1 {
+		return fmt.Errorf("count must be at least 1")
+	}
+
+	ctx := context.Background()
+	client, err := NewClientFromFlags(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	userID, err := resolveUserID(ctx, client, c.User)
+	if err != nil {
+		return err
+	}
+
+	entries, err := getRecentTimeEntries(ctx, client, api.TimeEntryListOptions{UserID: userID}, c.Count)
+	if err != nil {
+		return fmt.Errorf("list time entries: %w", err)
+	}
+
+	return outputTimeEntries(cli.Stdout, entries, output.ModeFromFlags(cli.JSON, cli.Plain), false, false)
 }
 
 // TimeShowCmd shows a single time entry.
 type TimeShowCmd struct {
-	ID int64 `arg:"" help:"Time entry ID"`
+	ID      int64 `arg:"" help:"Time entry ID"`
+	Rounded bool  `help:"Include the account's rounded hours in plain output"`
 }
 
 func (c *TimeShowCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -148,33 +217,90 @@ func (c *TimeShowCmd) Run(cli *CLI) error {
 		return fmt.Errorf("get time entry: %w", err)
 	}
 
-	return outputTimeEntry(os.Stdout, entry, output.ModeFromFlags(cli.JSON, cli.Plain))
+	return outputTimeEntry(cli.Stdout, entry, output.ModeFromFlags(cli.JSON, cli.Plain), c.Rounded)
 }
 
 // TimeAddCmd creates a new time entry.
 type TimeAddCmd struct {
-	Project       string  `help:"Project ID or name" short:"p"`
-	Task          string  `help:"Task ID or name"`
-	Date          string  `help:"Date (default: today)" short:"d"`
-	Hours         float64 `help:"Hours (duration mode)" short:"h"`
-	Start         string  `help:"Start time (timestamp mode)"`
-	End           string  `help:"End time (timestamp mode)"`
-	Notes         string  `help:"Notes" short:"n"`
-	Duration      bool    `help:"Use duration mode (hours)"`
-	Timestamp     bool    `help:"Use timestamp mode (start/end)"`
-	ExtRefID      string  `help:"External reference ID (e.g., JIRA-123)" name:"external-ref-id"`
-	ExtRefGroupID string  `help:"External reference group ID" name:"external-ref-group-id"`
-	ExtRefURL     string  `help:"External reference URL" name:"external-ref-url"`
-	ExtRefService string  `help:"External reference service name (e.g., jira, asana)" name:"external-ref-service"`
+	Project       string   `help:"Project ID or name" short:"p"`
+	Task          string   `help:"Task ID or name"`
+	Date          string   `help:"Date (default: today)" short:"d"`
+	Hours         *float64 `help:"Hours (duration mode); without it a timer is started, --hours 0 creates an empty entry" short:"h"`
+	Start         string   `help:"Start time (timestamp mode)"`
+	End           string   `help:"End time (timestamp mode)"`
+	Notes         string   `help:"Notes" short:"n"`
+	Duration      bool     `help:"Use duration mode (hours)"`
+	Timestamp     bool     `help:"Use timestamp mode (start/end)"`
+	ExtRefID      string   `help:"External reference ID (e.g., JIRA-123)" name:"external-ref-id"`
+	ExtRefGroupID string   `help:"External reference group ID" name:"external-ref-group-id"`
+	ExtRefURL     string   `help:"External reference URL" name:"external-ref-url"`
+	ExtRefService string   `help:"External reference service name (e.g., jira, asana)" name:"external-ref-service"`
+	NoDefault     bool     `help:"Ignore the configured default project/task and use the wizard" name:"no-default"`
+	CopyLast      bool     `help:"Copy project, task and notes from your most recent entry" name:"copy-last"`
+	Timezone      string   `help:"Read --start/--end in this IANA time zone (default: your Harvest time zone)"`
+	Entries       []string `help:"Create several entries: project=...,task=...,hours=...[,notes=...][,date=...] (repeatable)" name:"entry" sep:"none"`
+	Offline       bool     `help:"Queue the entry locally; create it later with 'harvest sync push'"`
+	NoTimer       bool     `help:"Fail instead of starting a timer when no --hours, --start or --end is given" name:"no-timer"`
+	StdinJSON     bool     `help:"Read a time entry, or an array of them, as JSON from stdin (fields of the Harvest API's time entry, plus project and task names)" name:"stdin-json"`
 }
 
 func (c *TimeAddCmd) Run(cli *CLI) error {
+	if c.StdinJSON {
+		if c.usesEntryFlags() {
+			return fmt.Errorf("--stdin-json cannot be combined with flags describing the entry; put its fields in the JSON")
+		}
+		ctx := context.Background()
+		client, err := NewClientFromFlags(ctx, cli)
+		if err != nil {
+			return err
+		}
+		return c.runStdinJSON(ctx, client, cli)
+	}
+
+	from, err := loadTimezoneFlag(c.Timezone)
+	if err != nil {
+		return err
+	}
+	if from != nil && c.Start == "" && c.End == "" {
+		return fmt.Errorf("--timezone only applies to --start and --end")
+	}
+
+	if len(c.Entries) > 0 && (c.CopyLast || c.Hours != nil || c.Start != "" || c.End != "" || c.Timestamp) {
+		return fmt.Errorf("--entry cannot be combined with --copy-last, --hours, --start, --end or --timestamp")
+	}
+	if err := c.checkTimes(); err != nil {
+		return err
+	}
+	if c.Offline && (len(c.Entries) > 0 || c.CopyLast || from != nil) {
+		return fmt.Errorf("--offline cannot be combined with --entry, --copy-last or --timezone")
+	}
+
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
 
+	if c.Offline {
+		return c.runOffline(cli, client)
+	}
+
+	if len(c.Entries) > 0 {
+		return c.runEntries(ctx, client, cli)
+	}
+
+	if c.CopyLast {
+		last, err := getMyLastTimeEntry(ctx, client)
+		if err != nil {
+			return err
+		}
+		c.copyFrom(last)
+		fmt.Fprintf(cli.Stderr, "Copying entry #%d (%s): %s - %s\n",
+			last.ID, last.SpentDate, last.Project.Name, last.Task.Name)
+	} else if !c.NoDefault {
+		c.Project, c.Task = applyDefaults(cli, client, c.Project, c.Task)
+	}
+
 	// If project/task not specified, run wizard
 	if c.Project == "" || c.Task == "" {
 		return c.runWizard(ctx, client, cli)
@@ -190,28 +316,174 @@ func (c *TimeAddCmd) Run(cli *CLI) error {
 		return err
 	}
 
-	input := &api.TimeEntryInput{
-		ProjectID: projectID,
-		TaskID:    taskID,
+	spentDate, err := c.spentDate()
+	if err != nil {
+		return err
+	}
+	if from != nil {
+		if err := c.convertTimestamps(ctx, client, spentDate, from); err != nil {
+			return err
+		}
 	}
 
-	// Parse date
-	if c.Date != "" {
-		t, err := dateparse.Parse(c.Date)
+	input, err := c.newInput(spentDate)
+	if err != nil {
+		return err
+	}
+	input.ProjectID = projectID
+	input.TaskID = taskID
+
+	if c.startsTimer() {
+		ok, err := c.confirmTimer(cli)
 		if err != nil {
-			return fmt.Errorf("invalid date: %w", err)
+			return err
 		}
-		input.SpentDate = dateparse.FormatDate(t)
-	} else {
-		input.SpentDate = dateparse.FormatDate(time.Now())
+		if !ok {
+			fmt.Fprintln(cli.Stderr, "Aborted; pass --hours 0 for an empty entry")
+			return nil
+		}
+	}
+
+	entry, err := client.CreateTimeEntry(ctx, input)
+	if err != nil {
+		return fmt.Errorf("create time entry: %w", err)
+	}
+
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, entry)
+	}
+
+	if c.Start != "" && c.End != "" {
+		// Harvest computes the hours of a timestamp entry
+		printSuccess(cli, entry.ID, "Created time entry #%d: %s - %s (%s-%s, %.2fh)\n",
+			entry.ID, entry.Project.Name, entry.Task.Name, entry.StartedTime, entry.EndedTime, entry.Hours)
+		return nil
+	}
+	printSuccess(cli, entry.ID, "Created time entry #%d: %s - %s (%.2fh)\n",
+		entry.ID, entry.Project.Name, entry.Task.Name, entry.Hours)
+	return nil
+}
+
+// checkTimes rejects --hours alongside --start/--end, since Harvest derives
+// the hours from the times, and an --end that is not after --start.
+func (c *TimeAddCmd) checkTimes() error {
+	if c.Start == "" && c.End == "" {
+		return nil
+	}
+	if c.Hours != nil {
+		return fmt.Errorf("--hours cannot be combined with --start/--end; Harvest computes the hours from the times")
+	}
+	if c.Start == "" || c.End == "" {
+		return nil
+	}
+
+	startHour, startMin, err := dateparse.ParseTimeOfDay(c.Start)
+	if err != nil {
+		return fmt.Errorf("invalid --start time: %w", err)
+	}
+	endHour, endMin, err := dateparse.ParseTimeOfDay(c.End)
+	if err != nil {
+		return fmt.Errorf("invalid --end time: %w", err)
+	}
+	if endHour*60+endMin <= startHour*60+startMin {
+		return fmt.Errorf("--end %s must be after --start %s", c.End, c.Start)
+	}
+	return nil
+}
+
+// runOffline queues the entry in the local queue instead of creating it.
+// Project and task are resolved when the queue is pushed.
+func (c *TimeAddCmd) runOffline(cli *CLI, client *api.Client) error {
+	if !c.NoDefault {
+		c.Project, c.Task = applyDefaults(cli, client, c.Project, c.Task)
+	}
+	if c.Project == "" || c.Task == "" {
+		return fmt.Errorf("--offline needs --project and --task (or configured defaults)")
+	}
+	// No hours would start a timer at push time rather than now
+	if c.startsTimer() {
+		return fmt.Errorf("--offline needs --hours or --start/--end")
+	}
+
+	spentDate, err := c.spentDate()
+	if err != nil {
+		return err
+	}
+	input, err := c.newInput(spentDate)
+	if err != nil {
+		return err
+	}
+
+	item := newQueuedEntry(client.AccountID(), queueKindAdd)
+	item.Project, item.Task, item.Input = c.Project, c.Task, input
+
+	if cli.DryRun {
+		fmt.Fprintf(cli.Stderr, "Dry run - not queueing %s\n", describeQueued(item))
+		return nil
 	}
+	if err := appendQueue(queuePath(), item); err != nil {
+		return err
+	}
+
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, item)
+	}
+	if cli.Quiet {
+		fmt.Fprintln(cli.Stdout, item.ID)
+		return nil
+	}
+	fmt.Fprintf(cli.Stdout, "Queued %s\n", describeQueued(item))
+	fmt.Fprintln(cli.Stderr, "Run 'harvest sync push' when back online to create it")
+	return nil
+}
+
+// startsTimer reports whether the entry has neither hours nor start/end
+// times, so creating it starts a timer.
+func (c *TimeAddCmd) startsTimer() bool {
+	return c.Hours == nil && !c.Timestamp && c.Start == "" && c.End == ""
+}
+
+// confirmTimer decides whether an entry without hours may start a timer:
+// never with --no-timer, after a prompt on a terminal, and otherwise with a
+// note on stderr so scripts keep working.
+func (c *TimeAddCmd) confirmTimer(cli *CLI) (bool, error) {
+	if c.NoTimer {
+		return false, fmt.Errorf("no --hours, --start or --end given and --no-timer is set; pass --hours 0 for an empty entry")
+	}
+	if !stdinIsTerminal(cli) {
+		fmt.Fprintln(cli.Stderr, "No --hours given; starting a timer (pass --hours 0 for an empty entry)")
+		return true, nil
+	}
+
+	confirmed, err := ui.ConfirmPrompt("No --hours given. Start a timer?")
+	if err == ui.ErrCanceled {
+		return false, nil
+	}
+	return confirmed, err
+}
+
+// spentDate returns the entry's date from --date, defaulting to today.
+func (c *TimeAddCmd) spentDate() (string, error) {
+	if c.Date == "" {
+		return dateparse.FormatDate(time.Now()), nil
+	}
+	t, err := dateparse.Parse(c.Date)
+	if err != nil {
+		return "", fmt.Errorf("invalid date: %w", err)
+	}
+	return dateparse.FormatDate(t), nil
+}
+
+// newInput builds the time entry from the flags, without project and task.
+func (c *TimeAddCmd) newInput(spentDate string) (*api.TimeEntryInput, error) {
+	input := &api.TimeEntryInput{SpentDate: spentDate}
 
 	// Validate hours
-	if c.Hours < 0 {
-		return fmt.Errorf("hours cannot be negative")
+	if c.Hours != nil && *c.Hours < 0 {
+		return nil, fmt.Errorf("hours cannot be negative")
 	}
-	if c.Hours > 24 {
-		return fmt.Errorf("hours cannot exceed 24")
+	if c.Hours != nil && *c.Hours > 24 {
+		return nil, fmt.Errorf("hours cannot exceed 24")
 	}
 
 	// Handle duration vs timestamp mode
@@ -222,13 +494,11 @@ func (c *TimeAddCmd) Run(cli *CLI) error {
 		if c.End != "" {
 			input.EndedTime = &c.End
 		}
-	} else if c.Hours > 0 {
-		input.Hours = &c.Hours
-	} else {
-		// Default to 0 hours (timer will be started)
-		h := 0.0
-		input.Hours = &h
+	} else if c.Hours != nil {
+		// An explicit --hours 0 creates a stopped, empty entry
+		input.Hours = c.Hours
 	}
+	// Without hours, Harvest starts a timer for the new entry
 
 	if c.Notes != "" {
 		input.Notes = &c.Notes
@@ -243,21 +513,217 @@ func (c *TimeAddCmd) Run(cli *CLI) error {
 			Service:   c.ExtRefService,
 		}
 	}
+	return input, nil
+}
 
-	entry, err := client.CreateTimeEntry(ctx, input)
+// runEntries creates one time entry per --entry spec. --project, --task,
+// --date and --notes (and the configured defaults) fill in fields a spec
+// leaves out. Invalid specs are reported and skipped.
+func (c *TimeAddCmd) runEntries(ctx context.Context, client *api.Client, cli *CLI) error {
+	project, task := c.Project, c.Task
+	if !c.NoDefault {
+		project, task = applyDefaults(cli, client, project, task)
+	}
+
+	resolver := newIDResolver(client)
+	var (
+		created []*api.TimeEntry
+		failed  int
+	)
+	for i, raw := range c.Entries {
+		input, err := c.entryInput(ctx, resolver, raw, project, task)
+		if err == nil {
+			var entry *api.TimeEntry
+			entry, err = client.CreateTimeEntry(ctx, input)
+			if err == nil {
+				created = append(created, entry)
+				if !cli.jsonOutput() {
+					printSuccess(cli, entry.ID, "[%d/%d] Created time entry #%d: %s - %s (%.2fh)\n",
+						i+1, len(c.Entries), entry.ID, entry.Project.Name, entry.Task.Name, entry.Hours)
+				}
+				continue
+			}
+			err = fmt.Errorf("create time entry: %w", err)
+		}
+		failed++
+		fmt.Fprintf(cli.Stderr, "Entry %d (%s): %v\n", i+1, raw, err)
+	}
+
+	if cli.jsonOutput() {
+		if created == nil {
+			created = []*api.TimeEntry{}
+		}
+		if err := output.WriteJSON(cli.Stdout, created); err != nil {
+			return err
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d entries failed", failed, len(c.Entries))
+	}
+	return nil
+}
+
+// entryInput builds the time entry for one --entry spec.
+func (c *TimeAddCmd) entryInput(ctx context.Context, resolver *idResolver, raw, project, task string) (*api.TimeEntryInput, error) {
+	spec, err := parseEntrySpec(raw)
 	if err != nil {
-		return fmt.Errorf("create time entry: %w", err)
+		return nil, err
+	}
+	if spec.Project == "" {
+		spec.Project = project
+	}
+	if spec.Task == "" {
+		spec.Task = task
+	}
+	if spec.Date == "" {
+		spec.Date = c.Date
+	}
+	if spec.Notes == "" {
+		spec.Notes = c.Notes
+	}
+	if spec.Project == "" || spec.Task == "" {
+		return nil, fmt.Errorf("project and task are required")
 	}
 
-	if cli.JSON {
-		return output.WriteJSON(os.Stdout, entry)
+	projectID, err := resolver.projectID(ctx, spec.Project)
+	if err != nil {
+		return nil, err
+	}
+	taskID, err := resolver.taskID(ctx, projectID, spec.Task)
+	if err != nil {
+		return nil, err
 	}
 
-	fmt.Fprintf(os.Stdout, "Created time entry #%d: %s - %s (%.2fh)\n",
-		entry.ID, entry.Project.Name, entry.Task.Name, entry.Hours)
+	input := &api.TimeEntryInput{
+		ProjectID: projectID,
+		TaskID:    taskID,
+		SpentDate: dateparse.FormatDate(time.Now()),
+		Hours:     &spec.Hours,
+	}
+	if spec.Date != "" {
+		t, err := dateparse.Parse(spec.Date)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date: %w", err)
+		}
+		input.SpentDate = dateparse.FormatDate(t)
+	}
+	if spec.Notes != "" {
+		input.Notes = &spec.Notes
+	}
+	return input, nil
+}
+
+// entrySpec is one parsed --entry value.
+type entrySpec struct {
+	Project string
+	Task    string
+	Hours   float64
+	Notes   string
+	Date    string
+}
+
+// parseEntrySpec parses comma-separated key=value pairs such as
+// "project=Acme,task=Dev,hours=2,notes=Standup". A part without "=" belongs
+// to the previous value, so notes may contain commas. Hours accept decimal
+// hours or durations like "1h30m".
+func parseEntrySpec(s string) (entrySpec, error) {
+	var (
+		spec  entrySpec
+		hours string
+	)
+	values := map[string]*string{
+		"project": &spec.Project,
+		"task":    &spec.Task,
+		"hours":   &hours,
+		"notes":   &spec.Notes,
+		"date":    &spec.Date,
+	}
+
+	var last *string
+	for _, part := range strings.Split(s, ",") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			if last == nil {
+				return spec, fmt.Errorf("invalid entry %q: expected key=value", s)
+			}
+			*last += "," + part
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		field, known := values[key]
+		if !known {
+			return spec, fmt.Errorf("unknown entry field %q (use project, task, hours, notes or date)", key)
+		}
+		*field = value
+		last = field
+	}
+
+	spec.Project = strings.TrimSpace(spec.Project)
+	spec.Task = strings.TrimSpace(spec.Task)
+	spec.Date = strings.TrimSpace(spec.Date)
+	spec.Notes = strings.TrimSpace(spec.Notes)
+
+	hours = strings.TrimSpace(hours)
+	if hours == "" {
+		return spec, fmt.Errorf("hours is required")
+	}
+	h, err := strconv.ParseFloat(hours, 64)
+	if err != nil {
+		d, derr := dateparse.ParseDuration(hours)
+		if derr != nil {
+			return spec, fmt.Errorf("invalid hours %q", hours)
+		}
+		h = d.Hours()
+	}
+	if h <= 0 || h > 24 {
+		return spec, fmt.Errorf("hours must be between 0 and 24, got %g", h)
+	}
+	spec.Hours = h
+	return spec, nil
+}
+
+// convertTimestamps rewrites --start and --end from zone from into the
+// user's Harvest time zone, on the entry's date.
+func (c *TimeAddCmd) convertTimestamps(ctx context.Context, client *api.Client, spentDate string, from *time.Location) error {
+	to, err := harvestUserLocation(ctx, client)
+	if err != nil {
+		return err
+	}
+	day, err := time.Parse("2006-01-02", spentDate)
+	if err != nil {
+		return fmt.Errorf("invalid date: %w", err)
+	}
+
+	for _, field := range []struct {
+		flag  string
+		value *string
+	}{{"--start", &c.Start}, {"--end", &c.End}} {
+		if *field.value == "" {
+			continue
+		}
+		t, err := dateparse.ConvertTimeOfDay(*field.value, day, from, to)
+		if err != nil {
+			return fmt.Errorf("invalid %s time: %w", field.flag, err)
+		}
+		*field.value = t.Format("3:04pm")
+	}
 	return nil
 }
 
+// copyFrom fills in project, task and notes from entry. Explicit flags win;
+// the task is only copied along with its project.
+func (c *TimeAddCmd) copyFrom(entry *api.TimeEntry) {
+	if c.Project == "" {
+		c.Project = strconv.FormatInt(entry.Project.ID, 10)
+		if c.Task == "" {
+			c.Task = strconv.FormatInt(entry.Task.ID, 10)
+		}
+	}
+	if c.Notes == "" {
+		c.Notes = entry.Notes
+	}
+}
+
 func (c *TimeAddCmd) runWizard(ctx context.Context, client *api.Client, cli *CLI) error {
 	projects, err := fetchProjectsForWizard(ctx, client)
 	if err != nil {
@@ -276,7 +742,7 @@ func (c *TimeAddCmd) runWizard(ctx context.Context, client *api.Client, cli *CLI
 	data, err := wizard.Run()
 	if err != nil {
 		if err == ui.ErrCanceled {
-			fmt.Fprintln(os.Stderr, "Canceled")
+			fmt.Fprintln(cli.Stderr, "Canceled")
 			return nil
 		}
 		return err
@@ -302,11 +768,11 @@ func (c *TimeAddCmd) runWizard(ctx context.Context, client *api.Client, cli *CLI
 		return fmt.Errorf("create time entry: %w", err)
 	}
 
-	if cli.JSON {
-		return output.WriteJSON(os.Stdout, entry)
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, entry)
 	}
 
-	fmt.Fprintf(os.Stdout, "Created time entry #%d: %s - %s (%.2fh)\n",
+	printSuccess(cli, entry.ID, "Created time entry #%d: %s - %s (%.2fh)\n",
 		entry.ID, entry.Project.Name, entry.Task.Name, entry.Hours)
 	return nil
 }
@@ -325,15 +791,27 @@ type TimeEditCmd struct {
 	ExtRefGroupID string  `help:"External reference group ID" name:"external-ref-group-id"`
 	ExtRefURL     string  `help:"External reference URL" name:"external-ref-url"`
 	ExtRefService string  `help:"External reference service name (e.g., jira, asana)" name:"external-ref-service"`
+	AllowLocked   bool    `help:"Attempt the update even if the entry is locked or billed" name:"allow-locked"`
 }
 
 func (c *TimeEditCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
 
+	var current *api.TimeEntry
+	if !c.AllowLocked {
+		current, err = client.GetTimeEntry(ctx, c.ID)
+		if err != nil {
+			return fmt.Errorf("get time entry: %w", err)
+		}
+		if reason := timeEntryLockReason(*current); reason != "" {
+			return lockedError("time entry", c.ID, reason)
+		}
+	}
+
 	input := &api.TimeEntryInput{}
 	hasChanges := false
 
@@ -351,11 +829,13 @@ func (c *TimeEditCmd) Run(cli *CLI) error {
 		projectID := input.ProjectID
 		if projectID == 0 {
 			// Get current entry to find project
-			entry, err := client.GetTimeEntry(ctx, c.ID)
-			if err != nil {
-				return fmt.Errorf("get time entry: %w", err)
+			if current == nil {
+				current, err = client.GetTimeEntry(ctx, c.ID)
+				if err != nil {
+					return fmt.Errorf("get time entry: %w", err)
+				}
 			}
-			projectID = entry.Project.ID
+			projectID = current.Project.ID
 		}
 		taskID, err := resolveTaskID(ctx, client, projectID, c.Task)
 		if err != nil {
@@ -420,24 +900,25 @@ func (c *TimeEditCmd) Run(cli *CLI) error {
 		return fmt.Errorf("update time entry: %w", err)
 	}
 
-	if cli.JSON {
-		return output.WriteJSON(os.Stdout, entry)
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, entry)
 	}
 
-	fmt.Fprintf(os.Stdout, "Updated time entry #%d: %s - %s (%.2fh)\n",
+	printSuccess(cli, entry.ID, "Updated time entry #%d: %s - %s (%.2fh)\n",
 		entry.ID, entry.Project.Name, entry.Task.Name, entry.Hours)
 	return nil
 }
 
 // TimeRemoveCmd deletes a time entry.
 type TimeRemoveCmd struct {
-	ID    int64 `arg:"" help:"Time entry ID"`
-	Force bool  `help:"Skip confirmation" short:"f"`
+	ID          int64 `arg:"" help:"Time entry ID"`
+	Force       bool  `help:"Skip confirmation" short:"f"`
+	AllowLocked bool  `help:"Attempt the delete even if the entry is locked or billed" name:"allow-locked"`
 }
 
 func (c *TimeRemoveCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -447,6 +928,9 @@ func (c *TimeRemoveCmd) Run(cli *CLI) error {
 	if err != nil {
 		return fmt.Errorf("get time entry: %w", err)
 	}
+	if reason := timeEntryLockReason(*entry); reason != "" && !c.AllowLocked {
+		return lockedError("time entry", c.ID, reason)
+	}
 
 	if !c.Force {
 		msg := fmt.Sprintf("Delete time entry #%d (%s - %s, %.2fh on %s)?",
@@ -454,13 +938,13 @@ func (c *TimeRemoveCmd) Run(cli *CLI) error {
 		confirmed, err := ui.ConfirmPrompt(msg)
 		if err != nil {
 			if err == ui.ErrCanceled {
-				fmt.Fprintln(os.Stderr, "Canceled")
+				fmt.Fprintln(cli.Stderr, "Canceled")
 				return nil
 			}
 			return err
 		}
 		if !confirmed {
-			fmt.Fprintln(os.Stderr, "Aborted")
+			fmt.Fprintln(cli.Stderr, "Aborted")
 			return nil
 		}
 	}
@@ -469,17 +953,175 @@ func (c *TimeRemoveCmd) Run(cli *CLI) error {
 		return fmt.Errorf("delete time entry: %w", err)
 	}
 
-	fmt.Fprintf(os.Stdout, "Deleted time entry #%d\n", c.ID)
+	printSuccess(cli, 0, "Deleted time entry #%d\n", c.ID)
 	return nil
 }
 
+// TimeMoveCmd reassigns time entries from one project to another.
+type TimeMoveCmd struct {
+	FromProject string `help:"Project ID or name to move entries from" name:"from-project" required:""`
+	FromTask    string `help:"Only move entries with this task ID or name" name:"from-task"`
+	ToProject   string `help:"Project ID or name to move entries to" name:"to-project" required:""`
+	ToTask      string `help:"Task ID or name to move entries to" name:"to-task" required:""`
+	From        string `help:"Start date (YYYY-MM-DD or 'today')" short:"f" aliases:"since"`
+	To          string `help:"End date" short:"t" aliases:"until"`
+	User        string `help:"Only move entries of this user ID, email or 'me'"`
+	Force       bool   `help:"Skip confirmation"`
+}
+
+func (c *TimeMoveCmd) Run(cli *CLI) error {
+	ctx := context.Background()
+	client, err := NewClientFromFlags(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	opts := api.TimeEntryListOptions{}
+
+	if opts.ProjectID, err = resolveProjectID(ctx, client, c.FromProject); err != nil {
+		return err
+	}
+	if c.FromTask != "" {
+		if opts.TaskID, err = resolveTaskID(ctx, client, opts.ProjectID, c.FromTask); err != nil {
+			return err
+		}
+	}
+	if c.User != "" {
+		if opts.UserID, err = resolveUserID(ctx, client, c.User); err != nil {
+			return err
+		}
+	}
+
+	if opts.From, opts.To, err = parseDateRange(c.From, c.To); err != nil {
+		return err
+	}
+
+	toProjectID, err := resolveProjectID(ctx, client, c.ToProject)
+	if err != nil {
+		return err
+	}
+	toTaskID, err := resolveTaskID(ctx, client, toProjectID, c.ToTask)
+	if err != nil {
+		return err
+	}
+
+	entries, err := client.ListAllTimeEntries(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("list time entries: %w", err)
+	}
+
+	movable, locked := splitLockedEntries(entries)
+	for _, e := range locked {
+		fmt.Fprintf(cli.Stderr, "Warning: skipping #%d, %s\n", e.ID, timeEntryLockReason(e))
+	}
+
+	if len(movable) == 0 {
+		if cli.jsonOutput() {
+			return output.WriteJSON(cli.Stdout, timeMoveResult(nil, locked, 0))
+		}
+		fmt.Fprintln(cli.Stdout, "No entries to move")
+		return nil
+	}
+
+	// Show what will be moved
+	fmt.Fprintf(cli.Stderr, "Entries to move (%d):\n", len(movable))
+	var totalHours float64
+	for _, e := range movable {
+		fmt.Fprintf(cli.Stderr, "  #%d: %s - %s - %.2fh (%s)\n",
+			e.ID, e.Project.Name, e.Task.Name, e.Hours, e.SpentDate)
+		totalHours += e.Hours
+	}
+	fmt.Fprintf(cli.Stderr, "Total: %.2fh\n\n", totalHours)
+
+	if cli.DryRun {
+		fmt.Fprintln(cli.Stderr, "Dry run - no entries moved")
+		return nil
+	}
+
+	if !c.Force {
+		msg := fmt.Sprintf("Move %d time entries?", len(movable))
+		confirmed, err := ui.ConfirmPrompt(msg)
+		if err != nil {
+			if err == ui.ErrCanceled {
+				fmt.Fprintln(cli.Stderr, "Canceled")
+				return nil
+			}
+			return err
+		}
+		if !confirmed {
+			fmt.Fprintln(cli.Stderr, "Aborted")
+			return nil
+		}
+	}
+
+	input := &api.TimeEntryInput{ProjectID: toProjectID, TaskID: toTaskID}
+	var moved []int64
+	for _, e := range movable {
+		if _, err := client.UpdateTimeEntry(ctx, e.ID, input); err != nil {
+			fmt.Fprintf(cli.Stderr, "Error moving #%d: %v\n", e.ID, err)
+			continue
+		}
+		moved = append(moved, e.ID)
+	}
+
+	failed := len(movable) - len(moved)
+	if cli.jsonOutput() {
+		if err := output.WriteJSON(cli.Stdout, timeMoveResult(moved, locked, failed)); err != nil {
+			return err
+		}
+	} else {
+		printSuccess(cli, 0, "Moved %d of %d entries (%d skipped)\n",
+			len(moved), len(movable), len(locked))
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d entries failed", failed, len(movable))
+	}
+	return nil
+}
+
+// timeMoveResult is the JSON result of time move: the IDs of the moved and
+// skipped entries and the number that failed.
+func timeMoveResult(moved []int64, locked []api.TimeEntry, failed int) map[string]any {
+	skipped := make([]int64, len(locked))
+	for i, e := range locked {
+		skipped[i] = e.ID
+	}
+	if moved == nil {
+		moved = []int64{}
+	}
+	return map[string]any{
+		"moved":   moved,
+		"skipped": skipped,
+		"failed":  failed,
+	}
+}
+
+// splitLockedEntries separates entries that can still be changed from
+// locked or billed ones, which Harvest rejects updates to.
+func splitLockedEntries(entries []api.TimeEntry) (open, locked []api.TimeEntry) {
+	for _, e := range entries {
+		if timeEntryLockReason(e) != "" {
+			locked = append(locked, e)
+		} else {
+			open = append(open, e)
+		}
+	}
+	return open, locked
+}
+
+// timeEntryLockReason explains why an entry cannot be changed, or returns
+// "" if it can.
+func timeEntryLockReason(e api.TimeEntry) string {
+	return lockReason(e.IsLocked, e.IsBilled, e.LockedReason)
+}
+
 // TimeLogCmd provides quick time entry with wizard fallback.
 type TimeLogCmd struct {
-	Notes   string  `arg:"" optional:"" help:"Entry notes"`
-	Project string  `help:"Project ID or name" short:"p"`
-	Task    string  `help:"Task ID or name"`
-	Hours   float64 `help:"Hours" short:"h"`
-	Date    string  `help:"Date (default: today)" short:"d"`
+	Notes   string   `arg:"" optional:"" help:"Entry notes"`
+	Project string   `help:"Project ID or name" short:"p"`
+	Task    string   `help:"Task ID or name"`
+	Hours   *float64 `help:"Hours" short:"h"`
+	Date    string   `help:"Date (default: today)" short:"d"`
 }
 
 func (c *TimeLogCmd) Run(cli *CLI) error {
@@ -493,3 +1135,24 @@ func (c *TimeLogCmd) Run(cli *CLI) error {
 	}
 	return add.Run(cli)
 }
+
+// TimeSubmitDayCmd submits a single day's unsubmitted entries for approval.
+type TimeSubmitDayCmd struct {
+	Date  string `arg:"" optional:"" help:"Date to submit (default: today)"`
+	Force bool   `help:"Skip confirmation" short:"f"`
+}
+
+func (c *TimeSubmitDayCmd) Run(cli *CLI) error {
+	date := c.Date
+	if date == "" {
+		date = "today"
+	}
+
+	// Delegate to ApprovalsSubmitCmd with a one-day window
+	submit := &ApprovalsSubmitCmd{
+		From:  date,
+		To:    date,
+		Force: c.Force,
+	}
+	return submit.Run(cli)
+}
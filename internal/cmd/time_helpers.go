@@ -4,15 +4,20 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/dedene/harvest-cli/internal/api"
+	"github.com/dedene/harvest-cli/internal/config"
+	"github.com/dedene/harvest-cli/internal/dateparse"
 	"github.com/dedene/harvest-cli/internal/output"
 	"github.com/dedene/harvest-cli/internal/ui"
 )
 
-// resolveProjectID resolves a project by ID or name.
+// resolveProjectID resolves a project by ID, name or code.
 func resolveProjectID(ctx context.Context, client *api.Client, input string) (int64, error) {
 	// Try as ID first
 	if id, err := strconv.ParseInt(input, 10, 64); err == nil {
@@ -20,22 +25,16 @@ func resolveProjectID(ctx context.Context, client *api.Client, input string) (in
 	}
 
 	// Search by name
-	projects, err := client.ListAllProjects(ctx, api.ProjectListOptions{IsActive: boolPtr(true)})
+	projects, err := client.ListAllProjects(ctx, api.ProjectListOptions{})
 	if err != nil {
 		return 0, fmt.Errorf("fetch projects: %w", err)
 	}
 
-	input = strings.ToLower(input)
-	for _, p := range projects {
-		if strings.ToLower(p.Name) == input || strings.Contains(strings.ToLower(p.Name), input) {
-			return p.ID, nil
-		}
-		if p.Code != "" && strings.ToLower(p.Code) == input {
-			return p.ID, nil
-		}
+	records := make([]namedRecord, len(projects))
+	for i, p := range projects {
+		records[i] = namedRecord{ID: p.ID, Name: p.Name, Code: p.Code, Detail: p.Client.Name, Active: p.IsActive}
 	}
-
-	return 0, fmt.Errorf("project not found: %s", input)
+	return lookupByName("project", input, records)
 }
 
 // resolveClientID resolves a client by ID or name.
@@ -46,19 +45,16 @@ func resolveClientID(ctx context.Context, client *api.Client, input string) (int
 	}
 
 	// Search by name
-	clients, err := client.ListAllClients(ctx, api.ClientListOptions{IsActive: boolPtr(true)})
+	clients, err := client.ListAllClients(ctx, api.ClientListOptions{})
 	if err != nil {
 		return 0, fmt.Errorf("fetch clients: %w", err)
 	}
 
-	input = strings.ToLower(input)
-	for _, c := range clients {
-		if strings.ToLower(c.Name) == input || strings.Contains(strings.ToLower(c.Name), input) {
-			return c.ID, nil
-		}
+	records := make([]namedRecord, len(clients))
+	for i, c := range clients {
+		records[i] = namedRecord{ID: c.ID, Name: c.Name, Active: c.IsActive}
 	}
-
-	return 0, fmt.Errorf("client not found: %s", input)
+	return lookupByName("client", input, records)
 }
 
 // resolveTaskID resolves a task by ID or name within a project.
@@ -74,19 +70,229 @@ func resolveTaskID(ctx context.Context, client *api.Client, projectID int64, inp
 		return 0, fmt.Errorf("fetch assignments: %w", err)
 	}
 
-	input = strings.ToLower(input)
+	var records []namedRecord
 	for _, pa := range assignments {
 		if pa.Project.ID != projectID {
 			continue
 		}
 		for _, ta := range pa.TaskAssignments {
-			if strings.ToLower(ta.Task.Name) == input || strings.Contains(strings.ToLower(ta.Task.Name), input) {
-				return ta.Task.ID, nil
+			records = append(records, namedRecord{ID: ta.Task.ID, Name: ta.Task.Name, Active: ta.IsActive})
+		}
+	}
+	return lookupByName("task", input, records)
+}
+
+// idResolver resolves project and task names to IDs, remembering results
+// so names repeated within one run are only looked up once.
+type idResolver struct {
+	client   *api.Client
+	projects map[string]int64
+	tasks    map[string]int64 // key: "projectID:task"
+}
+
+func newIDResolver(client *api.Client) *idResolver {
+	return &idResolver{
+		client:   client,
+		projects: make(map[string]int64),
+		tasks:    make(map[string]int64),
+	}
+}
+
+// projectID resolves a project like resolveProjectID.
+func (r *idResolver) projectID(ctx context.Context, input string) (int64, error) {
+	if id, ok := r.projects[input]; ok {
+		return id, nil
+	}
+	id, err := resolveProjectID(ctx, r.client, input)
+	if err != nil {
+		return 0, err
+	}
+	r.projects[input] = id
+	return id, nil
+}
+
+// taskID resolves a task within a project like resolveTaskID.
+func (r *idResolver) taskID(ctx context.Context, projectID int64, input string) (int64, error) {
+	key := fmt.Sprintf("%d:%s", projectID, input)
+	if id, ok := r.tasks[key]; ok {
+		return id, nil
+	}
+	id, err := resolveTaskID(ctx, r.client, projectID, input)
+	if err != nil {
+		return 0, err
+	}
+	r.tasks[key] = id
+	return id, nil
+}
+
+// flagFilter turns a pair of opposing boolean flags into an optional filter.
+// It returns nil when neither flag is set and an error when both are.
+func flagFilter(yes, no bool, yesName, noName string) (*bool, error) {
+	switch {
+	case yes && no:
+		return nil, fmt.Errorf("--%s and --%s cannot be combined", yesName, noName)
+	case yes:
+		return &yes, nil
+	case no:
+		f := false
+		return &f, nil
+	}
+	return nil, nil
+}
+
+// filterByBillable keeps items whose billable flag matches want. A nil want
+// keeps everything.
+func filterByBillable[T any](items []T, want *bool, billable func(T) bool) []T {
+	if want == nil {
+		return items
+	}
+	filtered := make([]T, 0, len(items))
+	for _, item := range items {
+		if billable(item) == *want {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
+}
+
+// ExcludeFlags leave out time entries on some projects or tasks. The API
+// only filters positively, so entries are dropped after they are fetched.
+type ExcludeFlags struct {
+	ExcludeProject []string `help:"Leave out entries on this project ID or name (repeatable)" name:"exclude-project" placeholder:"PROJECT" sep:"none"`
+	ExcludeTask    []string `help:"Leave out entries on this task ID or name (repeatable)" name:"exclude-task" placeholder:"TASK" sep:"none"`
+}
+
+// entryExclusion is the resolved form of ExcludeFlags.
+type entryExclusion struct {
+	projects map[int64]bool
+	tasks    map[int64]bool
+}
+
+// resolve looks up the excluded projects and tasks. Task names are matched
+// against all tasks in the account, since an exclusion is not tied to one
+// project.
+func (f ExcludeFlags) resolve(ctx context.Context, client *api.Client) (*entryExclusion, error) {
+	if len(f.ExcludeProject) == 0 && len(f.ExcludeTask) == 0 {
+		return nil, nil
+	}
+
+	x := &entryExclusion{projects: make(map[int64]bool), tasks: make(map[int64]bool)}
+	resolver := newIDResolver(client)
+	for _, p := range f.ExcludeProject {
+		id, err := resolver.projectID(ctx, p)
+		if err != nil {
+			return nil, fmt.Errorf("--exclude-project: %w", err)
+		}
+		x.projects[id] = true
+	}
+
+	var tasks []api.Task
+	for _, t := range f.ExcludeTask {
+		if id, err := strconv.ParseInt(t, 10, 64); err == nil {
+			x.tasks[id] = true
+			continue
+		}
+		if tasks == nil {
+			var err error
+			if tasks, err = client.ListAllTasks(ctx, api.TaskListOptions{}); err != nil {
+				return nil, fmt.Errorf("fetch tasks: %w", err)
 			}
 		}
+		id, err := matchTaskName(tasks, t)
+		if err != nil {
+			return nil, fmt.Errorf("--exclude-task: %w", err)
+		}
+		x.tasks[id] = true
+	}
+	return x, nil
+}
+
+// matchTaskName finds a task by name like the other resolvers.
+func matchTaskName(tasks []api.Task, input string) (int64, error) {
+	records := make([]namedRecord, len(tasks))
+	for i, t := range tasks {
+		records[i] = namedRecord{ID: t.ID, Name: t.Name, Active: t.IsActive}
+	}
+	return lookupByName("task", input, records)
+}
+
+// filter drops entries on excluded projects or tasks and returns how many
+// were dropped. A nil exclusion keeps everything.
+func (x *entryExclusion) filter(entries []api.TimeEntry) ([]api.TimeEntry, int) {
+	if x == nil {
+		return entries, 0
+	}
+	kept := make([]api.TimeEntry, 0, len(entries))
+	for _, e := range entries {
+		if x.projects[e.Project.ID] || x.tasks[e.Task.ID] {
+			continue
+		}
+		kept = append(kept, e)
+	}
+	return kept, len(entries) - len(kept)
+}
+
+// reportExcluded tells stderr how many entries --exclude-project and
+// --exclude-task left out.
+func reportExcluded(cli *CLI, x *entryExclusion, n int) {
+	if x == nil {
+		return
+	}
+	fmt.Fprintf(cli.Stderr, "Excluded %d entries\n", n)
+}
+
+// applyDefaults fills in the default project and task: the profile's when it
+// sets a project, otherwise those configured for the client's account. The
+// default task is only used with the default project.
+func applyDefaults(cli *CLI, client *api.Client, project, task string) (string, string) {
+	var d config.AccountDefaults
+	if cli.profile != nil && cli.profile.Project != "" {
+		d = config.AccountDefaults{Project: cli.profile.Project, Task: cli.profile.Task}
+	} else {
+		cfg, err := config.ReadConfig()
+		if err != nil {
+			return project, task
+		}
+		d = cfg.DefaultsFor(client.AccountID())
+	}
+
+	if project == "" {
+		project = d.Project
+	}
+	if task == "" && project == d.Project {
+		task = d.Task
+	}
+	return project, task
+}
+
+// resolveUserID resolves a user by ID, email address, or the literal "me".
+func resolveUserID(ctx context.Context, client *api.Client, input string) (int64, error) {
+	if input == "me" {
+		me, err := client.GetMe(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("get current user: %w", err)
+		}
+		return me.ID, nil
+	}
+
+	if id, err := strconv.ParseInt(input, 10, 64); err == nil {
+		return id, nil
+	}
+
+	if !strings.Contains(input, "@") {
+		return 0, fmt.Errorf("invalid user: %s (use a user ID, email or 'me')", input)
 	}
 
-	return 0, fmt.Errorf("task not found: %s", input)
+	users, err := client.ListAllUsers(ctx, api.UserListOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("list users: %w", err)
+	}
+	for _, u := range users {
+		if strings.EqualFold(u.Email, input) {
+			return u.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("no user with email %q", input)
 }
 
 // fetchProjectsForWizard fetches projects for the TUI picker.
@@ -141,74 +347,322 @@ func fetchTasksForProject(ctx context.Context, client *api.Client, projectID int
 	return nil, fmt.Errorf("project not found: %d", projectID)
 }
 
+// timeEntryTotals summarizes hours across a set of time entries.
+type timeEntryTotals struct {
+	Hours         float64 `json:"hours"`
+	BillableHours float64 `json:"billable_hours"`
+	RoundedHours  float64 `json:"rounded_hours"`
+}
+
+// sumTimeEntries totals hours, billable hours and rounded hours for entries.
+func sumTimeEntries(entries []api.TimeEntry) timeEntryTotals {
+	var totals timeEntryTotals
+	for _, e := range entries {
+		totals.Hours += e.Hours
+		totals.RoundedHours += e.RoundedHours
+		if e.Billable {
+			totals.BillableHours += e.Hours
+		}
+	}
+	return totals
+}
+
 // outputTimeEntries writes time entries in the specified format.
-func outputTimeEntries(w io.Writer, entries []api.TimeEntry, mode output.Mode) error {
+// When summary is set, a totals line (or JSON totals object) is included.
+// When rounded is set, a Rounded column shows the hours after the account's
+// rounding setting, which is what gets invoiced.
+func outputTimeEntries(w io.Writer, entries []api.TimeEntry, mode output.Mode, summary, rounded bool) error {
 	switch mode {
 	case output.ModeJSON:
+		if summary {
+			return output.WriteJSON(w, map[string]any{
+				"entries": entries,
+				"totals":  sumTimeEntries(entries),
+			})
+		}
 		return output.WriteJSON(w, entries)
 	case output.ModePlain:
-		headers := []string{"ID", "Date", "Project", "Task", "Hours", "ExtRef", "Notes"}
+		headers := timeEntryPlainHeaders(rounded)
 		rows := make([][]string, len(entries))
 		for i, e := range entries {
-			notes := e.Notes
-			if len(notes) > 40 {
-				notes = notes[:37] + "..."
-			}
-			extRef := ""
-			if e.ExternalReference != nil && e.ExternalReference.ID != "" {
-				extRef = e.ExternalReference.ID
-			}
-			rows[i] = []string{
-				strconv.FormatInt(e.ID, 10),
-				e.SpentDate,
-				e.Project.Name,
-				e.Task.Name,
-				fmt.Sprintf("%.2f", e.Hours),
-				extRef,
-				notes,
-			}
+			rows[i] = timeEntryPlainRow(e, rounded)
+		}
+		if summary {
+			rows = append(rows, timeTotalsPlainRow("TOTAL", sumTimeEntries(entries), rounded))
 		}
 		return output.WriteTSV(w, headers, rows)
 	default:
-		t := output.NewTable(w, "ID", "Date", "Project", "Task", "Hours", "ExtRef", "Notes")
+		showExtRef := hasExtRef(entries)
+		t := newTimeEntryTable(w, timeEntryTableHeaders(showExtRef, rounded), rounded)
 		for _, e := range entries {
-			notes := e.Notes
-			if len(notes) > 40 {
-				notes = notes[:37] + "..."
+			t.AddRow(timeEntryTableRow(e, showExtRef, rounded)...)
+		}
+		if err := t.Render(); err != nil {
+			return err
+		}
+		if summary {
+			writeTimeTotals(w, sumTimeEntries(entries), rounded)
+		}
+		return nil
+	}
+}
+
+// timeEntryGroup is a set of time entries sharing a project, task or day.
+type timeEntryGroup struct {
+	Group   string          `json:"group"`
+	Totals  timeEntryTotals `json:"totals"`
+	Entries []api.TimeEntry `json:"entries"`
+}
+
+// groupTimeEntries groups entries by "project", "task" or "day". Projects
+// and tasks are ordered by name, days by date; entries keep their order
+// within a group.
+func groupTimeEntries(entries []api.TimeEntry, by string) []timeEntryGroup {
+	type key struct {
+		id   int64
+		name string
+	}
+	keyOf := func(e api.TimeEntry) key {
+		switch by {
+		case "project":
+			return key{e.Project.ID, e.Project.Name}
+		case "task":
+			return key{e.Task.ID, e.Task.Name}
+		default:
+			return key{name: e.SpentDate}
+		}
+	}
+
+	var order []key
+	byKey := make(map[key][]api.TimeEntry)
+	for _, e := range entries {
+		k := keyOf(e)
+		if _, ok := byKey[k]; !ok {
+			order = append(order, k)
+		}
+		byKey[k] = append(byKey[k], e)
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		if order[i].name != order[j].name {
+			return order[i].name < order[j].name
+		}
+		return order[i].id < order[j].id
+	})
+
+	groups := make([]timeEntryGroup, len(order))
+	for i, k := range order {
+		groups[i] = timeEntryGroup{Group: k.name, Totals: sumTimeEntries(byKey[k]), Entries: byKey[k]}
+	}
+	return groups
+}
+
+// outputGroupedTimeEntries writes time entries grouped by "project", "task"
+// or "day", with a subtotal after each group. When summary is set, the
+// overall totals are included.
+func outputGroupedTimeEntries(w io.Writer, entries []api.TimeEntry, by string, mode output.Mode, summary, rounded bool) error {
+	groups := groupTimeEntries(entries, by)
+	switch mode {
+	case output.ModeJSON:
+		result := map[string]any{"group_by": by, "groups": groups}
+		if summary {
+			result["totals"] = sumTimeEntries(entries)
+		}
+		return output.WriteJSON(w, result)
+	case output.ModePlain:
+		var rows [][]string
+		for _, g := range groups {
+			for _, e := range g.Entries {
+				rows = append(rows, timeEntryPlainRow(e, rounded))
+			}
+			rows = append(rows, timeTotalsPlainRow("SUBTOTAL", g.Totals, rounded))
+		}
+		if summary {
+			rows = append(rows, timeTotalsPlainRow("TOTAL", sumTimeEntries(entries), rounded))
+		}
+		return output.WriteTSV(w, timeEntryPlainHeaders(rounded), rows)
+	default:
+		showExtRef := hasExtRef(entries)
+		headers := timeEntryTableHeaders(showExtRef, rounded)
+		colors := output.DefaultColors()
+		t := newTimeEntryTable(w, headers, rounded)
+		for i, g := range groups {
+			if i > 0 {
+				t.AddRow(make([]string, len(headers))...)
+			}
+			for _, e := range g.Entries {
+				t.AddRow(timeEntryTableRow(e, showExtRef, rounded)...)
 			}
-			extRef := ""
-			if e.ExternalReference != nil && e.ExternalReference.ID != "" {
-				extRef = e.ExternalReference.ID
+			subtotal := make([]string, len(headers))
+			subtotal[0] = "Subtotal"
+			subtotal[4] = fmt.Sprintf("%.2f", g.Totals.Hours)
+			if rounded {
+				subtotal[5] = fmt.Sprintf("%.2f", g.Totals.RoundedHours)
 			}
-			t.AddRow(
-				strconv.FormatInt(e.ID, 10),
-				e.SpentDate,
-				e.Project.Name,
-				e.Task.Name,
-				fmt.Sprintf("%.2f", e.Hours),
-				extRef,
-				notes,
-			)
+			subtotal[groupColumn[by]] = g.Group
+			t.AddStyledRow(colors.Bold, subtotal...)
 		}
-		return t.Render()
+		if err := t.Render(); err != nil {
+			return err
+		}
+		if summary {
+			writeTimeTotals(w, sumTimeEntries(entries), rounded)
+		}
+		return nil
+	}
+}
+
+// groupColumn is the table column that shows a group's key.
+var groupColumn = map[string]int{"day": 1, "project": 2, "task": 3}
+
+// hasExtRef reports whether any entry has an external reference. Tables
+// only show the column when one does.
+func hasExtRef(entries []api.TimeEntry) bool {
+	return slices.ContainsFunc(entries, func(e api.TimeEntry) bool { return formatExtRef(e.ExternalReference) != "" })
+}
+
+// timeEntryTableHeaders returns the table columns for time entries.
+func timeEntryTableHeaders(showExtRef, rounded bool) []string {
+	headers := []string{"ID", "Date", "Project", "Task", "Hours", "Notes"}
+	if showExtRef {
+		headers = slices.Insert(headers, 5, "Ext Ref")
+	}
+	if rounded {
+		headers = slices.Insert(headers, 5, "Rounded")
+	}
+	return headers
+}
+
+// newTimeEntryTable returns a table for timeEntryTableHeaders with the
+// date column formatted and the hours columns right-aligned.
+func newTimeEntryTable(w io.Writer, headers []string, rounded bool) *output.Table {
+	t := output.NewTable(w, headers...).SetAlign(output.AlignRight, 4).SetDateColumns(1)
+	if rounded {
+		t.SetAlign(output.AlignRight, 5)
+	}
+	return t
+}
+
+// timeEntryTableRow returns the table cells for a time entry.
+func timeEntryTableRow(e api.TimeEntry, showExtRef, rounded bool) []string {
+	row := []string{
+		strconv.FormatInt(e.ID, 10),
+		e.SpentDate,
+		e.Project.Name,
+		e.Task.Name,
+		fmt.Sprintf("%.2f", e.Hours),
+		e.Notes,
+	}
+	if showExtRef {
+		row = slices.Insert(row, 5, formatExtRef(e.ExternalReference))
+	}
+	if rounded {
+		row = slices.Insert(row, 5, fmt.Sprintf("%.2f", e.RoundedHours))
 	}
+	return row
 }
 
-// outputTimeEntry writes a single time entry in the specified format.
-func outputTimeEntry(w io.Writer, entry *api.TimeEntry, mode output.Mode) error {
+// timeEntryPlainHeaders returns the TSV columns for time entries.
+func timeEntryPlainHeaders(rounded bool) []string {
+	headers := []string{"ID", "Date", "Project", "Task", "Hours", "ExtRef", "Notes"}
+	if rounded {
+		headers = slices.Insert(headers, 5, "Rounded")
+	}
+	return headers
+}
+
+// timeEntryPlainRow returns the TSV fields for a time entry.
+func timeEntryPlainRow(e api.TimeEntry, rounded bool) []string {
+	extRef := ""
+	if e.ExternalReference != nil && e.ExternalReference.ID != "" {
+		extRef = e.ExternalReference.ID
+	}
+	row := []string{
+		strconv.FormatInt(e.ID, 10),
+		e.SpentDate,
+		e.Project.Name,
+		e.Task.Name,
+		fmt.Sprintf("%.2f", e.Hours),
+		extRef,
+		e.Notes,
+	}
+	if rounded {
+		row = slices.Insert(row, 5, fmt.Sprintf("%.2f", e.RoundedHours))
+	}
+	return row
+}
+
+// timeTotalsPlainRow returns a TSV totals row labeled in the ID column.
+func timeTotalsPlainRow(label string, totals timeEntryTotals, rounded bool) []string {
+	row := []string{label, "", "", "", fmt.Sprintf("%.2f", totals.Hours), "", ""}
+	if rounded {
+		row = slices.Insert(row, 5, fmt.Sprintf("%.2f", totals.RoundedHours))
+	}
+	return row
+}
+
+// formatExtRef renders an external reference as "service ID", e.g.
+// "jira PROJ-123", or "" when there is none.
+func formatExtRef(ref *api.ExternalReference) string {
+	if ref == nil || ref.ID == "" {
+		return ""
+	}
+	if ref.Service == "" {
+		return ref.ID
+	}
+	return ref.Service + " " + ref.ID
+}
+
+// lockReason explains why Harvest will refuse to change a time entry or
+// expense, or returns "" if it can be changed.
+func lockReason(isLocked, isBilled bool, reason string) string {
+	switch {
+	case isBilled:
+		return "billed"
+	case isLocked && reason != "":
+		return "locked (reason: " + reason + ")"
+	case isLocked:
+		return "locked"
+	}
+	return ""
+}
+
+// lockedError reports a locked record before Harvest rejects the change
+// with a less helpful error.
+func lockedError(kind string, id int64, reason string) error {
+	return fmt.Errorf("%s #%d is %s; use --allow-locked to try anyway", kind, id, reason)
+}
+
+// writeTimeTotals writes the human-readable totals line below a table,
+// including the rounded total when rounded is set.
+func writeTimeTotals(w io.Writer, totals timeEntryTotals, rounded bool) {
+	if rounded {
+		fmt.Fprintf(w, "\nTotal: %.2fh (billable %.2fh, rounded %.2fh)\n", totals.Hours, totals.BillableHours, totals.RoundedHours)
+		return
+	}
+	fmt.Fprintf(w, "\nTotal: %.2fh (billable %.2fh)\n", totals.Hours, totals.BillableHours)
+}
+
+// outputTimeEntry writes a single time entry in the specified format. The
+// table view always shows both raw and rounded hours; plain output adds the
+// rounded hours after the raw hours when rounded is set.
+func outputTimeEntry(w io.Writer, entry *api.TimeEntry, mode output.Mode, rounded bool) error {
 	switch mode {
 	case output.ModeJSON:
 		return output.WriteJSON(w, entry)
 	case output.ModePlain:
-		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%.2f\t%s\n",
-			entry.ID, entry.SpentDate, entry.Project.Name, entry.Task.Name, entry.Hours, entry.Notes)
-		return nil
+		row := []string{strconv.FormatInt(entry.ID, 10), entry.SpentDate, entry.Project.Name, entry.Task.Name,
+			fmt.Sprintf("%.2f", entry.Hours)}
+		if rounded {
+			row = append(row, fmt.Sprintf("%.2f", entry.RoundedHours))
+		}
+		return output.WriteTSV(w, nil, [][]string{append(row, entry.Notes)})
 	default:
 		fmt.Fprintf(w, "ID:      %d\n", entry.ID)
-		fmt.Fprintf(w, "Date:    %s\n", entry.SpentDate)
+		fmt.Fprintf(w, "Date:    %s\n", output.FormatDate(entry.SpentDate))
 		fmt.Fprintf(w, "Project: %s\n", entry.Project.Name)
 		fmt.Fprintf(w, "Task:    %s\n", entry.Task.Name)
 		fmt.Fprintf(w, "Hours:   %.2f\n", entry.Hours)
+		fmt.Fprintf(w, "Rounded: %.2f\n", entry.RoundedHours)
 		if entry.Notes != "" {
 			fmt.Fprintf(w, "Notes:   %s\n", entry.Notes)
 		}
@@ -238,3 +692,73 @@ func outputTimeEntry(w io.Writer, entry *api.TimeEntry, mode output.Mode) error
 func boolPtr(b bool) *bool {
 	return &b
 }
+
+// getCompany returns the account's company settings, fetching them at most
+// once per run.
+func getCompany(ctx context.Context, cli *CLI, client *api.Client) (*api.Company, error) {
+	if cli.company != nil {
+		return cli.company, nil
+	}
+	company, err := client.GetCompany(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get company: %w", err)
+	}
+	cli.company = company
+	return company, nil
+}
+
+// resolveWeekStart returns the first day of the week. The --week-start flag
+// wins, then the week_start config value, then the account's company setting.
+func resolveWeekStart(ctx context.Context, cli *CLI, client *api.Client) (time.Weekday, error) {
+	if cli.WeekStart != "" {
+		return parseWeekStartDay(cli.WeekStart), nil
+	}
+	if cfg, err := config.ReadConfig(); err == nil && cfg.WeekStart != "" {
+		return parseWeekStartDay(cfg.WeekStart), nil
+	}
+
+	company, err := getCompany(ctx, cli, client)
+	if err != nil {
+		return time.Monday, err
+	}
+	return parseWeekStartDay(company.WeekStartDay), nil
+}
+
+// loadTimezoneFlag validates a --timezone value, returning nil when unset.
+func loadTimezoneFlag(name string) (*time.Location, error) {
+	if name == "" {
+		return nil, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --timezone %q (use an IANA name, e.g. Europe/Paris)", name)
+	}
+	return loc, nil
+}
+
+// harvestUserLocation returns the current user's Harvest time zone, in which
+// the API reads started and ended times.
+func harvestUserLocation(ctx context.Context, client *api.Client) (*time.Location, error) {
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get current user: %w", err)
+	}
+	loc, err := dateparse.LoadLocation(me.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("your Harvest time zone: %w", err)
+	}
+	return loc, nil
+}
+
+// parseUpdatedSince parses an --updated-since value into the RFC 3339 UTC
+// timestamp the API expects. Full ISO 8601 timestamps are taken as-is;
+// anything else goes through dateparse, so "today" or "2 days ago" work.
+func parseUpdatedSince(s string) (string, error) {
+	t, err := time.Parse(time.RFC3339, strings.TrimSpace(s))
+	if err != nil {
+		if t, err = dateparse.Parse(s); err != nil {
+			return "", fmt.Errorf("invalid --updated-since: %w", err)
+		}
+	}
+	return t.UTC().Format(time.RFC3339), nil
+}
@@ -0,0 +1,396 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/dedene/harvest-cli/internal/api"
+	"github.com/dedene/harvest-cli/internal/config"
+	"github.com/dedene/harvest-cli/internal/output"
+)
+
+func TestFlagFilter(t *testing.T) {
+	if f, err := flagFilter(false, false, "billable", "non-billable"); err != nil || f != nil {
+		t.Errorf("neither flag: got %v, %v; want nil, nil", f, err)
+	}
+	if f, err := flagFilter(true, false, "billable", "non-billable"); err != nil || f == nil || !*f {
+		t.Errorf("yes flag: got %v, %v; want true", f, err)
+	}
+	if f, err := flagFilter(false, true, "billable", "non-billable"); err != nil || f == nil || *f {
+		t.Errorf("no flag: got %v, %v; want false", f, err)
+	}
+
+	_, err := flagFilter(true, true, "billable", "non-billable")
+	if err == nil || err.Error() != "--billable and --non-billable cannot be combined" {
+		t.Errorf("both flags: error = %v", err)
+	}
+}
+
+func TestFilterByBillable(t *testing.T) {
+	entries := []api.TimeEntry{
+		{ID: 1, Billable: true, IsBilled: false},
+		{ID: 2, Billable: false},
+		{ID: 3, Billable: true, IsBilled: true},
+	}
+	billable := func(e api.TimeEntry) bool { return e.Billable }
+
+	if got := filterByBillable(entries, nil, billable); len(got) != 3 {
+		t.Errorf("nil filter kept %d entries, want 3", len(got))
+	}
+
+	yes, no := true, false
+	if got := filterByBillable(entries, &yes, billable); len(got) != 2 || got[0].ID != 1 || got[1].ID != 3 {
+		t.Errorf("billable = %+v, want entries 1 and 3", got)
+	}
+	if got := filterByBillable(entries, &no, billable); len(got) != 1 || got[0].ID != 2 {
+		t.Errorf("non-billable = %+v, want entry 2", got)
+	}
+}
+
+func TestExcludeFlags(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/projects":
+			_, _ = w.Write([]byte(`{"projects":[{"id":10,"name":"Website"},{"id":11,"name":"Internal"}],"total_pages":1,"page":1}`))
+		case "/tasks":
+			_, _ = w.Write([]byte(`{"tasks":[{"id":20,"name":"Admin work"},{"id":21,"name":"Admin"}],"total_pages":1,"page":1}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"})
+	client := api.NewClientWithBaseURL(ts, 12345, "test@example.com", srv.URL)
+	ctx := context.Background()
+
+	if x, err := (ExcludeFlags{}).resolve(ctx, client); err != nil || x != nil {
+		t.Fatalf("resolve() without flags = %v, %v; want nil", x, err)
+	}
+
+	x, err := ExcludeFlags{ExcludeProject: []string{"internal"}, ExcludeTask: []string{"admin", "30"}}.resolve(ctx, client)
+	if err != nil {
+		t.Fatalf("resolve() error = %v", err)
+	}
+
+	entry := func(id, project, task int64) api.TimeEntry {
+		return api.TimeEntry{ID: id, Project: api.ProjectRef{ID: project}, Task: api.TaskRef{ID: task}}
+	}
+	entries := []api.TimeEntry{
+		entry(1, 10, 22),
+		entry(2, 11, 22), // excluded project
+		entry(3, 10, 21), // "admin" matches task 21 exactly, not 20
+		entry(4, 10, 20),
+		entry(5, 10, 30), // excluded task ID
+	}
+	kept, excluded := x.filter(entries)
+	if excluded != 3 || len(kept) != 2 || kept[0].ID != 1 || kept[1].ID != 4 {
+		t.Errorf("filter() = %+v, %d excluded; want entries 1 and 4, 3 excluded", kept, excluded)
+	}
+
+	if _, err := (ExcludeFlags{ExcludeTask: []string{"travel"}}).resolve(ctx, client); err == nil || !strings.Contains(err.Error(), "--exclude-task") {
+		t.Errorf("resolve() error = %v, want unknown task", err)
+	}
+
+	var nilExclusion *entryExclusion
+	if kept, n := nilExclusion.filter(entries); len(kept) != len(entries) || n != 0 {
+		t.Errorf("nil filter() kept %d, excluded %d; want all kept", len(kept), n)
+	}
+}
+
+func TestApplyDefaults(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	cfg := &config.File{}
+	cfg.SetDefaultsFor(12345, config.AccountDefaults{Project: "Website", Task: "Design"})
+	if err := config.WriteConfig(cfg); err != nil {
+		t.Fatalf("WriteConfig() error = %v", err)
+	}
+
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"})
+	client := api.NewClientWithBaseURL(ts, 12345, "test@example.com", "http://localhost")
+	other := api.NewClientWithBaseURL(ts, 999, "test@example.com", "http://localhost")
+
+	tests := []struct {
+		name                  string
+		client                *api.Client
+		profile               *config.Profile
+		project, task         string
+		wantProject, wantTask string
+	}{
+		{name: "both from defaults", client: client, wantProject: "Website", wantTask: "Design"},
+		{name: "explicit values win", client: client, project: "App", task: "Dev", wantProject: "App", wantTask: "Dev"},
+		{name: "other project keeps task empty", client: client, project: "App", wantProject: "App"},
+		{name: "explicit task with default project", client: client, task: "Meetings", wantProject: "Website", wantTask: "Meetings"},
+		{name: "other account has no defaults", client: other},
+		{name: "profile project wins", client: client, profile: &config.Profile{Project: "Ops", Task: "Support"}, wantProject: "Ops", wantTask: "Support"},
+		{name: "profile without project", client: client, profile: &config.Profile{Output: "json"}, wantProject: "Website", wantTask: "Design"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project, task := applyDefaults(&CLI{profile: tt.profile}, tt.client, tt.project, tt.task)
+			if project != tt.wantProject || task != tt.wantTask {
+				t.Errorf("applyDefaults() = %q, %q; want %q, %q", project, task, tt.wantProject, tt.wantTask)
+			}
+		})
+	}
+}
+
+func TestParseUpdatedSince(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "2024-01-15T10:30:00Z", want: "2024-01-15T10:30:00Z"},
+		{input: "2024-01-15T10:30:00+02:00", want: "2024-01-15T08:30:00Z"},
+		{input: "not a date", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseUpdatedSince(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseUpdatedSince() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseUpdatedSince() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// Friendly tokens resolve to local midnight, sent as UTC
+	got, err := parseUpdatedSince("today")
+	if err != nil {
+		t.Fatalf("parseUpdatedSince(today) error = %v", err)
+	}
+	now := time.Now()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if want := midnight.UTC().Format(time.RFC3339); got != want {
+		t.Errorf("parseUpdatedSince(today) = %q, want %q", got, want)
+	}
+}
+
+func TestFormatExtRef(t *testing.T) {
+	tests := []struct {
+		name string
+		ref  *api.ExternalReference
+		want string
+	}{
+		{"none", nil, ""},
+		{"empty id", &api.ExternalReference{Service: "jira"}, ""},
+		{"id only", &api.ExternalReference{ID: "PROJ-1"}, "PROJ-1"},
+		{"service and id", &api.ExternalReference{ID: "PROJ-1", Service: "jira"}, "jira PROJ-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatExtRef(tt.ref); got != tt.want {
+				t.Errorf("formatExtRef() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOutputTimeEntries_ExtRefColumn(t *testing.T) {
+	plain := []api.TimeEntry{{ID: 1, Hours: 1}}
+	linked := []api.TimeEntry{{ID: 2, Hours: 2, ExternalReference: &api.ExternalReference{ID: "PROJ-7", Service: "jira"}}}
+
+	var buf bytes.Buffer
+	if err := outputTimeEntries(&buf, plain, output.ModeTable, false, false); err != nil {
+		t.Fatalf("outputTimeEntries() error = %v", err)
+	}
+	if strings.Contains(buf.String(), "Ext Ref") {
+		t.Errorf("table without references should omit the column, got: %s", buf.String())
+	}
+
+	buf.Reset()
+	if err := outputTimeEntries(&buf, linked, output.ModeTable, false, false); err != nil {
+		t.Fatalf("outputTimeEntries() error = %v", err)
+	}
+	if out := buf.String(); !strings.Contains(out, "Ext Ref") || !strings.Contains(out, "jira PROJ-7") {
+		t.Errorf("table should show the reference, got: %s", out)
+	}
+}
+
+func TestOutputTimeEntries_Rounded(t *testing.T) {
+	entries := []api.TimeEntry{
+		{ID: 1, Hours: 0.9, RoundedHours: 1, Billable: true},
+		{ID: 2, Hours: 0.1, RoundedHours: 0.25},
+	}
+
+	var buf bytes.Buffer
+	if err := outputTimeEntries(&buf, entries, output.ModeTable, true, false); err != nil {
+		t.Fatalf("outputTimeEntries() error = %v", err)
+	}
+	if out := buf.String(); strings.Contains(out, "Rounded") || strings.Contains(out, "rounded") {
+		t.Errorf("output without --rounded should not mention rounding, got: %s", out)
+	}
+
+	buf.Reset()
+	if err := outputTimeEntries(&buf, entries, output.ModeTable, true, true); err != nil {
+		t.Fatalf("outputTimeEntries() error = %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"Rounded", "0.25", "Total: 1.00h (billable 0.90h, rounded 1.25h)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q, got: %s", want, out)
+		}
+	}
+
+	buf.Reset()
+	if err := outputTimeEntries(&buf, entries, output.ModePlain, true, true); err != nil {
+		t.Fatalf("outputTimeEntries() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if lines[0] != "ID\tDate\tProject\tTask\tHours\tRounded\tExtRef\tNotes" {
+		t.Errorf("plain header = %q", lines[0])
+	}
+	if last := lines[len(lines)-1]; last != "TOTAL\t\t\t\t1.00\t1.25\t\t" {
+		t.Errorf("plain totals = %q", last)
+	}
+}
+
+func TestGroupTimeEntries(t *testing.T) {
+	entries := []api.TimeEntry{
+		{ID: 1, SpentDate: "2024-01-02", Hours: 1, Project: api.ProjectRef{ID: 2, Name: "Website"}, Task: api.TaskRef{ID: 10, Name: "Dev"}},
+		{ID: 2, SpentDate: "2024-01-01", Hours: 2, Billable: true, Project: api.ProjectRef{ID: 1, Name: "App"}, Task: api.TaskRef{ID: 10, Name: "Dev"}},
+		{ID: 3, SpentDate: "2024-01-02", Hours: 0.5, Project: api.ProjectRef{ID: 2, Name: "Website"}, Task: api.TaskRef{ID: 11, Name: "Design"}},
+	}
+
+	tests := []struct {
+		by        string
+		wantKeys  []string
+		wantHours []float64
+	}{
+		{"project", []string{"App", "Website"}, []float64{2, 1.5}},
+		{"task", []string{"Design", "Dev"}, []float64{0.5, 3}},
+		{"day", []string{"2024-01-01", "2024-01-02"}, []float64{2, 1.5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.by, func(t *testing.T) {
+			groups := groupTimeEntries(entries, tt.by)
+			if len(groups) != len(tt.wantKeys) {
+				t.Fatalf("groupTimeEntries() = %+v", groups)
+			}
+			for i, g := range groups {
+				if g.Group != tt.wantKeys[i] || g.Totals.Hours != tt.wantHours[i] {
+					t.Errorf("group %d = %s %.2fh, want %s %.2fh", i, g.Group, g.Totals.Hours, tt.wantKeys[i], tt.wantHours[i])
+				}
+			}
+		})
+	}
+
+	// Entries keep their order within a group
+	if got := groupTimeEntries(entries, "project")[1].Entries; got[0].ID != 1 || got[1].ID != 3 {
+		t.Errorf("Website entries = %+v, want #1 then #3", got)
+	}
+}
+
+func TestOutputGroupedTimeEntries_Plain(t *testing.T) {
+	entries := []api.TimeEntry{
+		{ID: 1, SpentDate: "2024-01-02", Hours: 1, Project: api.ProjectRef{Name: "Website"}},
+		{ID: 2, SpentDate: "2024-01-01", Hours: 2, Project: api.ProjectRef{Name: "App"}},
+	}
+
+	var buf bytes.Buffer
+	if err := outputGroupedTimeEntries(&buf, entries, "project", output.ModePlain, true, false); err != nil {
+		t.Fatalf("outputGroupedTimeEntries() error = %v", err)
+	}
+	var ids []string
+	for _, line := range strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")[1:] {
+		ids = append(ids, strings.SplitN(line, "\t", 2)[0])
+	}
+	if got := strings.Join(ids, ","); got != "2,SUBTOTAL,1,SUBTOTAL,TOTAL" {
+		t.Errorf("rows = %s, want 2,SUBTOTAL,1,SUBTOTAL,TOTAL", got)
+	}
+}
+
+func TestResolveUserID(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/users/me":
+			_, _ = w.Write([]byte(`{"id":7}`))
+		case "/users":
+			_, _ = w.Write([]byte(`{"users":[` +
+				`{"id":11,"email":"ann@example.com"},` +
+				`{"id":12,"email":"Bob@Example.com"}` +
+				`]}`))
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"})
+	client := api.NewClientWithBaseURL(ts, 12345, "test@example.com", srv.URL)
+
+	tests := []struct {
+		input   string
+		want    int64
+		wantErr string
+	}{
+		{input: "me", want: 7},
+		{input: "42", want: 42},
+		{input: "ann@example.com", want: 11},
+		{input: "bob@example.com", want: 12},
+		{input: "nobody@example.com", wantErr: `no user with email "nobody@example.com"`},
+		{input: "bob", wantErr: "invalid user: bob"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := resolveUserID(context.Background(), client, tt.input)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("resolveUserID() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveUserID() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("resolveUserID() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLockReason(t *testing.T) {
+	tests := []struct {
+		name     string
+		isLocked bool
+		isBilled bool
+		reason   string
+		want     string
+	}{
+		{"open", false, false, "", ""},
+		{"locked with reason", true, false, "Item Approved and Locked for this Time Period", "locked (reason: Item Approved and Locked for this Time Period)"},
+		{"locked", true, false, "", "locked"},
+		{"billed wins", true, true, "Item Invoiced and Locked for this Time Period", "billed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lockReason(tt.isLocked, tt.isBilled, tt.reason); got != tt.want {
+				t.Errorf("lockReason() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	err := lockedError("expense", 42, "billed")
+	if want := "expense #42 is billed; use --allow-locked to try anyway"; err.Error() != want {
+		t.Errorf("lockedError() = %q, want %q", err, want)
+	}
+}
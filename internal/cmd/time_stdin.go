@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/dedene/harvest-cli/internal/api"
+	"github.com/dedene/harvest-cli/internal/dateparse"
+	"github.com/dedene/harvest-cli/internal/output"
+)
+
+// stdinTimeEntry is a time entry read by time add --stdin-json: the API's
+// time entry input, plus project and task names for callers without IDs.
+type stdinTimeEntry struct {
+	api.TimeEntryInput
+	Project string `json:"project,omitempty"`
+	Task    string `json:"task,omitempty"`
+}
+
+// stdinEntryResult reports what happened to one entry of a --stdin-json
+// array.
+type stdinEntryResult struct {
+	Index   int            `json:"index"`
+	Status  string         `json:"status"` // created or failed
+	EntryID int64          `json:"entry_id,omitempty"`
+	Error   string         `json:"error,omitempty"`
+	Entry   *api.TimeEntry `json:"entry,omitempty"`
+}
+
+// usesEntryFlags reports whether any flag describing the entry was given,
+// which --stdin-json replaces.
+func (c *TimeAddCmd) usesEntryFlags() bool {
+	return c.Project != "" || c.Task != "" || c.Date != "" || c.Hours != nil || c.Start != "" || c.End != "" ||
+		c.Notes != "" || c.Duration || c.Timestamp || c.ExtRefID != "" || c.ExtRefGroupID != "" ||
+		c.ExtRefURL != "" || c.ExtRefService != "" || c.CopyLast || c.Timezone != "" || len(c.Entries) > 0 ||
+		c.Offline || c.NoTimer
+}
+
+// runStdinJSON creates the time entry, or array of entries, read as JSON
+// from stdin. A single entry is created like a flag-built one; an array
+// reports a result per entry and fails if any entry did.
+func (c *TimeAddCmd) runStdinJSON(ctx context.Context, client *api.Client, cli *CLI) error {
+	data, err := io.ReadAll(cli.Stdin)
+	if err != nil {
+		return fmt.Errorf("read stdin: %w", err)
+	}
+	entries, isArray, err := parseStdinTimeEntries(data)
+	if err != nil {
+		return err
+	}
+
+	resolver := newIDResolver(client)
+	if !isArray {
+		entry, err := createStdinTimeEntry(ctx, client, resolver, entries[0])
+		if err != nil {
+			return err
+		}
+		if cli.jsonOutput() {
+			return output.WriteJSON(cli.Stdout, entry)
+		}
+		printSuccess(cli, entry.ID, "Created time entry #%d: %s - %s (%.2fh)\n",
+			entry.ID, entry.Project.Name, entry.Task.Name, entry.Hours)
+		return nil
+	}
+
+	results := make([]stdinEntryResult, len(entries))
+	failed := 0
+	for i, e := range entries {
+		results[i] = stdinEntryResult{Index: i, Status: "created"}
+		entry, err := createStdinTimeEntry(ctx, client, resolver, e)
+		if err != nil {
+			failed++
+			results[i].Status = "failed"
+			results[i].Error = err.Error()
+			continue
+		}
+		results[i].EntryID = entry.ID
+		results[i].Entry = entry
+	}
+
+	if err := outputStdinEntryResults(cli.Stdout, results, output.ModeFromFlags(cli.JSON, cli.Plain)); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d entries failed", failed, len(entries))
+	}
+	return nil
+}
+
+// parseStdinTimeEntries decodes a time entry object or an array of them.
+// Unknown fields are rejected so typos do not silently drop data.
+func parseStdinTimeEntries(data []byte) (entries []stdinTimeEntry, isArray bool, err error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, false, fmt.Errorf("no JSON on stdin; pipe a time entry object or an array of them")
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	isArray = data[0] == '['
+	if isArray {
+		err = dec.Decode(&entries)
+	} else {
+		var e stdinTimeEntry
+		err = dec.Decode(&e)
+		entries = []stdinTimeEntry{e}
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid time entry JSON: %w", err)
+	}
+	if dec.More() {
+		return nil, false, fmt.Errorf("invalid time entry JSON: unexpected data after the first value")
+	}
+	if len(entries) == 0 {
+		return nil, false, fmt.Errorf("no time entries in the JSON array")
+	}
+	return entries, isArray, nil
+}
+
+// createStdinTimeEntry validates one entry, resolves project and task names
+// when no IDs were given, and creates it.
+func createStdinTimeEntry(ctx context.Context, client *api.Client, resolver *idResolver, e stdinTimeEntry) (*api.TimeEntry, error) {
+	input := e.TimeEntryInput
+	if err := validateStdinTimeEntry(&input); err != nil {
+		return nil, err
+	}
+
+	var err error
+	switch {
+	case input.ProjectID > 0:
+	case e.Project != "":
+		if input.ProjectID, err = resolver.projectID(ctx, e.Project); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("project_id is required")
+	}
+	switch {
+	case input.TaskID > 0:
+	case e.Task != "":
+		if input.TaskID, err = resolver.taskID(ctx, input.ProjectID, e.Task); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("task_id is required")
+	}
+
+	entry, err := client.CreateTimeEntry(ctx, &input)
+	if err != nil {
+		return nil, fmt.Errorf("create time entry: %w", err)
+	}
+	return entry, nil
+}
+
+// validateStdinTimeEntry checks the fields of an entry that the API would
+// otherwise reject less clearly, defaulting spent_date to today.
+func validateStdinTimeEntry(input *api.TimeEntryInput) error {
+	if input.SpentDate == "" {
+		input.SpentDate = dateparse.FormatDate(time.Now())
+	} else if _, err := time.Parse("2006-01-02", input.SpentDate); err != nil {
+		return fmt.Errorf("invalid spent_date %q (want YYYY-MM-DD)", input.SpentDate)
+	}
+	if input.Hours != nil && (*input.Hours < 0 || *input.Hours > 24) {
+		return fmt.Errorf("hours must be between 0 and 24")
+	}
+	if input.Hours != nil && (input.StartedTime != nil || input.EndedTime != nil) {
+		return fmt.Errorf("hours cannot be combined with started_time/ended_time; Harvest computes the hours from the times")
+	}
+	return nil
+}
+
+// outputStdinEntryResults writes the per-entry results of a --stdin-json
+// array in the specified format.
+func outputStdinEntryResults(w io.Writer, results []stdinEntryResult, mode output.Mode) error {
+	switch mode {
+	case output.ModeJSON:
+		return output.WriteJSON(w, results)
+	case output.ModePlain:
+		headers := []string{"Index", "Status", "EntryID", "Error"}
+		rows := make([][]string, len(results))
+		for i, r := range results {
+			rows[i] = []string{strconv.Itoa(r.Index), r.Status, formatOptionalID(r.EntryID), r.Error}
+		}
+		return output.WriteTSV(w, headers, rows)
+	default:
+		t := output.NewTable(w, "Index", "Status", "Entry", "Detail")
+		for _, r := range results {
+			detail := r.Error
+			if r.Entry != nil {
+				detail = fmt.Sprintf("%s - %s (%.2fh)", r.Entry.Project.Name, r.Entry.Task.Name, r.Entry.Hours)
+			}
+			t.AddRow(strconv.Itoa(r.Index), r.Status, formatOptionalID(r.EntryID), detail)
+		}
+		return t.Render()
+	}
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/oauth2"
+
+	"github.com/dedene/harvest-cli/internal/api"
+)
+
+func TestParseStdinTimeEntries(t *testing.T) {
+	tests := []struct {
+		input     string
+		wantCount int
+		wantArray bool
+		wantErr   string
+	}{
+		{input: `{"project_id":1,"task_id":2,"hours":1.5}`, wantCount: 1},
+		{input: ` [{"project_id":1,"task_id":2},{"project":"Website","task":"Design"}] `, wantCount: 2, wantArray: true},
+		{input: `{"project_id":1,"task_id":2,"hour":1}`, wantErr: `unknown field "hour"`},
+		{input: `{"project_id":"one"}`, wantErr: "invalid time entry JSON"},
+		{input: `{"project_id":1} {"project_id":2}`, wantErr: "unexpected data"},
+		{input: `[]`, wantErr: "no time entries"},
+		{input: "  \n", wantErr: "no JSON on stdin"},
+	}
+	for _, tt := range tests {
+		entries, isArray, err := parseStdinTimeEntries([]byte(tt.input))
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parseStdinTimeEntries(%q) error = %v, want %q", tt.input, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || len(entries) != tt.wantCount || isArray != tt.wantArray {
+			t.Errorf("parseStdinTimeEntries(%q) = %d entries, array %t, %v", tt.input, len(entries), isArray, err)
+		}
+	}
+}
+
+func TestTimeAddStdinJSON(t *testing.T) {
+	var posted []api.TimeEntryInput
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != http.MethodPost || r.URL.Path != "/time_entries" {
+			t.Errorf("unexpected request %s %s; IDs should skip name resolution", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		var in api.TimeEntryInput
+		_ = json.NewDecoder(r.Body).Decode(&in)
+		posted = append(posted, in)
+		if in.ProjectID == 9 {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"message":"Project is archived"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":80,"spent_date":"` + in.SpentDate + `","hours":1.5,
+			"project":{"id":1,"name":"Website"},"task":{"id":2,"name":"Design"}}`))
+	}))
+	defer srv.Close()
+
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"})
+	client := api.NewClientWithBaseURL(ts, 12345, "test@example.com", srv.URL)
+
+	run := func(stdin string, jsonOut bool) (string, error) {
+		var stdout, stderr bytes.Buffer
+		cli := &CLI{Stdin: strings.NewReader(stdin), Stdout: &stdout, Stderr: &stderr, RootFlags: RootFlags{JSON: jsonOut}}
+		err := (&TimeAddCmd{StdinJSON: true}).runStdinJSON(context.Background(), client, cli)
+		return stdout.String(), err
+	}
+
+	out, err := run(`{"project_id":1,"task_id":2,"spent_date":"2024-05-02","hours":1.5,"notes":"Mockups"}`, false)
+	if err != nil {
+		t.Fatalf("single entry error = %v", err)
+	}
+	if !strings.Contains(out, "Created time entry #80: Website - Design (1.50h)") {
+		t.Errorf("stdout = %q", out)
+	}
+	if got := posted[0]; got.ProjectID != 1 || got.TaskID != 2 || got.SpentDate != "2024-05-02" || *got.Notes != "Mockups" {
+		t.Errorf("posted = %+v", got)
+	}
+
+	posted = nil
+	out, err = run(`[
+		{"project_id":1,"task_id":2,"spent_date":"2024-05-02","hours":1.5},
+		{"project_id":1,"task_id":2,"spent_date":"May 2"},
+		{"project_id":9,"task_id":2,"spent_date":"2024-05-02","hours":1}]`, true)
+	if err == nil || err.Error() != "2 of 3 entries failed" {
+		t.Errorf("array error = %v, want 2 of 3 entries failed", err)
+	}
+	if len(posted) != 2 {
+		t.Errorf("posted %d entries, want the invalid date rejected before posting", len(posted))
+	}
+
+	var results []stdinEntryResult
+	if err := json.Unmarshal([]byte(out), &results); err != nil {
+		t.Fatalf("results are not JSON: %v\n%s", err, out)
+	}
+	if len(results) != 3 ||
+		results[0].Status != "created" || results[0].EntryID != 80 ||
+		results[1].Status != "failed" || !strings.Contains(results[1].Error, `invalid spent_date "May 2"`) ||
+		results[2].Status != "failed" || !strings.Contains(results[2].Error, "archived") {
+		t.Errorf("results = %+v", results)
+	}
+}
+
+func TestTimeAddStdinJSONRejectsEntryFlags(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	var stdout, stderr bytes.Buffer
+	err := Execute([]string{"time", "add", "--stdin-json", "--hours", "1"}, &stdout, &stderr)
+	if err == nil || !strings.Contains(err.Error(), "--stdin-json cannot be combined") {
+		t.Errorf("Execute() error = %v, want the flags rejected", err)
+	}
+}
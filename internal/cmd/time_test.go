@@ -0,0 +1,396 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/dedene/harvest-cli/internal/api"
+	"github.com/dedene/harvest-cli/internal/auth"
+)
+
+func TestTimeAddCopyFrom(t *testing.T) {
+	last := &api.TimeEntry{
+		ID:      99,
+		Project: api.ProjectRef{ID: 10, Name: "Website"},
+		Task:    api.TaskRef{ID: 20, Name: "Design"},
+		Notes:   "Homepage mockups",
+	}
+
+	tests := []struct {
+		name        string
+		cmd         TimeAddCmd
+		wantProject string
+		wantTask    string
+		wantNotes   string
+	}{
+		{
+			name:        "copies everything",
+			wantProject: "10", wantTask: "20", wantNotes: "Homepage mockups",
+		},
+		{
+			name:        "explicit notes win",
+			cmd:         TimeAddCmd{Notes: "Review"},
+			wantProject: "10", wantTask: "20", wantNotes: "Review",
+		},
+		{
+			name:        "explicit task keeps copied project",
+			cmd:         TimeAddCmd{Task: "Development"},
+			wantProject: "10", wantTask: "Development", wantNotes: "Homepage mockups",
+		},
+		{
+			name:        "explicit project does not copy task",
+			cmd:         TimeAddCmd{Project: "Intranet"},
+			wantProject: "Intranet", wantTask: "", wantNotes: "Homepage mockups",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.cmd
+			c.copyFrom(last)
+			if c.Project != tt.wantProject || c.Task != tt.wantTask || c.Notes != tt.wantNotes {
+				t.Errorf("copyFrom() = project %q, task %q, notes %q; want %q, %q, %q",
+					c.Project, c.Task, c.Notes, tt.wantProject, tt.wantTask, tt.wantNotes)
+			}
+		})
+	}
+}
+
+func TestTimeAddConvertTimestamps(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":7,"timezone":"Eastern Time (US & Canada)"}`))
+	}))
+	defer srv.Close()
+
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"})
+	client := api.NewClientWithBaseURL(ts, 12345, "test@example.com", srv.URL)
+	paris, _ := time.LoadLocation("Europe/Paris")
+
+	c := &TimeAddCmd{Start: "9am", End: "17:30"}
+	if err := c.convertTimestamps(context.Background(), client, "2024-06-10", paris); err != nil {
+		t.Fatalf("convertTimestamps() error = %v", err)
+	}
+	if c.Start != "3:00am" || c.End != "11:30am" {
+		t.Errorf("start/end = %q/%q, want 3:00am/11:30am", c.Start, c.End)
+	}
+
+	c = &TimeAddCmd{Start: "1am"}
+	if err := c.convertTimestamps(context.Background(), client, "2024-06-10", paris); err == nil {
+		t.Error("convertTimestamps() should reject a start on the previous day")
+	}
+}
+
+func TestLoadTimezoneFlag(t *testing.T) {
+	if loc, err := loadTimezoneFlag(""); loc != nil || err != nil {
+		t.Errorf("loadTimezoneFlag(\"\") = %v, %v; want nil, nil", loc, err)
+	}
+	if loc, err := loadTimezoneFlag("Asia/Tokyo"); err != nil || loc.String() != "Asia/Tokyo" {
+		t.Errorf("loadTimezoneFlag(Asia/Tokyo) = %v, %v", loc, err)
+	}
+	if _, err := loadTimezoneFlag("Tokyo Time"); err == nil {
+		t.Error("loadTimezoneFlag() should reject non-IANA names")
+	}
+}
+
+func TestSplitLockedEntries(t *testing.T) {
+	entries := []api.TimeEntry{
+		{ID: 1},
+		{ID: 2, IsLocked: true, LockedReason: "Item Approved and Locked for this Time Period"},
+		{ID: 3, IsBilled: true, IsLocked: true},
+		{ID: 4, IsLocked: true},
+		{ID: 5},
+	}
+
+	open, locked := splitLockedEntries(entries)
+	if len(open) != 2 || open[0].ID != 1 || open[1].ID != 5 {
+		t.Errorf("open = %+v, want entries 1 and 5", open)
+	}
+	if len(locked) != 3 {
+		t.Fatalf("len(locked) = %d, want 3", len(locked))
+	}
+
+	reasons := []string{
+		"locked (reason: Item Approved and Locked for this Time Period)",
+		"billed",
+		"locked",
+	}
+	for i, want := range reasons {
+		if got := timeEntryLockReason(locked[i]); got != want {
+			t.Errorf("timeEntryLockReason(#%d) = %q, want %q", locked[i].ID, got, want)
+		}
+	}
+}
+
+func TestParseEntrySpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    entrySpec
+		wantErr string
+	}{
+		{
+			name: "all fields",
+			spec: "project=Acme, task=Dev,hours=2,date=2024-01-15,notes=Standup",
+			want: entrySpec{Project: "Acme", Task: "Dev", Hours: 2, Date: "2024-01-15", Notes: "Standup"},
+		},
+		{
+			name: "notes with commas",
+			spec: "hours=1.5,notes=Reviewed PRs, fixed tests,task=QA",
+			want: entrySpec{Task: "QA", Hours: 1.5, Notes: "Reviewed PRs, fixed tests"},
+		},
+		{
+			name: "duration hours",
+			spec: "project=1,task=2,hours=1h30m",
+			want: entrySpec{Project: "1", Task: "2", Hours: 1.5},
+		},
+		{name: "missing hours", spec: "project=Acme,task=Dev", wantErr: "hours is required"},
+		{name: "bad hours", spec: "hours=lots", wantErr: "invalid hours"},
+		{name: "too many hours", spec: "hours=25", wantErr: "between 0 and 24"},
+		{name: "unknown field", spec: "hours=1,client=Acme", wantErr: "unknown entry field"},
+		{name: "no key", spec: "Acme", wantErr: "expected key=value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseEntrySpec(tt.spec)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseEntrySpec() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseEntrySpec() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("parseEntrySpec() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIDResolverCaches(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"projects":[{"id":42,"name":"Acme Website"}],"page":1}`))
+	}))
+	defer srv.Close()
+
+	client := api.NewClientWithBaseURL(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"}), 12345, "test@example.com", srv.URL)
+	resolver := newIDResolver(client)
+
+	for range 3 {
+		id, err := resolver.projectID(context.Background(), "Acme")
+		if err != nil {
+			t.Fatalf("projectID() error = %v", err)
+		}
+		if id != 42 {
+			t.Errorf("projectID() = %d, want 42", id)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("API called %d times, want 1", calls)
+	}
+}
+
+func TestTimeAdd_ZeroHours(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(auth.PATEnvToken, "pat")
+	t.Setenv(auth.PATEnvAccountID, "1")
+
+	var bodies []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != http.MethodPost || r.URL.Path != "/time_entries" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+		_, _ = w.Write([]byte(`{"id":5,"hours":0,"project":{"name":"Site"},"task":{"name":"Dev"}}`))
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name      string
+		args      []string
+		wantErr   string
+		wantHours any // nil when hours must be omitted
+		wantPost  bool
+		wantNote  string
+	}{
+		{name: "explicit zero creates empty entry", args: []string{"--hours", "0"}, wantHours: float64(0), wantPost: true},
+		{name: "no hours starts timer", wantPost: true, wantNote: "starting a timer"},
+		{name: "no-timer refuses", args: []string{"--no-timer"}, wantErr: "--no-timer is set"},
+		{name: "no-timer allows explicit zero", args: []string{"--no-timer", "--hours", "0"}, wantHours: float64(0), wantPost: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bodies = nil
+			var stdout, stderr bytes.Buffer
+			args := append([]string{"time", "add", "-p", "1", "--task", "2", "--api-base-url", srv.URL}, tt.args...)
+			err := Execute(args, &stdout, &stderr)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Execute() error = %v, want %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("Execute() error = %v, stderr: %s", err, stderr.String())
+			}
+
+			if (len(bodies) == 1) != tt.wantPost {
+				t.Fatalf("got %d requests, want post = %v", len(bodies), tt.wantPost)
+			}
+			if tt.wantPost && bodies[0]["hours"] != tt.wantHours {
+				t.Errorf("hours = %v, want %v", bodies[0]["hours"], tt.wantHours)
+			}
+			if !strings.Contains(stderr.String(), tt.wantNote) {
+				t.Errorf("stderr = %q, want %q", stderr.String(), tt.wantNote)
+			}
+		})
+	}
+}
+
+func TestTimeAdd_StartEnd(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(auth.PATEnvToken, "pat")
+	t.Setenv(auth.PATEnvAccountID, "1")
+
+	var posts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != http.MethodPost || r.URL.Path != "/time_entries" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		posts++
+		_, _ = w.Write([]byte(`{"id":5,"hours":1.5,"started_time":"9:00am","ended_time":"10:30am","project":{"name":"Site"},"task":{"name":"Dev"}}`))
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantErr    string
+		wantStdout string
+	}{
+		{name: "prints computed hours", args: []string{"--start", "9am", "--end", "10:30am"}, wantStdout: "Created time entry #5: Site - Dev (9:00am-10:30am, 1.50h)\n"},
+		{name: "hours conflict", args: []string{"--start", "9am", "--end", "10:30am", "--hours", "2"}, wantErr: "--hours cannot be combined"},
+		{name: "hours conflict with start only", args: []string{"--start", "9am", "--hours", "2"}, wantErr: "--hours cannot be combined"},
+		{name: "end before start", args: []string{"--start", "14:00", "--end", "9am"}, wantErr: "--end 9am must be after --start 14:00"},
+		{name: "end equals start", args: []string{"--start", "9am", "--end", "9:00"}, wantErr: "must be after"},
+		{name: "bad end", args: []string{"--start", "9am", "--end", "soon"}, wantErr: "invalid --end time"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			posts = 0
+			var stdout, stderr bytes.Buffer
+			args := append([]string{"time", "add", "-p", "1", "--task", "2", "--api-base-url", srv.URL}, tt.args...)
+			err := Execute(args, &stdout, &stderr)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Execute() error = %v, want %q", err, tt.wantErr)
+				}
+				if posts != 0 {
+					t.Errorf("got %d requests, want none", posts)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v, stderr: %s", err, stderr.String())
+			}
+			if stdout.String() != tt.wantStdout {
+				t.Errorf("stdout = %q, want %q", stdout.String(), tt.wantStdout)
+			}
+		})
+	}
+}
+
+func TestTimeMove_PartialFailure(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(auth.PATEnvToken, "pat")
+	t.Setenv(auth.PATEnvAccountID, "1")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/time_entries":
+			_, _ = w.Write([]byte(`{"time_entries":[{"id":1,"hours":1},{"id":2,"hours":2}],"total_pages":1,"page":1}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/time_entries/1":
+			_, _ = w.Write([]byte(`{"id":1}`))
+		default:
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"message":"Task is not assigned"}`))
+		}
+	}))
+	defer srv.Close()
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"time", "move", "--from-project", "10", "--to-project", "20", "--to-task", "30",
+		"--force", "--json", "--api-base-url", srv.URL}
+	err := Execute(args, &stdout, &stderr)
+	if err == nil || err.Error() != "1 of 2 entries failed" {
+		t.Errorf("Execute() error = %v, want 1 of 2 entries failed", err)
+	}
+
+	var got struct {
+		Moved  []int64 `json:"moved"`
+		Failed int     `json:"failed"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatalf("stdout is not JSON: %v\n%s", err, stdout.String())
+	}
+	if len(got.Moved) != 1 || got.Moved[0] != 1 || got.Failed != 1 {
+		t.Errorf("result = %+v, want #1 moved and 1 failed", got)
+	}
+}
+
+func TestTimeMove_NothingToMoveJSON(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(auth.PATEnvToken, "pat")
+	t.Setenv(auth.PATEnvAccountID, "1")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != http.MethodGet || r.URL.Path != "/time_entries" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"time_entries":[{"id":3,"hours":1,"is_locked":true}],"total_pages":1,"page":1}`))
+	}))
+	defer srv.Close()
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"time", "move", "--from-project", "10", "--to-project", "20", "--to-task", "30",
+		"--force", "--json", "--api-base-url", srv.URL}
+	if err := Execute(args, &stdout, &stderr); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	var got struct {
+		Moved   []int64 `json:"moved"`
+		Skipped []int64 `json:"skipped"`
+		Failed  int     `json:"failed"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatalf("stdout is not JSON: %v\n%s", err, stdout.String())
+	}
+	if got.Moved == nil || len(got.Moved) != 0 || len(got.Skipped) != 1 || got.Skipped[0] != 3 || got.Failed != 0 {
+		t.Errorf("result = %+v, want nothing moved and #3 skipped", got)
+	}
+}
@@ -5,31 +5,42 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"os/signal"
+	"sort"
 	"strconv"
-	"strings"
+	"syscall"
 	"time"
 
 	"github.com/dedene/harvest-cli/internal/api"
+	"github.com/dedene/harvest-cli/internal/config"
+	"github.com/dedene/harvest-cli/internal/dateparse"
 	"github.com/dedene/harvest-cli/internal/output"
 	"github.com/dedene/harvest-cli/internal/ui"
 )
 
 // TimerCmd groups timer subcommands.
 type TimerCmd struct {
-	Status  TimerStatusCmd  `cmd:"" default:"1" help:"Show running timer"`
-	Start   TimerStartCmd   `cmd:"" help:"Start a timer"`
-	Stop    TimerStopCmd    `cmd:"" help:"Stop running timer"`
-	Restart TimerRestartCmd `cmd:"" help:"Restart a stopped timer"`
-	Toggle  TimerToggleCmd  `cmd:"" help:"Toggle timer (stop if running, start last if not)"`
+	Status      TimerStatusCmd      `cmd:"" default:"1" help:"Show running timer"`
+	Start       TimerStartCmd       `cmd:"" help:"Start a timer"`
+	Stop        TimerStopCmd        `cmd:"" help:"Stop running timer"`
+	Restart     TimerRestartCmd     `cmd:"" help:"Restart a stopped timer"`
+	RestartLast TimerRestartLastCmd `cmd:"" name:"restart-last" help:"Restart your most recent time entry"`
+	Toggle      TimerToggleCmd      `cmd:"" help:"Toggle timer (stop if running, start last if not)"`
+	Watch       TimerWatchCmd       `cmd:"" help:"Live-updating view of the running timer"`
 }
 
+// defaultIdleWarn is how long a timer may run before status warns about it.
+const defaultIdleWarn = 8 * time.Hour
+
 // TimerStatusCmd shows the current running timer.
-type TimerStatusCmd struct{}
+type TimerStatusCmd struct {
+	IdleWarn time.Duration `help:"Warn when the timer has run longer than this (default: idle_warn config or 8h)" name:"idle-warn"`
+}
 
 // Run executes the status command.
 func (c *TimerStatusCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -40,29 +51,215 @@ func (c *TimerStatusCmd) Run(cli *CLI) error {
 	}
 
 	if entry == nil {
-		fmt.Fprintln(os.Stdout, "No timer running")
+		fmt.Fprintln(cli.Stdout, "No timer running")
 		return nil
 	}
 
+	if threshold := c.idleThreshold(); timerElapsed(entry) > threshold {
+		fmt.Fprintf(cli.Stderr, "%s timer has been running for %s; it may have been left running. Stop it with 'harvest timer stop'.\n",
+			output.DefaultColors().Warning("Warning:"), calculateElapsed(entry))
+	}
+
 	mode := output.ModeFromFlags(cli.JSON, cli.Plain)
-	return formatTimerStatus(os.Stdout, entry, mode)
+	return formatTimerStatus(cli.Stdout, entry, mode)
+}
+
+// idleThreshold returns the --idle-warn flag, the idle_warn config value,
+// or the default, in that order.
+func (c *TimerStatusCmd) idleThreshold() time.Duration {
+	if c.IdleWarn > 0 {
+		return c.IdleWarn
+	}
+	if cfg, err := config.ReadConfig(); err == nil && cfg.IdleWarn != "" {
+		if d, err := time.ParseDuration(cfg.IdleWarn); err == nil && d > 0 {
+			return d
+		}
+	}
+	return defaultIdleWarn
+}
+
+// TimerWatchCmd polls the running timer and redraws its elapsed time.
+type TimerWatchCmd struct {
+	Interval time.Duration `help:"Polling interval" default:"30s"`
+}
+
+// Run executes the watch command.
+func (c *TimerWatchCmd) Run(cli *CLI) error {
+	if c.Interval <= 0 {
+		return fmt.Errorf("interval must be positive")
+	}
+
+	ctx, stop := watchContext()
+	defer stop()
+
+	client, err := NewClientFromFlags(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	return watchTimer(ctx, cli, client, c.Interval)
+}
+
+// watchContext returns a context canceled by Ctrl-C or SIGTERM, which ends
+// polling commands cleanly.
+func watchContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+}
+
+// timerWatchEvent is one JSON line emitted by timer watch.
+type timerWatchEvent struct {
+	Time    time.Time `json:"time"`
+	Running bool      `json:"running"`
+	ID      int64     `json:"id,omitempty"`
+	Project string    `json:"project,omitempty"`
+	Task    string    `json:"task,omitempty"`
+	Elapsed string    `json:"elapsed,omitempty"`
+	Seconds int64     `json:"elapsed_seconds,omitempty"`
+	Notes   string    `json:"notes,omitempty"`
+}
+
+// watchTimer polls until the timer stops or ctx is canceled. Terminals get
+// a single line redrawn in place; other writers get one line per poll.
+func watchTimer(ctx context.Context, cli *CLI, client *api.Client, interval time.Duration) error {
+	mode := output.ModeFromFlags(cli.JSON, cli.Plain)
+	redraw := mode == output.ModeTable && output.IsTerminal(cli.Stdout)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	polled := false
+	for {
+		entry, err := client.GetRunningTimeEntry(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			return fmt.Errorf("get running timer: %w", err)
+		}
+
+		if entry == nil {
+			if redraw && polled {
+				fmt.Fprintln(cli.Stdout)
+			}
+			switch {
+			case mode == output.ModeJSON:
+				return output.WriteNDJSON(cli.Stdout, []timerWatchEvent{{Time: time.Now(), Running: false}})
+			case polled:
+				fmt.Fprintln(cli.Stdout, "Timer stopped")
+			default:
+				fmt.Fprintln(cli.Stdout, "No timer running")
+			}
+			return nil
+		}
+		polled = true
+
+		elapsed := calculateElapsed(entry)
+		switch {
+		case mode == output.ModeJSON:
+			event := timerWatchEvent{
+				Time:    time.Now(),
+				Running: true,
+				ID:      entry.ID,
+				Project: entry.Project.Name,
+				Task:    entry.Task.Name,
+				Elapsed: elapsed,
+				Seconds: elapsedSeconds(entry),
+				Notes:   entry.Notes,
+			}
+			if err := output.WriteNDJSON(cli.Stdout, []timerWatchEvent{event}); err != nil {
+				return err
+			}
+		case mode == output.ModePlain:
+			if err := output.WriteTSV(cli.Stdout, nil, [][]string{{
+				strconv.FormatInt(entry.ID, 10), entry.Project.Name, entry.Task.Name, elapsed,
+				strconv.FormatInt(elapsedSeconds(entry), 10),
+			}}); err != nil {
+				return err
+			}
+		case redraw:
+			// Return to line start and clear it before redrawing
+			fmt.Fprintf(cli.Stdout, "\r\033[K%s %s - %s (%s elapsed)",
+				output.DefaultColors().Success("▶"), entry.Project.Name, entry.Task.Name, elapsed)
+		default:
+			fmt.Fprintf(cli.Stdout, "▶ %s - %s (%s elapsed)\n", entry.Project.Name, entry.Task.Name, elapsed)
+		}
+
+		select {
+		case <-ctx.Done():
+			if redraw {
+				fmt.Fprintln(cli.Stdout)
+			}
+			return nil
+		case <-ticker.C:
+		}
+	}
+
+	if redraw && polled {
+		fmt.Fprintln(cli.Stdout)
+	}
+	return nil
 }
 
 // TimerStartCmd starts a new timer.
 type TimerStartCmd struct {
-	Project string `help:"Project ID or name" short:"p"`
-	Task    string `help:"Task ID or name"`
-	Notes   string `help:"Notes" short:"n"`
+	Project   string `help:"Project ID or name" short:"p"`
+	Task      string `help:"Task ID or name"`
+	Notes     string `help:"Notes" short:"n"`
+	At        string `help:"Backdate the start to a time today (e.g., 9am, 14:30); requires timestamp timers"`
+	NoDefault bool   `help:"Ignore the configured default project/task and pick interactively" name:"no-default"`
+	FromLast  bool   `help:"Start a new timer on the project/task of your most recent entry" name:"from-last"`
+	Timezone  string `help:"Read --at in this IANA time zone (default: your Harvest time zone)"`
 }
 
 // Run executes the start command.
 func (c *TimerStartCmd) Run(cli *CLI) error {
+	if c.FromLast && (c.Project != "" || c.Task != "") {
+		return fmt.Errorf("--from-last cannot be combined with --project or --task")
+	}
+
+	from, err := loadTimezoneFlag(c.Timezone)
+	if err != nil {
+		return err
+	}
+	if from != nil && c.At == "" {
+		return fmt.Errorf("--timezone only applies to --at")
+	}
+
+	var startedTime string
+	if c.At != "" && from == nil {
+		startedTime, err = parseStartAt(c.At, time.Now(), time.Local, time.Local)
+		if err != nil {
+			return err
+		}
+	}
+
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
 
+	if from != nil {
+		to, err := harvestUserLocation(ctx, client)
+		if err != nil {
+			return err
+		}
+		startedTime, err = parseStartAt(c.At, time.Now(), from, to)
+		if err != nil {
+			return err
+		}
+	}
+
+	if startedTime != "" {
+		company, err := getCompany(ctx, cli, client)
+		if err != nil {
+			return err
+		}
+		if !company.WantsTimestampTimers {
+			return fmt.Errorf("--at requires timestamp timers; enable them with 'harvest company --edit --timestamps'")
+		}
+	}
+
 	// Check if timer is already running
 	running, err := client.GetRunningTimeEntry(ctx)
 	if err != nil {
@@ -74,6 +271,18 @@ func (c *TimerStartCmd) Run(cli *CLI) error {
 	}
 
 	// Resolve project and task
+	if c.FromLast {
+		last, err := getMyLastTimeEntry(ctx, client)
+		if err != nil {
+			return err
+		}
+		c.Project = strconv.FormatInt(last.Project.ID, 10)
+		c.Task = strconv.FormatInt(last.Task.ID, 10)
+		fmt.Fprintf(cli.Stderr, "Using entry #%d (%s): %s - %s\n",
+			last.ID, last.SpentDate, last.Project.Name, last.Task.Name)
+	} else if !c.NoDefault {
+		c.Project, c.Task = applyDefaults(cli, client, c.Project, c.Task)
+	}
 	projectID, taskID, err := c.resolveProjectTask(ctx, client)
 	if err != nil {
 		return err
@@ -88,6 +297,9 @@ func (c *TimerStartCmd) Run(cli *CLI) error {
 	if c.Notes != "" {
 		input.Notes = &c.Notes
 	}
+	if startedTime != "" {
+		input.StartedTime = &startedTime
+	}
 
 	entry, err := client.CreateTimeEntry(ctx, input)
 	if err != nil {
@@ -96,13 +308,31 @@ func (c *TimerStartCmd) Run(cli *CLI) error {
 
 	mode := output.ModeFromFlags(cli.JSON, cli.Plain)
 	if mode == output.ModeJSON {
-		return output.WriteJSON(os.Stdout, entry)
+		return output.WriteJSON(cli.Stdout, entry)
 	}
 
-	fmt.Fprintf(os.Stdout, "Started timer: %s - %s\n", entry.Project.Name, entry.Task.Name)
+	printSuccess(cli, entry.ID, "Started timer: %s - %s\n", entry.Project.Name, entry.Task.Name)
 	return nil
 }
 
+// parseStartAt validates a --at time, read as today's wall-clock time in
+// from, and returns it in Harvest's timestamp format for zone to. The time
+// must not be later than now.
+func parseStartAt(s string, now time.Time, from, to *time.Location) (string, error) {
+	hour, minute, err := dateparse.ParseTimeOfDay(s)
+	if err != nil {
+		return "", fmt.Errorf("invalid --at time: %w (use e.g. 9am, 9:30am or 14:30)", err)
+	}
+	at, err := dateparse.ConvertTimeOfDay(s, now.In(from), from, to)
+	if err != nil {
+		return "", fmt.Errorf("invalid --at time: %w", err)
+	}
+	if at.After(now) {
+		return "", fmt.Errorf("--at %s is in the future", dateparse.FormatTimeOfDay(hour, minute))
+	}
+	return at.Format("3:04pm"), nil
+}
+
 // resolveProjectTask resolves project and task IDs from flags or TUI picker.
 func (c *TimerStartCmd) resolveProjectTask(ctx context.Context, client *api.Client) (int64, int64, error) {
 	// Get all project assignments for the user
@@ -118,9 +348,9 @@ func (c *TimerStartCmd) resolveProjectTask(ctx context.Context, client *api.Clie
 	// Resolve project
 	var selectedAssignment *api.ProjectAssignment
 	if c.Project != "" {
-		selectedAssignment = findProjectAssignment(assignments, c.Project)
-		if selectedAssignment == nil {
-			return 0, 0, fmt.Errorf("project not found: %s", c.Project)
+		selectedAssignment, err = findProjectAssignment(assignments, c.Project)
+		if err != nil {
+			return 0, 0, err
 		}
 	} else {
 		// Use TUI picker
@@ -169,9 +399,9 @@ func (c *TimerStartCmd) resolveProjectTask(ctx context.Context, client *api.Clie
 	// Resolve task
 	var taskID int64
 	if c.Task != "" {
-		taskID = findTaskID(activeTasks, c.Task)
-		if taskID == 0 {
-			return 0, 0, fmt.Errorf("task not found: %s", c.Task)
+		taskID, err = findTaskID(activeTasks, c.Task)
+		if err != nil {
+			return 0, 0, err
 		}
 	} else {
 		// Use TUI picker
@@ -197,82 +427,75 @@ func (c *TimerStartCmd) resolveProjectTask(ctx context.Context, client *api.Clie
 	return selectedAssignment.Project.ID, taskID, nil
 }
 
-// findProjectAssignment finds a project by ID or name.
-func findProjectAssignment(assignments []api.ProjectAssignment, search string) *api.ProjectAssignment {
+// findProjectAssignment finds a project by ID, name or code.
+func findProjectAssignment(assignments []api.ProjectAssignment, search string) (*api.ProjectAssignment, error) {
 	// Try as ID first
 	if id, err := strconv.ParseInt(search, 10, 64); err == nil {
 		for i := range assignments {
 			if assignments[i].Project.ID == id {
-				return &assignments[i]
+				return &assignments[i], nil
 			}
 		}
 	}
 
-	// Try case-insensitive name match
-	searchLower := strings.ToLower(search)
-	for i := range assignments {
-		if strings.ToLower(assignments[i].Project.Name) == searchLower {
-			return &assignments[i]
-		}
+	records := make([]namedRecord, len(assignments))
+	for i, a := range assignments {
+		records[i] = namedRecord{ID: a.Project.ID, Name: a.Project.Name, Code: a.Project.Code, Detail: a.Client.Name, Active: a.IsActive}
+	}
+	id, err := lookupByName("project", search, records)
+	if err != nil {
+		return nil, err
 	}
-
-	// Try partial match
 	for i := range assignments {
-		if strings.Contains(strings.ToLower(assignments[i].Project.Name), searchLower) {
-			return &assignments[i]
+		if assignments[i].Project.ID == id {
+			return &assignments[i], nil
 		}
 	}
-
-	return nil
+	return nil, fmt.Errorf("project not found: %s", search)
 }
 
 // findTaskID finds a task by ID or name.
-func findTaskID(tasks []api.ProjectTaskAssignment, search string) int64 {
+func findTaskID(tasks []api.ProjectTaskAssignment, search string) (int64, error) {
 	// Try as ID first
 	if id, err := strconv.ParseInt(search, 10, 64); err == nil {
 		for _, t := range tasks {
 			if t.Task.ID == id {
-				return t.Task.ID
+				return t.Task.ID, nil
 			}
 		}
 	}
 
-	// Try case-insensitive name match
-	searchLower := strings.ToLower(search)
-	for _, t := range tasks {
-		if strings.ToLower(t.Task.Name) == searchLower {
-			return t.Task.ID
-		}
-	}
-
-	// Try partial match
-	for _, t := range tasks {
-		if strings.Contains(strings.ToLower(t.Task.Name), searchLower) {
-			return t.Task.ID
-		}
+	records := make([]namedRecord, len(tasks))
+	for i, t := range tasks {
+		records[i] = namedRecord{ID: t.Task.ID, Name: t.Task.Name, Active: t.IsActive}
 	}
-
-	return 0
+	return lookupByName("task", search, records)
 }
 
 // TimerStopCmd stops the running timer.
-type TimerStopCmd struct{}
+type TimerStopCmd struct {
+	Offline bool `help:"Queue the stop locally; apply it later with 'harvest sync push'"`
+}
 
 // Run executes the stop command.
 func (c *TimerStopCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
 
+	if c.Offline {
+		return c.runOffline(cli, client)
+	}
+
 	running, err := client.GetRunningTimeEntry(ctx)
 	if err != nil {
 		return fmt.Errorf("get running timer: %w", err)
 	}
 
 	if running == nil {
-		fmt.Fprintln(os.Stdout, "No timer running")
+		fmt.Fprintln(cli.Stdout, "No timer running")
 		return nil
 	}
 
@@ -283,14 +506,39 @@ func (c *TimerStopCmd) Run(cli *CLI) error {
 
 	mode := output.ModeFromFlags(cli.JSON, cli.Plain)
 	if mode == output.ModeJSON {
-		return output.WriteJSON(os.Stdout, stopped)
+		return output.WriteJSON(cli.Stdout, stopped)
 	}
 
-	fmt.Fprintf(os.Stdout, "Stopped: %s - %s (%.2fh)\n",
+	printSuccess(cli, stopped.ID, "Stopped: %s - %s (%.2fh)\n",
 		stopped.Project.Name, stopped.Task.Name, stopped.Hours)
 	return nil
 }
 
+// runOffline records the stop time in the local queue. The push stops the
+// running timer and trims it back to this time.
+func (c *TimerStopCmd) runOffline(cli *CLI, client *api.Client) error {
+	item := newQueuedEntry(client.AccountID(), queueKindStop)
+
+	if cli.DryRun {
+		fmt.Fprintf(cli.Stderr, "Dry run - not queueing %s\n", describeQueued(item))
+		return nil
+	}
+	if err := appendQueue(queuePath(), item); err != nil {
+		return err
+	}
+
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, item)
+	}
+	if cli.Quiet {
+		fmt.Fprintln(cli.Stdout, item.ID)
+		return nil
+	}
+	fmt.Fprintf(cli.Stdout, "Queued %s\n", describeQueued(item))
+	fmt.Fprintln(cli.Stderr, "Run 'harvest sync push' when back online to stop the timer")
+	return nil
+}
+
 // TimerRestartCmd restarts a stopped time entry.
 type TimerRestartCmd struct {
 	ID int64 `arg:"" help:"Time entry ID to restart"`
@@ -299,7 +547,7 @@ type TimerRestartCmd struct {
 // Run executes the restart command.
 func (c *TimerRestartCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -321,10 +569,52 @@ func (c *TimerRestartCmd) Run(cli *CLI) error {
 
 	mode := output.ModeFromFlags(cli.JSON, cli.Plain)
 	if mode == output.ModeJSON {
-		return output.WriteJSON(os.Stdout, entry)
+		return output.WriteJSON(cli.Stdout, entry)
 	}
 
-	fmt.Fprintf(os.Stdout, "Restarted: %s - %s\n", entry.Project.Name, entry.Task.Name)
+	printSuccess(cli, entry.ID, "Restarted: %s - %s\n", entry.Project.Name, entry.Task.Name)
+	return nil
+}
+
+// TimerRestartLastCmd restarts the current user's most recent stopped entry.
+type TimerRestartLastCmd struct{}
+
+// Run executes the restart-last command.
+func (c *TimerRestartLastCmd) Run(cli *CLI) error {
+	ctx := context.Background()
+	client, err := NewClientFromFlags(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	running, err := client.GetRunningTimeEntry(ctx)
+	if err != nil {
+		return fmt.Errorf("check running timer: %w", err)
+	}
+	if running != nil {
+		return fmt.Errorf("timer already running: %s - %s (use 'timer stop' first)",
+			running.Project.Name, running.Task.Name)
+	}
+
+	last, err := getMyLastTimeEntry(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	entry, err := client.RestartTimeEntry(ctx, last.ID)
+	if err != nil {
+		return fmt.Errorf("restart timer: %w", err)
+	}
+	if cli.DryRun {
+		return nil
+	}
+
+	mode := output.ModeFromFlags(cli.JSON, cli.Plain)
+	if mode == output.ModeJSON {
+		return output.WriteJSON(cli.Stdout, entry)
+	}
+
+	printSuccess(cli, entry.ID, "Restarted: %s - %s\n", entry.Project.Name, entry.Task.Name)
 	return nil
 }
 
@@ -337,7 +627,7 @@ type TimerToggleCmd struct {
 // Run executes the toggle command.
 func (c *TimerToggleCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -356,10 +646,10 @@ func (c *TimerToggleCmd) Run(cli *CLI) error {
 
 		mode := output.ModeFromFlags(cli.JSON, cli.Plain)
 		if mode == output.ModeJSON {
-			return output.WriteJSON(os.Stdout, stopped)
+			return output.WriteJSON(cli.Stdout, stopped)
 		}
 
-		fmt.Fprintf(os.Stdout, "Stopped: %s - %s (%.2fh)\n",
+		printSuccess(cli, stopped.ID, "Stopped: %s - %s (%.2fh)\n",
 			stopped.Project.Name, stopped.Task.Name, stopped.Hours)
 		return nil
 	}
@@ -375,7 +665,9 @@ func (c *TimerToggleCmd) Run(cli *CLI) error {
 	}
 
 	// Try to restart the most recent entry
-	lastEntry, err := getLastTimeEntry(ctx, client)
+	// Only today's entries can be restarted
+	today := time.Now().Format("2006-01-02")
+	lastEntry, err := getLastTimeEntry(ctx, client, api.TimeEntryListOptions{From: today, To: today})
 	if err != nil {
 		return fmt.Errorf("get last entry: %w", err)
 	}
@@ -394,27 +686,22 @@ func (c *TimerToggleCmd) Run(cli *CLI) error {
 
 	mode := output.ModeFromFlags(cli.JSON, cli.Plain)
 	if mode == output.ModeJSON {
-		return output.WriteJSON(os.Stdout, entry)
+		return output.WriteJSON(cli.Stdout, entry)
 	}
 
-	fmt.Fprintf(os.Stdout, "Restarted: %s - %s\n", entry.Project.Name, entry.Task.Name)
+	printSuccess(cli, entry.ID, "Restarted: %s - %s\n", entry.Project.Name, entry.Task.Name)
 	return nil
 }
 
-// getLastTimeEntry returns the most recent non-running time entry.
-func getLastTimeEntry(ctx context.Context, client *api.Client) (*api.TimeEntry, error) {
-	// Get today's entries first
-	today := time.Now().Format("2006-01-02")
-	resp, err := client.ListTimeEntries(ctx, api.TimeEntryListOptions{
-		From:    today,
-		To:      today,
-		PerPage: 10,
-	})
+// getLastTimeEntry returns the most recently updated non-running time entry
+// matching opts, or nil if there is none.
+func getLastTimeEntry(ctx context.Context, client *api.Client, opts api.TimeEntryListOptions) (*api.TimeEntry, error) {
+	entries, err := getRecentTimeEntries(ctx, client, opts, 0)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, e := range resp.TimeEntries {
+	for _, e := range entries {
 		if !e.IsRunning {
 			return &e, nil
 		}
@@ -423,24 +710,75 @@ func getLastTimeEntry(ctx context.Context, client *api.Client) (*api.TimeEntry,
 	return nil, nil
 }
 
+// getMyLastTimeEntry returns the current user's most recently updated
+// non-running entry, erroring when there is none.
+func getMyLastTimeEntry(ctx context.Context, client *api.Client) (*api.TimeEntry, error) {
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get current user: %w", err)
+	}
+	last, err := getLastTimeEntry(ctx, client, api.TimeEntryListOptions{UserID: me.ID})
+	if err != nil {
+		return nil, fmt.Errorf("get last entry: %w", err)
+	}
+	if last == nil {
+		return nil, fmt.Errorf("no previous time entry found")
+	}
+	return last, nil
+}
+
+// recentEntriesPageSize is how many entries are fetched to pick the most
+// recently touched ones from. The API sorts by spent date, so entries edited
+// on an older date would be missed with a smaller page.
+const recentEntriesPageSize = 100
+
+// getRecentTimeEntries returns entries matching opts, most recently updated
+// first. A positive n limits the result to n entries; more than a page of
+// them is fetched page by page.
+func getRecentTimeEntries(ctx context.Context, client *api.Client, opts api.TimeEntryListOptions, n int) ([]api.TimeEntry, error) {
+	opts.MaxItems = max(n, recentEntriesPageSize)
+	opts.PerPage = min(opts.MaxItems, api.DefaultPerPage)
+	entries, err := client.ListAllTimeEntries(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].UpdatedAt.After(entries[j].UpdatedAt)
+	})
+	if n > 0 && len(entries) > n {
+		entries = entries[:n]
+	}
+	return entries, nil
+}
+
+// timerStatusJSON is a running time entry with its elapsed time in seconds,
+// for status bars that need a raw number.
+type timerStatusJSON struct {
+	*api.TimeEntry
+	ElapsedSeconds int64 `json:"elapsed_seconds"`
+}
+
 // formatTimerStatus formats a running timer for display.
 func formatTimerStatus(w io.Writer, entry *api.TimeEntry, mode output.Mode) error {
 	if mode == output.ModeJSON {
-		return output.WriteJSON(w, entry)
+		return output.WriteJSON(w, timerStatusJSON{TimeEntry: entry, ElapsedSeconds: elapsedSeconds(entry)})
 	}
 
 	if mode == output.ModePlain {
 		elapsed := calculateElapsed(entry)
-		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n",
-			entry.ID, entry.Project.Name, entry.Task.Name, elapsed, entry.Notes)
-		return nil
+		return output.WriteTSV(w, nil, [][]string{{
+			strconv.FormatInt(entry.ID, 10), entry.Project.Name, entry.Task.Name, elapsed, entry.Notes,
+			strconv.FormatInt(elapsedSeconds(entry), 10),
+		}})
 	}
 
 	// Table/human format
 	elapsed := calculateElapsed(entry)
 	startTime := formatStartTime(entry)
 
-	fmt.Fprintf(w, "▶ Running: %s - %s\n", entry.Project.Name, entry.Task.Name)
+	running := output.DefaultColors().Success("▶ Running:")
+	fmt.Fprintf(w, "%s %s - %s\n", running, entry.Project.Name, entry.Task.Name)
 	fmt.Fprintf(w, "  Started: %s (%s elapsed)\n", startTime, elapsed)
 	if entry.Notes != "" {
 		fmt.Fprintf(w, "  Notes: %s\n", entry.Notes)
@@ -449,13 +787,27 @@ func formatTimerStatus(w io.Writer, entry *api.TimeEntry, mode output.Mode) erro
 	return nil
 }
 
+// timerElapsed returns how long the timer has been running, falling back to
+// the entry's hours when no start timestamp is available.
+func timerElapsed(entry *api.TimeEntry) time.Duration {
+	if entry.TimerStartedAt == nil {
+		return time.Duration(entry.Hours * float64(time.Hour))
+	}
+	return time.Since(*entry.TimerStartedAt)
+}
+
+// elapsedSeconds returns the timer's elapsed time in whole seconds.
+func elapsedSeconds(entry *api.TimeEntry) int64 {
+	return int64(timerElapsed(entry) / time.Second)
+}
+
 // calculateElapsed calculates elapsed time from timer start.
 func calculateElapsed(entry *api.TimeEntry) string {
 	if entry.TimerStartedAt == nil {
 		return fmt.Sprintf("%.2fh", entry.Hours)
 	}
 
-	duration := time.Since(*entry.TimerStartedAt)
+	duration := timerElapsed(entry)
 	hours := int(duration.Hours())
 	minutes := int(duration.Minutes()) % 60
 
@@ -0,0 +1,363 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/dedene/harvest-cli/internal/api"
+	"github.com/dedene/harvest-cli/internal/auth"
+	"github.com/dedene/harvest-cli/internal/output"
+)
+
+func TestWatchTimer_JSONUntilStopped(t *testing.T) {
+	var polls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if atomic.AddInt32(&polls, 1) <= 2 {
+			_, _ = w.Write([]byte(`{"time_entries":[{"id":7,"hours":1.5,"is_running":true,` +
+				`"project":{"id":1,"name":"Website"},"task":{"id":2,"name":"Design"}}],"total_pages":1}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"time_entries":[],"total_pages":1}`))
+	}))
+	defer srv.Close()
+
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"})
+	client := api.NewClientWithBaseURL(ts, 12345, "test@example.com", srv.URL)
+
+	var stdout bytes.Buffer
+	cli := &CLI{Stdout: &stdout}
+	cli.JSON = true
+
+	if err := watchTimer(context.Background(), cli, client, time.Millisecond); err != nil {
+		t.Fatalf("watchTimer() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3: %q", len(lines), stdout.String())
+	}
+
+	var first, last timerWatchEvent
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("invalid JSON line: %v", err)
+	}
+	if err := json.Unmarshal([]byte(lines[2]), &last); err != nil {
+		t.Fatalf("invalid JSON line: %v", err)
+	}
+
+	if !first.Running || first.ID != 7 || first.Project != "Website" || first.Elapsed != "1.50h" {
+		t.Errorf("unexpected first event: %+v", first)
+	}
+	if last.Running {
+		t.Errorf("last event should report the timer stopped: %+v", last)
+	}
+}
+
+func TestWatchTimer_NoTimer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"time_entries":[],"total_pages":1}`))
+	}))
+	defer srv.Close()
+
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"})
+	client := api.NewClientWithBaseURL(ts, 12345, "test@example.com", srv.URL)
+
+	var stdout bytes.Buffer
+	cli := &CLI{Stdout: &stdout}
+
+	if err := watchTimer(context.Background(), cli, client, time.Millisecond); err != nil {
+		t.Fatalf("watchTimer() error = %v", err)
+	}
+	if stdout.String() != "No timer running\n" {
+		t.Errorf("stdout = %q", stdout.String())
+	}
+}
+
+func TestTimerElapsed(t *testing.T) {
+	started := time.Now().Add(-9 * time.Hour)
+	running := &api.TimeEntry{TimerStartedAt: &started}
+	if d := timerElapsed(running); d < 9*time.Hour || d > 9*time.Hour+time.Minute {
+		t.Errorf("timerElapsed() = %v, want ~9h", d)
+	}
+
+	noStart := &api.TimeEntry{Hours: 1.5}
+	if d := timerElapsed(noStart); d != 90*time.Minute {
+		t.Errorf("timerElapsed() = %v, want 1h30m", d)
+	}
+}
+
+func TestElapsedSeconds(t *testing.T) {
+	started := time.Now().Add(-(2*time.Hour + 15*time.Minute))
+	running := &api.TimeEntry{TimerStartedAt: &started}
+	if s := elapsedSeconds(running); s < 8100 || s > 8160 {
+		t.Errorf("elapsedSeconds() = %d, want ~8100", s)
+	}
+
+	noStart := &api.TimeEntry{Hours: 0.5}
+	if s := elapsedSeconds(noStart); s != 1800 {
+		t.Errorf("elapsedSeconds() = %d, want 1800", s)
+	}
+}
+
+func TestFormatTimerStatus_ElapsedSeconds(t *testing.T) {
+	entry := &api.TimeEntry{ID: 7, Hours: 1.5, Notes: "Review"}
+
+	var buf bytes.Buffer
+	if err := formatTimerStatus(&buf, entry, output.ModeJSON); err != nil {
+		t.Fatalf("formatTimerStatus() error = %v", err)
+	}
+	var got struct {
+		ID             int64 `json:"id"`
+		ElapsedSeconds int64 `json:"elapsed_seconds"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if got.ID != 7 || got.ElapsedSeconds != 5400 {
+		t.Errorf("JSON = %+v, want id 7 and 5400 seconds", got)
+	}
+
+	buf.Reset()
+	if err := formatTimerStatus(&buf, entry, output.ModePlain); err != nil {
+		t.Fatalf("formatTimerStatus() error = %v", err)
+	}
+	if fields := strings.Split(strings.TrimSpace(buf.String()), "\t"); fields[len(fields)-1] != "5400" {
+		t.Errorf("plain = %q, want last column 5400", buf.String())
+	}
+}
+
+func TestTimerStatusIdleThreshold(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	if got := (&TimerStatusCmd{}).idleThreshold(); got != defaultIdleWarn {
+		t.Errorf("idleThreshold() = %v, want default %v", got, defaultIdleWarn)
+	}
+
+	if got := (&TimerStatusCmd{IdleWarn: 2 * time.Hour}).idleThreshold(); got != 2*time.Hour {
+		t.Errorf("idleThreshold() = %v, want 2h", got)
+	}
+}
+
+func TestParseStartAt(t *testing.T) {
+	now := time.Date(2024, 3, 15, 14, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "9am", want: "9:00am"},
+		{input: "9:30", want: "9:30am"},
+		{input: "13:45", want: "1:45pm"},
+		{input: "2pm", want: "2:00pm"},
+		{input: "3pm", wantErr: true},
+		{input: "noonish", wantErr: true},
+		{input: "25:00", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseStartAt(tt.input, now, time.Local, time.Local)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parseStartAt(%q) = %q, want error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseStartAt(%q) error = %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("parseStartAt(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseStartAt_Timezone(t *testing.T) {
+	paris, _ := time.LoadLocation("Europe/Paris")
+	newYork, _ := time.LoadLocation("America/New_York")
+	now := time.Date(2024, 6, 10, 14, 0, 0, 0, paris)
+
+	got, err := parseStartAt("9am", now, paris, newYork)
+	if err != nil {
+		t.Fatalf("parseStartAt() error = %v", err)
+	}
+	if got != "3:00am" {
+		t.Errorf("parseStartAt() = %q, want 3:00am in New York", got)
+	}
+
+	// 3pm in Paris has not happened yet at 2pm Paris time
+	if _, err := parseStartAt("3pm", now, paris, newYork); err == nil {
+		t.Error("parseStartAt() should reject a future time")
+	}
+}
+
+func TestGetRecentTimeEntries(t *testing.T) {
+	var query string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"time_entries":[` +
+			`{"id":1,"updated_at":"2024-03-14T10:00:00Z"},` +
+			`{"id":2,"updated_at":"2024-03-15T09:00:00Z"},` +
+			`{"id":3,"updated_at":"2024-03-10T08:00:00Z"}` +
+			`],"total_pages":1}`))
+	}))
+	defer srv.Close()
+
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"})
+	client := api.NewClientWithBaseURL(ts, 12345, "test@example.com", srv.URL)
+
+	entries, err := getRecentTimeEntries(context.Background(), client, api.TimeEntryListOptions{UserID: 9}, 2)
+	if err != nil {
+		t.Fatalf("getRecentTimeEntries() error = %v", err)
+	}
+
+	if len(entries) != 2 || entries[0].ID != 2 || entries[1].ID != 1 {
+		t.Errorf("entries = %+v, want IDs 2, 1", entries)
+	}
+	if !strings.Contains(query, "user_id=9") {
+		t.Errorf("query = %q, want user_id filter", query)
+	}
+}
+
+func TestGetRecentTimeEntries_MorePagesThanMax(t *testing.T) {
+	var pages []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("per_page") != "100" {
+			t.Errorf("per_page = %q, want the API maximum of 100", q.Get("per_page"))
+		}
+		pages = append(pages, q.Get("page"))
+		w.Header().Set("Content-Type", "application/json")
+		if q.Get("page") == "1" {
+			_, _ = w.Write([]byte(`{"time_entries":[{"id":1,"updated_at":"2024-03-14T10:00:00Z"}],"next_page":2}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"time_entries":[{"id":2,"updated_at":"2024-03-15T09:00:00Z"}]}`))
+	}))
+	defer srv.Close()
+
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"})
+	client := api.NewClientWithBaseURL(ts, 12345, "test@example.com", srv.URL)
+
+	entries, err := getRecentTimeEntries(context.Background(), client, api.TimeEntryListOptions{}, 150)
+	if err != nil {
+		t.Fatalf("getRecentTimeEntries() error = %v", err)
+	}
+	if strings.Join(pages, ",") != "1,2" {
+		t.Errorf("requested pages %v, want 1,2", pages)
+	}
+	if len(entries) != 2 || entries[0].ID != 2 {
+		t.Errorf("entries = %+v, want IDs 2, 1", entries)
+	}
+}
+
+func TestGetMyLastTimeEntry(t *testing.T) {
+	entries := `[` +
+		`{"id":1,"is_running":true,"updated_at":"2024-03-15T12:00:00Z"},` +
+		`{"id":2,"updated_at":"2024-03-15T09:00:00Z"}` +
+		`]`
+	var query string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/users/me" {
+			_, _ = w.Write([]byte(`{"id":9}`))
+			return
+		}
+		query = r.URL.RawQuery
+		_, _ = w.Write([]byte(`{"time_entries":` + entries + `,"total_pages":1}`))
+	}))
+	defer srv.Close()
+
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"})
+	client := api.NewClientWithBaseURL(ts, 12345, "test@example.com", srv.URL)
+
+	last, err := getMyLastTimeEntry(context.Background(), client)
+	if err != nil {
+		t.Fatalf("getMyLastTimeEntry() error = %v", err)
+	}
+	if last.ID != 2 {
+		t.Errorf("last.ID = %d, want 2 (running timers are skipped)", last.ID)
+	}
+	if !strings.Contains(query, "user_id=9") {
+		t.Errorf("query = %q, want user_id filter", query)
+	}
+
+	entries = `[]`
+	if _, err := getMyLastTimeEntry(context.Background(), client); err == nil {
+		t.Error("getMyLastTimeEntry() should error when there is no entry")
+	}
+}
+
+func TestTimerRestartLast(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(auth.PATEnvToken, "pat")
+	t.Setenv(auth.PATEnvAccountID, "1")
+
+	running := `[]`
+	var restarted string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/users/me":
+			_, _ = w.Write([]byte(`{"id":9}`))
+		case r.Method == http.MethodPatch:
+			restarted = r.URL.Path
+			_, _ = w.Write([]byte(`{"id":2,"is_running":true,"project":{"name":"Site"},"task":{"name":"Dev"}}`))
+		case r.URL.Query().Get("is_running") == "true":
+			_, _ = w.Write([]byte(`{"time_entries":` + running + `,"total_pages":1}`))
+		default:
+			_, _ = w.Write([]byte(`{"time_entries":[` +
+				`{"id":1,"updated_at":"2024-03-14T09:00:00Z"},` +
+				`{"id":2,"updated_at":"2024-03-15T09:00:00Z"}` +
+				`],"total_pages":1}`))
+		}
+	}))
+	defer srv.Close()
+
+	for _, args := range [][]string{{"timer", "restart-last"}, {"time", "restart-last"}} {
+		restarted = ""
+		var stdout, stderr bytes.Buffer
+		if err := Execute(append(args, "--api-base-url", srv.URL), &stdout, &stderr); err != nil {
+			t.Fatalf("%v: Execute() error = %v, stderr: %s", args, err, stderr.String())
+		}
+		if restarted != "/time_entries/2/restart" {
+			t.Errorf("%v: restarted %q, want entry 2", args, restarted)
+		}
+		if stdout.String() != "Restarted: Site - Dev\n" {
+			t.Errorf("%v: stdout = %q", args, stdout.String())
+		}
+	}
+
+	running = `[{"id":3,"is_running":true,"project":{"name":"Site"},"task":{"name":"Dev"}}]`
+	restarted = ""
+	var stdout, stderr bytes.Buffer
+	err := Execute([]string{"timer", "restart-last", "--api-base-url", srv.URL}, &stdout, &stderr)
+	if err == nil || !strings.Contains(err.Error(), "already running") {
+		t.Errorf("Execute() error = %v, want already running", err)
+	}
+	if restarted != "" {
+		t.Errorf("restarted %q while a timer was running", restarted)
+	}
+}
+
+func TestTimerStartFromLastConflicts(t *testing.T) {
+	cmd := &TimerStartCmd{FromLast: true, Project: "Website"}
+	err := cmd.Run(&CLI{})
+	if err == nil || !strings.Contains(err.Error(), "--from-last") {
+		t.Errorf("Run() error = %v, want --from-last conflict", err)
+	}
+}
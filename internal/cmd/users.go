@@ -2,10 +2,14 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/dedene/harvest-cli/internal/api"
 	"github.com/dedene/harvest-cli/internal/output"
@@ -14,23 +18,33 @@ import (
 
 // UsersCmd groups user subcommands.
 type UsersCmd struct {
-	List   UsersListCmd   `cmd:"" help:"List all users"`
-	Show   UsersShowCmd   `cmd:"" help:"Show a user by ID"`
-	Me     UsersMeCmd     `cmd:"" help:"Show current authenticated user"`
-	Add    UsersAddCmd    `cmd:"" help:"Create a new user"`
-	Edit   UsersEditCmd   `cmd:"" help:"Update a user"`
-	Remove UsersRemoveCmd `cmd:"" help:"Delete/deactivate a user"`
+	List        UsersListCmd        `cmd:"" help:"List all users"`
+	Show        UsersShowCmd        `cmd:"" help:"Show a user by ID"`
+	Me          UsersMeCmd          `cmd:"" help:"Show current authenticated user"`
+	Add         UsersAddCmd         `cmd:"" help:"Create a new user"`
+	Edit        UsersEditCmd        `cmd:"" help:"Update a user"`
+	Remove      UsersRemoveCmd      `cmd:"" help:"Delete/deactivate a user"`
+	Archive     UsersArchiveCmd     `cmd:"" help:"Deactivate a user"`
+	Unarchive   UsersUnarchiveCmd   `cmd:"" help:"Reactivate an archived user"`
+	Assignments UsersAssignmentsCmd `cmd:"" help:"List a user's project assignments"`
+	Import      UsersImportCmd      `cmd:"" help:"Create users from a CSV file"`
 }
 
 // UsersListCmd lists all users with optional filters.
 type UsersListCmd struct {
 	Active       *bool  `help:"Filter by active status"`
 	UpdatedSince string `help:"Filter by updated_since (ISO 8601)"`
+	NDJSON       bool   `help:"Output one JSON object per line" name:"ndjson"`
+	PagingFlags  `embed:""`
 }
 
 func (c *UsersListCmd) Run(cli *CLI) error {
+	if err := c.PagingFlags.validate(); err != nil {
+		return err
+	}
+
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -40,12 +54,48 @@ func (c *UsersListCmd) Run(cli *CLI) error {
 		UpdatedSince: c.UpdatedSince,
 	}
 
+	opts.PerPage = c.PerPage
+	opts.MaxItems = c.MaxItems
 	users, err := client.ListAllUsers(ctx, opts)
 	if err != nil {
 		return fmt.Errorf("list users: %w", err)
 	}
 
-	return outputUsers(os.Stdout, users, output.ModeFromFlags(cli.JSON, cli.Plain))
+	if c.NDJSON {
+		return output.WriteNDJSON(cli.Stdout, users)
+	}
+
+	return outputUsers(cli.Stdout, users, output.ModeFromFlags(cli.JSON, cli.Plain))
+}
+
+// UsersAssignmentsCmd lists the projects a user is assigned to.
+type UsersAssignmentsCmd struct {
+	User   string `arg:"" help:"User ID, email or 'me'"`
+	Active bool   `help:"Only active assignments"`
+}
+
+func (c *UsersAssignmentsCmd) Run(cli *CLI) error {
+	ctx := context.Background()
+	client, err := NewClientFromFlags(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	userID, err := resolveUserID(ctx, client, c.User)
+	if err != nil {
+		return err
+	}
+
+	assignments, err := client.ListAllUserProjectAssignments(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("list project assignments: %w", err)
+	}
+
+	if c.Active {
+		assignments = slices.DeleteFunc(assignments, func(pa api.ProjectAssignment) bool { return !pa.IsActive })
+	}
+
+	return outputProjectAssignments(cli.Stdout, assignments, output.ModeFromFlags(cli.JSON, cli.Plain))
 }
 
 // UsersShowCmd shows a single user by ID.
@@ -55,7 +105,7 @@ type UsersShowCmd struct {
 
 func (c *UsersShowCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -65,7 +115,7 @@ func (c *UsersShowCmd) Run(cli *CLI) error {
 		return fmt.Errorf("get user: %w", err)
 	}
 
-	return outputUser(os.Stdout, user, output.ModeFromFlags(cli.JSON, cli.Plain))
+	return outputUser(cli.Stdout, user, output.ModeFromFlags(cli.JSON, cli.Plain))
 }
 
 // UsersMeCmd shows the current authenticated user.
@@ -73,7 +123,7 @@ type UsersMeCmd struct{}
 
 func (c *UsersMeCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -83,7 +133,7 @@ func (c *UsersMeCmd) Run(cli *CLI) error {
 		return fmt.Errorf("get current user: %w", err)
 	}
 
-	return outputUser(os.Stdout, user, output.ModeFromFlags(cli.JSON, cli.Plain))
+	return outputUser(cli.Stdout, user, output.ModeFromFlags(cli.JSON, cli.Plain))
 }
 
 // UsersAddCmd creates a new user.
@@ -104,7 +154,7 @@ type UsersAddCmd struct {
 
 func (c *UsersAddCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -132,11 +182,11 @@ func (c *UsersAddCmd) Run(cli *CLI) error {
 		return fmt.Errorf("create user: %w", err)
 	}
 
-	if cli.JSON {
-		return output.WriteJSON(os.Stdout, user)
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, user)
 	}
 
-	fmt.Fprintf(os.Stdout, "Created user #%d: %s (%s)\n", user.ID, user.FullName(), user.Email)
+	printSuccess(cli, user.ID, "Created user #%d: %s (%s)\n", user.ID, user.FullName(), user.Email)
 	return nil
 }
 
@@ -159,7 +209,7 @@ type UsersEditCmd struct {
 
 func (c *UsersEditCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -225,11 +275,11 @@ func (c *UsersEditCmd) Run(cli *CLI) error {
 		return fmt.Errorf("update user: %w", err)
 	}
 
-	if cli.JSON {
-		return output.WriteJSON(os.Stdout, user)
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, user)
 	}
 
-	fmt.Fprintf(os.Stdout, "Updated user #%d: %s (%s)\n", user.ID, user.FullName(), user.Email)
+	printSuccess(cli, user.ID, "Updated user #%d: %s (%s)\n", user.ID, user.FullName(), user.Email)
 	return nil
 }
 
@@ -241,7 +291,7 @@ type UsersRemoveCmd struct {
 
 func (c *UsersRemoveCmd) Run(cli *CLI) error {
 	ctx := context.Background()
-	client, err := NewClientFromFlags(ctx, &cli.RootFlags)
+	client, err := NewClientFromFlags(ctx, cli)
 	if err != nil {
 		return err
 	}
@@ -257,13 +307,13 @@ func (c *UsersRemoveCmd) Run(cli *CLI) error {
 		confirmed, err := ui.ConfirmPrompt(msg)
 		if err != nil {
 			if err == ui.ErrCanceled {
-				fmt.Fprintln(os.Stderr, "Canceled")
+				fmt.Fprintln(cli.Stderr, "Canceled")
 				return nil
 			}
 			return err
 		}
 		if !confirmed {
-			fmt.Fprintln(os.Stderr, "Aborted")
+			fmt.Fprintln(cli.Stderr, "Aborted")
 			return nil
 		}
 	}
@@ -272,10 +322,105 @@ func (c *UsersRemoveCmd) Run(cli *CLI) error {
 		return fmt.Errorf("delete user: %w", err)
 	}
 
-	fmt.Fprintf(os.Stdout, "Deleted user #%d\n", c.ID)
+	printSuccess(cli, 0, "Deleted user #%d\n", c.ID)
 	return nil
 }
 
+// UsersArchiveCmd deactivates a user.
+type UsersArchiveCmd struct {
+	ID int64 `arg:"" help:"User ID"`
+}
+
+func (c *UsersArchiveCmd) Run(cli *CLI) error {
+	return setUserActive(cli, c.ID, false)
+}
+
+// UsersUnarchiveCmd reactivates an archived user.
+type UsersUnarchiveCmd struct {
+	ID int64 `arg:"" help:"User ID"`
+}
+
+func (c *UsersUnarchiveCmd) Run(cli *CLI) error {
+	return setUserActive(cli, c.ID, true)
+}
+
+// setUserActive archives or unarchives a user by updating only is_active.
+func setUserActive(cli *CLI, id int64, active bool) error {
+	ctx := context.Background()
+	client, err := NewClientFromFlags(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	user, err := client.UpdateUser(ctx, id, &api.UserInput{IsActive: &active})
+	if err != nil {
+		return fmt.Errorf("update user: %w", err)
+	}
+	if cli.DryRun {
+		return nil
+	}
+
+	if cli.jsonOutput() {
+		return output.WriteJSON(cli.Stdout, user)
+	}
+
+	printSuccess(cli, user.ID, "%s user #%d: %s\n", archiveVerb(active), user.ID, user.FullName())
+	return nil
+}
+
+// outputProjectAssignments writes a user's project assignments in the
+// specified format.
+func outputProjectAssignments(w io.Writer, assignments []api.ProjectAssignment, mode output.Mode) error {
+	switch mode {
+	case output.ModeJSON:
+		return output.WriteJSON(w, assignments)
+	case output.ModePlain:
+		headers := []string{"ProjectID", "Project", "Client", "Manager", "Active", "HourlyRate"}
+		rows := make([][]string, len(assignments))
+		for i, pa := range assignments {
+			rows[i] = []string{
+				strconv.FormatInt(pa.Project.ID, 10),
+				pa.Project.Name,
+				pa.Client.Name,
+				strconv.FormatBool(pa.IsProjectManager),
+				strconv.FormatBool(pa.IsActive),
+				formatRate(pa.HourlyRate),
+			}
+		}
+		return output.WriteTSV(w, headers, rows)
+	default:
+		t := output.NewTable(w, "Project ID", "Project", "Client", "Manager", "Active", "Hourly Rate")
+		for _, pa := range assignments {
+			t.AddRow(
+				strconv.FormatInt(pa.Project.ID, 10),
+				pa.Project.Name,
+				pa.Client.Name,
+				yesNo(pa.IsProjectManager),
+				yesNo(pa.IsActive),
+				formatRate(pa.HourlyRate),
+			)
+		}
+		return t.Render()
+	}
+}
+
+// yesNo renders a flag as "Yes" or "No" for tables. Plain output uses
+// strconv.FormatBool instead.
+func yesNo(b bool) string {
+	if b {
+		return "Yes"
+	}
+	return "No"
+}
+
+// formatRate renders an optional hourly rate, or "-" when unset.
+func formatRate(rate *float64) string {
+	if rate == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f", *rate)
+}
+
 // outputUsers writes users in the specified format.
 func outputUsers(w io.Writer, users []api.User, mode output.Mode) error {
 	switch mode {
@@ -337,9 +482,9 @@ func outputUser(w io.Writer, user *api.User, mode output.Mode) error {
 	case output.ModeJSON:
 		return output.WriteJSON(w, user)
 	case output.ModePlain:
-		fmt.Fprintf(w, "%d\t%s\t%s\t%v\n",
-			user.ID, user.FullName(), user.Email, user.IsActive)
-		return nil
+		return output.WriteTSV(w, nil, [][]string{{
+			strconv.FormatInt(user.ID, 10), user.FullName(), user.Email, strconv.FormatBool(user.IsActive),
+		}})
 	default:
 		fmt.Fprintf(w, "ID:         %d\n", user.ID)
 		fmt.Fprintf(w, "Name:       %s\n", user.FullName())
@@ -362,3 +507,204 @@ func outputUser(w io.Writer, user *api.User, mode output.Mode) error {
 		return nil
 	}
 }
+
+// UsersImportCmd creates users from a CSV file.
+// With the global --dry-run flag, it previews users without creating them.
+type UsersImportCmd struct {
+	File          string `arg:"" help:"CSV file with first_name, last_name, email and optional roles, weekly_capacity, hourly_rate columns"`
+	StrictHeaders bool   `help:"Reject unknown CSV columns instead of ignoring them" name:"strict-headers"`
+}
+
+// userImportColumns are the CSV columns understood by the user importer.
+var userImportColumns = []string{"first_name", "last_name", "email", "roles", "weekly_capacity", "hourly_rate"}
+
+// userImportRequiredColumns must be present in every user import file.
+var userImportRequiredColumns = []string{"first_name", "last_name", "email"}
+
+// userImportRow is a validated user import row.
+type userImportRow struct {
+	LineNum int
+	Input   *api.UserInput
+}
+
+func (c *UsersImportCmd) Run(cli *CLI) error {
+	f, err := os.Open(c.File)
+	if err != nil {
+		return fmt.Errorf("open file: %w", err)
+	}
+	defer f.Close()
+
+	records, err := readImportCSV(f, userImportColumns, userImportRequiredColumns, c.StrictHeaders)
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		fmt.Fprintln(cli.Stdout, "No users to import")
+		return nil
+	}
+
+	rows, err := parseUserImportRows(records)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	client, err := NewClientFromFlags(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	// Skip people who already have an account rather than failing on them
+	existing, err := client.ListAllUsers(ctx, api.UserListOptions{})
+	if err != nil {
+		return fmt.Errorf("list users: %w", err)
+	}
+	taken := make(map[string]bool, len(existing))
+	for _, u := range existing {
+		taken[strings.ToLower(u.Email)] = true
+	}
+	pending := rows[:0]
+	for _, r := range rows {
+		if taken[strings.ToLower(r.Input.Email)] {
+			fmt.Fprintf(cli.Stderr, "line %d: %s already exists, skipping\n", r.LineNum, r.Input.Email)
+			continue
+		}
+		pending = append(pending, r)
+	}
+	rows = pending
+
+	fmt.Fprintf(cli.Stdout, "%d users will be created\n\n", len(rows))
+
+	if cli.DryRun {
+		fmt.Fprintln(cli.Stdout, "Dry run - preview of users:")
+		for i, r := range rows {
+			fmt.Fprintf(cli.Stdout, "  %d. %s %s <%s>", i+1, r.Input.FirstName, r.Input.LastName, r.Input.Email)
+			if len(r.Input.Roles) > 0 {
+				fmt.Fprintf(cli.Stdout, " - %s", strings.Join(r.Input.Roles, ", "))
+			}
+			fmt.Fprintln(cli.Stdout)
+		}
+		return nil
+	}
+
+	// Create users one by one with progress. Retries are reported so a
+	// throttled import does not look stuck.
+	created, row := 0, 0
+	client.SetRetryHook(func(ev api.RetryEvent) {
+		fmt.Fprintln(cli.Stderr, describeRetry(ev, row, len(rows)))
+	})
+	defer client.SetRetryHook(nil)
+
+	var failures []string
+	for i, r := range rows {
+		row = i + 1
+		user, err := client.CreateUser(ctx, r.Input)
+		if err != nil {
+			msg := fmt.Sprintf("line %d: %s: %v", r.LineNum, r.Input.Email, err)
+			if isDuplicateEmailError(err) {
+				msg = fmt.Sprintf("line %d: %s already exists", r.LineNum, r.Input.Email)
+			}
+			fmt.Fprintf(cli.Stderr, "Error creating user %d: %s\n", i+1, msg)
+			failures = append(failures, msg)
+			continue
+		}
+		created++
+		fmt.Fprintf(cli.Stdout, "[%d/%d] Created #%d: %s (%s)\n",
+			i+1, len(rows), user.ID, user.FullName(), user.Email)
+	}
+
+	fmt.Fprintf(cli.Stdout, "\nImport complete: %d/%d users created\n", created, len(rows))
+	if len(failures) > 0 {
+		fmt.Fprintf(cli.Stdout, "Failed:\n  %s\n", strings.Join(failures, "\n  "))
+	}
+	return nil
+}
+
+// parseUserImportRows validates user import rows, reporting every invalid
+// row at once.
+func parseUserImportRows(records []csvRecord) ([]userImportRow, error) {
+	var rows []userImportRow
+	var errs []string
+	seen := make(map[string]int)
+
+	for _, rec := range records {
+		input, err := userInputFromRecord(rec)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("line %d: %v", rec.LineNum, err))
+			continue
+		}
+		email := strings.ToLower(input.Email)
+		if line, ok := seen[email]; ok {
+			errs = append(errs, fmt.Sprintf("line %d: %s is also on line %d", rec.LineNum, input.Email, line))
+			continue
+		}
+		seen[email] = rec.LineNum
+		rows = append(rows, userImportRow{LineNum: rec.LineNum, Input: input})
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("validation errors:\n  %s", strings.Join(errs, "\n  "))
+	}
+	return rows, nil
+}
+
+// userInputFromRecord builds a user from a CSV row. roles is a
+// semicolon-separated list, weekly_capacity is in hours per week and
+// hourly_rate is the default billable rate.
+func userInputFromRecord(rec csvRecord) (*api.UserInput, error) {
+	input := &api.UserInput{
+		FirstName: rec.get("first_name"),
+		LastName:  rec.get("last_name"),
+		Email:     rec.get("email"),
+	}
+	switch {
+	case input.FirstName == "":
+		return nil, fmt.Errorf("first_name is required")
+	case input.LastName == "":
+		return nil, fmt.Errorf("last_name is required")
+	case input.Email == "":
+		return nil, fmt.Errorf("email is required")
+	case !strings.Contains(input.Email, "@"):
+		return nil, fmt.Errorf("invalid email %q", input.Email)
+	}
+
+	for _, role := range strings.Split(rec.get("roles"), ";") {
+		if role = strings.TrimSpace(role); role != "" {
+			input.Roles = append(input.Roles, role)
+		}
+	}
+
+	if v := rec.get("weekly_capacity"); v != "" {
+		hours, err := strconv.ParseFloat(v, 64)
+		if err != nil || hours < 0 || hours > 168 {
+			return nil, fmt.Errorf("invalid weekly_capacity %q (hours per week)", v)
+		}
+		seconds := int(hours * 3600)
+		input.WeeklyCapacity = &seconds
+	}
+
+	if v := rec.get("hourly_rate"); v != "" {
+		rate, err := strconv.ParseFloat(v, 64)
+		if err != nil || rate < 0 {
+			return nil, fmt.Errorf("invalid hourly_rate %q", v)
+		}
+		input.DefaultHourlyRate = &rate
+	}
+	return input, nil
+}
+
+// isDuplicateEmailError reports whether Harvest rejected a new user because
+// the email address is already in use.
+func isDuplicateEmailError(err error) bool {
+	var verr *api.ValidationError
+	if errors.As(err, &verr) {
+		_, ok := verr.Fields["email"]
+		return ok
+	}
+	var apiErr *api.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnprocessableEntity {
+		details := strings.ToLower(apiErr.Details)
+		return strings.Contains(details, "email") && (strings.Contains(details, "taken") || strings.Contains(details, "exist"))
+	}
+	return false
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dedene/harvest-cli/internal/api"
+	"github.com/dedene/harvest-cli/internal/auth"
+	"github.com/dedene/harvest-cli/internal/output"
+)
+
+func TestOutputProjectAssignments_Plain(t *testing.T) {
+	rate := 120.0
+	assignments := []api.ProjectAssignment{
+		{
+			IsProjectManager: true,
+			IsActive:         true,
+			HourlyRate:       &rate,
+			Project:          api.ProjectRef{ID: 10, Name: "Website"},
+			Client:           api.ClientRef{Name: "Acme"},
+		},
+		{Project: api.ProjectRef{ID: 20, Name: "Intranet"}},
+	}
+
+	var buf bytes.Buffer
+	if err := outputProjectAssignments(&buf, assignments, output.ModePlain); err != nil {
+		t.Fatalf("outputProjectAssignments() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want header + 2 rows: %q", len(lines), buf.String())
+	}
+	if want := "ProjectID\tProject\tClient\tManager\tActive\tHourlyRate"; lines[0] != want {
+		t.Errorf("headers = %q, want %q", lines[0], want)
+	}
+	if want := "10\tWebsite\tAcme\ttrue\ttrue\t120.00"; lines[1] != want {
+		t.Errorf("row 1 = %q, want %q", lines[1], want)
+	}
+	if want := "20\tIntranet\t\tfalse\tfalse\t-"; lines[2] != want {
+		t.Errorf("row 2 = %q, want %q", lines[2], want)
+	}
+}
+
+func TestParseUserImportRows(t *testing.T) {
+	csv := "first_name,last_name,email,roles,weekly_capacity,hourly_rate\n" +
+		"Ada,Lovelace,ada@example.com,Designer; Contractor,35,120\n" +
+		"Alan,Turing,alan@example.com,,,\n"
+	records, err := readImportCSV(strings.NewReader(csv), userImportColumns, userImportRequiredColumns, true)
+	if err != nil {
+		t.Fatalf("readImportCSV() error = %v", err)
+	}
+	rows, err := parseUserImportRows(records)
+	if err != nil {
+		t.Fatalf("parseUserImportRows() error = %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("rows = %d, want 2", len(rows))
+	}
+
+	ada := rows[0].Input
+	if ada.Email != "ada@example.com" || strings.Join(ada.Roles, "|") != "Designer|Contractor" {
+		t.Errorf("ada = %+v", ada)
+	}
+	if ada.WeeklyCapacity == nil || *ada.WeeklyCapacity != 35*3600 {
+		t.Errorf("weekly capacity = %v, want 35h in seconds", ada.WeeklyCapacity)
+	}
+	if ada.DefaultHourlyRate == nil || *ada.DefaultHourlyRate != 120 {
+		t.Errorf("hourly rate = %v, want 120", ada.DefaultHourlyRate)
+	}
+	if alan := rows[1].Input; alan.Roles != nil || alan.WeeklyCapacity != nil || alan.DefaultHourlyRate != nil || rows[1].LineNum != 3 {
+		t.Errorf("alan = %+v on line %d, want no optional fields on line 3", alan, rows[1].LineNum)
+	}
+
+	bad := "first_name,last_name,email,weekly_capacity,hourly_rate\n" +
+		"Ada,,ada@example.com,,\n" +
+		"Bob,Smith,bob,,\n" +
+		"Cy,Young,cy@example.com,200,\n" +
+		"Di,Prince,di@example.com,,cheap\n" +
+		"Ed,One,ed@example.com,,\n" +
+		"Ed,Two,ED@example.com,,\n"
+	records, err = readImportCSV(strings.NewReader(bad), userImportColumns, userImportRequiredColumns, false)
+	if err != nil {
+		t.Fatalf("readImportCSV() error = %v", err)
+	}
+	_, err = parseUserImportRows(records)
+	for _, want := range []string{"line 2: last_name", "line 3: invalid email", "line 4: invalid weekly_capacity", "line 5: invalid hourly_rate", "line 7: ED@example.com is also on line 6"} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("parseUserImportRows() error = %v, want %q", err, want)
+		}
+	}
+}
+
+func TestUsersImport(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(auth.PATEnvToken, "pat")
+	t.Setenv(auth.PATEnvAccountID, "1")
+
+	var posted []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"users":[{"id":1,"email":"Existing@example.com"}],"total_pages":1}`))
+			return
+		}
+		var in api.UserInput
+		_ = json.NewDecoder(r.Body).Decode(&in)
+		posted = append(posted, in.Email)
+		if in.Email == "taken@example.com" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"message":"Email has already been taken"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":50,"first_name":"` + in.FirstName + `","last_name":"` + in.LastName + `","email":"` + in.Email + `"}`))
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "users.csv")
+	csv := "first_name,last_name,email\n" +
+		"Ada,Lovelace,ada@example.com\n" +
+		"Old,Timer,existing@example.com\n" +
+		"Tak,En,taken@example.com\n"
+	if err := os.WriteFile(path, []byte(csv), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if err := Execute([]string{"users", "import", path, "--dry-run", "--api-base-url", srv.URL}, &stdout, &stderr); err != nil {
+		t.Fatalf("dry run error = %v, stderr: %s", err, stderr.String())
+	}
+	if len(posted) != 0 || !strings.Contains(stdout.String(), "2 users will be created") {
+		t.Errorf("dry run posted %v, stdout %q", posted, stdout.String())
+	}
+
+	stdout.Reset()
+	stderr.Reset()
+	if err := Execute([]string{"users", "import", path, "--api-base-url", srv.URL}, &stdout, &stderr); err != nil {
+		t.Fatalf("Execute() error = %v, stderr: %s", err, stderr.String())
+	}
+	if strings.Join(posted, ",") != "ada@example.com,taken@example.com" {
+		t.Errorf("posted = %v, want the existing user skipped", posted)
+	}
+	out := stdout.String()
+	for _, want := range []string{"Created #50: Ada Lovelace", "1/2 users created", "line 4: taken@example.com already exists"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("stdout = %q, want %q", out, want)
+		}
+	}
+	if !strings.Contains(stderr.String(), "line 3: existing@example.com already exists, skipping") {
+		t.Errorf("stderr = %q, want the existing user reported", stderr.String())
+	}
+}
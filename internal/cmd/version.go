@@ -2,42 +2,95 @@ package cmd
 
 import (
 	"fmt"
-	"os"
+	"io"
+	"runtime"
 	"strings"
-)
 
-var (
-	version = "0.1.0"
-	commit  = ""
-	date    = ""
+	"github.com/dedene/harvest-cli/internal/output"
 )
 
+// BuildInfo describes the running binary. main fills it from -ldflags.
+type BuildInfo struct {
+	Version string
+	Commit  string
+	Date    string
+}
+
+// buildInfo is the metadata set by SetBuildInfo; newParser copies it onto
+// the CLI.
+var buildInfo = BuildInfo{Version: "dev"}
+
+// SetBuildInfo records the build metadata shown by the version command.
+func SetBuildInfo(info BuildInfo) {
+	buildInfo = info
+}
+
 // VersionString returns formatted version info.
 func VersionString() string {
-	v := strings.TrimSpace(version)
+	return buildInfo.String()
+}
+
+// String formats the version with the commit and build date when known.
+func (b BuildInfo) String() string {
+	v := strings.TrimSpace(b.Version)
 	if v == "" {
 		v = "dev"
 	}
+	commit := strings.TrimSpace(b.Commit)
+	date := strings.TrimSpace(b.Date)
 
-	if strings.TrimSpace(commit) == "" && strings.TrimSpace(date) == "" {
+	if commit == "" && date == "" {
 		return v
 	}
 
-	if strings.TrimSpace(commit) == "" {
-		return fmt.Sprintf("%s (%s)", v, strings.TrimSpace(date))
+	if commit == "" {
+		return fmt.Sprintf("%s (%s)", v, date)
 	}
 
-	if strings.TrimSpace(date) == "" {
-		return fmt.Sprintf("%s (%s)", v, strings.TrimSpace(commit))
+	if date == "" {
+		return fmt.Sprintf("%s (%s)", v, commit)
 	}
 
-	return fmt.Sprintf("%s (%s %s)", v, strings.TrimSpace(commit), strings.TrimSpace(date))
+	return fmt.Sprintf("%s (%s %s)", v, commit, date)
 }
 
 // VersionCmd prints version info.
 type VersionCmd struct{}
 
-func (c *VersionCmd) Run() error {
-	fmt.Fprintln(os.Stdout, "harvest", VersionString())
-	return nil
+// versionInfo is the build metadata shown by VersionCmd.
+type versionInfo struct {
+	Version    string `json:"version"`
+	Commit     string `json:"commit"`
+	Date       string `json:"date"`
+	GoVersion  string `json:"go_version"`
+	Platform   string `json:"platform"`
+	APIBaseURL string `json:"api_base_url"`
+}
+
+func (c *VersionCmd) Run(cli *CLI) error {
+	v := versionInfo{
+		Version:    cli.Build.Version,
+		Commit:     cli.Build.Commit,
+		Date:       cli.Build.Date,
+		GoVersion:  runtime.Version(),
+		Platform:   runtime.GOOS + "/" + runtime.GOARCH,
+		APIBaseURL: cli.APIBaseURL,
+	}
+	return outputVersion(cli.Stdout, v, cli.Build, output.ModeFromFlags(cli.JSON, cli.Plain))
+}
+
+// outputVersion writes the version line followed by the details useful in
+// bug reports.
+func outputVersion(w io.Writer, v versionInfo, build BuildInfo, mode output.Mode) error {
+	switch mode {
+	case output.ModeJSON:
+		return output.WriteJSON(w, v)
+	case output.ModePlain:
+		return output.WriteTSV(w, nil, [][]string{{v.Version, v.Commit, v.Date, v.GoVersion, v.Platform, v.APIBaseURL}})
+	default:
+		fmt.Fprintln(w, "harvest", build)
+		fmt.Fprintf(w, "  go:  %s %s\n", v.GoVersion, v.Platform)
+		fmt.Fprintf(w, "  api: %s\n", v.APIBaseURL)
+		return nil
+	}
 }
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestBuildInfoString(t *testing.T) {
+	tests := []struct {
+		info BuildInfo
+		want string
+	}{
+		{BuildInfo{}, "dev"},
+		{BuildInfo{Version: "1.2.0"}, "1.2.0"},
+		{BuildInfo{Version: "1.2.0", Commit: "abc123"}, "1.2.0 (abc123)"},
+		{BuildInfo{Version: "1.2.0", Date: "2024-05-01"}, "1.2.0 (2024-05-01)"},
+		{BuildInfo{Version: "1.2.0", Commit: "abc123", Date: "2024-05-01"}, "1.2.0 (abc123 2024-05-01)"},
+	}
+	for _, tt := range tests {
+		if got := tt.info.String(); got != tt.want {
+			t.Errorf("%+v.String() = %q, want %q", tt.info, got, tt.want)
+		}
+	}
+}
+
+func TestVersionCmd_JSON(t *testing.T) {
+	saved := buildInfo
+	t.Cleanup(func() { buildInfo = saved })
+	SetBuildInfo(BuildInfo{Version: "1.2.0", Commit: "abc123", Date: "2024-05-01"})
+
+	var stdout, stderr bytes.Buffer
+	if err := Execute([]string{"version", "--json"}, &stdout, &stderr); err != nil {
+		t.Fatalf("Execute() error = %v, stderr: %s", err, stderr.String())
+	}
+
+	var got map[string]string
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON %q: %v", stdout.String(), err)
+	}
+	if got["version"] != "1.2.0" || got["commit"] != "abc123" || got["date"] != "2024-05-01" {
+		t.Errorf("build fields = %v", got)
+	}
+	if !strings.HasPrefix(got["go_version"], "go") || got["api_base_url"] == "" {
+		t.Errorf("runtime fields = %v", got)
+	}
+}
+
+func TestVersionCmd_CSV(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	saved := buildInfo
+	t.Cleanup(func() { buildInfo = saved })
+	SetBuildInfo(BuildInfo{Version: "1.2.0", Commit: "abc123", Date: "2024-05-01"})
+
+	run := func(args ...string) string {
+		t.Helper()
+		var stdout, stderr bytes.Buffer
+		if err := Execute(append([]string{"version"}, args...), &stdout, &stderr); err != nil {
+			t.Fatalf("Execute(%v) error = %v, stderr: %s", args, err, stderr.String())
+		}
+		return stdout.String()
+	}
+
+	if out := run("--csv"); !strings.HasPrefix(out, "1.2.0,abc123,2024-05-01,go") {
+		t.Errorf("--csv output = %q, want comma-separated", out)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if err := Execute([]string{"config", "set", "default_output", "csv"}, &stdout, &stderr); err != nil {
+		t.Fatalf("config set error = %v", err)
+	}
+	if out := run(); !strings.HasPrefix(out, "1.2.0,abc123,") {
+		t.Errorf("csv default output = %q, want comma-separated", out)
+	}
+	if out := run("--plain"); !strings.HasPrefix(out, "1.2.0\tabc123\t") {
+		t.Errorf("--plain output = %q, want tabs over the csv default", out)
+	}
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/dedene/harvest-cli/internal/output"
+)
+
+// WhoamiCmd prints who you are authenticated as, and on which account.
+type WhoamiCmd struct{}
+
+// whoami is the identity shown by WhoamiCmd.
+type whoami struct {
+	Email     string `json:"email"`
+	AccountID int64  `json:"account_id"`
+	Name      string `json:"name"`
+	Company   string `json:"company"`
+}
+
+func (c *WhoamiCmd) Run(cli *CLI) error {
+	ctx := context.Background()
+	client, err := NewClientFromFlags(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		return fmt.Errorf("get current user: %w", err)
+	}
+	company, err := getCompany(ctx, cli, client)
+	if err != nil {
+		return err
+	}
+
+	w := whoami{
+		Email:     me.Email,
+		AccountID: client.AccountID(),
+		Name:      strings.TrimSpace(me.FullName()),
+		Company:   company.Name,
+	}
+	return outputWhoami(cli.Stdout, w, output.ModeFromFlags(cli.JSON, cli.Plain))
+}
+
+// outputWhoami writes the identity on a single line.
+func outputWhoami(w io.Writer, who whoami, mode output.Mode) error {
+	switch mode {
+	case output.ModeJSON:
+		return output.WriteJSON(w, who)
+	case output.ModePlain:
+		return output.WriteTSV(w, nil, [][]string{{who.Email, strconv.FormatInt(who.AccountID, 10), who.Name, who.Company}})
+	default:
+		fmt.Fprintf(w, "%s (%s) on %s, account %d\n", who.Email, who.Name, who.Company, who.AccountID)
+		return nil
+	}
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dedene/harvest-cli/internal/output"
+)
+
+func TestOutputWhoami(t *testing.T) {
+	who := whoami{Email: "jane@example.com", AccountID: 12345, Name: "Jane Doe", Company: "Acme"}
+
+	tests := []struct {
+		mode output.Mode
+		want string
+	}{
+		{output.ModeTable, "jane@example.com (Jane Doe) on Acme, account 12345\n"},
+		{output.ModePlain, "jane@example.com\t12345\tJane Doe\tAcme\n"},
+		{output.ModeJSON, "{\n  \"email\": \"jane@example.com\",\n  \"account_id\": 12345,\n  \"name\": \"Jane Doe\",\n  \"company\": \"Acme\"\n}\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode.String(), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := outputWhoami(&buf, who, tt.mode); err != nil {
+				t.Fatalf("outputWhoami() error = %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("outputWhoami() = %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 
 	"github.com/titanous/json5"
 )
@@ -20,8 +21,88 @@ type File struct {
 	DefaultTimezone string            `json:"default_timezone,omitempty"`
 	WeekStart       string            `json:"week_start,omitempty"`
 	Color           string            `json:"color,omitempty"`
+	DateFormat      string            `json:"date_format,omitempty"`
+	DefaultOutput   string            `json:"default_output,omitempty"`
+	FiscalYearStart string            `json:"fiscal_year_start,omitempty"`
 	KeyringBackend  string            `json:"keyring_backend,omitempty"`
 	ContactEmail    string            `json:"contact_email,omitempty"`
+	IdleWarn        string            `json:"idle_warn,omitempty"`
+
+	// ActiveProfile names the profile applied when --profile is not given.
+	ActiveProfile string `json:"active_profile,omitempty"`
+
+	// Profiles are named bundles of settings, selected with --profile.
+	Profiles map[string]Profile `json:"profiles,omitempty"`
+
+	// AccountDefaults maps a Harvest account ID to its default project/task.
+	AccountDefaults map[string]AccountDefaults `json:"account_defaults,omitempty"`
+
+	// SyncState maps a Harvest account ID to its incremental sync progress.
+	SyncState map[string]SyncState `json:"sync_state,omitempty"`
+}
+
+// Profile is a named bundle of settings. Flags given on the command line win
+// over a profile, and a profile wins over the global config. Empty fields
+// leave the setting alone.
+type Profile struct {
+	Account string `json:"account,omitempty"`
+	Project string `json:"project,omitempty"`
+	Task    string `json:"task,omitempty"`
+	Output  string `json:"output,omitempty"`
+	Color   string `json:"color,omitempty"`
+}
+
+// AccountDefaults are the project and task used when a command is given
+// neither. Values are IDs or names.
+type AccountDefaults struct {
+	Project string `json:"project,omitempty"`
+	Task    string `json:"task,omitempty"`
+}
+
+// DefaultsFor returns the defaults configured for a Harvest account.
+func (f *File) DefaultsFor(accountID int64) AccountDefaults {
+	return f.AccountDefaults[strconv.FormatInt(accountID, 10)]
+}
+
+// SetDefaultsFor stores the defaults for a Harvest account, dropping the
+// entry once it is empty.
+func (f *File) SetDefaultsFor(accountID int64, d AccountDefaults) {
+	key := strconv.FormatInt(accountID, 10)
+	if d == (AccountDefaults{}) {
+		delete(f.AccountDefaults, key)
+		return
+	}
+	if f.AccountDefaults == nil {
+		f.AccountDefaults = make(map[string]AccountDefaults)
+	}
+	f.AccountDefaults[key] = d
+}
+
+// SyncState records the newest updated_at seen per resource by
+// `harvest sync`, as RFC 3339 timestamps. Empty means never synced.
+type SyncState struct {
+	TimeEntries string `json:"time_entries,omitempty"`
+	Expenses    string `json:"expenses,omitempty"`
+	Invoices    string `json:"invoices,omitempty"`
+}
+
+// SyncStateFor returns the sync progress recorded for a Harvest account.
+func (f *File) SyncStateFor(accountID int64) SyncState {
+	return f.SyncState[strconv.FormatInt(accountID, 10)]
+}
+
+// SetSyncStateFor stores the sync progress for a Harvest account, dropping
+// the entry once it is empty.
+func (f *File) SetSyncStateFor(accountID int64, s SyncState) {
+	key := strconv.FormatInt(accountID, 10)
+	if s == (SyncState{}) {
+		delete(f.SyncState, key)
+		return
+	}
+	if f.SyncState == nil {
+		f.SyncState = make(map[string]SyncState)
+	}
+	f.SyncState[key] = s
 }
 
 // ReadConfig reads and parses the config file.
@@ -108,3 +108,59 @@ func TestInitMaps(t *testing.T) {
 		t.Error("ClientDomains should be initialized")
 	}
 }
+
+func TestAccountDefaults(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	cfg := &File{}
+	cfg.SetDefaultsFor(123, AccountDefaults{Project: "42", Task: "Development"})
+	cfg.SetDefaultsFor(456, AccountDefaults{Project: "7"})
+
+	if err := WriteConfig(cfg); err != nil {
+		t.Fatalf("WriteConfig() error: %v", err)
+	}
+	cfg2, err := ReadConfig()
+	if err != nil {
+		t.Fatalf("ReadConfig() error: %v", err)
+	}
+
+	if d := cfg2.DefaultsFor(123); d.Project != "42" || d.Task != "Development" {
+		t.Errorf("DefaultsFor(123) = %+v", d)
+	}
+	if d := cfg2.DefaultsFor(456); d.Project != "7" || d.Task != "" {
+		t.Errorf("DefaultsFor(456) = %+v", d)
+	}
+	if d := cfg2.DefaultsFor(789); d != (AccountDefaults{}) {
+		t.Errorf("DefaultsFor(789) = %+v, want empty", d)
+	}
+
+	// Clearing all fields removes the account entry
+	cfg2.SetDefaultsFor(456, AccountDefaults{})
+	if _, ok := cfg2.AccountDefaults["456"]; ok {
+		t.Error("empty defaults should be removed")
+	}
+}
+
+func TestSyncState(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	cfg := &File{}
+	cfg.SetSyncStateFor(123, SyncState{TimeEntries: "2024-01-02T03:04:05Z"})
+
+	if err := WriteConfig(cfg); err != nil {
+		t.Fatalf("WriteConfig() error: %v", err)
+	}
+	cfg2, err := ReadConfig()
+	if err != nil {
+		t.Fatalf("ReadConfig() error: %v", err)
+	}
+
+	if s := cfg2.SyncStateFor(123); s.TimeEntries != "2024-01-02T03:04:05Z" || s.Expenses != "" {
+		t.Errorf("SyncStateFor(123) = %+v", s)
+	}
+
+	cfg2.SetSyncStateFor(123, SyncState{})
+	if _, ok := cfg2.SyncState["123"]; ok {
+		t.Error("empty sync state should be removed")
+	}
+}
@@ -0,0 +1,62 @@
+package dateparse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A fiscal year starts on the first day of its start month and is named
+// after the calendar year it ends in: with an April start, fiscal 2025 runs
+// from 2024-04-01 to 2025-03-31. With a January start it is the calendar
+// year.
+
+// ParseMonth parses a month name ("april", "apr") or number ("4").
+func ParseMonth(s string) (time.Month, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+	if n, err := strconv.Atoi(s); err == nil {
+		if n < 1 || n > 12 {
+			return 0, fmt.Errorf("invalid month %q (use 1-12 or a month name)", s)
+		}
+		return time.Month(n), nil
+	}
+	if len(s) >= 3 {
+		for m := time.January; m <= time.December; m++ {
+			if strings.HasPrefix(strings.ToLower(m.String()), s) {
+				return m, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("invalid month %q (use 1-12 or a month name)", s)
+}
+
+// FiscalYear returns the first and last day of fiscal year year.
+func FiscalYear(year int, startMonth time.Month) (from, to time.Time) {
+	from = time.Date(year, startMonth, 1, 0, 0, 0, 0, time.Local)
+	if startMonth != time.January {
+		from = from.AddDate(-1, 0, 0)
+	}
+	return from, from.AddDate(1, 0, -1)
+}
+
+// FiscalQuarter returns the first and last day of quarter (1-4) of fiscal
+// year year.
+func FiscalQuarter(year, quarter int, startMonth time.Month) (from, to time.Time, err error) {
+	if quarter < 1 || quarter > 4 {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid quarter %d (use 1-4)", quarter)
+	}
+	start, _ := FiscalYear(year, startMonth)
+	from = start.AddDate(0, 3*(quarter-1), 0)
+	return from, from.AddDate(0, 3, -1), nil
+}
+
+// FiscalQuarterOf returns the fiscal year and quarter that day falls in.
+func FiscalQuarterOf(day time.Time, startMonth time.Month) (year, quarter int) {
+	months := (int(day.Month()) - int(startMonth) + 12) % 12
+	year = day.Year()
+	if startMonth != time.January && day.Month() >= startMonth {
+		year++
+	}
+	return year, months/3 + 1
+}
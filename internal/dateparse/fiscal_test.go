@@ -0,0 +1,98 @@
+package dateparse
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseMonth(t *testing.T) {
+	tests := map[string]time.Month{
+		"1":         time.January,
+		"12":        time.December,
+		"april":     time.April,
+		"Apr":       time.April,
+		"September": time.September,
+		"sept":      time.September,
+	}
+	for in, want := range tests {
+		got, err := ParseMonth(in)
+		if err != nil || got != want {
+			t.Errorf("ParseMonth(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+
+	for _, bad := range []string{"0", "13", "ju", "smarch", ""} {
+		if _, err := ParseMonth(bad); err == nil {
+			t.Errorf("ParseMonth(%q) should fail", bad)
+		}
+	}
+}
+
+func TestFiscalQuarter(t *testing.T) {
+	tests := []struct {
+		year, quarter int
+		start         time.Month
+		from, to      string
+	}{
+		{2025, 1, time.January, "2025-01-01", "2025-03-31"},
+		{2025, 4, time.January, "2025-10-01", "2025-12-31"},
+		{2025, 1, time.April, "2024-04-01", "2024-06-30"},
+		{2025, 4, time.April, "2025-01-01", "2025-03-31"},
+		{2025, 2, time.October, "2025-01-01", "2025-03-31"},
+		{2024, 3, time.July, "2024-01-01", "2024-03-31"},
+		{2024, 1, time.December, "2023-12-01", "2024-02-29"},
+	}
+
+	for _, tt := range tests {
+		from, to, err := FiscalQuarter(tt.year, tt.quarter, tt.start)
+		if err != nil {
+			t.Fatalf("FiscalQuarter(%d, %d, %v) error = %v", tt.year, tt.quarter, tt.start, err)
+		}
+		if FormatDate(from) != tt.from || FormatDate(to) != tt.to {
+			t.Errorf("FiscalQuarter(%d, %d, %v) = %s..%s, want %s..%s",
+				tt.year, tt.quarter, tt.start, FormatDate(from), FormatDate(to), tt.from, tt.to)
+		}
+	}
+
+	if _, _, err := FiscalQuarter(2025, 5, time.January); err == nil {
+		t.Error("FiscalQuarter() should reject quarter 5")
+	}
+}
+
+func TestFiscalYear(t *testing.T) {
+	from, to := FiscalYear(2025, time.January)
+	if FormatDate(from) != "2025-01-01" || FormatDate(to) != "2025-12-31" {
+		t.Errorf("FiscalYear(2025, January) = %s..%s", FormatDate(from), FormatDate(to))
+	}
+	from, to = FiscalYear(2025, time.April)
+	if FormatDate(from) != "2024-04-01" || FormatDate(to) != "2025-03-31" {
+		t.Errorf("FiscalYear(2025, April) = %s..%s", FormatDate(from), FormatDate(to))
+	}
+}
+
+func TestFiscalQuarterOf(t *testing.T) {
+	tests := []struct {
+		day           string
+		start         time.Month
+		year, quarter int
+	}{
+		{"2025-02-14", time.January, 2025, 1},
+		{"2025-12-31", time.January, 2025, 4},
+		{"2025-03-31", time.April, 2025, 4},
+		{"2025-04-01", time.April, 2026, 1},
+		{"2025-11-15", time.October, 2026, 1},
+		{"2025-09-30", time.October, 2025, 4},
+	}
+
+	for _, tt := range tests {
+		day, _ := time.ParseInLocation("2006-01-02", tt.day, time.Local)
+		year, quarter := FiscalQuarterOf(day, tt.start)
+		if year != tt.year || quarter != tt.quarter {
+			t.Errorf("FiscalQuarterOf(%s, %v) = FY%d Q%d, want FY%d Q%d", tt.day, tt.start, year, quarter, tt.year, tt.quarter)
+		}
+		from, to, _ := FiscalQuarter(year, quarter, tt.start)
+		if day.Before(from) || day.After(to) {
+			t.Errorf("%s is outside FY%d Q%d (%s..%s)", tt.day, year, quarter, FormatDate(from), FormatDate(to))
+		}
+	}
+}
@@ -19,7 +19,7 @@ var (
 	lastWeekdayRe = regexp.MustCompile(`^last\s+(monday|tuesday|wednesday|thursday|friday|saturday|sunday)$`)
 
 	// Time of day pattern
-	timeOfDayRe = regexp.MustCompile(`^(\d{1,2}):(\d{2})(?:\s*(am|pm))?$`)
+	timeOfDayRe = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(?:\s*(am|pm))?$`)
 
 	// Duration patterns
 	hoursMinutesRe = regexp.MustCompile(`^(\d+)h(\d+)m?$`)
@@ -135,12 +135,12 @@ func FormatDuration(d time.Duration) string {
 	return fmt.Sprintf("%.2gh", hours)
 }
 
-// ParseTimeOfDay parses time strings: "9:00", "9:00am", "14:30".
+// ParseTimeOfDay parses time strings: "9:00", "9:00am", "9am", "14:30".
 func ParseTimeOfDay(s string) (hour, minute int, err error) {
 	s = strings.TrimSpace(strings.ToLower(s))
 
 	m := timeOfDayRe.FindStringSubmatch(s)
-	if m == nil {
+	if m == nil || (m[2] == "" && m[3] == "") {
 		return 0, 0, fmt.Errorf("cannot parse time %q", s)
 	}
 
@@ -148,6 +148,10 @@ func ParseTimeOfDay(s string) (hour, minute int, err error) {
 	minute, _ = strconv.Atoi(m[2])
 	ampm := m[3]
 
+	if ampm != "" && (hour < 1 || hour > 12) {
+		return 0, 0, fmt.Errorf("invalid time %q", s)
+	}
+
 	// Handle 12-hour format
 	if ampm == "pm" && hour < 12 {
 		hour += 12
@@ -162,6 +166,12 @@ func ParseTimeOfDay(s string) (hour, minute int, err error) {
 	return hour, minute, nil
 }
 
+// FormatTimeOfDay formats a time of day the way Harvest expects timestamps,
+// e.g. "9:05am" or "2:30pm".
+func FormatTimeOfDay(hour, minute int) string {
+	return time.Date(0, 1, 1, hour, minute, 0, 0, time.UTC).Format("3:04pm")
+}
+
 // parseWeekday converts a weekday name to time.Weekday.
 func parseWeekday(name string) time.Weekday {
 	switch strings.ToLower(name) {
@@ -193,6 +193,8 @@ func TestParseTimeOfDay(t *testing.T) {
 		{"12:00pm", 12, 0},
 		{"12:00am", 0, 0},
 		{"11:59pm", 23, 59},
+		{"9am", 9, 0},
+		{"2 pm", 14, 0},
 	}
 
 	for _, tt := range tests {
@@ -213,6 +215,8 @@ func TestParseTimeOfDay_Invalid(t *testing.T) {
 		"not a time",
 		"25:00",
 		"12:60",
+		"9",
+		"13pm",
 		"",
 	}
 
@@ -226,6 +230,24 @@ func TestParseTimeOfDay_Invalid(t *testing.T) {
 	}
 }
 
+func TestFormatTimeOfDay(t *testing.T) {
+	tests := []struct {
+		hour, minute int
+		want         string
+	}{
+		{9, 0, "9:00am"},
+		{0, 5, "12:05am"},
+		{12, 0, "12:00pm"},
+		{14, 30, "2:30pm"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatTimeOfDay(tt.hour, tt.minute); got != tt.want {
+			t.Errorf("FormatTimeOfDay(%d, %d) = %q, want %q", tt.hour, tt.minute, got, tt.want)
+		}
+	}
+}
+
 func TestParse_LastWeekday(t *testing.T) {
 	// These tests are date-dependent but should not error
 	weekdays := []string{
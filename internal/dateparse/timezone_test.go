@@ -0,0 +1,60 @@
+package dateparse
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoadLocation(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{name: "Europe/Paris", want: "Europe/Paris"},
+		{name: "Eastern Time (US & Canada)", want: "America/New_York"},
+		{name: "Bern", want: "Europe/Zurich"},
+		{name: "Mars/Olympus_Mons", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc, err := LoadLocation(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadLocation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && loc.String() != tt.want {
+				t.Errorf("LoadLocation() = %s, want %s", loc, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadLocation_AllHarvestZones(t *testing.T) {
+	for name := range harvestZones {
+		if _, err := LoadLocation(name); err != nil {
+			t.Errorf("LoadLocation(%q) error = %v", name, err)
+		}
+	}
+}
+
+func TestConvertTimeOfDay(t *testing.T) {
+	paris, _ := time.LoadLocation("Europe/Paris")
+	newYork, _ := time.LoadLocation("America/New_York")
+	day := time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)
+
+	got, err := ConvertTimeOfDay("9am", day, paris, newYork)
+	if err != nil {
+		t.Fatalf("ConvertTimeOfDay() error = %v", err)
+	}
+	if got.Format("2006-01-02 3:04pm") != "2024-06-10 3:00am" {
+		t.Errorf("ConvertTimeOfDay() = %s, want 2024-06-10 3:00am", got.Format("2006-01-02 3:04pm"))
+	}
+
+	if _, err := ConvertTimeOfDay("2am", day, paris, newYork); err == nil {
+		t.Error("ConvertTimeOfDay() should reject a time that moves to another day")
+	}
+	if _, err := ConvertTimeOfDay("noonish", day, paris, newYork); err == nil {
+		t.Error("ConvertTimeOfDay() should reject an invalid time")
+	}
+}
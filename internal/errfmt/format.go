@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"sort"
 	"strings"
 
 	"github.com/dedene/harvest-cli/internal/api"
@@ -23,7 +24,12 @@ func FormatError(err error) string {
 	msg := err.Error()
 
 	// Check for specific error types and add suggestions
+	var valErr *api.ValidationError
 	switch {
+	case errors.As(err, &valErr):
+		sb.WriteString(msg)
+		writeFields(&sb, valErr.Fields)
+
 	case IsAuthError(err):
 		sb.WriteString(msg)
 		sb.WriteString("\n\nSuggestion: Authentication failed. Try 'harvest auth login'")
@@ -59,6 +65,19 @@ func FormatError(err error) string {
 	return sb.String()
 }
 
+// writeFields lists validation messages one field per line, sorted by
+// field name.
+func writeFields(sb *strings.Builder, fields map[string]string) {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(sb, "\n  %s: %s", name, fields[name])
+	}
+}
+
 // IsAuthError returns true if the error is authentication-related.
 func IsAuthError(err error) bool {
 	if err == nil {
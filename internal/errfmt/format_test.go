@@ -97,6 +97,22 @@ func TestFormatError_NetworkError(t *testing.T) {
 	}
 }
 
+func TestFormatError_ValidationError(t *testing.T) {
+	err := fmt.Errorf("create user: %w", &api.ValidationError{Fields: map[string]string{
+		"last_name":  "can't be blank",
+		"email":      "has already been taken",
+		"first_name": "can't be blank",
+	}})
+	result := FormatError(err)
+	want := "create user: validation error: 3 field(s) invalid\n" +
+		"  email: has already been taken\n" +
+		"  first_name: can't be blank\n" +
+		"  last_name: can't be blank"
+	if result != want {
+		t.Errorf("FormatError() = %q, want %q", result, want)
+	}
+}
+
 func TestFormatError_GenericError(t *testing.T) {
 	err := errors.New("something went wrong")
 	result := FormatError(err)
@@ -1,6 +1,7 @@
 package output
 
 import (
+	"io"
 	"os"
 
 	"github.com/muesli/termenv"
@@ -13,19 +14,44 @@ type Colors struct {
 	enabled bool
 }
 
+// defaultColors styles tables created by NewTable; nil disables styling.
+var defaultColors *Colors
+
+// SetDefaultColors configures the colors used for table output.
+func SetDefaultColors(c *Colors) {
+	defaultColors = c
+}
+
+// DefaultColors returns the colors configured for table output, or a
+// disabled instance when none were set.
+func DefaultColors() *Colors {
+	if defaultColors == nil {
+		return &Colors{output: termenv.NewOutput(io.Discard, termenv.WithProfile(termenv.Ascii))}
+	}
+	return defaultColors
+}
+
 // NewColors creates a Colors instance based on the mode setting.
 // mode: "auto" (detect), "always" (force), "never" (disable)
 func NewColors(mode string) *Colors {
-	enabled := IsColorEnabled(mode)
+	return NewColorsFor(os.Stdout, mode)
+}
 
-	var profile termenv.Profile
+// NewColorsFor creates a Colors instance for output written to w.
+// In "auto" mode colors are enabled only when w is a terminal.
+func NewColorsFor(w io.Writer, mode string) *Colors {
+	enabled := isColorEnabledFor(w, mode)
+
+	profile := termenv.Ascii
 	if enabled {
-		profile = termenv.NewOutput(os.Stdout).Profile
-	} else {
-		profile = termenv.Ascii
+		profile = termenv.NewOutput(w).Profile
+		if profile == termenv.Ascii {
+			// Forced colors on a non-terminal still emit basic ANSI
+			profile = termenv.ANSI
+		}
 	}
 
-	output := termenv.NewOutput(os.Stdout, termenv.WithProfile(profile))
+	output := termenv.NewOutput(w, termenv.WithProfile(profile))
 
 	return &Colors{
 		output:  output,
@@ -33,16 +59,40 @@ func NewColors(mode string) *Colors {
 	}
 }
 
+// IsTerminal reports whether w is a terminal.
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// TerminalWidth returns the width of w in columns, or 0 if w is not a
+// terminal.
+func TerminalWidth(w io.Writer) int {
+	f, ok := w.(*os.File)
+	if !ok || !term.IsTerminal(int(f.Fd())) {
+		return 0
+	}
+	width, _, err := term.GetSize(int(f.Fd()))
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
 // IsColorEnabled determines if color output should be enabled.
 func IsColorEnabled(mode string) bool {
+	return isColorEnabledFor(os.Stdout, mode)
+}
+
+func isColorEnabledFor(w io.Writer, mode string) bool {
 	switch mode {
 	case "always":
 		return true
 	case "never":
 		return false
 	default: // "auto"
-		// Check if stdout is a terminal
-		if !term.IsTerminal(int(os.Stdout.Fd())) {
+		// Check if output is a terminal
+		if !IsTerminal(w) {
 			return false
 		}
 		// Check NO_COLOR env var
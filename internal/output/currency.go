@@ -0,0 +1,126 @@
+package output
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Harvest company settings for currency display.
+const (
+	SymbolBefore = "symbol_before"
+	SymbolAfter  = "symbol_after"
+	CodeBefore   = "iso_code_before"
+	CodeAfter    = "iso_code_after"
+)
+
+// currencySymbols maps ISO 4217 codes to their display symbols.
+var currencySymbols = map[string]string{
+	"AUD": "A$",
+	"BRL": "R$",
+	"CAD": "C$",
+	"CNY": "¥",
+	"EUR": "€",
+	"GBP": "£",
+	"INR": "₹",
+	"JPY": "¥",
+	"KRW": "₩",
+	"MXN": "MX$",
+	"NZD": "NZ$",
+	"PLN": "zł",
+	"USD": "$",
+	"ZAR": "R",
+}
+
+// CurrencyFormat describes how monetary amounts are rendered, mirroring the
+// currency settings of a Harvest account.
+type CurrencyFormat struct {
+	SymbolDisplay      string // symbol_before, symbol_after, or none
+	CodeDisplay        string // iso_code_before, iso_code_after, or none
+	DecimalSymbol      string
+	ThousandsSeparator string
+}
+
+// Format renders amount in currency, e.g. "$1,234.56" or "1.234,56 €".
+func (f CurrencyFormat) Format(amount float64, currency string) string {
+	// Round to cents first so amounts that display as zero, and negative
+	// zero itself, carry no minus sign
+	amount = math.Round(amount*100) / 100
+	if amount == 0 {
+		amount = 0
+	}
+	s := FormatNumber(math.Abs(amount), f.DecimalSymbol, f.ThousandsSeparator)
+
+	if currency != "" {
+		symbol := CurrencySymbol(currency)
+		sep := ""
+		if isAlphabetic(symbol) {
+			sep = " "
+		}
+
+		switch f.SymbolDisplay {
+		case SymbolBefore:
+			s = symbol + sep + s
+		case SymbolAfter:
+			s = s + " " + symbol
+		}
+
+		switch f.CodeDisplay {
+		case CodeBefore:
+			s = currency + " " + s
+		case CodeAfter:
+			s = s + " " + currency
+		}
+	}
+
+	if amount < 0 && s != "" {
+		s = "-" + s
+	}
+	return s
+}
+
+// FormatNumber renders amount with two decimals, the given decimal symbol and
+// a thousands separator between groups of three digits. An empty decimal
+// symbol defaults to ".".
+func FormatNumber(amount float64, decimal, thousands string) string {
+	if decimal == "" {
+		decimal = "."
+	}
+
+	negative := amount < 0
+	raw := strconv.FormatFloat(math.Abs(amount), 'f', 2, 64)
+	intPart, fracPart, _ := strings.Cut(raw, ".")
+
+	var b strings.Builder
+	if negative && raw != "0.00" {
+		b.WriteByte('-')
+	}
+	for i, digit := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteString(thousands)
+		}
+		b.WriteRune(digit)
+	}
+	b.WriteString(decimal)
+	b.WriteString(fracPart)
+	return b.String()
+}
+
+// CurrencySymbol returns the display symbol for an ISO currency code, or the
+// code itself when no symbol is known.
+func CurrencySymbol(code string) string {
+	if symbol, ok := currencySymbols[strings.ToUpper(code)]; ok {
+		return symbol
+	}
+	return code
+}
+
+func isAlphabetic(s string) bool {
+	for _, r := range s {
+		if !unicode.IsLetter(r) {
+			return false
+		}
+	}
+	return s != ""
+}
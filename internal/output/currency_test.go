@@ -0,0 +1,67 @@
+package output
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFormatNumber(t *testing.T) {
+	tests := []struct {
+		name      string
+		amount    float64
+		decimal   string
+		thousands string
+		want      string
+	}{
+		{"small", 5, ".", ",", "5.00"},
+		{"hundreds", 999.999, ".", ",", "1,000.00"},
+		{"thousands", 1234.56, ".", ",", "1,234.56"},
+		{"millions", 1234567.891, ".", ",", "1,234,567.89"},
+		{"european", 1234.56, ",", ".", "1.234,56"},
+		{"space separator", 1234567, ",", " ", "1 234 567,00"},
+		{"no separator", 1234.5, ".", "", "1234.50"},
+		{"default decimal", 12.3, "", ",", "12.30"},
+		{"negative", -1234.5, ".", ",", "-1,234.50"},
+		{"negative zero", -0.001, ".", ",", "0.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatNumber(tt.amount, tt.decimal, tt.thousands); got != tt.want {
+				t.Errorf("FormatNumber(%v) = %q, want %q", tt.amount, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCurrencyFormat_Format(t *testing.T) {
+	us := CurrencyFormat{SymbolDisplay: SymbolBefore, DecimalSymbol: ".", ThousandsSeparator: ","}
+	eu := CurrencyFormat{SymbolDisplay: SymbolAfter, DecimalSymbol: ",", ThousandsSeparator: "."}
+
+	tests := []struct {
+		name     string
+		format   CurrencyFormat
+		amount   float64
+		currency string
+		want     string
+	}{
+		{"us dollars", us, 1234.56, "USD", "$1,234.56"},
+		{"us negative", us, -50, "USD", "-$50.00"},
+		{"european euros", eu, 1234.56, "EUR", "1.234,56 €"},
+		{"unknown code before", us, 10, "CHF", "CHF 10.00"},
+		{"iso code after", CurrencyFormat{CodeDisplay: CodeAfter, DecimalSymbol: "."}, 10, "USD", "10.00 USD"},
+		{"symbol and code", CurrencyFormat{SymbolDisplay: SymbolBefore, CodeDisplay: CodeBefore}, 10, "GBP", "GBP £10.00"},
+		{"no currency", us, 10, "", "10.00"},
+		{"small negative", us, -0.004, "USD", "$0.00"},
+		{"negative zero", eu, math.Copysign(0, -1), "EUR", "0,00 €"},
+		{"rounds to a cent", us, -0.005, "USD", "-$0.01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.format.Format(tt.amount, tt.currency); got != tt.want {
+				t.Errorf("Format(%v, %q) = %q, want %q", tt.amount, tt.currency, got, tt.want)
+			}
+		})
+	}
+}
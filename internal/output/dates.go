@@ -0,0 +1,109 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// dateLayout returns the Go layout tables show dates in. Empty keeps ISO
+// dates; nil means no layout was set.
+var dateLayout func() string
+
+// SetDateLayout makes tables show the dates in their date columns in
+// layout, a Go time layout. Empty restores ISO dates. Plain and JSON output
+// always stay ISO.
+func SetDateLayout(layout string) {
+	dateLayout = func() string { return layout }
+}
+
+// SetDateLayoutFunc is SetDateLayout for a layout that is expensive to
+// look up, such as the account's date format. resolve is called once, the
+// first time a date is shown, so output without dates never pays for it.
+func SetDateLayoutFunc(resolve func() string) {
+	dateLayout = sync.OnceValue(resolve)
+}
+
+// FormatDate reformats an ISO date for display in the layout set with
+// SetDateLayout. Anything that is not a YYYY-MM-DD date is returned as is.
+func FormatDate(s string) string {
+	if dateLayout == nil || len(s) != len("2006-01-02") {
+		return s
+	}
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return s
+	}
+	layout := dateLayout()
+	if layout == "" {
+		return s
+	}
+	return t.Format(layout)
+}
+
+// dateDirectives maps strftime directives, which Harvest uses for the
+// company date_format, to Go layout elements.
+var dateDirectives = map[string]string{
+	"%Y": "2006",
+	"%y": "06",
+	"%m": "01",
+	"%d": "02",
+	"%b": "Jan",
+	"%B": "January",
+}
+
+// dateTokens maps letter tokens to Go layout elements, longest first so
+// YYYY wins over YY and MMM over MM.
+var dateTokens = []struct{ token, layout string }{
+	{"YYYY", "2006"},
+	{"MMM", "Jan"},
+	{"YY", "06"},
+	{"MM", "01"},
+	{"DD", "02"},
+}
+
+// DateLayout converts a date pattern to a Go time layout. It accepts
+// Harvest's date_format values, which use strftime directives (%m/%d/%Y),
+// and the same written with letters (MM/DD/YYYY). Fields may be separated
+// by '/', '-', '.', ',' or spaces.
+func DateLayout(pattern string) (string, error) {
+	var b strings.Builder
+	fields := 0
+	for rest := pattern; rest != ""; {
+		if strings.HasPrefix(rest, "%") && len(rest) >= 2 {
+			layout, ok := dateDirectives[rest[:2]]
+			if !ok {
+				return "", fmt.Errorf("unsupported date format %q: unknown directive %s", pattern, rest[:2])
+			}
+			b.WriteString(layout)
+			rest = rest[2:]
+			fields++
+			continue
+		}
+
+		matched := false
+		for _, tok := range dateTokens {
+			if strings.HasPrefix(rest, tok.token) {
+				b.WriteString(tok.layout)
+				rest = rest[len(tok.token):]
+				fields++
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+
+		if !strings.ContainsRune("/-., ", rune(rest[0])) {
+			return "", fmt.Errorf("unsupported date format %q: use YYYY, YY, MM, MMM and DD, or %%Y, %%m and %%d", pattern)
+		}
+		b.WriteByte(rest[0])
+		rest = rest[1:]
+	}
+	if fields == 0 {
+		return "", fmt.Errorf("unsupported date format %q: no date fields", pattern)
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,114 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDateLayout(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    string
+		wantErr bool
+	}{
+		{pattern: "%Y-%m-%d", want: "2006-01-02"},
+		{pattern: "%m/%d/%Y", want: "01/02/2006"},
+		{pattern: "%d/%m/%Y", want: "02/01/2006"},
+		{pattern: "%d.%m.%Y", want: "02.01.2006"},
+		{pattern: "%d %b %Y", want: "02 Jan 2006"},
+		{pattern: "%B %d, %Y", want: "January 02, 2006"},
+		{pattern: "%d/%m/%y", want: "02/01/06"},
+		{pattern: "DD/MM/YYYY", want: "02/01/2006"},
+		{pattern: "YYYY.MM.DD", want: "2006.01.02"},
+		{pattern: "DD MMM YY", want: "02 Jan 06"},
+		{pattern: "%H:%M", wantErr: true},
+		{pattern: "DD/MM/YYYY hh", wantErr: true},
+		{pattern: "--", wantErr: true},
+		{pattern: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			got, err := DateLayout(tt.pattern)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("DateLayout(%q) = %q, want error", tt.pattern, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DateLayout(%q) error = %v", tt.pattern, err)
+			}
+			if got != tt.want {
+				t.Errorf("DateLayout(%q) = %q, want %q", tt.pattern, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatDate(t *testing.T) {
+	defer SetDateLayout("")
+
+	if got := FormatDate("2026-03-09"); got != "2026-03-09" {
+		t.Errorf("FormatDate() without layout = %q, want ISO", got)
+	}
+
+	SetDateLayout("02/01/2006")
+	tests := map[string]string{
+		"2026-03-09": "09/03/2026",
+		"2026-13-09": "2026-13-09",
+		"Website":    "Website",
+		"":           "",
+		"10.00":      "10.00",
+	}
+	for in, want := range tests {
+		if got := FormatDate(in); got != want {
+			t.Errorf("FormatDate(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestTable_DateLayout(t *testing.T) {
+	SetMaxWidth(0)
+	SetDateLayout("Jan 02, 2006")
+	defer SetDateLayout("")
+
+	var buf bytes.Buffer
+	tbl := NewTable(&buf, "Date", "Notes").SetDateColumns(0)
+	tbl.AddRow("2026-03-09", "2026-03-10")
+	if err := tbl.Render(); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "Mar 09, 2026  2026-03-10") {
+		t.Errorf("table = %q, want only the date column formatted", buf.String())
+	}
+}
+
+func TestSetDateLayoutFunc(t *testing.T) {
+	SetMaxWidth(0)
+	defer SetDateLayout("")
+
+	calls := 0
+	SetDateLayoutFunc(func() string {
+		calls++
+		return "02.01.2006"
+	})
+
+	var buf bytes.Buffer
+	tbl := NewTable(&buf, "Name", "Notes")
+	tbl.AddRow("Website", "2026-03-10")
+	if err := tbl.Render(); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("layout resolved %d times for a table without dates, want 0", calls)
+	}
+
+	if got := FormatDate("2026-03-09") + FormatDate("2026-03-10"); got != "09.03.202610.03.2026" {
+		t.Errorf("FormatDate() = %q", got)
+	}
+	if calls != 1 {
+		t.Errorf("layout resolved %d times, want once", calls)
+	}
+}
@@ -0,0 +1,170 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// jsonFields limits JSON output to these keys when set.
+var jsonFields []string
+
+// SetJSONFields configures WriteJSON and WriteNDJSON to keep only the given
+// keys of each object. Dot paths select nested keys, e.g. "project.name".
+func SetJSONFields(fields []string) {
+	jsonFields = fields
+}
+
+// ProjectFields re-shapes v to the given fields: v itself when it marshals to
+// an object, or each element when it marshals to an array of objects. Kept
+// keys appear in the order given, nested paths stay nested, and objects
+// missing a path get null there. A path found in none of the objects is an
+// error.
+func ProjectFields(v any, fields []string) (any, error) {
+	paths := make([][]string, len(fields))
+	for i, f := range fields {
+		paths[i] = strings.Split(f, ".")
+		for _, seg := range paths[i] {
+			if seg == "" {
+				return nil, fmt.Errorf("invalid field %q", f)
+			}
+		}
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	var objects []map[string]any
+	switch d := doc.(type) {
+	case map[string]any:
+		objects = []map[string]any{d}
+	case []any:
+		objects = make([]map[string]any, len(d))
+		for i, el := range d {
+			obj, ok := el.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("--fields only applies to objects")
+			}
+			objects[i] = obj
+		}
+	case nil:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("--fields only applies to objects")
+	}
+
+	found := make([]bool, len(paths))
+	projected := make([]*orderedObject, len(objects))
+	for i, obj := range objects {
+		out := &orderedObject{}
+		for j, path := range paths {
+			val, ok := lookupPath(obj, path)
+			found[j] = found[j] || ok
+			out.setPath(path, val)
+		}
+		projected[i] = out
+	}
+	if len(objects) > 0 {
+		for j, ok := range found {
+			if !ok {
+				return nil, fmt.Errorf("unknown field %q (available: %s)", fields[j], strings.Join(objectKeys(objects[0]), ", "))
+			}
+		}
+	}
+
+	if _, ok := doc.(map[string]any); ok {
+		return projected[0], nil
+	}
+	return projected, nil
+}
+
+// lookupPath follows path through nested objects.
+func lookupPath(obj map[string]any, path []string) (any, bool) {
+	var cur any = obj
+	for _, key := range path {
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if cur, ok = m[key]; !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// objectKeys returns the keys of obj in sorted order.
+func objectKeys(obj map[string]any) []string {
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// orderedObject is a JSON object that marshals its keys in insertion order.
+type orderedObject struct {
+	keys []string
+	vals map[string]any
+}
+
+// setPath stores val under path, creating nested objects as needed.
+func (o *orderedObject) setPath(path []string, val any) {
+	if o.vals == nil {
+		o.vals = make(map[string]any)
+	}
+	key := path[0]
+	if len(path) == 1 {
+		if _, ok := o.vals[key]; !ok {
+			o.keys = append(o.keys, key)
+		}
+		o.vals[key] = val
+		return
+	}
+
+	child, ok := o.vals[key].(*orderedObject)
+	if !ok {
+		if _, exists := o.vals[key]; !exists {
+			o.keys = append(o.keys, key)
+		}
+		child = &orderedObject{}
+		o.vals[key] = child
+	}
+	child.setPath(path[1:], val)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (o *orderedObject) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+
+	b.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		if err := enc.Encode(key); err != nil {
+			return nil, err
+		}
+		b.Truncate(b.Len() - 1) // Encode appends a newline
+		b.WriteByte(':')
+		if err := enc.Encode(o.vals[key]); err != nil {
+			return nil, err
+		}
+		b.Truncate(b.Len() - 1)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
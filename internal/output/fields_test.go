@@ -0,0 +1,89 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+type fieldsProject struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type fieldsEntry struct {
+	ID      int64          `json:"id"`
+	Hours   float64        `json:"hours"`
+	Notes   string         `json:"notes,omitempty"`
+	Project *fieldsProject `json:"project"`
+}
+
+func TestWriteJSON_Fields(t *testing.T) {
+	SetCompactJSON(true)
+	defer SetCompactJSON(false)
+	SetJSONFields([]string{"project.name", "id", "notes"})
+	defer SetJSONFields(nil)
+
+	entries := []fieldsEntry{
+		{ID: 1, Hours: 1.5, Notes: "a <b>", Project: &fieldsProject{ID: 7, Name: "Site"}},
+		{ID: 2, Hours: 2},
+	}
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, entries); err != nil {
+		t.Fatalf("WriteJSON error: %v", err)
+	}
+
+	want := `[{"project":{"name":"Site"},"id":1,"notes":"a <b>"},{"project":{"name":null},"id":2,"notes":null}]` + "\n"
+	if buf.String() != want {
+		t.Errorf("output = %s, want %s", buf.String(), want)
+	}
+}
+
+func TestWriteJSON_FieldsSingleObject(t *testing.T) {
+	SetJSONFields([]string{"hours"})
+	defer SetJSONFields(nil)
+
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, fieldsEntry{ID: 1, Hours: 1.25}); err != nil {
+		t.Fatalf("WriteJSON error: %v", err)
+	}
+	if want := "{\n  \"hours\": 1.25\n}\n"; buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestWriteNDJSON_Fields(t *testing.T) {
+	SetJSONFields([]string{"id"})
+	defer SetJSONFields(nil)
+
+	var buf bytes.Buffer
+	if err := WriteNDJSON(&buf, []fieldsEntry{{ID: 1}, {ID: 2}}); err != nil {
+		t.Fatalf("WriteNDJSON error: %v", err)
+	}
+	if want := "{\"id\":1}\n{\"id\":2}\n"; buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestProjectFields_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		v      any
+		fields []string
+		want   string
+	}{
+		{"unknown", []fieldsEntry{{ID: 1}}, []string{"id", "bogus"}, `unknown field "bogus" (available: hours, id, project)`},
+		{"unknown nested", []fieldsEntry{{Project: &fieldsProject{}}}, []string{"project.code"}, `unknown field "project.code"`},
+		{"empty segment", fieldsEntry{}, []string{"project..name"}, `invalid field "project..name"`},
+		{"not objects", []string{"a"}, []string{"id"}, "only applies to objects"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ProjectFields(tt.v, tt.fields)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ProjectFields() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package output
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// FileFormats lists the extensions WriteFile understands.
+var FileFormats = []string{".csv", ".xlsx"}
+
+// CheckFileFormat returns an error unless path has an extension WriteFile
+// can write.
+func CheckFileFormat(path string) error {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, f := range FileFormats {
+		if ext == f {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported output file %q; use a %s extension", path, strings.Join(FileFormats, " or "))
+}
+
+// WriteFile writes headers and rows to path as CSV or XLSX, chosen by the
+// file extension.
+func WriteFile(path string, headers []string, rows [][]string) (err error) {
+	if err := CheckFileFormat(path); err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", path, err)
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil && cerr != nil {
+			err = fmt.Errorf("close %s: %w", path, cerr)
+		}
+	}()
+
+	if strings.EqualFold(filepath.Ext(path), ".xlsx") {
+		return WriteXLSX(f, headers, rows)
+	}
+	return WriteCSV(f, headers, rows)
+}
+
+// WriteCSV writes rows as comma-separated values with a header row.
+func WriteCSV(w io.Writer, headers []string, rows [][]string) error {
+	cw := csv.NewWriter(w)
+	if len(headers) > 0 {
+		if err := cw.Write(headers); err != nil {
+			return err
+		}
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// numericCell matches plain decimal numbers, which XLSX stores as numbers so
+// spreadsheets can sum them. Values with a leading zero, such as invoice
+// numbers like "0042", and anything else, including dates, stay text.
+var numericCell = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?$`)
+
+// maxNumericDigits is the precision of a spreadsheet number; longer values
+// would be rounded, so they stay text.
+const maxNumericDigits = 15
+
+// isNumericCell reports whether cell is written as an XLSX number.
+func isNumericCell(cell string) bool {
+	if !numericCell.MatchString(cell) {
+		return false
+	}
+	digits := strings.TrimPrefix(strings.Replace(cell, ".", "", 1), "-")
+	return len(digits) <= maxNumericDigits
+}
+
+// WriteXLSX writes rows as a single-sheet Excel workbook with a header row.
+// Text cells go through a shared string table, as spreadsheet applications
+// write them, so repeated values such as client names are stored once.
+func WriteXLSX(w io.Writer, headers []string, rows [][]string) error {
+	all := rows
+	if len(headers) > 0 {
+		all = append([][]string{headers}, rows...)
+	}
+	strs := newSharedStrings()
+	sheet := xlsxSheet(all, len(headers) > 0, strs)
+	shared, err := strs.part()
+	if err != nil {
+		return err
+	}
+
+	zw := zip.NewWriter(w)
+	parts := []struct{ name, body string }{
+		{"[Content_Types].xml", xml.Header + xlsxContentTypes},
+		{"_rels/.rels", xml.Header + xlsxRootRels},
+		{"xl/workbook.xml", xml.Header + xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xml.Header + xlsxWorkbookRels},
+		{"xl/sharedStrings.xml", shared},
+		{"xl/worksheets/sheet1.xml", sheet},
+	}
+	for _, p := range parts {
+		fw, err := zw.Create(p.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(fw, p.body); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// xlsxSheet returns the worksheet XML, adding text cells to strs. With
+// header set, the first row is always text.
+func xlsxSheet(rows [][]string, header bool, strs *sharedStrings) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	for i, row := range rows {
+		r := i + 1
+		fmt.Fprintf(&b, `<row r="%d">`, r)
+		for j, cell := range row {
+			ref := xlsxColumn(j) + strconv.Itoa(r)
+			if (i > 0 || !header) && isNumericCell(cell) {
+				fmt.Fprintf(&b, `<c r="%s"><v>%s</v></c>`, ref, cell)
+				continue
+			}
+			fmt.Fprintf(&b, `<c r="%s" t="s"><v>%d</v></c>`, ref, strs.index(cell))
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData></worksheet>`)
+	return b.String()
+}
+
+// sharedStrings is the workbook's table of distinct text cell values.
+type sharedStrings struct {
+	values []string
+	ids    map[string]int
+	count  int // cells referencing the table, including repeats
+}
+
+func newSharedStrings() *sharedStrings {
+	return &sharedStrings{ids: map[string]int{}}
+}
+
+// index returns the table index of s, adding it on first use.
+func (t *sharedStrings) index(s string) int {
+	t.count++
+	if id, ok := t.ids[s]; ok {
+		return id
+	}
+	id := len(t.values)
+	t.ids[s] = id
+	t.values = append(t.values, s)
+	return id
+}
+
+// part returns the shared string table part. xml.EscapeText replaces
+// characters XML cannot hold, such as control characters, with U+FFFD.
+func (t *sharedStrings) part() (string, error) {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	fmt.Fprintf(&b, `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" count="%d" uniqueCount="%d">`,
+		t.count, len(t.values))
+	for _, v := range t.values {
+		b.WriteString(`<si><t xml:space="preserve">`)
+		if err := xml.EscapeText(&b, []byte(v)); err != nil {
+			return "", err
+		}
+		b.WriteString(`</t></si>`)
+	}
+	b.WriteString(`</sst>`)
+	return b.String(), nil
+}
+
+// xlsxColumn returns the spreadsheet column name for a zero-based index:
+// A, B, ..., Z, AA, AB, ...
+func xlsxColumn(i int) string {
+	var name []byte
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = append([]byte{byte('A' + (i-1)%26)}, name...)
+	}
+	return string(name)
+}
+
+const xlsxContentTypes = `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+	`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+	`<Override PartName="/xl/sharedStrings.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sharedStrings+xml"/>` +
+	`</Types>`
+
+const xlsxRootRels = `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+const xlsxWorkbook = `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+	`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+	`<sheets><sheet name="Report" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+const xlsxWorkbookRels = `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+	`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/sharedStrings" Target="sharedStrings.xml"/>` +
+	`</Relationships>`
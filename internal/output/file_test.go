@@ -0,0 +1,218 @@
+package output
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	rows := [][]string{{"1", "Website, phase 2", "2.50"}, {"2", "Say \"hi\"", "1.00"}}
+	if err := WriteCSV(&buf, []string{"ID", "Project", "Hours"}, rows); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	want := "ID,Project,Hours\n1,\"Website, phase 2\",2.50\n2,\"Say \"\"hi\"\"\",1.00\n"
+	if buf.String() != want {
+		t.Errorf("WriteCSV() = %q, want %q", buf.String(), want)
+	}
+}
+
+// readXLSXPart returns the named part of an XLSX archive.
+func readXLSXPart(t *testing.T, data []byte, name string) string {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("not a zip archive: %v", err)
+	}
+	for _, f := range zr.File {
+		if f.Name != name {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rc.Close()
+		part, err := io.ReadAll(rc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(part)
+	}
+	t.Fatalf("archive has no %s", name)
+	return ""
+}
+
+func TestWriteXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	rows := [][]string{
+		{"2024-01-02", "R&D <internal>", "3.25"},
+		{"2024-01-03", "R&D <internal>", "-1"},
+	}
+	if err := WriteXLSX(&buf, []string{"Date", "Project", "Hours"}, rows); err != nil {
+		t.Fatalf("WriteXLSX() error = %v", err)
+	}
+
+	sheet := readXLSXPart(t, buf.Bytes(), "xl/worksheets/sheet1.xml")
+	for _, want := range []string{
+		`<c r="A1" t="s"><v>0</v></c>`,
+		`<c r="A2" t="s"><v>3</v></c>`,
+		`<c r="B2" t="s"><v>4</v></c>`,
+		`<c r="C2"><v>3.25</v></c>`,
+		`<c r="B3" t="s"><v>4</v></c>`,
+		`<c r="C3"><v>-1</v></c>`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("sheet missing %q, got: %s", want, sheet)
+		}
+	}
+
+	shared := readXLSXPart(t, buf.Bytes(), "xl/sharedStrings.xml")
+	for _, want := range []string{
+		`count="7" uniqueCount="6"`,
+		`<si><t xml:space="preserve">Date</t></si>`,
+		`<si><t xml:space="preserve">R&amp;D &lt;internal&gt;</t></si>`,
+	} {
+		if !strings.Contains(shared, want) {
+			t.Errorf("shared strings missing %q, got: %s", want, shared)
+		}
+	}
+
+	types := readXLSXPart(t, buf.Bytes(), "[Content_Types].xml")
+	rels := readXLSXPart(t, buf.Bytes(), "xl/_rels/workbook.xml.rels")
+	if !strings.Contains(types, "/xl/sharedStrings.xml") || !strings.Contains(rels, `Target="sharedStrings.xml"`) {
+		t.Errorf("shared strings part not registered:\n%s\n%s", types, rels)
+	}
+}
+
+func TestWriteXLSX_Escaping(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteXLSX(&buf, nil, [][]string{{"a \"quoted\" 'b'", "bell\x07", "  padded  "}}); err != nil {
+		t.Fatalf("WriteXLSX() error = %v", err)
+	}
+	shared := readXLSXPart(t, buf.Bytes(), "xl/sharedStrings.xml")
+	for _, want := range []string{
+		`a &#34;quoted&#34; &#39;b&#39;`,
+		"bell\uFFFD",
+		`<t xml:space="preserve">  padded  </t>`,
+	} {
+		if !strings.Contains(shared, want) {
+			t.Errorf("shared strings missing %q, got: %s", want, shared)
+		}
+	}
+	if err := xml.Unmarshal([]byte(shared), new(struct{})); err != nil {
+		t.Errorf("shared strings are not well-formed XML: %v", err)
+	}
+}
+
+func TestIsNumericCell(t *testing.T) {
+	tests := map[string]bool{
+		"0":                 true,
+		"3.25":              true,
+		"-12.5":             true,
+		"1250":              true,
+		"0.5":               true,
+		"0042":              false,
+		"2024-01-02":        false,
+		"1,250.00":          false,
+		"1e5":               false,
+		".5":                false,
+		"5.":                false,
+		"":                  false,
+		"123456789012345":   true,
+		"1234567890123456":  false,
+		"-1234567890.12345": true,
+	}
+	for cell, want := range tests {
+		if got := isNumericCell(cell); got != want {
+			t.Errorf("isNumericCell(%q) = %t, want %t", cell, got, want)
+		}
+	}
+}
+
+func TestXLSXColumn(t *testing.T) {
+	tests := map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"}
+	for i, want := range tests {
+		if got := xlsxColumn(i); got != want {
+			t.Errorf("xlsxColumn(%d) = %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "report.CSV")
+	if err := WriteFile(path, []string{"A"}, [][]string{{"1"}}); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "A\n1\n" {
+		t.Errorf("file = %q", data)
+	}
+
+	if err := WriteFile(filepath.Join(dir, "report.txt"), nil, nil); err == nil || !strings.Contains(err.Error(), ".csv or .xlsx") {
+		t.Errorf("WriteFile(.txt) error = %v, want unsupported format", err)
+	}
+}
+
+// TestWriteFile_XLSXOpens reads a written workbook back with a spreadsheet
+// library, as a spreadsheet application would open it.
+func TestWriteFile_XLSXOpens(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.xlsx")
+	headers := []string{"Date", "Invoice", "Project", "Amount"}
+	rows := [][]string{
+		{"2024-01-02", "0042", "R&D <internal>", "1250.25"},
+		{"2024-01-03", "0043", "  padded  ", "-3"},
+		{"2024-01-04", "", "Say \"hi\"", "1234567890123456"},
+	}
+	if err := WriteFile(path, headers, rows); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile() error = %v", err)
+	}
+	defer f.Close()
+
+	if sheets := f.GetSheetList(); len(sheets) != 1 || sheets[0] != "Report" {
+		t.Fatalf("sheets = %v, want [Report]", sheets)
+	}
+	got, err := f.GetRows("Report")
+	if err != nil {
+		t.Fatalf("GetRows() error = %v", err)
+	}
+	want := append([][]string{headers}, rows...)
+	if len(got) != len(want) {
+		t.Fatalf("read %d rows, want %d: %q", len(got), len(want), got)
+	}
+	for i := range want {
+		if strings.Join(got[i], "|") != strings.Join(want[i], "|") {
+			t.Errorf("row %d = %q, want %q", i+1, got[i], want[i])
+		}
+	}
+
+	for cell, wantNumber := range map[string]bool{
+		"D2": true,  // amount
+		"D3": true,  // negative amount
+		"B2": false, // invoice number with a leading zero
+		"A2": false, // date
+		"D4": false, // too many digits for a spreadsheet number
+	} {
+		typ, err := f.GetCellType("Report", cell)
+		if err != nil {
+			t.Fatalf("GetCellType(%s) error = %v", cell, err)
+		}
+		if isNumber := typ != excelize.CellTypeSharedString; isNumber != wantNumber {
+			t.Errorf("cell %s type = %v, want number %t", cell, typ, wantNumber)
+		}
+	}
+}
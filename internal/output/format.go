@@ -19,6 +19,12 @@ const (
 	ModeJSON
 	// ModePlain outputs tab-separated values.
 	ModePlain
+	// ModeMarkdown outputs GitHub-flavored markdown tables.
+	ModeMarkdown
+	// ModeCSV outputs comma-separated values. It is only a default mode:
+	// ModeFromFlags turns it into ModePlain, whose output is then written
+	// as CSV (see SetPlainCSV).
+	ModeCSV
 )
 
 // String returns the string representation of the mode.
@@ -28,11 +34,29 @@ func (m Mode) String() string {
 		return "json"
 	case ModePlain:
 		return "plain"
+	case ModeMarkdown:
+		return "markdown"
+	case ModeCSV:
+		return "csv"
 	default:
 		return "table"
 	}
 }
 
+// ParseMode returns the mode named s, as written by String. Empty is
+// ModeTable.
+func ParseMode(s string) (Mode, error) {
+	for _, m := range []Mode{ModeTable, ModeJSON, ModePlain, ModeMarkdown, ModeCSV} {
+		if s == m.String() {
+			return m, nil
+		}
+	}
+	if s == "" {
+		return ModeTable, nil
+	}
+	return ModeTable, fmt.Errorf("unknown output format %q (use table, json, plain, csv or markdown)", s)
+}
+
 type contextKey string
 
 const modeKey contextKey = "output_mode"
@@ -51,28 +75,107 @@ func GetMode(ctx context.Context) Mode {
 }
 
 // ModeFromFlags returns the output mode based on command flags.
-// JSON takes precedence over plain.
+// JSON takes precedence over plain, and both over the default mode (see
+// SetDefaultMode). A ModeCSV default is returned as ModePlain, so commands
+// write CSV through their plain output.
 func ModeFromFlags(jsonFlag, plainFlag bool) Mode {
 	if jsonFlag {
 		return ModeJSON
 	}
-	if plainFlag {
+	if plainFlag || defaultMode == ModeCSV {
 		return ModePlain
 	}
-	return ModeTable
+	return defaultMode
+}
+
+// defaultMode is the mode used when no JSON or plain flag is given.
+var defaultMode = ModeTable
+
+// SetDefaultMode sets the mode ModeFromFlags returns when no JSON or plain
+// flag is given, e.g. from --markdown or a configured default output. With
+// ModeMarkdown, tables render as GitHub-flavored markdown, so commands that
+// fall back to a table for unknown modes pick it up without handling
+// ModeMarkdown themselves.
+func SetDefaultMode(mode Mode) {
+	defaultMode = mode
 }
 
-// WriteJSON writes v as indented JSON to w.
+// plainCSV makes WriteTSV and tables write comma-separated values.
+var plainCSV bool
+
+// SetPlainCSV configures whether plain output is written as CSV instead of
+// TSV, for --csv or a csv default output.
+func SetPlainCSV(csv bool) {
+	plainCSV = csv
+}
+
+// compactJSON disables indentation in WriteJSON when set.
+var compactJSON bool
+
+// SetCompactJSON configures whether WriteJSON emits single-line JSON.
+func SetCompactJSON(compact bool) {
+	compactJSON = compact
+}
+
+// WriteJSON writes v as JSON to w, indented unless compact JSON is enabled.
+// Objects are reduced to the keys set with SetJSONFields, if any.
 func WriteJSON(w io.Writer, v any) error {
+	if len(jsonFields) > 0 {
+		projected, err := ProjectFields(v, jsonFields)
+		if err != nil {
+			return err
+		}
+		v = projected
+	}
+	return WriteJSONUnfiltered(w, v)
+}
+
+// WriteJSONUnfiltered writes v like WriteJSON but ignores SetJSONFields, for
+// output whose shape callers rely on regardless of --fields, such as error
+// envelopes.
+func WriteJSONUnfiltered(w io.Writer, v any) error {
 	enc := json.NewEncoder(w)
-	enc.SetIndent("", "  ")
+	if !compactJSON {
+		enc.SetIndent("", "  ")
+	}
 	enc.SetEscapeHTML(false)
 	return enc.Encode(v)
 }
 
-// WriteTSV writes rows as tab-separated values.
-// If headers is non-empty, it's written as the first row.
+// WriteNDJSON writes items as newline-delimited JSON, one object per line.
+// Like WriteJSON, it honors SetJSONFields.
+func WriteNDJSON[T any](w io.Writer, items []T) error {
+	var lines []any
+	if len(jsonFields) > 0 && len(items) > 0 {
+		projected, err := ProjectFields(items, jsonFields)
+		if err != nil {
+			return err
+		}
+		for _, obj := range projected.([]*orderedObject) {
+			lines = append(lines, obj)
+		}
+	} else {
+		for _, item := range items {
+			lines = append(lines, item)
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	for _, item := range lines {
+		if err := enc.Encode(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteTSV writes rows as tab-separated values, or as CSV when enabled
+// with SetPlainCSV. If headers is non-empty, it's written as the first row.
 func WriteTSV(w io.Writer, headers []string, rows [][]string) error {
+	if plainCSV {
+		return WriteCSV(w, headers, rows)
+	}
 	if len(headers) > 0 {
 		if _, err := fmt.Fprintln(w, strings.Join(headers, "\t")); err != nil {
 			return err
@@ -86,6 +189,47 @@ func WriteTSV(w io.Writer, headers []string, rows [][]string) error {
 	return nil
 }
 
+// WriteMarkdown writes rows as a GitHub-flavored markdown table. Pipes in
+// cells are escaped and line breaks flattened so each row stays on one line.
+func WriteMarkdown(w io.Writer, headers []string, rows [][]string) error {
+	return writeMarkdown(w, headers, rows, nil)
+}
+
+// writeMarkdown writes a markdown table whose separator row marks the
+// right-aligned columns in aligns with "---:".
+func writeMarkdown(w io.Writer, headers []string, rows [][]string, aligns []Align) error {
+	if len(headers) == 0 {
+		// Markdown tables need a header row
+		width := 0
+		for _, row := range rows {
+			width = max(width, len(row))
+		}
+		headers = make([]string, width)
+	}
+
+	sep := make([]string, len(headers))
+	for i := range sep {
+		sep[i] = "---"
+		if i < len(aligns) && aligns[i] == AlignRight {
+			sep[i] = "---:"
+		}
+	}
+
+	lines := append([][]string{headers, sep}, rows...)
+	for _, line := range lines {
+		cells := make([]string, len(line))
+		for i, cell := range line {
+			cells[i] = markdownEscaper.Replace(cell)
+		}
+		if _, err := fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var markdownEscaper = strings.NewReplacer("|", "\\|", "\r\n", " ", "\n", " ")
+
 // Formatter provides a unified interface for outputting data.
 type Formatter struct {
 	Mode   Mode
@@ -16,6 +16,8 @@ func TestMode_String(t *testing.T) {
 		{ModeTable, "table"},
 		{ModeJSON, "json"},
 		{ModePlain, "plain"},
+		{ModeMarkdown, "markdown"},
+		{ModeCSV, "csv"},
 	}
 
 	for _, tt := range tests {
@@ -45,6 +47,48 @@ func TestModeFromFlags(t *testing.T) {
 	}
 }
 
+func TestModeFromFlags_Default(t *testing.T) {
+	for _, mode := range []Mode{ModeMarkdown, ModeJSON, ModePlain} {
+		SetDefaultMode(mode)
+		if got := ModeFromFlags(false, false); got != mode {
+			t.Errorf("ModeFromFlags(false, false) with default %v = %v", mode, got)
+		}
+	}
+	SetDefaultMode(ModeMarkdown)
+	defer SetDefaultMode(ModeTable)
+	if got := ModeFromFlags(false, true); got != ModePlain {
+		t.Errorf("ModeFromFlags(false, true) = %v, want plain", got)
+	}
+	if got := ModeFromFlags(true, false); got != ModeJSON {
+		t.Errorf("ModeFromFlags(true, false) = %v, want json", got)
+	}
+}
+
+func TestModeFromFlags_CSVDefault(t *testing.T) {
+	SetDefaultMode(ModeCSV)
+	defer SetDefaultMode(ModeTable)
+	if got := ModeFromFlags(false, false); got != ModePlain {
+		t.Errorf("ModeFromFlags(false, false) with a csv default = %v, want plain", got)
+	}
+	if got := ModeFromFlags(true, false); got != ModeJSON {
+		t.Errorf("ModeFromFlags(true, false) = %v, want json", got)
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	for _, mode := range []Mode{ModeTable, ModeJSON, ModePlain, ModeMarkdown, ModeCSV} {
+		if got, err := ParseMode(mode.String()); err != nil || got != mode {
+			t.Errorf("ParseMode(%q) = %v, %v", mode.String(), got, err)
+		}
+	}
+	if got, err := ParseMode(""); err != nil || got != ModeTable {
+		t.Errorf("ParseMode(\"\") = %v, %v; want table", got, err)
+	}
+	if _, err := ParseMode("xml"); err == nil {
+		t.Error("ParseMode(\"xml\") should fail")
+	}
+}
+
 func TestContextMode(t *testing.T) {
 	ctx := context.Background()
 
@@ -94,6 +138,58 @@ func TestWriteJSON(t *testing.T) {
 	}
 }
 
+func TestWriteJSON_Compact(t *testing.T) {
+	SetCompactJSON(true)
+	defer SetCompactJSON(false)
+
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, map[string]any{"name": "test", "count": 42}); err != nil {
+		t.Fatalf("WriteJSON error: %v", err)
+	}
+
+	if strings.Count(buf.String(), "\n") != 1 {
+		t.Errorf("Compact output should be a single line, got: %q", buf.String())
+	}
+}
+
+func TestWriteNDJSON(t *testing.T) {
+	var buf bytes.Buffer
+	items := []map[string]any{
+		{"id": 1},
+		{"id": 2},
+		{"id": 3},
+	}
+
+	if err := WriteNDJSON(&buf, items); err != nil {
+		t.Fatalf("WriteNDJSON error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3: %q", len(lines), buf.String())
+	}
+
+	for i, line := range lines {
+		var decoded map[string]any
+		if err := json.Unmarshal([]byte(line), &decoded); err != nil {
+			t.Fatalf("line %d is not valid JSON: %v", i, err)
+		}
+		if decoded["id"] != float64(i+1) {
+			t.Errorf("line %d id = %v, want %d", i, decoded["id"], i+1)
+		}
+	}
+}
+
+func TestWriteNDJSON_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteNDJSON(&buf, []string{}); err != nil {
+		t.Fatalf("WriteNDJSON error: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Empty input should produce no output, got: %q", buf.String())
+	}
+}
+
 func TestWriteTSV(t *testing.T) {
 	var buf bytes.Buffer
 	headers := []string{"Name", "Value"}
@@ -120,6 +216,30 @@ func TestWriteTSV(t *testing.T) {
 	}
 }
 
+func TestWriteTSV_PlainCSV(t *testing.T) {
+	SetPlainCSV(true)
+	defer SetPlainCSV(false)
+
+	var buf bytes.Buffer
+	if err := WriteTSV(&buf, []string{"ID", "Name"}, [][]string{{"1", "Acme, Inc."}}); err != nil {
+		t.Fatalf("WriteTSV() error = %v", err)
+	}
+	if want := "ID,Name\n1,\"Acme, Inc.\"\n"; buf.String() != want {
+		t.Errorf("WriteTSV() = %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	tbl := NewTable(&buf, "ID", "Name").SetDateColumns(1)
+	tbl.AddRow("2", "2024-01-02")
+	tbl.AddFooter("Total", "1")
+	if err := tbl.Render(); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if want := "ID,Name\n2,2024-01-02\nTotal,1\n"; buf.String() != want {
+		t.Errorf("Render() = %q, want %q", buf.String(), want)
+	}
+}
+
 func TestWriteTSV_NoHeaders(t *testing.T) {
 	var buf bytes.Buffer
 	rows := [][]string{
@@ -173,3 +293,36 @@ func TestFormatter_Output(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	headers := []string{"ID", "Notes"}
+	rows := [][]string{
+		{"1", "a|b"},
+		{"2", "line one\nline two"},
+	}
+
+	if err := WriteMarkdown(&buf, headers, rows); err != nil {
+		t.Fatalf("WriteMarkdown error: %v", err)
+	}
+
+	want := "| ID | Notes |\n" +
+		"| --- | --- |\n" +
+		"| 1 | a\\|b |\n" +
+		"| 2 | line one line two |\n"
+	if buf.String() != want {
+		t.Errorf("WriteMarkdown output:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestWriteMarkdown_NoHeaders(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteMarkdown(&buf, nil, [][]string{{"a", "b"}}); err != nil {
+		t.Fatalf("WriteMarkdown error: %v", err)
+	}
+
+	want := "|  |  |\n| --- | --- |\n| a | b |\n"
+	if buf.String() != want {
+		t.Errorf("WriteMarkdown output = %q, want %q", buf.String(), want)
+	}
+}
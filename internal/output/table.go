@@ -1,24 +1,63 @@
 package output
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"slices"
 	"strings"
 	"text/tabwriter"
+	"unicode/utf8"
+)
+
+// tablePadding is the number of spaces between columns.
+const tablePadding = 2
+
+// minColumnWidth is the narrowest a column is truncated to when fitting a
+// table to maxWidth, unless its header is wider.
+const minColumnWidth = 8
+
+// maxWidth is the width tables are fitted to; 0 disables truncation.
+var maxWidth int
+
+// SetMaxWidth configures the width tables shrink their widest columns to
+// fit, usually the terminal width. 0 renders full values.
+func SetMaxWidth(width int) {
+	maxWidth = width
+}
+
+// Align is the horizontal alignment of a table column.
+type Align int
+
+const (
+	// AlignLeft pads cells on the right. It is the default.
+	AlignLeft Align = iota
+	// AlignRight pads cells on the left, so numbers line up on their last
+	// digit.
+	AlignRight
 )
 
 // Table is a simple table renderer using tabwriter.
 type Table struct {
-	w       *tabwriter.Writer
-	headers []string
-	rows    [][]string
+	w        *tabwriter.Writer
+	out      io.Writer
+	colors   *Colors
+	headers  []string
+	rows     [][]string
+	styles   []func(string) string
+	aligns   []Align
+	dateCols []int
+	footers  [][]string
 }
 
 // NewTable creates a new table with the given headers.
+// Headers are bold when default colors are enabled.
 func NewTable(w io.Writer, headers ...string) *Table {
-	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	tw := tabwriter.NewWriter(w, 0, 0, tablePadding, ' ', 0)
 	return &Table{
 		w:       tw,
+		out:     w,
+		colors:  defaultColors,
 		headers: headers,
 		rows:    make([][]string, 0),
 	}
@@ -26,11 +65,100 @@ func NewTable(w io.Writer, headers ...string) *Table {
 
 // AddRow adds a row to the table.
 func (t *Table) AddRow(cells ...string) {
+	t.AddStyledRow(nil, cells...)
+}
+
+// AddStyledRow adds a row whose cells are rendered with style when colors
+// are enabled, e.g. Colors.Error for over-budget rows.
+func (t *Table) AddStyledRow(style func(string) string, cells ...string) {
 	t.rows = append(t.rows, cells)
+	t.styles = append(t.styles, style)
+}
+
+// AddFooter adds a totals row, rendered below the rows after a separator
+// so its values line up under their columns. Missing trailing cells are
+// left empty.
+func (t *Table) AddFooter(cells ...string) {
+	for len(cells) < len(t.headers) {
+		cells = append(cells, "")
+	}
+	t.footers = append(t.footers, cells)
+}
+
+// SetAlign sets the alignment of the columns at the given zero-based
+// indexes, including their headers, and returns the table.
+func (t *Table) SetAlign(align Align, cols ...int) *Table {
+	for _, col := range cols {
+		for len(t.aligns) <= col {
+			t.aligns = append(t.aligns, AlignLeft)
+		}
+		t.aligns[col] = align
+	}
+	return t
+}
+
+// SetDateColumns marks the columns at the given zero-based indexes as
+// holding ISO dates, which are shown in the layout set with SetDateLayout,
+// and returns the table. Other cells are never reformatted.
+func (t *Table) SetDateColumns(cols ...int) *Table {
+	t.dateCols = append(t.dateCols, cols...)
+	return t
+}
+
+// datedRows returns the rows with their date columns formatted, leaving
+// t.rows untouched.
+func (t *Table) datedRows() [][]string {
+	if len(t.dateCols) == 0 {
+		return t.rows
+	}
+	rows := make([][]string, len(t.rows))
+	for n, row := range t.rows {
+		rows[n] = slices.Clone(row)
+		for _, col := range t.dateCols {
+			if col < len(row) {
+				rows[n][col] = FormatDate(row[col])
+			}
+		}
+	}
+	return rows
+}
+
+// align returns the alignment of column i.
+func (t *Table) align(i int) Align {
+	if i < len(t.aligns) {
+		return t.aligns[i]
+	}
+	return AlignLeft
 }
 
 // Render writes the table to the underlying writer.
 func (t *Table) Render() error {
+	if c, ok := t.out.(*TableCapture); ok {
+		c.Tables = append(c.Tables, CapturedTable{
+			Headers:  t.headers,
+			Rows:     t.rows,
+			Styles:   t.styles,
+			Footers:  t.footers,
+			Aligns:   t.aligns,
+			DateCols: t.dateCols,
+		})
+		return nil
+	}
+
+	if plainCSV {
+		return WriteCSV(t.out, t.headers, append(slices.Clip(t.rows), t.footers...))
+	}
+	all := append(slices.Clip(t.datedRows()), t.footers...)
+	if defaultMode == ModeMarkdown {
+		return writeMarkdown(t.out, t.headers, all, t.aligns)
+	}
+
+	fitted := fitRows(t.headers, all, t.aligns, maxWidth)
+	rows, footers := fitted[:len(t.rows)], fitted[len(t.rows):]
+	if (t.colors != nil && t.colors.Enabled()) || slices.Contains(t.aligns, AlignRight) || len(footers) > 0 {
+		return t.renderAligned(rows, footers)
+	}
+
 	// Write headers
 	if len(t.headers) > 0 {
 		if _, err := fmt.Fprintln(t.w, strings.Join(t.headers, "\t")); err != nil {
@@ -47,7 +175,7 @@ func (t *Table) Render() error {
 	}
 
 	// Write rows
-	for _, row := range t.rows {
+	for _, row := range rows {
 		if _, err := fmt.Fprintln(t.w, strings.Join(row, "\t")); err != nil {
 			return err
 		}
@@ -56,6 +184,170 @@ func (t *Table) Render() error {
 	return t.w.Flush()
 }
 
+// renderAligned pads columns on the unstyled text and then applies styles,
+// since escape sequences would otherwise throw off tabwriter's widths. It
+// also handles right-aligned columns, which tabwriter cannot mix with
+// left-aligned ones, and footers, whose separator spans the column widths.
+func (t *Table) renderAligned(rows, footers [][]string) error {
+	var widths []int
+	measure := func(cells []string) {
+		for i, cell := range cells {
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+
+	lines := make([][]string, 0, len(rows)+2)
+	styles := make([]func(string) string, 0, len(rows)+2)
+	if len(t.headers) > 0 {
+		sep := make([]string, len(t.headers))
+		for i, h := range t.headers {
+			sep[i] = strings.Repeat("-", len(h))
+		}
+		var bold func(string) string
+		if t.colors != nil && t.colors.Enabled() {
+			bold = t.colors.Bold
+		}
+		lines = append(lines, t.headers, sep)
+		styles = append(styles, bold, nil)
+	}
+	lines = append(lines, rows...)
+	styles = append(styles, t.styles...)
+
+	for _, line := range append(lines, footers...) {
+		measure(line)
+	}
+	if len(footers) > 0 {
+		sep := make([]string, len(widths))
+		for i, w := range widths {
+			sep[i] = strings.Repeat("-", w)
+		}
+		lines = append(lines, sep)
+		lines = append(lines, footers...)
+		styles = append(styles, make([]func(string) string, len(footers)+1)...)
+	}
+
+	for n, line := range lines {
+		var b strings.Builder
+		for i, cell := range line {
+			styled := cell
+			if styles[n] != nil && cell != "" {
+				styled = styles[n](cell)
+			}
+			pad := widths[i] - utf8.RuneCountInString(cell)
+			if t.align(i) == AlignRight {
+				b.WriteString(strings.Repeat(" ", pad))
+				pad = 0
+			}
+			b.WriteString(styled)
+			if i < len(line)-1 {
+				b.WriteString(strings.Repeat(" ", pad+tablePadding))
+			}
+		}
+		if _, err := fmt.Fprintln(t.out, b.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fitRows truncates cells so the table fits within width columns, taking
+// from the widest columns first. Headers and right-aligned columns, which
+// hold numbers that would be misread when cut, are never truncated. With
+// width 0, or when the table already fits, rows are returned unchanged.
+func fitRows(headers []string, rows [][]string, aligns []Align, width int) [][]string {
+	if width <= 0 {
+		return rows
+	}
+
+	var widths []int
+	for _, line := range append([][]string{headers}, rows...) {
+		for i, cell := range line {
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+	total := tablePadding * (len(widths) - 1)
+	floors := make([]int, len(widths))
+	for i, w := range widths {
+		total += w
+		floors[i] = minColumnWidth
+		if i < len(headers) {
+			floors[i] = max(floors[i], utf8.RuneCountInString(headers[i]))
+		}
+		if i < len(aligns) && aligns[i] == AlignRight {
+			floors[i] = w
+		}
+	}
+	if total <= width {
+		return rows
+	}
+
+	for total > width {
+		widest := -1
+		for i, w := range widths {
+			if w > floors[i] && (widest < 0 || w > widths[widest]) {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			break
+		}
+		widths[widest]--
+		total--
+	}
+
+	fitted := make([][]string, len(rows))
+	for n, row := range rows {
+		fitted[n] = make([]string, len(row))
+		for i, cell := range row {
+			fitted[n][i] = truncateCell(cell, widths[i])
+		}
+	}
+	return fitted
+}
+
+// truncateCell shortens s to at most width runes, marking the cut with
+// "...".
+func truncateCell(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	if width <= 3 {
+		return string(runes[:width])
+	}
+	return string(runes[:width-3]) + "..."
+}
+
+// TableCapture is a writer that collects the tables rendered to it instead
+// of drawing them, so the tables of several runs can be merged into one.
+// Anything else written to it is kept in Text.
+type TableCapture struct {
+	Tables []CapturedTable
+	Text   bytes.Buffer
+}
+
+// Write appends p to the captured text.
+func (c *TableCapture) Write(p []byte) (int, error) {
+	return c.Text.Write(p)
+}
+
+// CapturedTable is the content of a table rendered to a TableCapture, with
+// dates and widths not yet applied.
+type CapturedTable struct {
+	Headers  []string
+	Rows     [][]string
+	Styles   []func(string) string
+	Footers  [][]string
+	Aligns   []Align
+	DateCols []int
+}
+
 // RowCount returns the number of rows added.
 func (t *Table) RowCount() int {
 	return len(t.rows)
@@ -70,8 +362,10 @@ type TableBuilder struct {
 func NewTableBuilder(w io.Writer) *TableBuilder {
 	return &TableBuilder{
 		table: &Table{
-			w:    tabwriter.NewWriter(w, 0, 0, 2, ' ', 0),
-			rows: make([][]string, 0),
+			w:      tabwriter.NewWriter(w, 0, 0, tablePadding, ' ', 0),
+			out:    w,
+			colors: defaultColors,
+			rows:   make([][]string, 0),
 		},
 	}
 }
@@ -2,6 +2,7 @@ package output
 
 import (
 	"bytes"
+	"regexp"
 	"strings"
 	"testing"
 )
@@ -127,3 +128,163 @@ func TestTable_Alignment(t *testing.T) {
 	// Just verify it renders without error and produces multiple lines
 	// The actual alignment depends on tabwriter
 }
+
+func TestTable_RightAlign(t *testing.T) {
+	var buf bytes.Buffer
+	tbl := NewTable(&buf, "Project", "Hours", "Notes").SetAlign(AlignRight, 1)
+	tbl.AddRow("Website", "12.50", "design")
+	tbl.AddRow("App", "3.00", "")
+	tbl.AddRow("Total", "115.50", "")
+
+	if err := tbl.Render(); err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+
+	want := "Project   Hours  Notes\n" +
+		"-------   -----  -----\n" +
+		"Website   12.50  design\n" +
+		"App        3.00  \n" +
+		"Total    115.50  \n"
+	if buf.String() != want {
+		t.Errorf("Render() =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestTable_Footer(t *testing.T) {
+	var buf bytes.Buffer
+	tbl := NewTable(&buf, "Project", "Hours", "Notes").SetAlign(AlignRight, 1)
+	tbl.AddRow("Website", "12.50", "design")
+	tbl.AddRow("App", "103.00", "")
+	tbl.AddFooter("Total", "115.50")
+
+	if err := tbl.Render(); err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+
+	want := "Project   Hours  Notes\n" +
+		"-------   -----  -----\n" +
+		"Website   12.50  design\n" +
+		"App      103.00  \n" +
+		"-------  ------  ------\n" +
+		"Total    115.50  \n"
+	if buf.String() != want {
+		t.Errorf("Render() =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestTable_StyledMatchesPlainLayout(t *testing.T) {
+	var plain bytes.Buffer
+	pt := NewTable(&plain, "ID", "Name", "Remaining")
+	pt.AddRow("1", "Short", "10.00")
+	pt.AddRow("22", "A longer name", "-5.00")
+	if err := pt.Render(); err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+
+	var styled bytes.Buffer
+	st := NewTable(&styled, "ID", "Name", "Remaining")
+	st.colors = NewColorsFor(&styled, "always")
+	st.AddRow("1", "Short", "10.00")
+	st.AddStyledRow(st.colors.Error, "22", "A longer name", "-5.00")
+	if err := st.Render(); err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+
+	if !strings.Contains(styled.String(), "\x1b[") {
+		t.Fatalf("Styled output should contain ANSI escapes, got: %q", styled.String())
+	}
+
+	stripped := regexp.MustCompile(`\x1b\[[0-9;]*m`).ReplaceAllString(styled.String(), "")
+	if stripped != plain.String() {
+		t.Errorf("Styled layout differs from plain layout:\nstyled: %q\nplain:  %q", stripped, plain.String())
+	}
+}
+
+func TestTable_Markdown(t *testing.T) {
+	SetDefaultMode(ModeMarkdown)
+	defer SetDefaultMode(ModeTable)
+
+	var buf bytes.Buffer
+	tbl := NewTable(&buf, "Name", "Value")
+	tbl.AddRow("foo", "1")
+
+	if err := tbl.Render(); err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+
+	want := "| Name | Value |\n| --- | --- |\n| foo | 1 |\n"
+	if buf.String() != want {
+		t.Errorf("Render() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestTable_MarkdownAlignAndFooter(t *testing.T) {
+	SetDefaultMode(ModeMarkdown)
+	defer SetDefaultMode(ModeTable)
+
+	var buf bytes.Buffer
+	tbl := NewTable(&buf, "Name", "Hours", "Notes").SetAlign(AlignRight, 1)
+	tbl.AddRow("foo", "1.00", "x")
+	tbl.AddFooter("Total", "1.00")
+
+	if err := tbl.Render(); err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+
+	want := "| Name | Hours | Notes |\n| --- | ---: | --- |\n| foo | 1.00 | x |\n| Total | 1.00 |  |\n"
+	if buf.String() != want {
+		t.Errorf("Render() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestTable_FitsMaxWidth(t *testing.T) {
+	SetMaxWidth(30)
+	defer SetMaxWidth(0)
+
+	var buf bytes.Buffer
+	tbl := NewTable(&buf, "ID", "Notes")
+	tbl.AddRow("1", "a rather long note that will not fit")
+	tbl.AddRow("2", "short")
+
+	if err := tbl.Render(); err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	for _, line := range lines {
+		if n := len([]rune(line)); n > 30 {
+			t.Errorf("line %q is %d wide, want <= 30", line, n)
+		}
+	}
+	if !strings.Contains(lines[2], "a rather long note that...") {
+		t.Errorf("long note not truncated with ellipsis: %q", lines[2])
+	}
+	if !strings.Contains(lines[3], "short") {
+		t.Errorf("short note should be kept: %q", lines[3])
+	}
+}
+
+func TestFitRows(t *testing.T) {
+	rows := [][]string{{"Website redesign", "Acme Corporation"}}
+
+	if got := fitRows([]string{"Project", "Client"}, rows, nil, 0); got[0][0] != "Website redesign" {
+		t.Errorf("fitRows() with width 0 = %q, want rows unchanged", got)
+	}
+
+	// Both columns shrink to the minimum before the table gives up
+	got := fitRows([]string{"Project", "Client"}, rows, nil, 10)
+	want := []string{"Websi...", "Acme ..."}
+	if got[0][0] != want[0] || got[0][1] != want[1] {
+		t.Errorf("fitRows() = %q, want %q", got[0], want)
+	}
+	if rows[0][0] != "Website redesign" {
+		t.Error("fitRows() should not modify its input")
+	}
+
+	// Right-aligned amounts keep their full width; the text column gives way
+	amounts := [][]string{{"Website redesign", "1,234,567.89 EUR"}}
+	got = fitRows([]string{"Project", "Amount"}, amounts, []Align{AlignLeft, AlignRight}, 20)
+	if got[0][1] != "1,234,567.89 EUR" || got[0][0] != "Websi..." {
+		t.Errorf("fitRows() = %q, want only the project truncated", got[0])
+	}
+}
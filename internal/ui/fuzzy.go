@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// Scores awarded by fuzzyMatch on top of one point per matched rune.
+const (
+	fuzzyConsecutiveBonus = 5
+	fuzzyWordStartBonus   = 10
+	fuzzyPrefixBonus      = 20
+)
+
+// fuzzyMatch reports whether the runes of query appear in order in
+// candidate, ignoring case, and scores the match: runes that follow each
+// other, start a word, or start the candidate score higher. It also returns
+// the rune indexes of candidate that matched.
+func fuzzyMatch(query, candidate string) (score int, matched []int, ok bool) {
+	q := []rune(strings.ToLower(query))
+	if len(q) == 0 {
+		return 0, nil, true
+	}
+	c := []rune(strings.ToLower(candidate))
+
+	qi := 0
+	prev := -2
+	for ci, r := range c {
+		if qi == len(q) {
+			break
+		}
+		if r != q[qi] {
+			continue
+		}
+
+		score++
+		switch {
+		case ci == 0:
+			score += fuzzyPrefixBonus + fuzzyWordStartBonus
+		case !unicode.IsLetter(c[ci-1]) && !unicode.IsDigit(c[ci-1]):
+			score += fuzzyWordStartBonus
+		}
+		if ci == prev+1 {
+			score += fuzzyConsecutiveBonus
+		}
+
+		matched = append(matched, ci)
+		prev = ci
+		qi++
+	}
+
+	if qi < len(q) {
+		return 0, nil, false
+	}
+	return score, matched, true
+}
+
+// fuzzyFilter ranks targets with fuzzyMatch, best first. Equal scores keep
+// the original order so results are deterministic.
+func fuzzyFilter(term string, targets []string) []list.Rank {
+	type scored struct {
+		rank  list.Rank
+		score int
+	}
+
+	var matches []scored
+	for i, target := range targets {
+		score, matched, ok := fuzzyMatch(term, target)
+		if !ok {
+			continue
+		}
+		matches = append(matches, scored{list.Rank{Index: i, MatchedIndexes: matched}, score})
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+
+	ranks := make([]list.Rank, len(matches))
+	for i, m := range matches {
+		ranks[i] = m.rank
+	}
+	return ranks
+}
@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		query     string
+		candidate string
+		ok        bool
+		matched   []int
+	}{
+		{"", "Website", true, nil},
+		{"web", "Website", true, []int{0, 1, 2}},
+		{"WEB", "website", true, []int{0, 1, 2}},
+		{"acw", "Acme | Website", true, []int{0, 1, 7}},
+		{"wbs", "Website", true, []int{0, 2, 3}},
+		{"xyz", "Website", false, nil},
+		{"websites", "Website", false, nil},
+	}
+
+	for _, tt := range tests {
+		_, matched, ok := fuzzyMatch(tt.query, tt.candidate)
+		if ok != tt.ok {
+			t.Errorf("fuzzyMatch(%q, %q) ok = %v, want %v", tt.query, tt.candidate, ok, tt.ok)
+			continue
+		}
+		if !reflect.DeepEqual(matched, tt.matched) {
+			t.Errorf("fuzzyMatch(%q, %q) matched = %v, want %v", tt.query, tt.candidate, matched, tt.matched)
+		}
+	}
+}
+
+func TestFuzzyMatch_Scoring(t *testing.T) {
+	score := func(query, candidate string) int {
+		s, _, ok := fuzzyMatch(query, candidate)
+		if !ok {
+			t.Fatalf("fuzzyMatch(%q, %q) did not match", query, candidate)
+		}
+		return s
+	}
+
+	if prefix, inner := score("app", "App redesign"), score("app", "Mobile app"); prefix <= inner {
+		t.Errorf("prefix match scored %d, not above word match %d", prefix, inner)
+	}
+	if word, scattered := score("app", "Mobile app"), score("app", "Campaign plan"); word <= scattered {
+		t.Errorf("word match scored %d, not above scattered match %d", word, scattered)
+	}
+}
+
+func TestFuzzyFilter(t *testing.T) {
+	targets := []string{
+		"Campaign plan - Acme",
+		"Mobile app - Globex",
+		"Internal",
+		"App redesign - Initech",
+	}
+
+	ranks := fuzzyFilter("app", targets)
+	var got []int
+	for _, r := range ranks {
+		got = append(got, r.Index)
+	}
+	if want := []int{3, 1, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("fuzzyFilter() order = %v, want %v", got, want)
+	}
+
+	// Client names and codes are part of the filter value
+	ranks = fuzzyFilter("globex", targets)
+	if len(ranks) != 1 || ranks[0].Index != 1 {
+		t.Errorf("fuzzyFilter(globex) = %+v, want only index 1", ranks)
+	}
+}
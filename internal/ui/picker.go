@@ -3,7 +3,6 @@ package ui
 import (
 	"fmt"
 	"io"
-	"os"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/list"
@@ -62,7 +61,8 @@ func (d itemDelegate) Render(w io.Writer, m list.Model, index int, item list.Ite
 	}
 }
 
-// Picker is a searchable list picker for selecting items.
+// Picker is a searchable list picker for selecting items. Typing narrows
+// the list with fuzzy matching on each item's title and description.
 type Picker struct {
 	list     list.Model
 	selected PickerItem
@@ -81,6 +81,7 @@ func NewPicker(title string, items []PickerItem) *Picker {
 	l.Title = title
 	l.SetShowStatusBar(true)
 	l.SetFilteringEnabled(true)
+	l.Filter = fuzzyFilter
 	l.SetShowHelp(true)
 	l.Styles.Title = TitleStyle
 	l.Styles.FilterPrompt = PromptStyle
@@ -107,8 +108,20 @@ func (p *Picker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return p, nil
 
 	case tea.KeyMsg:
-		// Don't intercept keys when filtering
+		if msg.String() == "ctrl+c" {
+			p.canceled = true
+			return p, tea.Quit
+		}
+
 		if p.list.FilterState() == list.Filtering {
+			// Enter picks the best match instead of just applying the filter
+			if msg.String() == "enter" {
+				if item, ok := p.list.SelectedItem().(listItem); ok {
+					p.selected = item.item
+					p.done = true
+					return p, tea.Quit
+				}
+			}
 			break
 		}
 
@@ -119,10 +132,15 @@ func (p *Picker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				p.done = true
 				return p, tea.Quit
 			}
-		case "esc", "ctrl+c", "q":
+		case "esc":
 			p.canceled = true
 			return p, tea.Quit
 		}
+
+		// Start filtering as soon as the user types
+		if msg.Type == tea.KeyRunes && msg.String() != "/" && p.list.FilterState() == list.Unfiltered {
+			p.list.SetFilterState(list.Filtering)
+		}
 	}
 
 	var cmd tea.Cmd
@@ -140,7 +158,7 @@ func (p *Picker) View() string {
 
 // Run executes the picker and returns the selected item.
 func (p *Picker) Run() (PickerItem, error) {
-	program := tea.NewProgram(p, tea.WithOutput(os.Stderr))
+	program := tea.NewProgram(p, tea.WithOutput(promptOutput))
 	finalModel, err := program.Run()
 	if err != nil {
 		return nil, err
@@ -222,6 +240,18 @@ func (u UserItem) ID() int64           { return u.UserID }
 func (u UserItem) Title() string       { return fmt.Sprintf("%s %s", u.FirstName, u.LastName) }
 func (u UserItem) Description() string { return u.Email }
 
+// NamedItem implements PickerItem for any record with a name, such as an
+// expense category.
+type NamedItem struct {
+	ItemID int64
+	Name   string
+	Detail string
+}
+
+func (n NamedItem) ID() int64           { return n.ItemID }
+func (n NamedItem) Title() string       { return n.Name }
+func (n NamedItem) Description() string { return n.Detail }
+
 // PickProject shows a project picker and returns the selected project.
 func PickProject(title string, projects []ProjectItem) (*ProjectItem, error) {
 	items := make([]PickerItem, len(projects))
@@ -2,7 +2,6 @@ package ui
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
@@ -54,7 +53,7 @@ func (s *Spinner) Start() {
 		spinner: s.spinner,
 		message: s.message,
 	}
-	s.program = tea.NewProgram(model, tea.WithOutput(os.Stderr))
+	s.program = tea.NewProgram(model, tea.WithOutput(promptOutput))
 
 	go func() {
 		_, _ = s.program.Run()
@@ -3,6 +3,7 @@ package ui
 import (
 	"errors"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/charmbracelet/bubbles/textinput"
@@ -12,9 +13,25 @@ import (
 // ErrCanceled indicates user canceled the operation.
 var ErrCanceled = errors.New("operation canceled")
 
+// promptOutput receives interactive prompt rendering.
+var promptOutput io.Writer = os.Stderr
+
+// assumeYes makes ConfirmPrompt answer yes without prompting.
+var assumeYes bool
+
+// SetOutput configures where prompts and TUI programs are rendered.
+func SetOutput(w io.Writer) {
+	promptOutput = w
+}
+
+// SetAssumeYes configures ConfirmPrompt to auto-confirm for non-interactive use.
+func SetAssumeYes(yes bool) {
+	assumeYes = yes
+}
+
 // RunProgram executes a bubbletea program and returns any error.
 func RunProgram(model tea.Model) error {
-	p := tea.NewProgram(model, tea.WithOutput(os.Stderr))
+	p := tea.NewProgram(model, tea.WithOutput(promptOutput))
 	_, err := p.Run()
 	return err
 }
@@ -76,13 +93,18 @@ func (m confirmModel) View() string {
 }
 
 // ConfirmPrompt displays a yes/no confirmation prompt.
+// It returns true immediately when assume-yes is enabled.
 func ConfirmPrompt(message string) (bool, error) {
+	if assumeYes {
+		return true, nil
+	}
+
 	model := confirmModel{
 		message:  message,
 		selected: true, // default to yes
 	}
 
-	p := tea.NewProgram(model, tea.WithOutput(os.Stderr))
+	p := tea.NewProgram(model, tea.WithOutput(promptOutput))
 	finalModel, err := p.Run()
 	if err != nil {
 		return false, err
@@ -145,7 +167,7 @@ func TextPrompt(message, placeholder string) (string, error) {
 		message: message,
 	}
 
-	p := tea.NewProgram(model, tea.WithOutput(os.Stderr))
+	p := tea.NewProgram(model, tea.WithOutput(promptOutput))
 	finalModel, err := p.Run()
 	if err != nil {
 		return "", err
@@ -171,7 +193,7 @@ func NumberPrompt(message string, defaultVal float64) (float64, error) {
 		message: message,
 	}
 
-	p := tea.NewProgram(model, tea.WithOutput(os.Stderr))
+	p := tea.NewProgram(model, tea.WithOutput(promptOutput))
 	finalModel, err := p.Run()
 	if err != nil {
 		return 0, err
@@ -0,0 +1,16 @@
+package ui
+
+import "testing"
+
+func TestConfirmPrompt_AssumeYes(t *testing.T) {
+	SetAssumeYes(true)
+	defer SetAssumeYes(false)
+
+	confirmed, err := ConfirmPrompt("Delete everything?")
+	if err != nil {
+		t.Fatalf("ConfirmPrompt error: %v", err)
+	}
+	if !confirmed {
+		t.Error("ConfirmPrompt should auto-confirm when assume-yes is set")
+	}
+}